@@ -0,0 +1,91 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Severity is the urgency of an alert
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityWarning  Severity = "warning"
+)
+
+// Status is whether the condition of an alert still holds
+type Status string
+
+const (
+	StatusFiring   Status = "firing"
+	StatusResolved Status = "resolved"
+)
+
+// Alert is a notification of a rule whose condition holds for a subject,
+// e.g., a finality provider
+type Alert struct {
+	Rule       string    `json:"rule"`
+	Subject    string    `json:"subject,omitempty"`
+	Severity   Severity  `json:"severity"`
+	Status     Status    `json:"status"`
+	Summary    string    `json:"summary"`
+	StartsAt   time.Time `json:"starts_at"`
+	ResolvedAt time.Time `json:"resolved_at,omitzero"`
+}
+
+// Key identifies the alert across evaluations, so that it is notified
+// once when it fires rather than at each evaluation
+func (a *Alert) Key() string {
+	if a.Subject == "" {
+		return a.Rule
+	}
+
+	return a.Rule + "/" + a.Subject
+}
+
+// Firing is a subject for which the condition of a rule holds
+type Firing struct {
+	Subject string
+	Summary string
+}
+
+// Rule is a condition evaluated periodically
+type Rule struct {
+	Name     string
+	Severity Severity
+	// Evaluate returns the subjects for which the condition holds
+	Evaluate func(ctx context.Context) ([]Firing, error)
+}
+
+// Silence suppresses the notifications of a rule, until the given time
+// if it is not zero
+type Silence struct {
+	Rule  string
+	Until time.Time
+}
+
+// ParseSilence parses a silence written as <rule> or <rule>@<RFC3339 time>
+func ParseSilence(s string) (Silence, error) {
+	rule, until, found := strings.Cut(s, "@")
+	if rule == "" {
+		return Silence{}, fmt.Errorf("invalid silence %q: empty rule", s)
+	}
+	if !found {
+		return Silence{Rule: rule}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, until)
+	if err != nil {
+		return Silence{}, fmt.Errorf("invalid silence %q: %w", s, err)
+	}
+
+	return Silence{Rule: rule, Until: t}, nil
+}
+
+// IsActive returns whether the silence suppresses the notifications of
+// the rule at the given time
+func (s Silence) IsActive(rule string, now time.Time) bool {
+	return s.Rule == rule && (s.Until.IsZero() || now.Before(s.Until))
+}
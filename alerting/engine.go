@@ -0,0 +1,164 @@
+package alerting
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// activeAlert is an alert firing since an earlier evaluation
+type activeAlert struct {
+	alert Alert
+	// notifiedAt is zero until a notification of the alert is sent,
+	// so that it is retried at the next evaluation if sending failed
+	notifiedAt time.Time
+}
+
+// Engine evaluates the rules and notifies the alerts when they fire, again
+// every repeat interval while they keep firing, and when they are resolved
+type Engine struct {
+	rules          []Rule
+	notifiers      []Notifier
+	repeatInterval time.Duration
+	silences       []Silence
+	logger         *zap.Logger
+
+	mu     sync.Mutex
+	active map[string]*activeAlert
+}
+
+// NewEngine creates an engine of the given rules. A repeat interval of 0
+// notifies a firing alert only once.
+func NewEngine(
+	rules []Rule,
+	notifiers []Notifier,
+	repeatInterval time.Duration,
+	silences []Silence,
+	logger *zap.Logger,
+) *Engine {
+	return &Engine{
+		rules:          rules,
+		notifiers:      notifiers,
+		repeatInterval: repeatInterval,
+		silences:       silences,
+		logger:         logger,
+		active:         make(map[string]*activeAlert),
+	}
+}
+
+// Evaluate evaluates all the rules at the given time and sends the
+// notifications of the alerts which fired, are due to be repeated or
+// were resolved since the last evaluation
+func (e *Engine) Evaluate(ctx context.Context, now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, rule := range e.rules {
+		firings, err := rule.Evaluate(ctx)
+		if err != nil {
+			// the alerts of the rule are kept as they are, as
+			// whether their condition still holds is unknown
+			e.logger.Warn("failed to evaluate the alerting rule",
+				zap.String("rule", rule.Name), zap.Error(err))
+
+			continue
+		}
+
+		firing := make(map[string]struct{}, len(firings))
+		for _, f := range firings {
+			alert := Alert{
+				Rule:     rule.Name,
+				Subject:  f.Subject,
+				Severity: rule.Severity,
+				Status:   StatusFiring,
+				Summary:  f.Summary,
+				StartsAt: now,
+			}
+			key := alert.Key()
+			firing[key] = struct{}{}
+
+			a, ok := e.active[key]
+			if !ok {
+				e.logger.Warn("alert firing",
+					zap.String("rule", rule.Name),
+					zap.String("subject", f.Subject),
+					zap.String("summary", f.Summary))
+				a = &activeAlert{alert: alert}
+				e.active[key] = a
+			}
+			a.alert.Summary = f.Summary
+
+			if a.notifiedAt.IsZero() || (e.repeatInterval > 0 && now.Sub(a.notifiedAt) >= e.repeatInterval) {
+				if e.notify(ctx, a.alert, now) {
+					a.notifiedAt = now
+				}
+			}
+		}
+
+		for key, a := range e.active {
+			if a.alert.Rule != rule.Name {
+				continue
+			}
+			if _, ok := firing[key]; ok {
+				continue
+			}
+
+			e.logger.Info("alert resolved",
+				zap.String("rule", rule.Name), zap.String("subject", a.alert.Subject))
+			delete(e.active, key)
+
+			// the resolution is only notified if the alert was
+			if a.notifiedAt.IsZero() {
+				continue
+			}
+			resolved := a.alert
+			resolved.Status = StatusResolved
+			resolved.ResolvedAt = now
+			e.notify(ctx, resolved, now)
+		}
+	}
+}
+
+// Active returns the alerts currently firing
+func (e *Engine) Active() []Alert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	alerts := make([]Alert, 0, len(e.active))
+	for _, a := range e.active {
+		alerts = append(alerts, a.alert)
+	}
+
+	return alerts
+}
+
+// notify sends the alert to all the notifiers unless the rule is silenced.
+// It returns whether the alert was sent to at least one of them.
+func (e *Engine) notify(ctx context.Context, alert Alert, now time.Time) bool {
+	for _, s := range e.silences {
+		if s.IsActive(alert.Rule, now) {
+			e.logger.Debug("the alert is silenced",
+				zap.String("rule", alert.Rule), zap.String("subject", alert.Subject))
+
+			return false
+		}
+	}
+
+	sent := false
+	for _, n := range e.notifiers {
+		if err := n.Notify(ctx, alert); err != nil {
+			e.logger.Error("failed to send the alert",
+				zap.String("rule", alert.Rule),
+				zap.String("subject", alert.Subject),
+				zap.String("status", string(alert.Status)),
+				zap.Error(err))
+
+			continue
+		}
+		sent = true
+	}
+
+	return sent
+}
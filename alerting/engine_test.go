@@ -0,0 +1,232 @@
+package alerting_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/alerting"
+)
+
+// receiver is a local webhook recording the payloads posted to it
+type receiver struct {
+	mu       sync.Mutex
+	payloads []map[string]any
+	status   int
+}
+
+func newReceiver(t *testing.T) (*receiver, *httptest.Server) {
+	t.Helper()
+
+	r := &receiver{status: http.StatusOK}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var payload map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.status == http.StatusOK {
+			r.payloads = append(r.payloads, payload)
+		}
+		w.WriteHeader(r.status)
+	}))
+	t.Cleanup(srv.Close)
+
+	return r, srv
+}
+
+func (r *receiver) received() []map[string]any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]map[string]any(nil), r.payloads...)
+}
+
+func (r *receiver) setStatus(status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.status = status
+}
+
+func newNotifier(t *testing.T, format, url string) alerting.Notifier {
+	t.Helper()
+
+	webhook, err := alerting.ParseWebhook(format + "=" + url)
+	require.NoError(t, err)
+	n, err := alerting.NewWebhookNotifier(webhook, "routing-key")
+	require.NoError(t, err)
+
+	return n
+}
+
+func TestEngineNotifications(t *testing.T) {
+	t.Parallel()
+
+	rcv, srv := newReceiver(t)
+
+	var mu sync.Mutex
+	var firings []alerting.Firing
+	setFirings := func(f ...alerting.Firing) {
+		mu.Lock()
+		defer mu.Unlock()
+		firings = f
+	}
+	rule := alerting.Rule{
+		Name:     "missed_votes",
+		Severity: alerting.SeverityCritical,
+		Evaluate: func(_ context.Context) ([]alerting.Firing, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return firings, nil
+		},
+	}
+
+	e := alerting.NewEngine([]alerting.Rule{rule}, []alerting.Notifier{newNotifier(t, alerting.FormatJSON, srv.URL)},
+		time.Hour, nil, zap.NewNop())
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// nothing is notified as long as the condition does not hold
+	e.Evaluate(t.Context(), now)
+	require.Empty(t, rcv.received())
+
+	// the alert is notified once when it fires
+	setFirings(alerting.Firing{Subject: "fp1", Summary: "no votes"})
+	e.Evaluate(t.Context(), now)
+	e.Evaluate(t.Context(), now.Add(time.Minute))
+	require.Len(t, rcv.received(), 1)
+	got := rcv.received()[0]
+	require.Equal(t, "missed_votes", got["rule"])
+	require.Equal(t, "fp1", got["subject"])
+	require.Equal(t, "firing", got["status"])
+	require.Equal(t, "critical", got["severity"])
+	require.NotContains(t, got, "resolved_at")
+	require.Len(t, e.Active(), 1)
+
+	// and again once the repeat interval elapsed
+	e.Evaluate(t.Context(), now.Add(time.Hour))
+	require.Len(t, rcv.received(), 2)
+
+	// the resolution is notified once the condition no longer holds
+	setFirings()
+	e.Evaluate(t.Context(), now.Add(2*time.Hour))
+	e.Evaluate(t.Context(), now.Add(3*time.Hour))
+	require.Len(t, rcv.received(), 3)
+	require.Equal(t, "resolved", rcv.received()[2]["status"])
+	require.Contains(t, rcv.received()[2], "resolved_at")
+	require.Empty(t, e.Active())
+
+	// an alert failing to be sent is retried at the next evaluation
+	rcv.setStatus(http.StatusInternalServerError)
+	setFirings(alerting.Firing{Subject: "fp1", Summary: "no votes"})
+	e.Evaluate(t.Context(), now.Add(4*time.Hour))
+	require.Len(t, rcv.received(), 3)
+	rcv.setStatus(http.StatusOK)
+	e.Evaluate(t.Context(), now.Add(4*time.Hour+time.Minute))
+	require.Len(t, rcv.received(), 4)
+}
+
+func TestEngineSilences(t *testing.T) {
+	t.Parallel()
+
+	rcv, srv := newReceiver(t)
+
+	rules := []alerting.Rule{
+		{Name: "silenced", Severity: alerting.SeverityWarning, Evaluate: func(_ context.Context) ([]alerting.Firing, error) {
+			return []alerting.Firing{{Summary: "silenced"}}, nil
+		}},
+		{Name: "silenced_until", Severity: alerting.SeverityWarning, Evaluate: func(_ context.Context) ([]alerting.Firing, error) {
+			return []alerting.Firing{{Summary: "silenced until"}}, nil
+		}},
+	}
+	silence, err := alerting.ParseSilence("silenced")
+	require.NoError(t, err)
+	silenceUntil, err := alerting.ParseSilence("silenced_until@2026-01-01T01:00:00Z")
+	require.NoError(t, err)
+	_, err = alerting.ParseSilence("silenced_until@tomorrow")
+	require.Error(t, err)
+
+	e := alerting.NewEngine(rules, []alerting.Notifier{newNotifier(t, alerting.FormatJSON, srv.URL)},
+		0, []alerting.Silence{silence, silenceUntil}, zap.NewNop())
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	e.Evaluate(t.Context(), now)
+	require.Empty(t, rcv.received())
+	require.Len(t, e.Active(), 2)
+
+	// the alert still firing is notified once its silence expires
+	e.Evaluate(t.Context(), now.Add(time.Hour))
+	require.Len(t, rcv.received(), 1)
+	require.Equal(t, "silenced_until", rcv.received()[0]["rule"])
+}
+
+func TestWebhookFormats(t *testing.T) {
+	t.Parallel()
+
+	alert := alerting.Alert{
+		Rule:     "finality_provider_jailed",
+		Subject:  "fp1",
+		Severity: alerting.SeverityCritical,
+		Status:   alerting.StatusFiring,
+		Summary:  "the finality provider is JAILED",
+		StartsAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("slack", func(t *testing.T) {
+		t.Parallel()
+
+		rcv, srv := newReceiver(t)
+		require.NoError(t, newNotifier(t, alerting.FormatSlack, srv.URL).Notify(t.Context(), alert))
+		require.Equal(t, []map[string]any{{
+			"text": "[FIRING] critical finality_provider_jailed (fp1): the finality provider is JAILED",
+		}}, rcv.received())
+	})
+
+	t.Run("pagerduty", func(t *testing.T) {
+		t.Parallel()
+
+		rcv, srv := newReceiver(t)
+		n := newNotifier(t, alerting.FormatPagerDuty, srv.URL)
+		require.NoError(t, n.Notify(t.Context(), alert))
+		resolved := alert
+		resolved.Status = alerting.StatusResolved
+		require.NoError(t, n.Notify(t.Context(), resolved))
+
+		got := rcv.received()
+		require.Len(t, got, 2)
+		require.Equal(t, "routing-key", got[0]["routing_key"])
+		require.Equal(t, "trigger", got[0]["event_action"])
+		require.Equal(t, "finality_provider_jailed/fp1", got[0]["dedup_key"])
+		payload, ok := got[0]["payload"].(map[string]any)
+		require.True(t, ok)
+		require.Equal(t, "critical", payload["severity"])
+		require.Equal(t, alert.Summary, payload["summary"])
+		require.Equal(t, "resolve", got[1]["event_action"])
+		require.Equal(t, got[0]["dedup_key"], got[1]["dedup_key"])
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		for _, w := range []string{"http://localhost", "teams=http://localhost", "json=localhost:8080"} {
+			_, err := alerting.ParseWebhook(w)
+			require.Error(t, err, w)
+		}
+		webhook, err := alerting.ParseWebhook("pagerduty=https://events.pagerduty.com/v2/enqueue")
+		require.NoError(t, err)
+		_, err = alerting.NewWebhookNotifier(webhook, "")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,181 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Formats of the payloads posted to the webhooks
+const (
+	FormatJSON      = "json"
+	FormatSlack     = "slack"
+	FormatPagerDuty = "pagerduty"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// Notifier sends the alerts to a sink
+type Notifier interface {
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// Webhook is a sink of the alerts
+type Webhook struct {
+	Format string
+	URL    string
+}
+
+// ParseWebhook parses a webhook written as <format>=<url>
+func ParseWebhook(s string) (Webhook, error) {
+	format, rawURL, found := strings.Cut(s, "=")
+	if !found {
+		return Webhook{}, fmt.Errorf("invalid webhook %q, expected <format>=<url>", s)
+	}
+
+	switch format {
+	case FormatJSON, FormatSlack, FormatPagerDuty:
+	default:
+		return Webhook{}, fmt.Errorf("invalid webhook format %s, expected json, slack or pagerduty", format)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Webhook{}, fmt.Errorf("invalid webhook url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return Webhook{}, fmt.Errorf("invalid webhook url %s, expected an http or https url", rawURL)
+	}
+
+	return Webhook{Format: format, URL: rawURL}, nil
+}
+
+// WebhookNotifier posts the alerts to a webhook as JSON in its format
+type WebhookNotifier struct {
+	webhook    Webhook
+	routingKey string
+	source     string
+	client     *http.Client
+}
+
+// NewWebhookNotifier creates a notifier of the webhook. The routing key is
+// the integration key of the PagerDuty service, it is only used by the
+// pagerduty format.
+func NewWebhookNotifier(webhook Webhook, routingKey string) (*WebhookNotifier, error) {
+	if webhook.Format == FormatPagerDuty && routingKey == "" {
+		return nil, fmt.Errorf("the pagerduty webhook %s requires a routing key", webhook.URL)
+	}
+
+	source, err := os.Hostname()
+	if err != nil {
+		source = "fpd"
+	}
+
+	return &WebhookNotifier{
+		webhook:    webhook,
+		routingKey: routingKey,
+		source:     source,
+		client:     &http.Client{Timeout: webhookTimeout},
+	}, nil
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	var payload any
+	switch n.webhook.Format {
+	case FormatSlack:
+		payload = slackPayload(alert)
+	case FormatPagerDuty:
+		payload = n.pagerDutyPayload(alert)
+	default:
+		payload = alert
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode the alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create the request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post the alert to the %s webhook: %w", n.webhook.Format, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+
+		return fmt.Errorf("the %s webhook responded %s: %s", n.webhook.Format, res.Status, strings.TrimSpace(string(msg)))
+	}
+
+	return nil
+}
+
+// slackPayload is the message of the alert for Slack incoming webhooks,
+// which is also accepted by Mattermost and Discord's Slack-compatible ones
+func slackPayload(alert Alert) map[string]string {
+	text := fmt.Sprintf("[%s] %s %s", strings.ToUpper(string(alert.Status)), alert.Severity, alert.Rule)
+	if alert.Subject != "" {
+		text += fmt.Sprintf(" (%s)", alert.Subject)
+	}
+	text += ": " + alert.Summary
+
+	return map[string]string{"text": text}
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      Severity          `json:"severity"`
+	Timestamp     time.Time         `json:"timestamp"`
+	Component     string            `json:"component"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+// pagerDutyPayload is the event of the alert for the PagerDuty Events API
+// v2, whose dedup key resolves the incident triggered by the alert
+func (n *WebhookNotifier) pagerDutyPayload(alert Alert) pagerDutyEvent {
+	event := pagerDutyEvent{
+		RoutingKey:  n.routingKey,
+		EventAction: "trigger",
+		DedupKey:    alert.Key(),
+	}
+	if alert.Status == StatusResolved {
+		event.EventAction = "resolve"
+
+		return event
+	}
+
+	event.Payload = &pagerDutyPayload{
+		Summary:   alert.Summary,
+		Source:    n.source,
+		Severity:  alert.Severity,
+		Timestamp: alert.StartsAt,
+		Component: "fpd",
+		CustomDetails: map[string]string{
+			"rule":    alert.Rule,
+			"subject": alert.Subject,
+		},
+	}
+
+	return event
+}
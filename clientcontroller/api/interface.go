@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"cosmossdk.io/math"
 	btcstakingtypes "github.com/babylonlabs-io/babylon/v4/x/btcstaking/types"
@@ -30,6 +31,10 @@ type BabylonController interface {
 	// EditFinalityProvider edits description and commission of a finality provider
 	EditFinalityProvider(ctx context.Context, req *EditFinalityProviderRequest) (*btcstakingtypes.MsgEditFinalityProvider, error)
 
+	// QueryFeePayerBalance queries the balance in the given denom of the
+	// account paying the transaction fees
+	QueryFeePayerBalance(ctx context.Context, denom string) (*FeePayerBalanceResponse, error)
+
 	// Close cleanly shuts down the client
 	Close() error
 }
@@ -58,6 +63,14 @@ type ConsumerController interface {
 	Close() error
 }
 
+// BlockTimeQuerier is implemented by the consumer controllers which can query
+// the production time of the blocks, to measure the latency of the votes
+type BlockTimeQuerier interface {
+	// QueryBlockTimes returns the production times of the blocks from startHeight
+	// to endHeight, which may only include the latest blocks of the range
+	QueryBlockTimes(ctx context.Context, startHeight, endHeight uint64) (map[uint64]time.Time, error)
+}
+
 // RandomnessCommitter handles public randomness commitment operations
 type RandomnessCommitter interface {
 	// CommitPubRandList commits a list of EOTS public randomness to the consumer chain
@@ -132,6 +145,12 @@ type FinalityProviderStatusResponse struct {
 	Jailed  bool
 }
 
+// FeePayerBalanceResponse contains the balance of the account paying the transaction fees
+type FeePayerBalanceResponse struct {
+	Address string
+	Amount  math.Int
+}
+
 type QueryFinalityProviderHasPowerRequest struct {
 	FpPk        *btcec.PublicKey
 	BlockHeight uint64
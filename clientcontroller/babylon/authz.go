@@ -0,0 +1,243 @@
+package babylon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	sdkErr "cosmossdk.io/errors"
+	"github.com/avast/retry-go/v4"
+	"github.com/babylonlabs-io/babylon/v4/client/babylonclient"
+	bbnclient "github.com/babylonlabs-io/babylon/v4/client/client"
+	finalitytypes "github.com/babylonlabs-io/babylon/v4/x/finality/types"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	legacyerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/tracing"
+)
+
+// retry settings used when sending txs with a fee granter,
+// same as the ones of the Babylon client
+var (
+	rtyAttNum = uint(5)
+	rtyAtt    = retry.Attempts(rtyAttNum)
+	rtyDel    = retry.Delay(time.Millisecond * 400)
+	rtyErr    = retry.LastErrorOnly(true)
+)
+
+const defaultBroadcastWaitTimeout = 10 * time.Minute
+
+// feeGrantTxProvider builds and broadcasts the txs whose fees are paid by
+// the fee granter, it is implemented by the provider of the Babylon client
+type feeGrantTxProvider interface {
+	BuildMessages(
+		ctx context.Context,
+		txf tx.Factory,
+		msgs []babylonclient.RelayerMessage,
+		memo string,
+		gas uint64,
+		txSignerKey string,
+		sequenceGuard *babylonclient.WalletState,
+	) ([]byte, uint64, sdk.Coins, error)
+	BroadcastTx(
+		ctx context.Context,
+		tx []byte,
+		asyncCtx context.Context,
+		asyncTimeout time.Duration,
+		asyncCallbacks []func(*babylonclient.RelayerTxResponse, error),
+	) error
+	UpdateNextAccountSequence(sequenceGuard *babylonclient.WalletState, seq uint64)
+}
+
+// isGrantedMsg returns true for the msgs the finality provider grants to the
+// key through authz, i.e., the finality votes and the randomness commits
+func isGrantedMsg(msg sdk.Msg) bool {
+	switch msg.(type) {
+	case *finalitytypes.MsgAddFinalitySig, *finalitytypes.MsgCommitPubRandList:
+		return true
+	default:
+		return false
+	}
+}
+
+// wrapMsgsInExec wraps each granted msg in its own authz MsgExec with the key
+// as grantee, so the finality provider address never needs to sign on this host.
+// If no granter is configured, the msgs are returned untouched.
+func (bc *BabylonConsumerController) wrapMsgsInExec(msgs []sdk.Msg) []sdk.Msg {
+	if bc.cfg.Granter == "" {
+		return msgs
+	}
+
+	return wrapGrantedMsgsInExec(bc.GetKeyAddress(), msgs)
+}
+
+// wrapGrantedMsgsInExec wraps each granted msg in its own authz MsgExec with
+// the given grantee, the other msgs are returned as they are
+func wrapGrantedMsgsInExec(grantee sdk.AccAddress, msgs []sdk.Msg) []sdk.Msg {
+	wrapped := make([]sdk.Msg, 0, len(msgs))
+	for _, msg := range msgs {
+		if !isGrantedMsg(msg) {
+			wrapped = append(wrapped, msg)
+
+			continue
+		}
+		msgExec := authz.NewMsgExec(grantee, []sdk.Msg{msg})
+		wrapped = append(wrapped, &msgExec)
+	}
+
+	return wrapped
+}
+
+// sendMsgs sends the msgs signed by the key. Fees are paid by the fee granter
+// if one is configured, otherwise by the key itself.
+func (bc *BabylonConsumerController) sendMsgs(
+	ctx context.Context,
+	msgs []sdk.Msg,
+	expectedErrs []*sdkErr.Error,
+	unrecoverableErrs []*sdkErr.Error,
+) (_ *babylonclient.RelayerTxResponse, err error) {
+	ctx, span := tracing.StartSpan(ctx, "BabylonConsumerController.sendMsgs",
+		attribute.Int("tx.msg_count", len(msgs)),
+		attribute.Bool("tx.fee_granted", bc.cfg.FeeGranter != ""),
+	)
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	bc.gasPricesMu.RLock()
+	defer bc.gasPricesMu.RUnlock()
+
+	if bc.cfg.FeeGranter == "" {
+		//nolint:wrapcheck
+		return bc.bbnClient.ReliablySendMsgs(ctx, msgs, expectedErrs, unrecoverableErrs)
+	}
+
+	return bc.reliablySendMsgsWithFeeGranter(ctx, bc.bbnClient.Provider(), msgs, expectedErrs, unrecoverableErrs)
+}
+
+// reliablySendMsgsWithFeeGranter mirrors ReliablySendMsgs from the Babylon client
+// but sets the configured fee granter in the tx, so the fees are deducted from
+// the allowance given to the key instead of its own balance.
+func (bc *BabylonConsumerController) reliablySendMsgsWithFeeGranter(
+	ctx context.Context,
+	provider feeGrantTxProvider,
+	msgs []sdk.Msg,
+	expectedErrs []*sdkErr.Error,
+	unrecoverableErrs []*sdkErr.Error,
+) (*babylonclient.RelayerTxResponse, error) {
+	feeGranter, err := sdk.GetFromBech32(bc.cfg.FeeGranter, bc.cfg.AccountPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fee granter address %s: %w", bc.cfg.FeeGranter, err)
+	}
+
+	var (
+		rlyResp     *babylonclient.RelayerTxResponse
+		callbackErr error
+		wg          sync.WaitGroup
+	)
+
+	callback := func(rtr *babylonclient.RelayerTxResponse, err error) {
+		rlyResp = rtr
+		callbackErr = err
+		wg.Done()
+	}
+
+	wg.Add(1)
+
+	relayerMsgs := bbnclient.ToProviderMsgs(msgs)
+
+	if err := retry.Do(func() error {
+		sendMsgErr := bc.sendMsgsToMempoolWithFeeGranter(ctx, provider, feeGranter, relayerMsgs, callback)
+		if sendMsgErr == nil {
+			return nil
+		}
+		if errorContained(sendMsgErr, unrecoverableErrs) {
+			bc.logger.Error("unrecoverable err when submitting the tx, skip retrying", zap.Error(sendMsgErr))
+
+			return retry.Unrecoverable(sendMsgErr)
+		}
+		if errorContained(sendMsgErr, expectedErrs) {
+			// the callback is not executed if the tx is not
+			// broadcast, so release the wait group here
+			wg.Done()
+			bc.logger.Error("expected err when submitting the tx, skip retrying", zap.Error(sendMsgErr))
+
+			return nil
+		}
+
+		return sendMsgErr
+	}, retry.Context(ctx), rtyAtt, rtyDel, rtyErr, retry.OnRetry(func(n uint, err error) {
+		bc.logger.Debug("retrying", zap.Uint("attempt", n+1), zap.Uint("max_attempts", rtyAttNum), zap.Error(err))
+	})); err != nil {
+		return nil, fmt.Errorf("failed to send msgs with fee granter: %w", err)
+	}
+
+	wg.Wait()
+
+	if callbackErr != nil {
+		if errorContained(callbackErr, expectedErrs) {
+			return nil, nil
+		}
+
+		return nil, callbackErr
+	}
+
+	if rlyResp == nil {
+		// expected error within the retry
+		return nil, nil
+	}
+
+	if rlyResp.Code != 0 {
+		return rlyResp, fmt.Errorf("transaction failed with code: %d", rlyResp.Code)
+	}
+
+	return rlyResp, nil
+}
+
+func (bc *BabylonConsumerController) sendMsgsToMempoolWithFeeGranter(
+	ctx context.Context,
+	provider feeGrantTxProvider,
+	feeGranter sdk.AccAddress,
+	msgs []babylonclient.RelayerMessage,
+	callback func(*babylonclient.RelayerTxResponse, error),
+) error {
+	bc.feeGrantWallet.Mu.Lock()
+	defer bc.feeGrantWallet.Mu.Unlock()
+
+	blockTimeout := bc.cfg.BlockTimeout
+	if blockTimeout == 0 {
+		blockTimeout = defaultBroadcastWaitTimeout
+	}
+
+	txf := tx.Factory{}.WithFeeGranter(feeGranter)
+	txBytes, sequence, _, err := provider.BuildMessages(ctx, txf, msgs, "", 0, bc.cfg.Key, bc.feeGrantWallet)
+	if err != nil {
+		bc.resetSequenceOnMismatch(err)
+
+		return fmt.Errorf("failed to build tx: %w", err)
+	}
+
+	if err := provider.BroadcastTx(ctx, txBytes, ctx, blockTimeout, []func(*babylonclient.RelayerTxResponse, error){callback}); err != nil {
+		bc.resetSequenceOnMismatch(err)
+
+		return fmt.Errorf("failed to broadcast tx: %w", err)
+	}
+
+	provider.UpdateNextAccountSequence(bc.feeGrantWallet, sequence+1)
+
+	return nil
+}
+
+// resetSequenceOnMismatch drops the locally tracked sequence, so the next
+// tx is built with the account sequence queried from the chain
+func (bc *BabylonConsumerController) resetSequenceOnMismatch(err error) {
+	if strings.Contains(err.Error(), legacyerrors.ErrWrongSequence.Error()) {
+		bc.feeGrantWallet.NextAccountSequence = 0
+	}
+}
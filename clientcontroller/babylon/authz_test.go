@@ -0,0 +1,231 @@
+package babylon
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sdkErr "cosmossdk.io/errors"
+	"github.com/babylonlabs-io/babylon/v4/client/babylonclient"
+	finalitytypes "github.com/babylonlabs-io/babylon/v4/x/finality/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	legacyerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+var (
+	errTestUnrecoverable = sdkErr.Register("authz_test", 2, "unrecoverable")
+	errTestExpected      = sdkErr.Register("authz_test", 3, "expected")
+)
+
+func TestWrapGrantedMsgsInExec(t *testing.T) {
+	t.Parallel()
+	grantee := sdk.AccAddress([]byte("grantee_address_____"))
+	granter := sdk.MustBech32ifyAddressBytes("bbn", []byte("granter_address_____"))
+
+	vote := &finalitytypes.MsgAddFinalitySig{Signer: granter, BlockHeight: 10}
+	commit := &finalitytypes.MsgCommitPubRandList{Signer: granter, StartHeight: 10, NumPubRand: 100}
+	unjail := &finalitytypes.MsgUnjailFinalityProvider{Signer: granter}
+	exec := authz.NewMsgExec(grantee, []sdk.Msg{vote})
+
+	wrapped := wrapGrantedMsgsInExec(grantee, []sdk.Msg{vote, commit, unjail, &exec})
+	require.Len(t, wrapped, 4)
+
+	// the votes and randomness commits are wrapped on their own
+	for i, msg := range []sdk.Msg{vote, commit} {
+		msgExec, ok := wrapped[i].(*authz.MsgExec)
+		require.True(t, ok)
+		require.Equal(t, grantee.String(), msgExec.Grantee)
+		msgs, err := msgExec.GetMessages()
+		require.NoError(t, err)
+		require.Equal(t, []sdk.Msg{msg}, msgs)
+	}
+
+	// while the msgs which are not granted are sent as they are
+	require.Same(t, unjail, wrapped[2])
+	require.Same(t, &exec, wrapped[3])
+
+	// nothing is wrapped without a granter
+	bc := &BabylonConsumerController{cfg: &fpcfg.BBNConfig{}}
+	msgs := []sdk.Msg{vote, commit}
+	require.Equal(t, msgs, bc.wrapMsgsInExec(msgs))
+
+	// the unjail msg is not granted, so it cannot be sent with a granter
+	bc.cfg.Granter = granter
+	fpSk, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	_, err = bc.UnjailFinalityProvider(t.Context(), fpSk.PubKey())
+	require.ErrorContains(t, err, "must be signed by the finality provider address")
+}
+
+func TestReliablySendMsgsWithFeeGranter(t *testing.T) {
+	t.Parallel()
+	feeGranter := sdk.MustBech32ifyAddressBytes("bbn", []byte("fee_granter_address_"))
+	msgs := []sdk.Msg{&finalitytypes.MsgAddFinalitySig{Signer: feeGranter, BlockHeight: 10}}
+	newController := func(nextSequence uint64) *BabylonConsumerController {
+		return &BabylonConsumerController{
+			cfg: &fpcfg.BBNConfig{
+				Key:           "key",
+				AccountPrefix: "bbn",
+				FeeGranter:    feeGranter,
+				BlockTimeout:  time.Second,
+			},
+			logger:         zap.NewNop(),
+			feeGrantWallet: &babylonclient.WalletState{NextAccountSequence: nextSequence},
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		bc := newController(7)
+		p := &fakeFeeGrantProvider{resp: &babylonclient.RelayerTxResponse{TxHash: "hash"}}
+
+		resp, err := bc.reliablySendMsgsWithFeeGranter(t.Context(), p, msgs, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, "hash", resp.TxHash)
+		require.Equal(t, []uint64{7}, p.buildSequences)
+		require.Equal(t, uint64(8), bc.feeGrantWallet.NextAccountSequence)
+	})
+
+	t.Run("sequence reset on mismatch", func(t *testing.T) {
+		t.Parallel()
+		bc := newController(7)
+		p := &fakeFeeGrantProvider{
+			chainSequence: 3,
+			broadcastErrs: []error{legacyerrors.ErrWrongSequence.Wrap("account sequence mismatch, expected 3, got 7")},
+			resp:          &babylonclient.RelayerTxResponse{TxHash: "hash"},
+		}
+
+		resp, err := bc.reliablySendMsgsWithFeeGranter(t.Context(), p, msgs, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, "hash", resp.TxHash)
+		// the retry is built with the sequence of the chain
+		require.Equal(t, []uint64{7, 3}, p.buildSequences)
+		require.Equal(t, uint64(4), bc.feeGrantWallet.NextAccountSequence)
+	})
+
+	t.Run("sequence kept on other errors", func(t *testing.T) {
+		t.Parallel()
+		bc := newController(7)
+		p := &fakeFeeGrantProvider{
+			chainSequence: 3,
+			buildErrs:     []error{errors.New("connection refused")},
+			resp:          &babylonclient.RelayerTxResponse{TxHash: "hash"},
+		}
+
+		_, err := bc.reliablySendMsgsWithFeeGranter(t.Context(), p, msgs, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{7, 7}, p.buildSequences)
+		require.Equal(t, uint64(8), bc.feeGrantWallet.NextAccountSequence)
+	})
+
+	t.Run("unrecoverable error", func(t *testing.T) {
+		t.Parallel()
+		bc := newController(7)
+		p := &fakeFeeGrantProvider{broadcastErrs: []error{errTestUnrecoverable.Wrap("rejected")}}
+
+		_, err := bc.reliablySendMsgsWithFeeGranter(t.Context(), p, msgs, nil, []*sdkErr.Error{errTestUnrecoverable})
+		require.ErrorContains(t, err, "unrecoverable")
+		require.Len(t, p.buildSequences, 1)
+	})
+
+	t.Run("expected error", func(t *testing.T) {
+		t.Parallel()
+		bc := newController(7)
+		p := &fakeFeeGrantProvider{broadcastErrs: []error{errTestExpected.Wrap("already done")}}
+
+		resp, err := bc.reliablySendMsgsWithFeeGranter(t.Context(), p, msgs, []*sdkErr.Error{errTestExpected}, nil)
+		require.NoError(t, err)
+		require.Nil(t, resp)
+		require.Len(t, p.buildSequences, 1)
+	})
+
+	t.Run("failed tx", func(t *testing.T) {
+		t.Parallel()
+		bc := newController(7)
+		p := &fakeFeeGrantProvider{resp: &babylonclient.RelayerTxResponse{TxHash: "hash", Code: 5}}
+
+		resp, err := bc.reliablySendMsgsWithFeeGranter(t.Context(), p, msgs, nil, nil)
+		require.ErrorContains(t, err, "transaction failed with code: 5")
+		require.Equal(t, "hash", resp.TxHash)
+	})
+
+	t.Run("invalid fee granter", func(t *testing.T) {
+		t.Parallel()
+		bc := newController(7)
+		bc.cfg.FeeGranter = "invalid"
+
+		_, err := bc.reliablySendMsgsWithFeeGranter(t.Context(), &fakeFeeGrantProvider{}, msgs, nil, nil)
+		require.ErrorContains(t, err, "invalid fee granter address")
+	})
+}
+
+// fakeFeeGrantProvider builds the txs with the tracked account sequence, or the
+// one of the chain if it is not tracked, and includes the broadcast txs in a block
+type fakeFeeGrantProvider struct {
+	chainSequence uint64
+	buildErrs     []error
+	broadcastErrs []error
+	resp          *babylonclient.RelayerTxResponse
+
+	buildSequences []uint64
+}
+
+func (p *fakeFeeGrantProvider) BuildMessages(
+	_ context.Context,
+	_ tx.Factory,
+	_ []babylonclient.RelayerMessage,
+	_ string,
+	_ uint64,
+	_ string,
+	sequenceGuard *babylonclient.WalletState,
+) ([]byte, uint64, sdk.Coins, error) {
+	sequence := sequenceGuard.NextAccountSequence
+	if sequence == 0 {
+		sequence = p.chainSequence
+	}
+	p.buildSequences = append(p.buildSequences, sequence)
+
+	if len(p.buildErrs) > 0 {
+		err := p.buildErrs[0]
+		p.buildErrs = p.buildErrs[1:]
+
+		return nil, 0, nil, err
+	}
+
+	return []byte("tx"), sequence, nil, nil
+}
+
+func (p *fakeFeeGrantProvider) BroadcastTx(
+	_ context.Context,
+	_ []byte,
+	_ context.Context,
+	_ time.Duration,
+	asyncCallbacks []func(*babylonclient.RelayerTxResponse, error),
+) error {
+	if len(p.broadcastErrs) > 0 {
+		err := p.broadcastErrs[0]
+		p.broadcastErrs = p.broadcastErrs[1:]
+
+		return err
+	}
+
+	for _, cb := range asyncCallbacks {
+		cb(p.resp, nil)
+	}
+
+	return nil
+}
+
+func (p *fakeFeeGrantProvider) UpdateNextAccountSequence(sequenceGuard *babylonclient.WalletState, seq uint64) {
+	if seq > sequenceGuard.NextAccountSequence {
+		sequenceGuard.NextAccountSequence = seq
+	}
+}
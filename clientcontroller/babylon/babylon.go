@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/babylonlabs-io/babylon/v4/client/babylonclient"
 
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 
 	sdkErr "cosmossdk.io/errors"
+	"cosmossdk.io/math"
 	bbnclient "github.com/babylonlabs-io/babylon/v4/client/client"
 	bbntypes "github.com/babylonlabs-io/babylon/v4/types"
 	btcctypes "github.com/babylonlabs-io/babylon/v4/x/btccheckpoint/types"
@@ -18,8 +20,10 @@ import (
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/cosmos/cosmos-sdk/client"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	sttypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"go.uber.org/zap"
 	protobuf "google.golang.org/protobuf/proto"
@@ -47,6 +51,9 @@ type ClientWrapper struct {
 	bbnClient *bbnclient.Client
 	cfg       *fpcfg.BBNConfig
 	logger    *zap.Logger
+	// gasPricesMu guards the gas prices of the client, which are read
+	// while the transactions are built
+	gasPricesMu sync.RWMutex
 }
 
 func NewBabylonController(
@@ -55,12 +62,20 @@ func NewBabylonController(
 	logger *zap.Logger,
 ) (*ClientWrapper, error) {
 	return &ClientWrapper{
-		bbnClient,
-		cfg,
-		logger,
+		bbnClient: bbnClient,
+		cfg:       cfg,
+		logger:    logger,
 	}, nil
 }
 
+// SetGasPrices sets the gas prices of the transactions sent from now on
+func (bc *ClientWrapper) SetGasPrices(gasPrices string) {
+	bc.gasPricesMu.Lock()
+	defer bc.gasPricesMu.Unlock()
+
+	bc.bbnClient.Provider().PCfg.GasPrices = gasPrices
+}
+
 func (bc *ClientWrapper) Start() error {
 	// makes sure that the key in config really exists and is a valid bech32 addr
 	// to allow using mustGetTxSigner
@@ -111,6 +126,9 @@ func (bc *ClientWrapper) reliablySendMsg(ctx context.Context, msg sdk.Msg, expec
 }
 
 func (bc *ClientWrapper) reliablySendMsgs(ctx context.Context, msgs []sdk.Msg, expectedErrs []*sdkErr.Error, unrecoverableErrs []*sdkErr.Error) (*babylonclient.RelayerTxResponse, error) {
+	bc.gasPricesMu.RLock()
+	defer bc.gasPricesMu.RUnlock()
+
 	resp, err := bc.bbnClient.ReliablySendMsgs(
 		ctx,
 		msgs,
@@ -223,6 +241,37 @@ func (bc *ClientWrapper) QueryFinalityProvider(_ context.Context, fpPk *btcec.Pu
 	return res, nil
 }
 
+// QueryFeePayerBalance queries the balance of the fee granter if one is
+// configured, otherwise the balance of the key signing the transactions
+func (bc *ClientWrapper) QueryFeePayerBalance(ctx context.Context, denom string) (*api.FeePayerBalanceResponse, error) {
+	addr := bc.cfg.FeeGranter
+	if addr == "" {
+		addr = bc.MustGetTxSigner()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, bc.cfg.Timeout)
+	defer cancel()
+
+	queryClient := banktypes.NewQueryClient(client.Context{Client: bc.bbnClient.RPCClient})
+	res, err := queryClient.Balance(ctx, &banktypes.QueryBalanceRequest{
+		Address: addr,
+		Denom:   denom,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the balance of %s: %w", addr, err)
+	}
+
+	amount := math.ZeroInt()
+	if res.Balance != nil {
+		amount = res.Balance.Amount
+	}
+
+	return &api.FeePayerBalanceResponse{
+		Address: addr,
+		Amount:  amount,
+	}, nil
+}
+
 func (bc *ClientWrapper) NodeTxIndexEnabled() (bool, error) {
 	res, err := bc.bbnClient.GetStatus()
 	if err != nil {
@@ -7,6 +7,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	sdkErr "cosmossdk.io/errors"
 	"github.com/babylonlabs-io/babylon/v4/client/babylonclient"
@@ -27,6 +29,7 @@ import (
 )
 
 var _ api.ConsumerController = &BabylonConsumerController{}
+var _ api.BlockTimeQuerier = &BabylonConsumerController{}
 var messageIndexRegex = regexp.MustCompile(`message index:\s*(\d+)`)
 
 //nolint:revive
@@ -34,6 +37,12 @@ type BabylonConsumerController struct {
 	bbnClient *bbnclient.Client
 	cfg       *fpcfg.BBNConfig
 	logger    *zap.Logger
+	// feeGrantWallet guards the account sequence of the key when
+	// transactions are built with a fee granter
+	feeGrantWallet *babylonclient.WalletState
+	// gasPricesMu guards the gas prices of the client, which are read
+	// while the transactions are built
+	gasPricesMu sync.RWMutex
 }
 
 func NewBabylonConsumerController(
@@ -54,12 +63,21 @@ func NewBabylonConsumerController(
 	}
 
 	return &BabylonConsumerController{
-		bc,
-		cfg,
-		logger,
+		bbnClient:      bc,
+		cfg:            cfg,
+		logger:         logger,
+		feeGrantWallet: &babylonclient.WalletState{},
 	}, nil
 }
 
+// SetGasPrices sets the gas prices of the transactions sent from now on
+func (bc *BabylonConsumerController) SetGasPrices(gasPrices string) {
+	bc.gasPricesMu.Lock()
+	defer bc.gasPricesMu.Unlock()
+
+	bc.bbnClient.Provider().PCfg.GasPrices = gasPrices
+}
+
 func (bc *BabylonConsumerController) MustGetTxSigner() string {
 	signer := bc.GetKeyAddress()
 	prefix := bc.cfg.AccountPrefix
@@ -67,6 +85,16 @@ func (bc *BabylonConsumerController) MustGetTxSigner() string {
 	return sdk.MustBech32ifyAddressBytes(prefix, signer)
 }
 
+// MustGetMsgSigner returns the address set as signer of the finality provider
+// messages. It is the authz granter if one is configured, otherwise the tx signer
+func (bc *BabylonConsumerController) MustGetMsgSigner() string {
+	if bc.cfg.Granter != "" {
+		return bc.cfg.Granter
+	}
+
+	return bc.MustGetTxSigner()
+}
+
 func (bc *BabylonConsumerController) Client() *bbnclient.Client {
 	return bc.bbnClient
 }
@@ -97,9 +125,9 @@ func (bc *BabylonConsumerController) reliablySendMsg(ctx context.Context, msg sd
 }
 
 func (bc *BabylonConsumerController) reliablySendMsgs(ctx context.Context, msgs []sdk.Msg, expectedErrs []*sdkErr.Error, unrecoverableErrs []*sdkErr.Error) (*babylonclient.RelayerTxResponse, error) {
-	resp, err := bc.bbnClient.ReliablySendMsgs(
+	resp, err := bc.sendMsgs(
 		ctx,
-		msgs,
+		bc.wrapMsgsInExec(msgs),
 		expectedErrs,
 		unrecoverableErrs,
 	)
@@ -117,7 +145,7 @@ func (bc *BabylonConsumerController) CommitPubRandList(
 	req *api.CommitPubRandListRequest,
 ) (*types.TxResponse, error) {
 	msg := &finalitytypes.MsgCommitPubRandList{
-		Signer:      bc.MustGetTxSigner(),
+		Signer:      bc.MustGetMsgSigner(),
 		FpBtcPk:     bbntypes.NewBIP340PubKeyFromBTCPK(req.FpPk),
 		StartHeight: req.StartHeight,
 		NumPubRand:  req.NumPubRand,
@@ -157,7 +185,7 @@ func (bc *BabylonConsumerController) SubmitBatchFinalitySigs(
 		}
 
 		msg := &finalitytypes.MsgAddFinalitySig{
-			Signer:       bc.MustGetTxSigner(),
+			Signer:       bc.MustGetMsgSigner(),
 			FpBtcPk:      bbntypes.NewBIP340PubKeyFromBTCPK(req.FpPk),
 			BlockHeight:  b.GetHeight(),
 			PubRand:      bbntypes.NewSchnorrPubRandFromFieldVal(req.PubRandList[i]),
@@ -236,6 +264,24 @@ func (bc *BabylonConsumerController) QueryBlocks(_ context.Context, req *api.Que
 	return bc.queryLatestBlocks(sdk.Uint64ToBigEndian(req.StartHeight), count, finalitytypes.QueriedBlockStatus_ANY, false)
 }
 
+// QueryBlockTimes returns the production times of the latest blocks of the range,
+// at most 20, from their comet headers in a single query
+func (bc *BabylonConsumerController) QueryBlockTimes(ctx context.Context, startHeight, endHeight uint64) (map[uint64]time.Time, error) {
+	// #nosec G115 -- block heights fit in int64
+	chainInfo, err := bc.bbnClient.RPCClient.BlockchainInfo(ctx, int64(startHeight), int64(endHeight))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the block headers from %d to %d: %w", startHeight, endHeight, err)
+	}
+
+	times := make(map[uint64]time.Time, len(chainInfo.BlockMetas))
+	for _, meta := range chainInfo.BlockMetas {
+		// #nosec G115 -- block heights are positive
+		times[uint64(meta.Header.Height)] = meta.Header.Time
+	}
+
+	return times, nil
+}
+
 func (bc *BabylonConsumerController) queryLatestBlocks(startKey []byte, count uint64, status finalitytypes.QueriedBlockStatus, reverse bool) ([]types.BlockDescription, error) {
 	var blocks []types.BlockDescription
 	pagination := &sdkquery.PageRequest{
@@ -413,8 +459,15 @@ func (bc *BabylonConsumerController) Close() error {
 
 // UnjailFinalityProvider sends an unjail transaction to the consumer chain
 func (bc *BabylonConsumerController) UnjailFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey) (*types.TxResponse, error) {
+	// the unjail msg is not granted to the key, it has
+	// to be signed by the finality provider address
+	if bc.cfg.Granter != "" {
+		return nil, fmt.Errorf("the unjail tx must be signed by the finality provider address %s, "+
+			"which does not sign on this host when a granter is configured", bc.cfg.Granter)
+	}
+
 	msg := &finalitytypes.MsgUnjailFinalityProvider{
-		Signer:  bc.MustGetTxSigner(),
+		Signer:  bc.MustGetMsgSigner(),
 		FpBtcPk: bbntypes.NewBIP340PubKeyFromBTCPK(fpPk),
 	}
 
@@ -499,6 +552,9 @@ func (bc *BabylonConsumerController) reliablySendMsgsResendingOnMsgErr(
 	unrecoverableErrs []*sdkErr.Error,
 ) (*types.TxResponse, error) {
 	var err error
+	// each msg is wrapped on its own so the failed message index
+	// reported by the chain still maps to the original msg
+	msgs = bc.wrapMsgsInExec(msgs)
 	maxRetries := BatchRetries(msgs, bc.cfg.MaxRetriesBatchRemovingMsgs)
 	for i := uint64(0); i < maxRetries; i++ {
 		// Combine expectedErrs and unrecoverableErrs for fail-fast behavior
@@ -506,7 +562,7 @@ func (bc *BabylonConsumerController) reliablySendMsgsResendingOnMsgErr(
 		// rather than retrying, so we can handle them by removing the message
 		allUnrecoverable := append([]*sdkErr.Error{}, unrecoverableErrs...)
 		allUnrecoverable = append(allUnrecoverable, expectedErrs...)
-		res, errSendMsg := bc.bbnClient.ReliablySendMsgs(ctx, msgs, nil, allUnrecoverable)
+		res, errSendMsg := bc.sendMsgs(ctx, msgs, nil, allUnrecoverable)
 		if errSendMsg != nil {
 			// concatenate the errors, to throw out if needed
 			err = errors.Join(err, errSendMsg)
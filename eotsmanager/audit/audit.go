@@ -0,0 +1,302 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+	// OutcomePending is the outcome of the entry recording a signing request
+	// before the signing, which is followed by the entry of its outcome
+	OutcomePending = "pending"
+
+	// maxEntrySize is the largest entry the reader accepts
+	maxEntrySize = 1024 * 1024
+
+	// MethodRecovery is the method of the entry recording
+	// the truncation of a partially written entry
+	MethodRecovery = "AuditLogRecovery"
+)
+
+// genesisHash is the previous hash of the first entry of the log
+var genesisHash = hex.EncodeToString(make([]byte, sha256.Size))
+
+var ErrChainBroken = errors.New("audit log hash chain is broken")
+
+// Entry is a single record of the audit log. Each entry commits to the hash of
+// the previous one, so that removing, reordering or changing an entry breaks
+// the hash chain of all the following entries.
+type Entry struct {
+	Seq      uint64    `json:"seq"`
+	Time     time.Time `json:"time"`
+	PrevHash string    `json:"prev_hash"`
+
+	Method      string   `json:"method"`
+	CallerAddr  string   `json:"caller_addr,omitempty"`
+	CallerAuth  string   `json:"caller_auth,omitempty"`
+	CallerCert  string   `json:"caller_cert,omitempty"`
+	CallerKeyID string   `json:"caller_key_id,omitempty"`
+	EotsPk      string   `json:"eots_pk,omitempty"`
+	ChainID     string   `json:"chain_id,omitempty"`
+	Heights     []uint64 `json:"heights,omitempty"`
+	StartHeight uint64   `json:"start_height,omitempty"`
+	Num         uint32   `json:"num,omitempty"`
+	MsgHashes   []string `json:"msg_hashes,omitempty"`
+	Details     string   `json:"details,omitempty"`
+	Outcome     string   `json:"outcome"`
+	Error       string   `json:"error,omitempty"`
+
+	Hash string `json:"hash"`
+}
+
+// computeHash returns the hash of the entry, which covers all the
+// fields except the hash itself
+func (e *Entry) computeHash() (string, error) {
+	c := *e
+	c.Hash = ""
+
+	bz, err := json.Marshal(&c)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+
+	h := sha256.Sum256(bz)
+
+	return hex.EncodeToString(h[:]), nil
+}
+
+// MsgHash returns the hex encoded sha256 of the signed msg,
+// so that the log does not contain the msgs themselves
+func MsgHash(msg []byte) string {
+	h := sha256.Sum256(msg)
+
+	return hex.EncodeToString(h[:])
+}
+
+// Logger appends entries to the audit log file
+type Logger struct {
+	mu       sync.Mutex
+	f        *os.File
+	seq      uint64
+	lastHash string
+}
+
+// Open opens the audit log at the given path, creating it if it does not exist.
+// The existing entries are verified, so that new entries are not chained to a
+// tampered log. A partially written last entry, e.g., after a crash during a
+// write, is truncated and the recovery is recorded in the log.
+func Open(path string) (*Logger, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	res, torn, err := recoverLog(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// #nosec G304 - The path is provided by the operator config
+	f, err := os.OpenFile(filepath.Clean(path), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	l := &Logger{f: f, lastHash: genesisHash}
+	if res != nil && res.Entries > 0 {
+		l.seq = res.LastSeq
+		l.lastHash = res.LastHash
+	}
+
+	if torn > 0 {
+		if err := l.Append(&Entry{
+			Method:  MethodRecovery,
+			Details: fmt.Sprintf("truncated a partially written entry of %d bytes", torn),
+			Outcome: OutcomeSuccess,
+		}); err != nil {
+			_ = f.Close()
+
+			return nil, err
+		}
+	}
+
+	return l, nil
+}
+
+// recoverLog verifies the complete entries of the audit log at the given path and
+// truncates the bytes after the last one, which are left by an interrupted write.
+// It returns the size of the truncated part.
+func recoverLog(path string) (*VerifyResult, int64, error) {
+	// #nosec G304 - The path is provided by the operator config
+	f, err := os.OpenFile(filepath.Clean(path), os.O_RDWR, 0)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to stat audit log: %w", err)
+	}
+	size := info.Size()
+
+	// each entry is written with its newline at once, so
+	// only the last line of the log can be incomplete
+	tail := min(size, maxEntrySize+1)
+	buf := make([]byte, tail)
+	if _, err := f.ReadAt(buf, size-tail); err != nil {
+		return nil, 0, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	complete := size
+	if i := bytes.LastIndexByte(buf, '\n'); i < len(buf)-1 {
+		if i < 0 && tail < size {
+			return nil, 0, fmt.Errorf("%w: the last entry is too large", ErrChainBroken)
+		}
+		complete = size - tail + int64(i) + 1
+	}
+
+	res, err := VerifyReader(io.NewSectionReader(f, 0, complete))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if complete < size {
+		if err := f.Truncate(complete); err != nil {
+			return nil, 0, fmt.Errorf("failed to truncate audit log: %w", err)
+		}
+		if err := f.Sync(); err != nil {
+			return nil, 0, fmt.Errorf("failed to sync audit log: %w", err)
+		}
+	}
+
+	return res, size - complete, nil
+}
+
+// Append chains the entry to the log and writes it to disk. The sequence number,
+// time and hashes of the entry are set by the logger.
+func (l *Logger) Append(e *Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e.Seq = l.seq + 1
+	e.Time = time.Now().UTC()
+	e.PrevHash = l.lastHash
+
+	hash, err := e.computeHash()
+	if err != nil {
+		return err
+	}
+	e.Hash = hash
+
+	bz, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+
+	if _, err := l.f.Write(append(bz, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+
+	if err := l.f.Sync(); err != nil {
+		return fmt.Errorf("failed to sync audit log: %w", err)
+	}
+
+	l.seq = e.Seq
+	l.lastHash = e.Hash
+
+	return nil
+}
+
+// Close closes the audit log file
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.f.Close(); err != nil {
+		return fmt.Errorf("failed to close audit log: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyResult is the summary of a verified audit log
+type VerifyResult struct {
+	Entries  uint64 `json:"entries"`
+	LastSeq  uint64 `json:"last_seq"`
+	LastHash string `json:"last_hash"`
+}
+
+// Verify checks the hash chain of the audit log at the given path
+func Verify(path string) (*VerifyResult, error) {
+	// #nosec G304 - The path is provided by the operator
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	return VerifyReader(f)
+}
+
+// VerifyReader checks the hash chain of the audit log entries read from r
+func VerifyReader(r io.Reader) (*VerifyResult, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEntrySize)
+
+	res := &VerifyResult{LastHash: genesisHash}
+	line := 0
+	for scanner.Scan() {
+		line++
+		bz := scanner.Bytes()
+		if len(bytes.TrimSpace(bz)) == 0 {
+			return nil, fmt.Errorf("%w: empty entry at line %d", ErrChainBroken, line)
+		}
+
+		var e Entry
+		dec := json.NewDecoder(bytes.NewReader(bz))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&e); err != nil {
+			return nil, fmt.Errorf("%w: invalid entry at line %d: %s", ErrChainBroken, line, err.Error())
+		}
+
+		if e.Seq != res.LastSeq+1 {
+			return nil, fmt.Errorf("%w: expected seq %d at line %d, got %d", ErrChainBroken, res.LastSeq+1, line, e.Seq)
+		}
+
+		if e.PrevHash != res.LastHash {
+			return nil, fmt.Errorf("%w: entry %d does not link to the previous entry", ErrChainBroken, e.Seq)
+		}
+
+		hash, err := e.computeHash()
+		if err != nil {
+			return nil, err
+		}
+		if hash != e.Hash {
+			return nil, fmt.Errorf("%w: entry %d was modified", ErrChainBroken, e.Seq)
+		}
+
+		res.Entries++
+		res.LastSeq = e.Seq
+		res.LastHash = e.Hash
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,126 @@
+package audit_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/audit"
+)
+
+func TestAuditLogHashChain(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "audit", "audit.log")
+
+	l, err := audit.Open(path)
+	require.NoError(t, err)
+
+	for i := uint64(1); i <= 3; i++ {
+		require.NoError(t, l.Append(&audit.Entry{
+			Method:    "SignEOTS",
+			EotsPk:    "pk",
+			ChainID:   "chain",
+			Heights:   []uint64{i},
+			MsgHashes: []string{audit.MsgHash([]byte("msg"))},
+			Outcome:   audit.OutcomeSuccess,
+		}))
+	}
+	require.NoError(t, l.Close())
+
+	res, err := audit.Verify(path)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), res.Entries)
+
+	// reopening the log keeps chaining the entries
+	l, err = audit.Open(path)
+	require.NoError(t, err)
+	require.NoError(t, l.Append(&audit.Entry{Method: "UnlockKey", Outcome: audit.OutcomeFailure, Error: "wrong passphrase"}))
+	require.NoError(t, l.Close())
+
+	res, err = audit.Verify(path)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), res.Entries)
+	require.Equal(t, uint64(4), res.LastSeq)
+
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(bz)), "\n")
+	require.Len(t, lines, 4)
+
+	// modified entry
+	tampered := append([]string{}, lines...)
+	tampered[1] = strings.Replace(tampered[1], `"heights":[2]`, `"heights":[5]`, 1)
+	_, err = audit.VerifyReader(strings.NewReader(strings.Join(tampered, "\n")))
+	require.ErrorIs(t, err, audit.ErrChainBroken)
+
+	// removed entry
+	removed := append(append([]string{}, lines[:1]...), lines[2:]...)
+	_, err = audit.VerifyReader(strings.NewReader(strings.Join(removed, "\n")))
+	require.ErrorIs(t, err, audit.ErrChainBroken)
+
+	// reordered entries
+	reordered := []string{lines[0], lines[2], lines[1], lines[3]}
+	_, err = audit.VerifyReader(strings.NewReader(strings.Join(reordered, "\n")))
+	require.ErrorIs(t, err, audit.ErrChainBroken)
+
+	// truncating the tail cannot be detected from the log alone,
+	// the last hash has to be compared with a previous verification
+	res, err = audit.VerifyReader(strings.NewReader(strings.Join(lines[:3], "\n")))
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), res.Entries)
+
+	// a tampered log is not reopened
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(tampered, "\n")+"\n"), 0600))
+	_, err = audit.Open(path)
+	require.ErrorIs(t, err, audit.ErrChainBroken)
+}
+
+func TestAuditLogTornEntry(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	l, err := audit.Open(path)
+	require.NoError(t, err)
+	for i := uint64(1); i <= 2; i++ {
+		require.NoError(t, l.Append(&audit.Entry{Method: "SignEOTS", Heights: []uint64{i}, Outcome: audit.OutcomeSuccess}))
+	}
+	require.NoError(t, l.Close())
+
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	// a crash in the middle of a write leaves a partial last entry
+	torn := append(append([]byte{}, bz...), []byte(`{"seq":3,"time":"20`)...)
+	require.NoError(t, os.WriteFile(path, torn, 0600))
+	_, err = audit.Verify(path)
+	require.ErrorIs(t, err, audit.ErrChainBroken)
+
+	// reopening the log truncates the partial entry and records the recovery
+	l, err = audit.Open(path)
+	require.NoError(t, err)
+	require.NoError(t, l.Append(&audit.Entry{Method: "SignEOTS", Heights: []uint64{3}, Outcome: audit.OutcomeSuccess}))
+	require.NoError(t, l.Close())
+
+	res, err := audit.Verify(path)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), res.Entries)
+
+	recovered, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(recovered), string(bz)))
+	lines := strings.Split(strings.TrimSpace(string(recovered)), "\n")
+	require.Contains(t, lines[2], `"method":"`+audit.MethodRecovery+`"`)
+	require.Contains(t, lines[2], "19 bytes")
+
+	// a broken hash chain before the partial entry is not recovered
+	tampered := strings.Replace(string(bz), `"heights":[2]`, `"heights":[5]`, 1)
+	require.NoError(t, os.WriteFile(path, []byte(tampered+`{"seq":3`), 0600))
+	_, err = audit.Open(path)
+	require.ErrorIs(t, err, audit.ErrChainBroken)
+	unchanged, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, tampered+`{"seq":3`, string(unchanged))
+}
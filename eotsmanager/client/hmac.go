@@ -3,9 +3,13 @@ package client
 import (
 	"context"
 	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"strconv"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
@@ -15,8 +19,58 @@ import (
 const (
 	// HMACHeaderKey is the metadata key for the HMAC
 	HMACHeaderKey = "X-FPD-HMAC"
+	// HMACTimestampHeaderKey is the metadata key for the time the request was
+	// signed at, in unix milliseconds
+	HMACTimestampHeaderKey = "X-FPD-HMAC-Timestamp"
+	// HMACNonceHeaderKey is the metadata key for the random nonce of the request
+	HMACNonceHeaderKey = "X-FPD-HMAC-Nonce"
+	// HMACKeyIDHeaderKey is the metadata key for the ID of the HMAC key. The
+	// server uses the key of its config if it is not set.
+	HMACKeyIDHeaderKey = "X-FPD-HMAC-Key-ID"
+
+	hmacNonceSize = 16
 )
 
+// ComputeHMAC returns the HMAC of a request, which covers the full gRPC method
+// name, the timestamp and the nonce of the request so that a captured request
+// cannot be replayed later or for another method.
+func ComputeHMAC(hmacKey, method, timestamp, nonce string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(hmacKey))
+	h.Write([]byte(method))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(timestamp))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(nonce))
+	h.Write([]byte{'\n'})
+	h.Write(body)
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// ComputeLegacyHMAC returns the HMAC over the request body only, as sent by
+// clients which do not support replay protection
+func ComputeLegacyHMAC(hmacKey string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(hmacKey))
+	h.Write(body)
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// WithHMACKeyID returns a dial option sending the ID of the HMAC key along
+// with the HMAC, so that the server can accept several keys during a rotation
+func WithHMACKeyID(keyID string) grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		return invoker(metadata.AppendToOutgoingContext(ctx, HMACKeyIDHeaderKey, keyID), method, req, reply, cc, opts...)
+	})
+}
+
 // HMACUnaryClientInterceptor creates a gRPC client interceptor that adds HMAC
 // to outgoing requests. It skips adding HMAC for the Ping method and SaveEOTSKeyName.
 func HMACUnaryClientInterceptor(hmacKey string) grpc.UnaryClientInterceptor {
@@ -51,10 +105,14 @@ func HMACUnaryClientInterceptor(hmacKey string) grpc.UnaryClientInterceptor {
 			return fmt.Errorf("failed to marshal request: %w", err)
 		}
 
-		// Generate HMAC using SHA-256
-		h := hmac.New(sha256.New, []byte(hmacKey))
-		h.Write(data)
-		hmacValue := base64.StdEncoding.EncodeToString(h.Sum(nil))
+		nonceBz := make([]byte, hmacNonceSize)
+		if _, err := rand.Read(nonceBz); err != nil {
+			return fmt.Errorf("failed to generate HMAC nonce: %w", err)
+		}
+		nonce := hex.EncodeToString(nonceBz)
+		timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+
+		hmacValue := ComputeHMAC(hmacKey, method, timestamp, nonce, data)
 
 		// Add HMAC to outgoing context
 		md, ok := metadata.FromOutgoingContext(ctx)
@@ -64,6 +122,8 @@ func HMACUnaryClientInterceptor(hmacKey string) grpc.UnaryClientInterceptor {
 			md = md.Copy()
 		}
 		md.Set(HMACHeaderKey, hmacValue)
+		md.Set(HMACTimestampHeaderKey, timestamp)
+		md.Set(HMACNonceHeaderKey, nonce)
 		newCtx := metadata.NewOutgoingContext(ctx, md)
 
 		return invoker(newCtx, method, req, reply, cc, opts...)
@@ -28,10 +28,6 @@ func TestHMACGeneration(t *testing.T) {
 	data, err := protobuf.Marshal(testReq)
 	require.NoError(t, err)
 
-	h := hmac.New(sha256.New, []byte(testKey))
-	h.Write(data)
-	expectedHMAC := base64.StdEncoding.EncodeToString(h.Sum(nil))
-
 	var capturedMD metadata.MD
 	fakeInvoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
 		md, ok := metadata.FromOutgoingContext(ctx)
@@ -41,10 +37,11 @@ func TestHMACGeneration(t *testing.T) {
 		return nil
 	}
 
+	method := "/proto.EOTSManager/SignEOTS"
 	interceptor := HMACUnaryClientInterceptor(testKey)
 	err = interceptor(
 		t.Context(),
-		"/proto.EOTSManager/SignEOTS",
+		method,
 		testReq,
 		nil,
 		nil,
@@ -52,7 +49,22 @@ func TestHMACGeneration(t *testing.T) {
 	)
 	require.NoError(t, err)
 
+	timestamps := capturedMD.Get(HMACTimestampHeaderKey)
+	require.Len(t, timestamps, 1)
+	nonces := capturedMD.Get(HMACNonceHeaderKey)
+	require.Len(t, nonces, 1)
+
+	h := hmac.New(sha256.New, []byte(testKey))
+	h.Write([]byte(method + "\n" + timestamps[0] + "\n" + nonces[0] + "\n"))
+	h.Write(data)
+	expectedHMAC := base64.StdEncoding.EncodeToString(h.Sum(nil))
+
 	hmacValues := capturedMD.Get(HMACHeaderKey)
 	require.Len(t, hmacValues, 1)
 	require.Equal(t, expectedHMAC, hmacValues[0])
+
+	// every request gets a fresh nonce
+	err = interceptor(t.Context(), method, testReq, nil, nil, fakeInvoker)
+	require.NoError(t, err)
+	require.NotEqual(t, nonces[0], capturedMD.Get(HMACNonceHeaderKey)[0])
 }
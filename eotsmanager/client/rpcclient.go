@@ -3,6 +3,8 @@ package client
 import (
 	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
@@ -21,14 +23,16 @@ type EOTSManagerGRPCClient struct {
 }
 
 // NewEOTSManagerGRPCClient creates a new EOTS manager gRPC client
-// The hmacKey parameter is used for authentication with the EOTS manager server
+// The hmacKey parameter is used for authentication with the EOTS manager server.
+// The connection is insecure unless grpcOpts set the transport credentials, e.g., to use TLS.
 func NewEOTSManagerGRPCClient(remoteAddr string, hmacKey string, grpcOpts ...grpc.DialOption) (*EOTSManagerGRPCClient, error) {
 	processedHmacKey, err := ProcessHMACKey(hmacKey)
 	if err != nil {
-		// Log warning and continue without HMAC authentication
-		fmt.Printf("Warning: Failed to process HMAC key: %v\n", err)
+		return nil, fmt.Errorf("failed to get the HMAC key: %w", err)
 	}
 
+	// the transport credentials set in grpcOpts take
+	// precedence, as the last option applied wins
 	dialOpts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 	}
@@ -41,7 +45,8 @@ func NewEOTSManagerGRPCClient(remoteAddr string, hmacKey string, grpcOpts ...grp
 	if processedHmacKey != "" {
 		dialOpts = append(dialOpts, grpc.WithUnaryInterceptor(HMACUnaryClientInterceptor(processedHmacKey)))
 	} else {
-		fmt.Printf("Warning: HMAC key not configured. Authentication will not be enabled.\n")
+		// the warning goes to stderr so as not to mix with the output of the commands
+		fmt.Fprintf(os.Stderr, "Warning: HMAC key not configured. Authentication will not be enabled.\n")
 	}
 
 	conn, err := grpc.NewClient(remoteAddr, dialOpts...)
@@ -154,7 +159,7 @@ func (c *EOTSManagerGRPCClient) UnsafeSignEOTS(uid, chaiID, msg []byte, height u
 	return &s, nil
 }
 
-func (c *EOTSManagerGRPCClient) SignBatchEOTS(req *eotsmanager.SignBatchEOTSRequest) ([]eotsmanager.SignDataResponse, error) {
+func (c *EOTSManagerGRPCClient) SignBatchEOTS(ctx context.Context, req *eotsmanager.SignBatchEOTSRequest) ([]eotsmanager.SignDataResponse, error) {
 	signRequests := make([]*proto.SignDataRequest, len(req.SignRequest))
 	for i, signReq := range req.SignRequest {
 		signRequests[i] = &proto.SignDataRequest{
@@ -169,7 +174,7 @@ func (c *EOTSManagerGRPCClient) SignBatchEOTS(req *eotsmanager.SignBatchEOTSRequ
 		SignRequests: signRequests,
 	}
 
-	res, err := c.client.SignBatchEOTS(context.Background(), protoReq)
+	res, err := c.client.SignBatchEOTS(ctx, protoReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign batch EOTS: %w", err)
 	}
@@ -217,6 +222,76 @@ func (c *EOTSManagerGRPCClient) Unlock(uid []byte, passphrase string) error {
 	return nil
 }
 
+// LockKey locks an unlocked key of the file keyring of eotsd
+func (c *EOTSManagerGRPCClient) LockKey(uid []byte) error {
+	req := &proto.LockKeyRequest{Uid: uid}
+
+	if _, err := c.client.LockKey(context.Background(), req); err != nil {
+		return fmt.Errorf("failed to lock key: %w", err)
+	}
+
+	return nil
+}
+
+// ListUnlockedKeys returns the unlocked keys of the file keyring of eotsd
+func (c *EOTSManagerGRPCClient) ListUnlockedKeys() ([]*proto.UnlockedKey, error) {
+	res, err := c.client.ListUnlockedKeys(context.Background(), &proto.ListUnlockedKeysRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unlocked keys: %w", err)
+	}
+
+	return res.Keys, nil
+}
+
+// GetSignRecord returns the sign record of the key for the chain at the
+// height, or nil if nothing was signed at the height
+func (c *EOTSManagerGRPCClient) GetSignRecord(uid, chainID []byte, height uint64) (*proto.SignRecord, error) {
+	req := &proto.GetSignRecordRequest{Uid: uid, ChainId: chainID, Height: height}
+
+	res, err := c.client.GetSignRecord(context.Background(), req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sign record: %w", err)
+	}
+	if !res.Found {
+		return nil, nil
+	}
+
+	return res.Record, nil
+}
+
+// ListSignRecords returns a page of the sign records of the key for the
+// chain from startHeight to endHeight inclusive, or with no upper bound if
+// endHeight is 0
+func (c *EOTSManagerGRPCClient) ListSignRecords(uid, chainID []byte, startHeight, endHeight uint64, limit uint32) (*proto.ListSignRecordsResponse, error) {
+	req := &proto.ListSignRecordsRequest{
+		Uid:         uid,
+		ChainId:     chainID,
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+		Limit:       limit,
+	}
+
+	res, err := c.client.ListSignRecords(context.Background(), req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sign records: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetLastSignedHeight returns the highest height signed with the key for the
+// chain, and whether anything was signed
+func (c *EOTSManagerGRPCClient) GetLastSignedHeight(uid, chainID []byte) (uint64, bool, error) {
+	req := &proto.GetLastSignedHeightRequest{Uid: uid, ChainId: chainID}
+
+	res, err := c.client.GetLastSignedHeight(context.Background(), req)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get last signed height: %w", err)
+	}
+
+	return res.Height, res.Found, nil
+}
+
 func (c *EOTSManagerGRPCClient) Backup(dbPath string, backupDir string) (string, error) {
 	req := &proto.BackupRequest{
 		DbPath:    dbPath,
@@ -231,6 +306,56 @@ func (c *EOTSManagerGRPCClient) Backup(dbPath string, backupDir string) (string,
 	return res.BackupName, nil
 }
 
+// AddHMACKey adds an HMAC key accepted by eotsd within the given validity
+// window. A zero time leaves the corresponding bound open.
+func (c *EOTSManagerGRPCClient) AddHMACKey(id, key string, notBefore, notAfter time.Time) error {
+	req := &proto.AddHMACKeyRequest{
+		Id:        id,
+		Key:       key,
+		NotBefore: unixOrZero(notBefore),
+		NotAfter:  unixOrZero(notAfter),
+	}
+
+	if _, err := c.client.AddHMACKey(context.Background(), req); err != nil {
+		return fmt.Errorf("failed to add HMAC key: %w", err)
+	}
+
+	return nil
+}
+
+// RetireHMACKey stops eotsd from accepting the HMAC key from the given
+// time, or immediately if it is zero
+func (c *EOTSManagerGRPCClient) RetireHMACKey(id string, retireAt time.Time) error {
+	req := &proto.RetireHMACKeyRequest{
+		Id:       id,
+		RetireAt: unixOrZero(retireAt),
+	}
+
+	if _, err := c.client.RetireHMACKey(context.Background(), req); err != nil {
+		return fmt.Errorf("failed to retire HMAC key: %w", err)
+	}
+
+	return nil
+}
+
+// ListHMACKeys returns the HMAC keys of eotsd, without their secret
+func (c *EOTSManagerGRPCClient) ListHMACKeys() ([]*proto.HMACKeyInfo, error) {
+	res, err := c.client.ListHMACKeys(context.Background(), &proto.ListHMACKeysRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list HMAC keys: %w", err)
+	}
+
+	return res.Keys, nil
+}
+
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.Unix()
+}
+
 func (c *EOTSManagerGRPCClient) Close() error {
 	if err := c.conn.Close(); err != nil {
 		return fmt.Errorf("failed to close EOTS manager client connection: %w", err)
@@ -1,11 +1,15 @@
 package client
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"os"
+	"os/exec"
 	"strings"
+	"time"
 )
 
-// TODO: Decide on which ones to support
 const (
 	// AWSPrefix is the prefix for AWS Secret Manager references
 	AWSPrefix = "arn:aws:secretsmanager:"
@@ -13,28 +17,76 @@ const (
 	GCPPrefix = "projects/"
 	// AzurePrefix is the prefix for Azure Key Vault references
 	AzurePrefix = "https://"
+	// FilePrefix is the prefix for references to a file containing the secret
+	FilePrefix = "file://"
+	// EnvPrefix is the prefix for references to an environment variable containing the secret
+	EnvPrefix = "env://"
+	// ExecPrefix is the prefix for references to a command printing the secret
+	ExecPrefix = "exec://"
+
+	secretTimeout = 30 * time.Second
 )
 
+// SecretProvider resolves the secret references of a given backend
+type SecretProvider interface {
+	// Matches returns whether the reference is handled by the provider
+	Matches(reference string) bool
+	// GetSecret returns the secret of the reference
+	GetSecret(ctx context.Context, reference string) (string, error)
+}
+
+// secretProviders are checked in order, a reference matched by none
+// of them is the secret itself
+var secretProviders = []SecretProvider{
+	&fileSecretProvider{},
+	&envSecretProvider{},
+	&execSecretProvider{},
+	&awsSecretProvider{},
+	&gcpSecretProvider{endpoint: gcpSecretManagerEndpoint},
+	&azureSecretProvider{},
+}
+
 // GetSecretValue retrieves a secret value from various sources based on the reference format
 func GetSecretValue(reference string) (string, error) {
 	if reference == "" {
 		return "", nil
 	}
 
-	// If it's a reference to a cloud secret manager, fetch the secret
-	switch {
-	case strings.HasPrefix(reference, AWSPrefix):
-		return getAWSSecret(reference)
-	case strings.HasPrefix(reference, GCPPrefix) && strings.Contains(reference, "/secrets/"):
-		return getGCPSecret(reference)
-	case strings.HasPrefix(reference, AzurePrefix) && strings.Contains(reference, ".vault.azure.net/secrets/"):
-		return getAzureSecret(reference)
-	default:
-		return reference, nil
+	for _, p := range secretProviders {
+		if !p.Matches(reference) {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), secretTimeout)
+		defer cancel()
+
+		secret, err := p.GetSecret(ctx, reference)
+		if err != nil {
+			return "", err
+		}
+		if secret == "" {
+			return "", fmt.Errorf("the secret of %s is empty", reference)
+		}
+
+		return secret, nil
 	}
+
+	return reference, nil
 }
 
-// ProcessHMACKey processes the HMAC key, handling cloud secret references if needed
+// IsSecretReference returns whether the value is a reference to a secret,
+// rather than the secret itself
+func IsSecretReference(value string) bool {
+	for _, p := range secretProviders {
+		if p.Matches(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ProcessHMACKey processes the HMAC key, handling secret references if needed
 func ProcessHMACKey(hmacKey string) (string, error) {
 	if hmacKey == "" {
 		return "", nil
@@ -43,23 +95,62 @@ func ProcessHMACKey(hmacKey string) (string, error) {
 	return GetSecretValue(hmacKey)
 }
 
-// getAWSSecret gets a secret from AWS secrets manager
-func getAWSSecret(arn string) (string, error) {
-	// TODO: Needs to be implemented
-	// NOTE: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/service/secretsmanager
-	return "", fmt.Errorf("AWS Secrets Manager integration not implemented: %s", arn)
+// fileSecretProvider reads the secret from a file, e.g., file:///run/secrets/hmac
+type fileSecretProvider struct{}
+
+func (p *fileSecretProvider) Matches(reference string) bool {
+	return strings.HasPrefix(reference, FilePrefix)
+}
+
+func (p *fileSecretProvider) GetSecret(_ context.Context, reference string) (string, error) {
+	path := strings.TrimPrefix(reference, FilePrefix)
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file %s: %w", path, err)
+	}
+
+	return strings.TrimRight(string(bz), "\r\n"), nil
+}
+
+// envSecretProvider reads the secret from an environment variable, e.g., env://EOTSD_HMAC_KEY
+type envSecretProvider struct{}
+
+func (p *envSecretProvider) Matches(reference string) bool {
+	return strings.HasPrefix(reference, EnvPrefix)
 }
 
-// getGCPSecret gets a secret from GCP secrets manager
-func getGCPSecret(secretName string) (string, error) {
-	// TODO: Needs to be implemented
-	// NOTE: https://cloud.google.com/secret-manager/docs/reference/libraries#client-libraries-install-go
-	return "", fmt.Errorf("google cloud secret Manager integration not implemented: %s", secretName)
+func (p *envSecretProvider) GetSecret(_ context.Context, reference string) (string, error) {
+	name := strings.TrimPrefix(reference, EnvPrefix)
+	secret, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s of the secret is not set", name)
+	}
+
+	return secret, nil
 }
 
-// getAzureSecret gets the secret from Azure Key Vault
-func getAzureSecret(secretName string) (string, error) {
-	// TODO: Needs to be implemented
-	// NOTE: https://pkg.go.dev/github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets
-	return "", fmt.Errorf("azure key vault integration not implemented: %s", secretName)
+// execSecretProvider runs a command and reads the secret from its output, e.g.,
+// exec://pass show eotsd/hmac. The command is not run in a shell, its arguments
+// are separated by spaces.
+type execSecretProvider struct{}
+
+func (p *execSecretProvider) Matches(reference string) bool {
+	return strings.HasPrefix(reference, ExecPrefix)
+}
+
+func (p *execSecretProvider) GetSecret(ctx context.Context, reference string) (string, error) {
+	args := strings.Fields(strings.TrimPrefix(reference, ExecPrefix))
+	if len(args) == 0 {
+		return "", fmt.Errorf("no command in the secret reference %s", reference)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...) //nolint:gosec
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to run the secret command %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimRight(stdout.String(), "\r\n"), nil
 }
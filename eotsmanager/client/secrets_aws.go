@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+// awsSecretProvider gets a secret from AWS Secrets Manager by its ARN, e.g.,
+// arn:aws:secretsmanager:us-east-1:123456789012:secret:eotsd-hmac. The
+// credentials are resolved by the default chain of the AWS SDK: environment
+// variables, shared config files, and the roles of ECS tasks and EC2 instances.
+type awsSecretProvider struct {
+	// endpoint and creds override the defaults of the SDK if set
+	endpoint string
+	creds    *credentials.Credentials
+}
+
+func (p *awsSecretProvider) Matches(reference string) bool {
+	return strings.HasPrefix(reference, AWSPrefix)
+}
+
+func (p *awsSecretProvider) GetSecret(ctx context.Context, reference string) (string, error) {
+	parsed, err := arn.Parse(reference)
+	if err != nil {
+		return "", fmt.Errorf("invalid AWS secret ARN %s: %w", reference, err)
+	}
+
+	cfg := aws.NewConfig().WithRegion(parsed.Region)
+	if p.endpoint != "" {
+		cfg = cfg.WithEndpoint(p.endpoint)
+	}
+	if p.creds != nil {
+		cfg = cfg.WithCredentials(p.creds)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *cfg,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create AWS session: %w", err)
+	}
+
+	out, err := secretsmanager.New(sess).GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(reference),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get AWS secret %s: %w", reference, err)
+	}
+
+	if out.SecretString != nil {
+		return *out.SecretString, nil
+	}
+
+	return string(out.SecretBinary), nil
+}
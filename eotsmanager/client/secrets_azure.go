@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"golang.org/x/oauth2/microsoft"
+)
+
+const (
+	azureKeyVaultAPIVersion = "7.4"
+	azureKeyVaultResource   = "https://vault.azure.net"
+	azureIMDSTokenURL       = "http://169.254.169.254/metadata/identity/oauth2/token"
+	azureKeyVaultHostSuffix = ".vault.azure.net"
+)
+
+// azureSecretProvider gets a secret from Azure Key Vault by its identifier, e.g.,
+// https://my-vault.vault.azure.net/secrets/eotsd-hmac, optionally followed by
+// /<version>, the latest version being used otherwise. The requests are
+// authenticated with the service principal of the AZURE_TENANT_ID,
+// AZURE_CLIENT_ID and AZURE_CLIENT_SECRET environment variables if they are
+// set, and with the managed identity of the host otherwise.
+type azureSecretProvider struct {
+	// client authenticates the requests, the credentials
+	// described above are used if it is nil
+	client *http.Client
+}
+
+// Matches only accepts the identifiers of the secrets of a vault, as the access
+// token of the vault is sent to the host of the reference
+func (p *azureSecretProvider) Matches(reference string) bool {
+	if !strings.HasPrefix(reference, AzurePrefix) {
+		return false
+	}
+
+	u, err := url.Parse(reference)
+	if err != nil || u.Scheme != "https" || u.User != nil || u.RawPath != "" || u.RawQuery != "" || u.Fragment != "" {
+		return false
+	}
+
+	// the host is <vault>.vault.azure.net, without a port
+	vault, ok := strings.CutSuffix(u.Host, azureKeyVaultHostSuffix)
+	if !ok || vault == "" || strings.ContainsAny(vault, ".:") {
+		return false
+	}
+
+	// the path is /secrets/<name>[/<version>]
+	parts := strings.Split(u.Path, "/")
+	if len(parts) < 3 || len(parts) > 4 || parts[0] != "" || parts[1] != "secrets" {
+		return false
+	}
+	for _, part := range parts[2:] {
+		if part == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *azureSecretProvider) GetSecret(ctx context.Context, reference string) (string, error) {
+	client := p.client
+	if client == nil {
+		client = oauth2.NewClient(ctx, azureTokenSource(ctx))
+	}
+
+	var res struct {
+		Value string `json:"value"`
+	}
+	if err := getSecretJSON(ctx, client, reference+"?api-version="+azureKeyVaultAPIVersion, &res); err != nil {
+		return "", fmt.Errorf("failed to get Azure secret %s: %w", reference, err)
+	}
+
+	return res.Value, nil
+}
+
+func azureTokenSource(ctx context.Context) oauth2.TokenSource {
+	tenantID := os.Getenv("AZURE_TENANT_ID")
+	clientID := os.Getenv("AZURE_CLIENT_ID")
+	clientSecret := os.Getenv("AZURE_CLIENT_SECRET")
+	if tenantID != "" && clientID != "" && clientSecret != "" {
+		cfg := &clientcredentials.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			TokenURL:     microsoft.AzureADEndpoint(tenantID).TokenURL,
+			Scopes:       []string{azureKeyVaultResource + "/.default"},
+		}
+
+		return cfg.TokenSource(ctx)
+	}
+
+	return oauth2.ReuseTokenSource(nil, &azureManagedIdentityTokenSource{
+		ctx:      ctx,
+		tokenURL: azureIMDSTokenURL,
+		clientID: clientID,
+	})
+}
+
+// azureManagedIdentityTokenSource gets the tokens of the managed identity
+// from the instance metadata service of the host
+type azureManagedIdentityTokenSource struct {
+	ctx      context.Context //nolint:containedctx
+	tokenURL string
+	// clientID selects a user-assigned identity, if set
+	clientID string
+}
+
+func (s *azureManagedIdentityTokenSource) Token() (*oauth2.Token, error) {
+	params := url.Values{}
+	params.Set("api-version", "2018-02-01")
+	params.Set("resource", azureKeyVaultResource)
+	if s.clientID != "" {
+		params.Set("client_id", s.clientID)
+	}
+
+	var res struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   string `json:"expires_in"`
+		TokenType   string `json:"token_type"`
+	}
+	client := &http.Client{Transport: metadataHeaderTransport{}}
+	if err := getSecretJSON(s.ctx, client, s.tokenURL+"?"+params.Encode(), &res); err != nil {
+		return nil, fmt.Errorf("failed to get the Azure managed identity token: %w", err)
+	}
+
+	token := &oauth2.Token{AccessToken: res.AccessToken, TokenType: res.TokenType}
+	if secs, err := strconv.Atoi(res.ExpiresIn); err == nil {
+		token.Expiry = time.Now().Add(time.Duration(secs) * time.Second)
+	}
+
+	return token, nil
+}
+
+// metadataHeaderTransport adds the header required by the instance metadata service
+type metadataHeaderTransport struct{}
+
+func (metadataHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Metadata", "true")
+
+	return http.DefaultTransport.RoundTrip(req) //nolint:wrapcheck
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	gcpSecretManagerEndpoint = "https://secretmanager.googleapis.com"
+	gcpCloudPlatformScope    = "https://www.googleapis.com/auth/cloud-platform"
+
+	// maxSecretResponseSize bounds the responses of the secret managers
+	maxSecretResponseSize = 1024 * 1024
+)
+
+// gcpSecretProvider gets a secret from Google Cloud Secret Manager by its
+// resource name, e.g., projects/my-project/secrets/eotsd-hmac, optionally
+// followed by /versions/<version>, the latest version being used otherwise.
+// The credentials are the application default credentials.
+type gcpSecretProvider struct {
+	endpoint string
+	// client authenticates the requests, the application
+	// default credentials are used if it is nil
+	client *http.Client
+}
+
+func (p *gcpSecretProvider) Matches(reference string) bool {
+	return strings.HasPrefix(reference, GCPPrefix) && strings.Contains(reference, "/secrets/")
+}
+
+func (p *gcpSecretProvider) GetSecret(ctx context.Context, reference string) (string, error) {
+	name := reference
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+
+	client := p.client
+	if client == nil {
+		var err error
+		client, err = google.DefaultClient(ctx, gcpCloudPlatformScope)
+		if err != nil {
+			return "", fmt.Errorf("failed to get the Google Cloud default credentials: %w", err)
+		}
+	}
+
+	var res struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+	if err := getSecretJSON(ctx, client, p.endpoint+"/v1/"+name+":access", &res); err != nil {
+		return "", fmt.Errorf("failed to get Google Cloud secret %s: %w", name, err)
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(res.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode Google Cloud secret %s: %w", name, err)
+	}
+
+	return string(secret), nil
+}
+
+// getSecretJSON gets the url with the client and decodes the JSON response into res
+func getSecretJSON(ctx context.Context, client *http.Client, url string, res interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSecretResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.Unmarshal(body, res); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
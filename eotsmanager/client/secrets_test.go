@@ -0,0 +1,183 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalSecretProviders(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "hmac")
+	require.NoError(t, os.WriteFile(file, []byte("file-secret\n"), 0600))
+	secret, err := GetSecretValue(FilePrefix + file)
+	require.NoError(t, err)
+	require.Equal(t, "file-secret", secret)
+
+	secret, err = GetSecretValue(EnvPrefix + "PATH")
+	require.NoError(t, err)
+	require.Equal(t, os.Getenv("PATH"), secret)
+	_, err = GetSecretValue(EnvPrefix + "EOTSD_SECRET_TEST_UNSET_VARIABLE")
+	require.Error(t, err)
+
+	secret, err = GetSecretValue(ExecPrefix + "echo exec-secret")
+	require.NoError(t, err)
+	require.Equal(t, "exec-secret", secret)
+	_, err = GetSecretValue(ExecPrefix + "false")
+	require.Error(t, err)
+
+	// anything else is the secret itself
+	secret, err = GetSecretValue("plain-secret")
+	require.NoError(t, err)
+	require.Equal(t, "plain-secret", secret)
+}
+
+func TestAWSSecretProvider(t *testing.T) {
+	t.Parallel()
+	ref := "arn:aws:secretsmanager:us-east-1:123456789012:secret:eotsd-hmac"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" ||
+			r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		var req struct {
+			SecretID string `json:"SecretId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.SecretID != ref {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		_, _ = w.Write([]byte(`{"ARN":"` + ref + `","SecretString":"aws-secret"}`))
+	}))
+	defer server.Close()
+
+	p := &awsSecretProvider{
+		endpoint: server.URL,
+		creds:    credentials.NewStaticCredentials("id", "secret", ""),
+	}
+	require.True(t, p.Matches(ref))
+
+	secret, err := p.GetSecret(t.Context(), ref)
+	require.NoError(t, err)
+	require.Equal(t, "aws-secret", secret)
+
+	_, err = p.GetSecret(t.Context(), "arn:aws:secretsmanager:invalid")
+	require.Error(t, err)
+}
+
+func TestGCPSecretProvider(t *testing.T) {
+	t.Parallel()
+	ref := "projects/my-project/secrets/eotsd-hmac"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/"+ref+"/versions/latest:access" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		data := base64.StdEncoding.EncodeToString([]byte("gcp-secret"))
+		_, _ = w.Write([]byte(`{"name":"` + ref + `/versions/1","payload":{"data":"` + data + `"}}`))
+	}))
+	defer server.Close()
+
+	p := &gcpSecretProvider{endpoint: server.URL, client: server.Client()}
+	require.True(t, p.Matches(ref))
+
+	secret, err := p.GetSecret(t.Context(), ref)
+	require.NoError(t, err)
+	require.Equal(t, "gcp-secret", secret)
+
+	_, err = p.GetSecret(t.Context(), ref+"/versions/2")
+	require.ErrorContains(t, err, "404")
+}
+
+// redirectTransport sends all the requests to the test server
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (rt redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = rt.target.Scheme
+	req.URL.Host = rt.target.Host
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestAzureSecretProvider(t *testing.T) {
+	t.Parallel()
+	ref := "https://my-vault.vault.azure.net/secrets/eotsd-hmac"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/secrets/eotsd-hmac":
+			if r.URL.Query().Get("api-version") != azureKeyVaultAPIVersion {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+			_, _ = w.Write([]byte(`{"value":"azure-secret","id":"` + ref + `/1"}`))
+		case "/token":
+			if r.Header.Get("Metadata") != "true" || r.URL.Query().Get("resource") != azureKeyVaultResource {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token":"token","expires_in":"3599","token_type":"Bearer"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	p := &azureSecretProvider{client: &http.Client{Transport: redirectTransport{target: target}}}
+	require.True(t, p.Matches(ref))
+	require.True(t, p.Matches(ref+"/version"))
+
+	// the access token must only be sent to a vault
+	for _, invalid := range []string{
+		"https://evil.example/.vault.azure.net/secrets/x",
+		"https://evil.example/?.vault.azure.net/secrets/x",
+		"https://my-vault.vault.azure.net.evil.example/secrets/x",
+		"https://my-vault.vault.azure.net@evil.example/secrets/x",
+		"https://my-vault.vault.azure.net:8443/secrets/x",
+		"https://.vault.azure.net/secrets/x",
+		"http://my-vault.vault.azure.net/secrets/x",
+		"https://my-vault.vault.azure.net/keys/x",
+		"https://my-vault.vault.azure.net/secrets/",
+		"https://my-vault.vault.azure.net/secrets/x/version/extra",
+		"https://my-vault.vault.azure.net/secrets/x?api-version=1",
+	} {
+		require.False(t, p.Matches(invalid), invalid)
+	}
+
+	secret, err := p.GetSecret(t.Context(), ref)
+	require.NoError(t, err)
+	require.Equal(t, "azure-secret", secret)
+
+	ts := &azureManagedIdentityTokenSource{ctx: t.Context(), tokenURL: server.URL + "/token"}
+	token, err := ts.Token()
+	require.NoError(t, err)
+	require.Equal(t, "token", token.AccessToken)
+	require.False(t, token.Expiry.IsZero())
+}
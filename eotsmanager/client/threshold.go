@@ -0,0 +1,569 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/babylonlabs-io/babylon/v4/crypto/eots"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/threshold"
+)
+
+// GetThresholdKeyShareInfo returns the public information of the share of
+// the threshold EOTS key held by the eotsd
+func (c *EOTSManagerGRPCClient) GetThresholdKeyShareInfo(uid []byte) (*proto.GetThresholdKeyShareInfoResponse, error) {
+	res, err := c.client.GetThresholdKeyShareInfo(context.Background(), &proto.GetThresholdKeyShareInfoRequest{Uid: uid})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get threshold key share info: %w", err)
+	}
+
+	return res, nil
+}
+
+// CreatePartialRandomnessList returns the public nonces of the share of the
+// threshold EOTS key held by the eotsd
+func (c *EOTSManagerGRPCClient) CreatePartialRandomnessList(uid, chainID []byte, startHeight uint64, num uint32, options ...eotsmanager.RandomnessOption) ([]*btcec.PublicKey, error) {
+	return c.createPartialRandomnessList(context.Background(), uid, chainID, startHeight, num, options...)
+}
+
+func (c *EOTSManagerGRPCClient) createPartialRandomnessList(ctx context.Context, uid, chainID []byte, startHeight uint64, num uint32, options ...eotsmanager.RandomnessOption) ([]*btcec.PublicKey, error) {
+	cfg := &eotsmanager.RandomnessConfig{}
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	req := &proto.CreatePartialRandomnessListRequest{
+		Uid:         uid,
+		ChainId:     chainID,
+		StartHeight: startHeight,
+		Num:         num,
+		Interval:    cfg.Interval,
+	}
+
+	res, err := c.client.CreatePartialRandomnessList(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create partial randomness list: %w", err)
+	}
+
+	nonces := make([]*btcec.PublicKey, 0, len(res.PubNonces))
+	for _, b := range res.PubNonces {
+		nonce, err := btcec.ParsePubKey(b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public nonce: %w", err)
+		}
+		nonces = append(nonces, nonce)
+	}
+
+	return nonces, nil
+}
+
+// GetPartialEOTSNonce returns the public nonce of the share of the threshold
+// EOTS key held by the eotsd at the height, without recording the randomness
+func (c *EOTSManagerGRPCClient) GetPartialEOTSNonce(uid, chainID []byte, height uint64) (*btcec.PublicKey, error) {
+	return c.getPartialEOTSNonce(context.Background(), uid, chainID, height)
+}
+
+func (c *EOTSManagerGRPCClient) getPartialEOTSNonce(ctx context.Context, uid, chainID []byte, height uint64) (*btcec.PublicKey, error) {
+	req := &proto.GetPartialEOTSNonceRequest{
+		Uid:     uid,
+		ChainId: chainID,
+		Height:  height,
+	}
+	res, err := c.client.GetPartialEOTSNonce(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get partial EOTS nonce: %w", err)
+	}
+
+	nonce, err := btcec.ParsePubKey(res.PubNonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public nonce: %w", err)
+	}
+
+	return nonce, nil
+}
+
+// SignPartialEOTS signs a partial EOTS with the share of the threshold EOTS
+// key held by the eotsd
+func (c *EOTSManagerGRPCClient) SignPartialEOTS(uid, chainID, msg []byte, height uint64, groupNonce *btcec.PublicKey) (*btcec.ModNScalar, error) {
+	return c.signPartialEOTS(context.Background(), uid, chainID, msg, height, groupNonce)
+}
+
+func (c *EOTSManagerGRPCClient) signPartialEOTS(ctx context.Context, uid, chainID, msg []byte, height uint64, groupNonce *btcec.PublicKey) (*btcec.ModNScalar, error) {
+	req := &proto.SignPartialEOTSRequest{
+		Uid:           uid,
+		ChainId:       chainID,
+		Msg:           msg,
+		Height:        height,
+		GroupPubNonce: groupNonce.SerializeCompressed(),
+	}
+	res, err := c.client.SignPartialEOTS(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign partial EOTS: %w", err)
+	}
+
+	var s btcec.ModNScalar
+	s.SetByteSlice(res.Sig)
+
+	return &s, nil
+}
+
+// CreatePartialSchnorrNonce returns the public nonce of the share of the
+// threshold EOTS key held by the eotsd for the Schnorr signature of msg
+func (c *EOTSManagerGRPCClient) CreatePartialSchnorrNonce(uid, msg []byte) (*btcec.PublicKey, error) {
+	res, err := c.client.CreatePartialSchnorrNonce(context.Background(), &proto.CreatePartialSchnorrNonceRequest{Uid: uid, Msg: msg})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create partial schnorr nonce: %w", err)
+	}
+
+	nonce, err := btcec.ParsePubKey(res.PubNonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public nonce: %w", err)
+	}
+
+	return nonce, nil
+}
+
+// SignPartialSchnorrSig signs a partial Schnorr sig with the share of the
+// threshold EOTS key held by the eotsd
+func (c *EOTSManagerGRPCClient) SignPartialSchnorrSig(uid, msg []byte, groupNonce *btcec.PublicKey) (*btcec.ModNScalar, error) {
+	req := &proto.SignPartialSchnorrSigRequest{
+		Uid:           uid,
+		Msg:           msg,
+		GroupPubNonce: groupNonce.SerializeCompressed(),
+	}
+	res, err := c.client.SignPartialSchnorrSig(context.Background(), req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign partial schnorr sig: %w", err)
+	}
+
+	var s btcec.ModNScalar
+	s.SetByteSlice(res.Sig)
+
+	return &s, nil
+}
+
+var _ eotsmanager.EOTSManager = &ThresholdEOTSManager{}
+
+// ThresholdEOTSManager signs with threshold EOTS keys, whose shares are held
+// by several eotsd. It combines the public randomness and the signatures from
+// the public nonces and the partial signatures of the eotsd, so that none of
+// them holds the key or the randomness.
+type ThresholdEOTSManager struct {
+	threshold uint32
+	clients   []*EOTSManagerGRPCClient
+
+	mu   sync.Mutex
+	keys map[string]*thresholdKey
+}
+
+// thresholdKey is the public information of a threshold EOTS key
+type thresholdKey struct {
+	pk                 *btcec.PublicKey
+	threshold          uint32
+	verificationShares []*btcec.PublicKey
+	// parties are the clients of the eotsd holding a share, by index
+	parties map[uint32]*EOTSManagerGRPCClient
+}
+
+// NewThresholdEOTSManager creates an EOTS manager signing with the given
+// threshold of the eotsd of the clients
+func NewThresholdEOTSManager(t uint32, clients ...*EOTSManagerGRPCClient) (*ThresholdEOTSManager, error) {
+	if !threshold.ValidThreshold(t, uint32(len(clients))) {
+		return nil, fmt.Errorf("the threshold must be more than half of the eotsd and at most their number, got %d of %d", t, len(clients))
+	}
+
+	return &ThresholdEOTSManager{
+		threshold: t,
+		clients:   clients,
+		keys:      make(map[string]*thresholdKey),
+	}, nil
+}
+
+// fanOut calls fn for all the parties in parallel, and returns the results
+// of the parties which succeeded and the errors of the others
+func fanOut[T any](parties map[uint32]*EOTSManagerGRPCClient, fn func(c *EOTSManagerGRPCClient) (T, error)) (map[uint32]T, map[uint32]error) {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[uint32]T)
+		errs    = make(map[uint32]error)
+	)
+
+	for i, c := range parties {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := fn(c)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[i] = err
+			} else {
+				results[i] = res
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results, errs
+}
+
+// joinErrs returns the errors of the parties sorted by index
+func joinErrs(errs map[uint32]error) error {
+	indices := make([]uint32, 0, len(errs))
+	for i := range errs {
+		indices = append(indices, i)
+	}
+	sort.Slice(indices, func(a, b int) bool { return indices[a] < indices[b] })
+
+	joined := make([]error, 0, len(errs))
+	for _, i := range indices {
+		joined = append(joined, fmt.Errorf("party %d: %w", i, errs[i]))
+	}
+
+	return errors.Join(joined...)
+}
+
+// key returns the public information of the threshold EOTS key, checking
+// that the eotsd agree on it. It is cached once all the eotsd answered.
+func (m *ThresholdEOTSManager) key(uid []byte) (*thresholdKey, error) {
+	m.mu.Lock()
+	k, ok := m.keys[hex.EncodeToString(uid)]
+	m.mu.Unlock()
+	if ok {
+		return k, nil
+	}
+
+	pk, err := schnorr.ParsePubKey(uid)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EOTS public key: %w", err)
+	}
+
+	clients := make(map[uint32]*EOTSManagerGRPCClient, len(m.clients))
+	for i, c := range m.clients {
+		clients[uint32(i)] = c
+	}
+	infos, errs := fanOut(clients, func(c *EOTSManagerGRPCClient) (*proto.GetThresholdKeyShareInfoResponse, error) {
+		return c.GetThresholdKeyShareInfo(uid)
+	})
+	if len(infos) < int(m.threshold) {
+		return nil, fmt.Errorf("only %d eotsd hold a share of the EOTS key %s, expected at least %d: %w",
+			len(infos), hex.EncodeToString(uid), m.threshold, joinErrs(errs))
+	}
+
+	k = &thresholdKey{pk: pk, parties: make(map[uint32]*EOTSManagerGRPCClient)}
+	var first *proto.GetThresholdKeyShareInfoResponse
+	for i, info := range infos {
+		if first == nil {
+			first = info
+			k.threshold = info.Threshold
+			for _, b := range info.VerificationShares {
+				vs, err := btcec.ParsePubKey(b)
+				if err != nil {
+					return nil, fmt.Errorf("invalid verification share: %w", err)
+				}
+				k.verificationShares = append(k.verificationShares, vs)
+			}
+		}
+
+		switch {
+		case info.Threshold != first.Threshold || info.Parties != first.Parties ||
+			len(info.VerificationShares) != len(first.VerificationShares):
+			return nil, fmt.Errorf("the eotsd disagree on the threshold EOTS key %s", hex.EncodeToString(uid))
+		case info.Index == 0 || int(info.Index) > len(info.VerificationShares):
+			return nil, fmt.Errorf("invalid share index %d", info.Index)
+		case k.parties[info.Index] != nil:
+			return nil, fmt.Errorf("several eotsd hold the share %d of the EOTS key %s", info.Index, hex.EncodeToString(uid))
+		}
+		for j, vs := range info.VerificationShares {
+			if hex.EncodeToString(vs) != hex.EncodeToString(first.VerificationShares[j]) {
+				return nil, fmt.Errorf("the eotsd disagree on the threshold EOTS key %s", hex.EncodeToString(uid))
+			}
+		}
+
+		k.parties[info.Index] = m.clients[i]
+	}
+
+	if k.threshold != m.threshold {
+		return nil, fmt.Errorf("the threshold of the EOTS key %s is %d, expected %d", hex.EncodeToString(uid), k.threshold, m.threshold)
+	}
+	if !threshold.ValidThreshold(k.threshold, first.Parties) {
+		return nil, fmt.Errorf("invalid threshold %d of %d parties of the EOTS key %s", k.threshold, first.Parties, hex.EncodeToString(uid))
+	}
+
+	// the verification shares must be shares of the EOTS public key
+	vShares := make(map[uint32]*btcec.PublicKey, len(k.verificationShares))
+	for i, vs := range k.verificationShares {
+		vShares[uint32(i+1)] = vs
+	}
+	combined, err := threshold.CombineNonces(k.threshold, vShares)
+	if err != nil {
+		return nil, err
+	}
+	if !combined.IsEqual(pk) {
+		return nil, fmt.Errorf("the verification shares do not match the EOTS key %s", hex.EncodeToString(uid))
+	}
+
+	if len(errs) == 0 {
+		m.mu.Lock()
+		m.keys[hex.EncodeToString(uid)] = k
+		m.mu.Unlock()
+	}
+
+	return k, nil
+}
+
+// combineNonces combines the public nonces of the parties, checking that
+// the other parties agree on the public randomness
+func (k *thresholdKey) combineNonces(nonces map[uint32]*btcec.PublicKey) (*btcec.PublicKey, error) {
+	groupNonce, err := threshold.CombineNonces(k.threshold, nonces)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nonces) > int(k.threshold) {
+		// the last parties must agree with the first ones
+		indices := make([]uint32, 0, len(nonces))
+		for i := range nonces {
+			indices = append(indices, i)
+		}
+		sort.Slice(indices, func(a, b int) bool { return indices[a] > indices[b] })
+
+		last := make(map[uint32]*btcec.PublicKey, k.threshold)
+		for _, i := range indices[:k.threshold] {
+			last[i] = nonces[i]
+		}
+		other, err := threshold.CombineNonces(k.threshold, last)
+		if err != nil {
+			return nil, err
+		}
+		if !other.IsEqual(groupNonce) {
+			return nil, fmt.Errorf("the eotsd disagree on the public randomness")
+		}
+	}
+
+	return groupNonce, nil
+}
+
+// sign collects the public nonces and the partial signatures of the
+// parties, and returns the public randomness and the combined signature
+func (k *thresholdKey) sign(
+	msgHash []byte,
+	getNonce func(c *EOTSManagerGRPCClient) (*btcec.PublicKey, error),
+	signPartial func(c *EOTSManagerGRPCClient, groupNonce *btcec.PublicKey) (*btcec.ModNScalar, error),
+) (*btcec.PublicKey, *btcec.ModNScalar, error) {
+	nonces, errs := fanOut(k.parties, getNonce)
+	if len(nonces) < int(k.threshold) {
+		return nil, nil, fmt.Errorf("failed to get enough public nonces: %w", joinErrs(errs))
+	}
+
+	groupNonce, err := k.combineNonces(nonces)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signers := make(map[uint32]*EOTSManagerGRPCClient, len(nonces))
+	for i := range nonces {
+		signers[i] = k.parties[i]
+	}
+	partials, errs := fanOut(signers, func(c *EOTSManagerGRPCClient) (*btcec.ModNScalar, error) {
+		return signPartial(c, groupNonce)
+	})
+
+	// a party refusing to sign another message with the same randomness
+	// means a conflicting signature was requested, so do not sign
+	for _, err := range errs {
+		if status.Code(err) == codes.FailedPrecondition {
+			return nil, nil, err
+		}
+	}
+
+	for i, s := range partials {
+		if err := threshold.VerifyPartialSig(k.pk, k.verificationShares[i-1], nonces[i], groupNonce, msgHash, s); err != nil {
+			errs[i] = err
+			delete(partials, i)
+		}
+	}
+	if len(partials) < int(k.threshold) {
+		return nil, nil, fmt.Errorf("failed to get enough partial signatures: %w", joinErrs(errs))
+	}
+
+	sig, err := threshold.CombineSigs(k.threshold, partials)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return groupNonce, sig, nil
+}
+
+func (m *ThresholdEOTSManager) CreateRandomnessPairList(uid, chainID []byte, startHeight uint64, num uint32, options ...eotsmanager.RandomnessOption) ([]*btcec.FieldVal, error) {
+	k, err := m.key(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	lists, errs := fanOut(k.parties, func(c *EOTSManagerGRPCClient) ([]*btcec.PublicKey, error) {
+		nonces, err := c.CreatePartialRandomnessList(uid, chainID, startHeight, num, options...)
+		if err == nil && len(nonces) != int(num) {
+			err = fmt.Errorf("expected %d public nonces, got %d", num, len(nonces))
+		}
+
+		return nonces, err
+	})
+	if len(lists) < int(k.threshold) {
+		return nil, fmt.Errorf("failed to create randomness pair list: %w", joinErrs(errs))
+	}
+
+	pubRandList := make([]*btcec.FieldVal, 0, num)
+	for j := 0; j < int(num); j++ {
+		nonces := make(map[uint32]*btcec.PublicKey, len(lists))
+		for i, list := range lists {
+			nonces[i] = list[j]
+		}
+
+		groupNonce, err := k.combineNonces(nonces)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create randomness pair list: %w", err)
+		}
+		pubRandList = append(pubRandList, threshold.PublicRand(groupNonce))
+	}
+
+	return pubRandList, nil
+}
+
+func (m *ThresholdEOTSManager) SignEOTS(uid, chainID, msg []byte, height uint64) (*btcec.ModNScalar, error) {
+	return m.signEOTS(context.Background(), uid, chainID, msg, height)
+}
+
+// signEOTS signs with the shares of the parties, the requests to them
+// carrying the trace of the context. The nonces are fetched without
+// recording randomness, so that the eotsd check the height against the
+// randomness committed before.
+func (m *ThresholdEOTSManager) signEOTS(ctx context.Context, uid, chainID, msg []byte, height uint64) (*btcec.ModNScalar, error) {
+	k, err := m.key(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	groupNonce, sig, err := k.sign(
+		threshold.EOTSMsgHash(msg),
+		func(c *EOTSManagerGRPCClient) (*btcec.PublicKey, error) {
+			return c.getPartialEOTSNonce(ctx, uid, chainID, height)
+		},
+		func(c *EOTSManagerGRPCClient, groupNonce *btcec.PublicKey) (*btcec.ModNScalar, error) {
+			return c.signPartialEOTS(ctx, uid, chainID, msg, height, groupNonce)
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign EOTS: %w", err)
+	}
+
+	if err := eots.Verify(k.pk, threshold.PublicRand(groupNonce), msg, sig); err != nil {
+		return nil, fmt.Errorf("the combined EOTS signature is invalid: %w", err)
+	}
+
+	return sig, nil
+}
+
+// UnsafeSignEOTS is not supported, as the eotsd never sign twice at a height
+func (m *ThresholdEOTSManager) UnsafeSignEOTS(_, _, _ []byte, _ uint64) (*btcec.ModNScalar, error) {
+	return nil, fmt.Errorf("unsafe EOTS signing is not supported with threshold EOTS keys")
+}
+
+func (m *ThresholdEOTSManager) SignBatchEOTS(ctx context.Context, req *eotsmanager.SignBatchEOTSRequest) ([]eotsmanager.SignDataResponse, error) {
+	res := make([]eotsmanager.SignDataResponse, 0, len(req.SignRequest))
+	for _, signReq := range req.SignRequest {
+		sig, err := m.signEOTS(ctx, req.UID, req.ChainID, signReq.Msg, signReq.Height)
+		if err != nil {
+			// as with a single eotsd, the signatures
+			// refused as double signs are left out
+			if status.Code(err) == codes.FailedPrecondition {
+				continue
+			}
+
+			return nil, err
+		}
+
+		res = append(res, eotsmanager.SignDataResponse{Signature: sig, Height: signReq.Height})
+	}
+
+	return res, nil
+}
+
+func (m *ThresholdEOTSManager) SignSchnorrSig(uid, msg []byte) (*schnorr.Signature, error) {
+	k, err := m.key(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	groupNonce, s, err := k.sign(
+		msg,
+		func(c *EOTSManagerGRPCClient) (*btcec.PublicKey, error) {
+			return c.CreatePartialSchnorrNonce(uid, msg)
+		},
+		func(c *EOTSManagerGRPCClient, groupNonce *btcec.PublicKey) (*btcec.ModNScalar, error) {
+			return c.SignPartialSchnorrSig(uid, msg, groupNonce)
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign schnorr signature: %w", err)
+	}
+
+	sig := threshold.SchnorrSig(groupNonce, s)
+	if !sig.Verify(msg, k.pk) {
+		return nil, fmt.Errorf("the combined schnorr signature is invalid")
+	}
+
+	return sig, nil
+}
+
+// Unlock is not supported, as the shares of threshold EOTS keys are not
+// kept in the keyring
+func (m *ThresholdEOTSManager) Unlock(_ []byte, _ string) error {
+	return fmt.Errorf("threshold EOTS keys are not kept in the keyring")
+}
+
+// Backup is not supported, the database of each eotsd is backed up on its own
+func (m *ThresholdEOTSManager) Backup(_ string, _ string) (string, error) {
+	return "", fmt.Errorf("the backup of the eotsd of threshold EOTS keys is done on each eotsd")
+}
+
+// Ping returns an error if fewer eotsd than the threshold are reachable,
+// as the threshold EOTS keys cannot sign then
+func (m *ThresholdEOTSManager) Ping() error {
+	var errs []error
+	for _, c := range m.clients {
+		if err := c.Ping(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// #nosec G115 -- the number of eotsd is small
+	if reachable := uint32(len(m.clients) - len(errs)); reachable < m.threshold {
+		return fmt.Errorf("only %d eotsd of the threshold %d are reachable: %w", reachable, m.threshold, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func (m *ThresholdEOTSManager) Close() error {
+	var errs []error
+	for _, c := range m.clients {
+		if err := c.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
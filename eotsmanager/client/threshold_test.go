@@ -0,0 +1,189 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/babylon/v4/crypto/eots"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/jessevdk/go-flags"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/service"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/threshold"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// eotsdHomeEnv makes the test binary run an eotsd with the given home
+// instead of the tests, so that each party of the threshold key runs in
+// its own process
+const eotsdHomeEnv = "THRESHOLD_TEST_EOTSD_HOME"
+
+func TestMain(m *testing.M) {
+	if home := os.Getenv(eotsdHomeEnv); home != "" {
+		if err := runEOTSD(home); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+func runEOTSD(home string) error {
+	cfg, err := config.LoadConfig(home)
+	if err != nil {
+		return err
+	}
+	db, err := cfg.DatabaseConfig.GetDBBackend()
+	if err != nil {
+		return err
+	}
+	logger := zap.NewNop()
+	em, err := eotsmanager.NewLocalEOTSManager(home, cfg.KeyringBackend, db, logger,
+		eotsmanager.WithMaxHeightBeyondRandomness(cfg.SigningGuard.MaxHeightBeyondRandomness))
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	return service.NewEOTSManagerServer(cfg, logger, em, db).RunUntilShutdown(ctx)
+}
+
+// startParty imports the share in a new eotsd home, and runs the eotsd in a
+// child process
+func startParty(t *testing.T, share *threshold.KeyShare) (*client.EOTSManagerGRPCClient, *exec.Cmd) {
+	t.Helper()
+
+	home := filepath.Join(t.TempDir(), fmt.Sprintf("eotsd-%d", share.Index))
+	cfg := config.DefaultConfigWithHomePathAndPorts(home, testutil.AllocateUniquePort(t), testutil.AllocateUniquePort(t))
+	cfg.SigningGuard.MaxHeightBeyondRandomness = 1
+	require.NoError(t, os.MkdirAll(home, 0700))
+	fileParser := flags.NewParser(cfg, flags.Default)
+	require.NoError(t, flags.NewIniParser(fileParser).WriteFile(config.CfgFile(home), flags.IniIncludeComments|flags.IniIncludeDefaults))
+
+	db, err := cfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	em, err := eotsmanager.NewLocalEOTSManager(home, cfg.KeyringBackend, db, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, em.ImportThresholdKeyShare(share))
+	require.Error(t, em.ImportThresholdKeyShare(share))
+	require.NoError(t, em.Close())
+
+	cmd := exec.Command(os.Args[0], "-test.run=^$") // #nosec G204 -- the test binary itself
+	cmd.Env = append(os.Environ(), eotsdHomeEnv+"="+home)
+	cmd.Stderr = os.Stderr
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() {
+		_ = cmd.Process.Signal(syscall.SIGTERM)
+		_ = cmd.Wait()
+	})
+
+	var c *client.EOTSManagerGRPCClient
+	require.Eventually(t, func() bool {
+		c, err = client.NewEOTSManagerGRPCClient(cfg.RPCListener, "")
+
+		return err == nil
+	}, 20*time.Second, 100*time.Millisecond)
+
+	return c, cmd
+}
+
+func TestThresholdEOTSManager(t *testing.T) {
+	t.Parallel()
+
+	groupPk, shares, err := threshold.Deal(2, 3)
+	require.NoError(t, err)
+	uid := schnorr.SerializePubKey(groupPk)
+
+	clients := make([]*client.EOTSManagerGRPCClient, 0, len(shares))
+	cmds := make([]*exec.Cmd, 0, len(shares))
+	for _, share := range shares {
+		c, cmd := startParty(t, share)
+		clients = append(clients, c)
+		cmds = append(cmds, cmd)
+	}
+
+	_, err = client.NewThresholdEOTSManager(4, clients...)
+	require.Error(t, err)
+	_, err = client.NewThresholdEOTSManager(2, append(clients, clients[0])...)
+	require.Error(t, err)
+	em, err := client.NewThresholdEOTSManager(2, clients...)
+	require.NoError(t, err)
+	defer em.Close()
+
+	chainID := []byte("threshold-chain")
+	startHeight := uint64(100)
+	pubRandList, err := em.CreateRandomnessPairList(uid, chainID, startHeight, 10)
+	require.NoError(t, err)
+	require.Len(t, pubRandList, 10)
+
+	// the combined signature verifies against the committed randomness
+	msg := []byte("block at height 101")
+	sig, err := em.SignEOTS(uid, chainID, msg, 101)
+	require.NoError(t, err)
+	require.NoError(t, eots.Verify(groupPk, pubRandList[1], msg, sig))
+
+	// signing the same message again returns the same signature
+	again, err := em.SignEOTS(uid, chainID, msg, 101)
+	require.NoError(t, err)
+	require.True(t, sig.Equals(again))
+
+	// the eotsd refuse to sign another message at the same height
+	_, err = em.SignEOTS(uid, chainID, []byte("conflicting block at height 101"), 101)
+	require.ErrorContains(t, err, "FailedPrecondition")
+
+	batch, err := em.SignBatchEOTS(t.Context(), &eotsmanager.SignBatchEOTSRequest{
+		UID:     uid,
+		ChainID: chainID,
+		SignRequest: []*eotsmanager.SignDataRequest{
+			{Msg: []byte("conflicting block at height 101"), Height: 101},
+			{Msg: []byte("block at height 102"), Height: 102},
+			{Msg: []byte("block at height 103"), Height: 103},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, batch, 2)
+	for _, res := range batch {
+		require.NoError(t, eots.Verify(groupPk, pubRandList[res.Height-startHeight], []byte(fmt.Sprintf("block at height %d", res.Height)), res.Signature))
+	}
+
+	// signing does not generate randomness, so the heights
+	// beyond the committed randomness are refused
+	_, err = em.SignEOTS(uid, chainID, []byte("block at height 200"), 200)
+	require.ErrorContains(t, err, "OutOfRange")
+
+	hash := chainhash.HashB([]byte("commit public randomness"))
+	schnorrSig, err := em.SignSchnorrSig(uid, hash)
+	require.NoError(t, err)
+	require.True(t, schnorrSig.Verify(hash, groupPk))
+
+	// any threshold of eotsd keep signing
+	require.NoError(t, cmds[0].Process.Signal(syscall.SIGTERM))
+	_, _ = cmds[0].Process.Wait()
+	msg = []byte("block at height 104")
+	sig, err = em.SignEOTS(uid, chainID, msg, 104)
+	require.NoError(t, err)
+	require.NoError(t, eots.Verify(groupPk, pubRandList[4], msg, sig))
+
+	// fewer eotsd cannot sign
+	require.NoError(t, cmds[1].Process.Signal(syscall.SIGTERM))
+	_, _ = cmds[1].Process.Wait()
+	_, err = em.SignEOTS(uid, chainID, []byte("block at height 105"), 105)
+	require.Error(t, err)
+}
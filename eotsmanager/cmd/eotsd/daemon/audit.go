@@ -0,0 +1,86 @@
+package daemon
+
+import (
+	"fmt"
+
+	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/audit"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+const flagAuditLog = "audit-log"
+
+func NewAuditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Inspect the audit log of the signing requests",
+	}
+
+	cmd.AddCommand(NewAuditVerifyCmd())
+
+	return cmd
+}
+
+func NewAuditVerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the hash chain of the audit log",
+		Long: `Verify that no entry of the audit log was modified, removed or reordered by checking
+the hash chain across the entries. The hash of the last entry is printed, keep it to detect
+the removal of the latest entries in the next verification.`,
+		Example: `eotsd audit verify --home /path/to/eotsd/home`,
+		Args:    cobra.NoArgs,
+		RunE:    verifyAuditLog,
+	}
+
+	f := cmd.Flags()
+	f.String(sdkflags.FlagHome, config.DefaultEOTSDir, "EOTS home directory")
+	f.String(flagAuditLog, "", "Path to the audit log, defaults to the one in the config of the home directory")
+	f.String(flagOutputFile, "", "Path to output JSON file")
+
+	return cmd
+}
+
+func verifyAuditLog(cmd *cobra.Command, _ []string) error {
+	path, err := auditLogPath(cmd)
+	if err != nil {
+		return err
+	}
+
+	res, err := audit.Verify(path)
+	if err != nil {
+		return fmt.Errorf("failed to verify audit log %s: %w", path, err)
+	}
+
+	return handleOutputJSON(cmd, res)
+}
+
+func auditLogPath(cmd *cobra.Command) (string, error) {
+	path, err := cmd.Flags().GetString(flagAuditLog)
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s flag: %w", flagAuditLog, err)
+	}
+
+	if path != "" {
+		return util.CleanAndExpandPath(path), nil
+	}
+
+	homePath, err := getHomePath(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	cfg, err := config.LoadConfig(homePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to load config at %s: %w", homePath, err)
+	}
+
+	if cfg.AuditLogPath != "" {
+		return cfg.AuditLogPath, nil
+	}
+
+	return config.AuditLogFile(homePath), nil
+}
@@ -4,9 +4,19 @@ import (
 	"fmt"
 
 	eotsclient "github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	"github.com/babylonlabs-io/finality-provider/output"
 	"github.com/spf13/cobra"
 )
 
+// BackupOutput describes a backup of the eotsd database
+type BackupOutput struct {
+	Path string `json:"path"`
+}
+
+func (o BackupOutput) Text() string {
+	return "Successfully created backup at: " + o.Path
+}
+
 func NewBackupCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "backup",
@@ -19,6 +29,7 @@ func NewBackupCmd() *cobra.Command {
 	f.String(flagDBPath, "", "Full path to eots.db")
 	f.String(flagBackupDir, "", "Full path to backup directory")
 	f.String(rpcClientFlag, "", "The RPC address of a running eotsd")
+	addRPCClientTLSFlags(cmd)
 
 	if err := cmd.MarkFlagRequired(flagDBPath); err != nil {
 		panic(err)
@@ -51,7 +62,12 @@ func backup(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to get %s flag: %w", rpcClientFlag, err)
 	}
 
-	eotsdClient, err := eotsclient.NewEOTSManagerGRPCClient(rpcListener, "")
+	dialOpts, err := rpcClientDialOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	eotsdClient, err := eotsclient.NewEOTSManagerGRPCClient(rpcListener, "", dialOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to create eotsd client: %w", err)
 	}
@@ -61,8 +77,5 @@ func backup(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to do backup: %w", err)
 	}
 
-	path := fmt.Sprintf("%s/%s", backupDir, backupName)
-	cmd.Printf("Successfully created backup at: %s", path)
-
-	return nil
+	return output.Print(cmd, BackupOutput{Path: fmt.Sprintf("%s/%s", backupDir, backupName)})
 }
@@ -20,4 +20,19 @@ const (
 	flagMnemonicSrc       = "source"
 	flagDBPath            = "db-path"
 	flagBackupDir         = "backup-dir"
+	flagTLSCAFile         = "tls-ca-file"
+	flagTLSCertFile       = "tls-cert-file"
+	flagTLSKeyFile        = "tls-key-file"
+	flagTLSServerName     = "tls-server-name"
+	flagGenerateTLS       = "generate-tls"
+	flagTLSHosts          = "tls-hosts"
+	flagTLSClientName     = "tls-client-name"
+	flagTLSValidity       = "tls-validity"
+	flagPassphraseRef     = "passphrase-ref"
+	flagHMACKeyID         = "hmac-key-id"
+	flagHMACSecret        = "key"
+	flagNotBefore         = "not-before"
+	flagNotAfter          = "not-after"
+	flagRetireAt          = "at"
+	flagGracePeriod       = "grace-period"
 )
@@ -0,0 +1,280 @@
+package daemon
+
+import (
+	"fmt"
+	"time"
+
+	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+
+	eotsclient "github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	"github.com/babylonlabs-io/finality-provider/output"
+)
+
+// HMACKeyOutput describes an HMAC key of a running eotsd
+type HMACKeyOutput struct {
+	ID        string `json:"id"`
+	NotBefore string `json:"not_before,omitempty"`
+	NotAfter  string `json:"not_after,omitempty"`
+	Active    bool   `json:"active"`
+}
+
+// HMACKeyChangeOutput describes an HMAC key added to or retired from a
+// running eotsd
+type HMACKeyChangeOutput struct {
+	ID      string `json:"id"`
+	Added   bool   `json:"added"`
+	Retired bool   `json:"retired"`
+	// RetireAt is when the key will be retired, if it is not yet
+	RetireAt string `json:"retire_at,omitempty"`
+}
+
+func (o HMACKeyChangeOutput) Text() string {
+	switch {
+	case o.Added:
+		return fmt.Sprintf("Successfully added HMAC key %s", o.ID)
+	case o.Retired:
+		return fmt.Sprintf("Successfully retired HMAC key %s", o.ID)
+	default:
+		return fmt.Sprintf("HMAC key %s will be retired at %s", o.ID, o.RetireAt)
+	}
+}
+
+func NewHMACKeysCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hmac-keys",
+		Short: "Manage the HMAC keys accepted by a running eotsd",
+		Long: `Manage the HMAC keys accepted by a running eotsd, to rotate them without restarting it.
+
+The key of the eotsd config is identified as "default". A rotation is done by:
+  1. adding the new key with "eotsd hmac-keys add",
+  2. setting the new key and its ID in the hmackey and hmackeyid options of the fpd config,
+  3. retiring the old key with "eotsd hmac-keys retire" once fpd uses the new one.
+
+The commands authenticate with the admin HMAC key of the HMAC_ADMIN_KEY environment variable or
+of the hmacadminkey option of the config in the "home" directory, which is the only key allowed to
+add and retire HMAC keys.`,
+	}
+
+	cmd.AddCommand(
+		NewHMACKeysAddCmd(),
+		NewHMACKeysRetireCmd(),
+		NewHMACKeysListCmd(),
+	)
+
+	return cmd
+}
+
+func NewHMACKeysAddCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add [id]",
+		Short: "Add an HMAC key to a running eotsd",
+		Long: `Add an HMAC key to a running eotsd, which accepts it along with the existing keys until it
+is retired. The key can be a reference to a secret store, e.g., file:///run/secrets/hmac, so that
+it is not exposed in the command line. The reference is resolved by this command, and eotsd stores
+the key encrypted with the admin HMAC key.`,
+		Example: `eotsd hmac-keys add 2026-q4 --key file:///run/secrets/hmac-2026-q4 --rpc-client 127.0.0.1:12582 --home /path/to/eotsd/home`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    addHMACKey,
+	}
+
+	f := cmd.Flags()
+	f.String(flagHMACSecret, "", "The HMAC key, or a reference to it in a secret store")
+	f.String(flagNotBefore, "", "The time from which the key is accepted, in RFC3339 format; the key is accepted immediately if empty")
+	f.String(flagNotAfter, "", "The time from which the key is no longer accepted, in RFC3339 format; the key is accepted until it is retired if empty")
+	addHMACKeysClientFlags(cmd)
+
+	if err := cmd.MarkFlagRequired(flagHMACSecret); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
+
+func NewHMACKeysRetireCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "retire [id]",
+		Short: "Retire an HMAC key of a running eotsd",
+		Long: `Retire an HMAC key of a running eotsd, which rejects the requests authenticated with it from
+the given time. The key is retired immediately, unless the "at" or "grace-period" flag is set.`,
+		Example: `eotsd hmac-keys retire default --grace-period 1h --rpc-client 127.0.0.1:12582 --home /path/to/eotsd/home`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    retireHMACKey,
+	}
+
+	f := cmd.Flags()
+	f.String(flagRetireAt, "", "The time from which the key is no longer accepted, in RFC3339 format")
+	f.Duration(flagGracePeriod, 0, "The time during which the key is still accepted")
+	cmd.MarkFlagsMutuallyExclusive(flagRetireAt, flagGracePeriod)
+	addHMACKeysClientFlags(cmd)
+
+	return cmd
+}
+
+func NewHMACKeysListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List the HMAC keys of a running eotsd, without their secret",
+		Example: `eotsd hmac-keys list --rpc-client 127.0.0.1:12582 --home /path/to/eotsd/home`,
+		Args:    cobra.NoArgs,
+		RunE:    listHMACKeys,
+	}
+
+	f := cmd.Flags()
+	f.String(flagOutputFile, "", "Path to output JSON file")
+	addHMACKeysClientFlags(cmd)
+
+	return cmd
+}
+
+func addHMACKeysClientFlags(cmd *cobra.Command) {
+	f := cmd.Flags()
+	f.String(rpcClientFlag, "", "The RPC address of a running eotsd")
+	f.String(sdkflags.FlagHome, "", "The path to the eotsd home directory, whose config holds the admin HMAC key")
+	addRPCClientTLSFlags(cmd)
+
+	if err := cmd.MarkFlagRequired(rpcClientFlag); err != nil {
+		panic(err)
+	}
+}
+
+func newHMACKeysClient(cmd *cobra.Command) (*eotsclient.EOTSManagerGRPCClient, error) {
+	rpcListener, err := cmd.Flags().GetString(rpcClientFlag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s flag: %w", rpcClientFlag, err)
+	}
+
+	hmacKey, hmacOpts, err := rpcClientAdminHMAC(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	dialOpts, err := rpcClientDialOptions(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	eotsdClient, err := eotsclient.NewEOTSManagerGRPCClient(rpcListener, hmacKey, append(dialOpts, hmacOpts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create eotsd client: %w", err)
+	}
+
+	return eotsdClient, nil
+}
+
+// getTimeFlag parses the RFC3339 time of the flag, or returns the zero time if it is empty
+func getTimeFlag(cmd *cobra.Command, flag string) (time.Time, error) {
+	v, err := cmd.Flags().GetString(flag)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get %s flag: %w", flag, err)
+	}
+	if v == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s flag %s: %w", flag, v, err)
+	}
+
+	return t, nil
+}
+
+func addHMACKey(cmd *cobra.Command, args []string) error {
+	keyRef, err := cmd.Flags().GetString(flagHMACSecret)
+	if err != nil {
+		return fmt.Errorf("failed to get %s flag: %w", flagHMACSecret, err)
+	}
+
+	// eotsd does not resolve the secret references of the clients
+	key, err := eotsclient.GetSecretValue(keyRef)
+	if err != nil {
+		return fmt.Errorf("failed to get the HMAC key: %w", err)
+	}
+
+	notBefore, err := getTimeFlag(cmd, flagNotBefore)
+	if err != nil {
+		return err
+	}
+
+	notAfter, err := getTimeFlag(cmd, flagNotAfter)
+	if err != nil {
+		return err
+	}
+
+	eotsdClient, err := newHMACKeysClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = eotsdClient.Close()
+	}()
+
+	if err := eotsdClient.AddHMACKey(args[0], key, notBefore, notAfter); err != nil {
+		return err
+	}
+
+	return output.Print(cmd, HMACKeyChangeOutput{ID: args[0], Added: true})
+}
+
+func retireHMACKey(cmd *cobra.Command, args []string) error {
+	retireAt, err := getTimeFlag(cmd, flagRetireAt)
+	if err != nil {
+		return err
+	}
+
+	gracePeriod, err := cmd.Flags().GetDuration(flagGracePeriod)
+	if err != nil {
+		return fmt.Errorf("failed to get %s flag: %w", flagGracePeriod, err)
+	}
+	if gracePeriod > 0 {
+		retireAt = time.Now().Add(gracePeriod)
+	}
+
+	eotsdClient, err := newHMACKeysClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = eotsdClient.Close()
+	}()
+
+	if err := eotsdClient.RetireHMACKey(args[0], retireAt); err != nil {
+		return err
+	}
+
+	if retireAt.IsZero() {
+		return output.Print(cmd, HMACKeyChangeOutput{ID: args[0], Retired: true})
+	}
+
+	return output.Print(cmd, HMACKeyChangeOutput{ID: args[0], RetireAt: retireAt.UTC().Format(time.RFC3339)})
+}
+
+func listHMACKeys(cmd *cobra.Command, _ []string) error {
+	eotsdClient, err := newHMACKeysClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = eotsdClient.Close()
+	}()
+
+	keys, err := eotsdClient.ListHMACKeys()
+	if err != nil {
+		return err
+	}
+
+	res := make([]HMACKeyOutput, 0, len(keys))
+	for _, k := range keys {
+		out := HMACKeyOutput{ID: k.Id, Active: k.Active}
+		if k.NotBefore != 0 {
+			out.NotBefore = time.Unix(k.NotBefore, 0).UTC().Format(time.RFC3339)
+		}
+		if k.NotAfter != 0 {
+			out.NotAfter = time.Unix(k.NotAfter, 0).UTC().Format(time.RFC3339)
+		}
+		res = append(res, out)
+	}
+
+	return handleOutputJSON(cmd, res)
+}
@@ -2,14 +2,33 @@ package daemon
 
 import (
 	"fmt"
+	"path/filepath"
+	"time"
 
 	"github.com/jessevdk/go-flags"
 	"github.com/spf13/cobra"
 
 	eotscfg "github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/output"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
 
+const defaultTLSValidity = 5 * 365 * 24 * time.Hour
+
+// InitOutput describes the initialized home directory
+type InitOutput struct {
+	Home   string `json:"home"`
+	Config string `json:"config"`
+	// TLSDir and ClientCertFingerprint are set if the TLS certificates were
+	// generated
+	TLSDir                string `json:"tls_dir,omitempty"`
+	ClientCertFingerprint string `json:"client_cert_fingerprint,omitempty"`
+}
+
+func (o InitOutput) Text() string {
+	return fmt.Sprintf("Initialized the eotsd home directory %s with the config %s", o.Home, o.Config)
+}
+
 func NewInitCmd() *cobra.Command {
 	initCmd := &cobra.Command{
 		Use:   "init <path to executable>",
@@ -18,6 +37,10 @@ func NewInitCmd() *cobra.Command {
 	}
 
 	initCmd.Flags().Bool(forceFlag, false, "Override existing configuration")
+	initCmd.Flags().Bool(flagGenerateTLS, false, "Generate a private CA, a server certificate and a client certificate for fpd, and enable mutual TLS with the client certificate pinned")
+	initCmd.Flags().StringSlice(flagTLSHosts, []string{"127.0.0.1", "localhost"}, "The IP addresses and DNS names eotsd is reached at by fpd, included in the generated server certificate")
+	initCmd.Flags().String(flagTLSClientName, "fpd", "The common name of the generated client certificate, identifying the finality provider")
+	initCmd.Flags().Duration(flagTLSValidity, defaultTLSValidity, "The validity of the generated certificates")
 
 	return initCmd
 }
@@ -52,11 +75,95 @@ func initHome(cmd *cobra.Command, _ []string) error {
 
 	defaultConfig := eotscfg.DefaultConfig()
 	defaultConfig.DatabaseConfig.DBPath = dataDir
+	defaultConfig.AuditLogPath = eotscfg.AuditLogFile(homePath)
+
+	generateTLS, err := cmd.Flags().GetBool(flagGenerateTLS)
+	if err != nil {
+		return fmt.Errorf("failed to get %s flag: %w", flagGenerateTLS, err)
+	}
+	out := InitOutput{Home: homePath, Config: eotscfg.CfgFile(homePath)}
+	if generateTLS {
+		tlsCfg, err := generateTLSFiles(cmd, homePath)
+		if err != nil {
+			return err
+		}
+		defaultConfig.TLS = tlsCfg
+		out.TLSDir = eotscfg.TLSDir(homePath)
+		out.ClientCertFingerprint = tlsCfg.PinnedClientCerts[0]
+	}
+
 	fileParser := flags.NewParser(defaultConfig, flags.Default)
 
 	if err := flags.NewIniParser(fileParser).WriteFile(eotscfg.CfgFile(homePath), flags.IniIncludeComments|flags.IniIncludeDefaults); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
-	return nil
+	return output.Print(cmd, out)
+}
+
+// generateTLSFiles bootstraps a private CA in the home directory, issues the
+// certificates of eotsd and fpd with it, and returns the TLS config of eotsd
+// requiring the fpd certificate
+func generateTLSFiles(cmd *cobra.Command, homePath string) (*eotscfg.TLSConfig, error) {
+	f := cmd.Flags()
+	hosts, err := f.GetStringSlice(flagTLSHosts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s flag: %w", flagTLSHosts, err)
+	}
+	clientName, err := f.GetString(flagTLSClientName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s flag: %w", flagTLSClientName, err)
+	}
+	validity, err := f.GetDuration(flagTLSValidity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s flag: %w", flagTLSValidity, err)
+	}
+	if validity <= 0 {
+		return nil, fmt.Errorf("invalid %s: %s", flagTLSValidity, validity)
+	}
+
+	tlsDir := eotscfg.TLSDir(homePath)
+	if err := util.MakeDirectory(tlsDir); err != nil {
+		return nil, fmt.Errorf("failed to create TLS directory: %w", err)
+	}
+	path := func(name string) string {
+		return filepath.Join(tlsDir, name)
+	}
+
+	ca, err := util.GenerateTLSCA("eotsd CA", validity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CA: %w", err)
+	}
+	if err := ca.WriteFiles(path(eotscfg.TLSCACertFilename), path(eotscfg.TLSCAKeyFilename)); err != nil {
+		return nil, err
+	}
+
+	server, err := util.GenerateTLSServerCert(ca, "eotsd", hosts, validity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate server certificate: %w", err)
+	}
+	if err := server.WriteFiles(path(eotscfg.TLSServerCertFilename), path(eotscfg.TLSServerKeyFilename)); err != nil {
+		return nil, err
+	}
+
+	client, err := util.GenerateTLSClientCert(ca, clientName, validity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate client certificate: %w", err)
+	}
+	if err := client.WriteFiles(path(eotscfg.TLSClientCertFilename), path(eotscfg.TLSClientKeyFilename)); err != nil {
+		return nil, err
+	}
+
+	output.Notef(cmd, "Generated the TLS certificates in %s", tlsDir)
+	output.Notef(cmd, "Copy %s, %s and %s to the fpd host and set them in the [eotsmanagertls] section of fpd.conf",
+		eotscfg.TLSCACertFilename, eotscfg.TLSClientCertFilename, eotscfg.TLSClientKeyFilename)
+	output.Notef(cmd, "The client certificate %s is pinned with fingerprint %s", clientName, client.Fingerprint())
+	output.Notef(cmd, "Move %s offline once the certificates are distributed, it is only needed to issue new ones", eotscfg.TLSCAKeyFilename)
+
+	return &eotscfg.TLSConfig{
+		CertFile:          path(eotscfg.TLSServerCertFilename),
+		KeyFile:           path(eotscfg.TLSServerKeyFilename),
+		ClientCAFile:      path(eotscfg.TLSCACertFilename),
+		PinnedClientCerts: []string{client.Fingerprint()},
+	}, nil
 }
@@ -24,6 +24,7 @@ import (
 	eotsclient "github.com/babylonlabs-io/finality-provider/eotsmanager/client"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
 	"github.com/babylonlabs-io/finality-provider/log"
+	"github.com/babylonlabs-io/finality-provider/output"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
 
@@ -48,6 +49,11 @@ func NewKeysCmd() *cobra.Command {
 
 	// Add home flag to root command so all subcommands inherit it
 	keysCmd.PersistentFlags().String(flags.FlagHome, config.DefaultEOTSDir, "The path to the eotsd home directory")
+	// the output flag of the sdk shadows the one of eotsd, the keys printed
+	// by eotsd support yaml as well
+	if f := keysCmd.PersistentFlags().Lookup(flags.FlagOutput); f != nil {
+		f.Usage = "Output format (text|json|yaml)"
+	}
 
 	listCmd.RunE = runCommandPrintAllKeys
 
@@ -68,6 +74,7 @@ func NewKeysCmd() *cobra.Command {
 	}
 
 	addCmd.Flags().String(rpcClientFlag, "", "The RPC address of a running eotsd to connect and save new key")
+	addRPCClientTLSFlags(addCmd)
 
 	// Override the original RunE function to run almost the same as
 	// the sdk, but it allows empty hd path and allow to save the key
@@ -116,6 +123,7 @@ func saveKeyOnPostRun(cmd *cobra.Command, commandName string) {
 	}
 
 	subCmd.Flags().String(rpcClientFlag, "", "The RPC address of a running eotsd to connect and save new key")
+	addRPCClientTLSFlags(subCmd)
 
 	subCmd.PostRunE = func(cmd *cobra.Command, args []string) error {
 		clientCtx, err := client.GetClientQueryContext(cmd)
@@ -144,7 +152,12 @@ func saveKeyNameMapping(cmd *cobra.Command, clientCtx client.Context, keyName st
 	}
 
 	if len(rpcListener) > 0 {
-		eotsClient, err := eotsclient.NewEOTSManagerGRPCClient(rpcListener, "")
+		dialOpts, err := rpcClientDialOptions(cmd)
+		if err != nil {
+			return nil, err
+		}
+
+		eotsClient, err := eotsclient.NewEOTSManagerGRPCClient(rpcListener, "", dialOpts...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create eots client: %w", err)
 		}
@@ -179,7 +192,7 @@ func saveKeyNameMapping(cmd *cobra.Command, clientCtx client.Context, keyName st
 	}
 	defer func() {
 		if err := dbBackend.Close(); err != nil {
-			fmt.Printf("Error closing database: %v\n", err)
+			cmd.PrintErrf("Error closing database: %v\n", err)
 		}
 	}()
 
@@ -250,7 +263,7 @@ func runCommandPrintAllKeys(cmd *cobra.Command, _ []string) error {
 		EOTSPK string `json:"eots_pk"`
 	}
 
-	var keys []keyInfo
+	keys := make([]keyInfo, 0, len(eotsKeys))
 	for keyName, key := range eotsKeys {
 		pk, err := schnorr.ParsePubKey(key)
 		if err != nil {
@@ -264,22 +277,13 @@ func runCommandPrintAllKeys(cmd *cobra.Command, _ []string) error {
 		})
 	}
 
-	output, err := cmd.Flags().GetString(flags.FlagOutput)
+	format, err := output.Format(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get output flag: %w", err)
+		return err
 	}
 
-	if strings.EqualFold(output, flags.OutputFormatJSON) {
-		bz, err := json.MarshalIndent(keys, "", "  ")
-		if err != nil {
-			return fmt.Errorf("failed to marshal keys: %w", err)
-		}
-
-		if _, err := fmt.Fprintln(cmd.OutOrStdout(), string(bz)); err != nil {
-			return fmt.Errorf("failed to print keys: %w", err)
-		}
-
-		return nil
+	if format != output.FormatText {
+		return output.Print(cmd, keys)
 	}
 
 	for _, k := range keys {
@@ -315,7 +319,7 @@ func getAllEOTSKeys(cmd *cobra.Command) (map[string][]byte, error) {
 	}
 	defer func() {
 		if err := dbBackend.Close(); err != nil {
-			fmt.Printf("Error closing database: %v\n", err)
+			cmd.PrintErrf("Error closing database: %v\n", err)
 		}
 	}()
 
@@ -381,17 +385,17 @@ func printCreatePubKeyHex(cmd *cobra.Command, k *cryptokeyring.Record, eotsPk *t
 				return fmt.Errorf("failed to print mnemonic: %s", err.Error())
 			}
 		}
-	case flags.OutputFormatJSON:
+	case flags.OutputFormatJSON, output.FormatYAML:
 		if showMnemonic {
 			keyOutput.Mnemonic = mnemonic
 		}
 
-		jsonString, err := json.MarshalIndent(keyOutput, "", "  ")
+		bz, err := output.Marshal(keyOutput, outputFormat)
 		if err != nil {
 			return fmt.Errorf("failed to marshal keys: %w", err)
 		}
 
-		cmd.Println(string(jsonString))
+		cmd.Println(strings.TrimSuffix(string(bz), "\n"))
 
 	default:
 		return fmt.Errorf("invalid output format %s", outputFormat)
@@ -316,7 +316,7 @@ func readMnemonicFromFile(filePath string) (string, error) {
 	}
 	defer func() {
 		if err := file.Close(); err != nil {
-			fmt.Printf("Error closing file: %v\n", err)
+			_, _ = fmt.Fprintf(os.Stderr, "Error closing file: %v\n", err)
 		}
 	}()
 
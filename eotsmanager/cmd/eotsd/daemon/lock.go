@@ -0,0 +1,133 @@
+package daemon
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/output"
+)
+
+// UnlockedKeyOutput describes an unlocked key of the file keyring of a running eotsd
+type UnlockedKeyOutput struct {
+	KeyName    string `json:"key_name"`
+	EotsPk     string `json:"eots_pk"`
+	UnlockedAt string `json:"unlocked_at"`
+	LastUsedAt string `json:"last_used_at"`
+	ExpiresAt  string `json:"expires_at,omitempty"`
+}
+
+// KeyLockOutput describes the EOTS key locked or unlocked in the memory of a
+// running eotsd
+type KeyLockOutput struct {
+	EotsPk   string `json:"eots_pk"`
+	Unlocked bool   `json:"unlocked"`
+}
+
+func (o KeyLockOutput) Text() string {
+	if o.Unlocked {
+		return fmt.Sprintf("Successfully unlocked keystore to load the EOTS private key for %s in memory of eotsd", o.EotsPk)
+	}
+
+	return fmt.Sprintf("Successfully locked the EOTS private key for %s in memory of eotsd", o.EotsPk)
+}
+
+func NewLockKeyringCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lock",
+		Short: `Locks an EOTS key of the "file" based keyring, removing its private key from the memory of eotsd`,
+		Long: `Locks an EOTS key of the "file" based keyring, removing its private key from the memory of eotsd.
+eotsd cannot sign with the key until it is unlocked again with "eotsd unlock".
+
+The HMAC key is provided as for "eotsd unlock".`,
+		Example: `eotsd lock --eots-pk <eots-pk> --rpc-client 127.0.0.1:12582 --home /path/to/eotsd/home`,
+		Args:    cobra.NoArgs,
+		RunE:    lockKeyring,
+	}
+
+	cmd.Flags().String(eotsPkFlag, "", "EOTS public key of the finality-provider")
+	addHMACKeysClientFlags(cmd)
+
+	if err := cmd.MarkFlagRequired(eotsPkFlag); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
+
+func NewListUnlockedKeysCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-unlocked",
+		Short: `Lists the EOTS keys of the "file" based keyring which are unlocked in a running eotsd`,
+		Long: `Lists the EOTS keys of the "file" based keyring which are unlocked in a running eotsd, with the
+time they were unlocked and last used at, and the time they are locked at unless they are used before,
+according to the unlockttl and unlockidletimeout options of the eotsd config.`,
+		Example: `eotsd list-unlocked --rpc-client 127.0.0.1:12582 --home /path/to/eotsd/home`,
+		Args:    cobra.NoArgs,
+		RunE:    listUnlockedKeys,
+	}
+
+	cmd.Flags().String(flagOutputFile, "", "Path to output JSON file")
+	addHMACKeysClientFlags(cmd)
+
+	return cmd
+}
+
+func lockKeyring(cmd *cobra.Command, _ []string) error {
+	eotsFpPubKeyStr, err := cmd.Flags().GetString(eotsPkFlag)
+	if err != nil {
+		return fmt.Errorf("failed to get %s flag: %w", eotsPkFlag, err)
+	}
+
+	eotsFpPub, err := hex.DecodeString(eotsFpPubKeyStr)
+	if err != nil {
+		return fmt.Errorf("failed to decode eots public key: %w", err)
+	}
+
+	eotsdClient, err := newHMACKeysClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = eotsdClient.Close()
+	}()
+
+	if err := eotsdClient.LockKey(eotsFpPub); err != nil {
+		return err
+	}
+
+	return output.Print(cmd, KeyLockOutput{EotsPk: eotsFpPubKeyStr})
+}
+
+func listUnlockedKeys(cmd *cobra.Command, _ []string) error {
+	eotsdClient, err := newHMACKeysClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = eotsdClient.Close()
+	}()
+
+	keys, err := eotsdClient.ListUnlockedKeys()
+	if err != nil {
+		return err
+	}
+
+	res := make([]UnlockedKeyOutput, 0, len(keys))
+	for _, k := range keys {
+		out := UnlockedKeyOutput{
+			KeyName:    k.KeyName,
+			EotsPk:     hex.EncodeToString(k.Uid),
+			UnlockedAt: time.Unix(k.UnlockedAt, 0).UTC().Format(time.RFC3339),
+			LastUsedAt: time.Unix(k.LastUsedAt, 0).UTC().Format(time.RFC3339),
+		}
+		if k.ExpiresAt != 0 {
+			out.ExpiresAt = time.Unix(k.ExpiresAt, 0).UTC().Format(time.RFC3339)
+		}
+		res = append(res, out)
+	}
+
+	return handleOutputJSON(cmd, res)
+}
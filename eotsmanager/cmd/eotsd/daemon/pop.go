@@ -25,6 +25,7 @@ import (
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
 	"github.com/babylonlabs-io/finality-provider/log"
+	"github.com/babylonlabs-io/finality-provider/output"
 )
 
 const (
@@ -55,6 +56,15 @@ type PoPExport struct {
 	BabyAddress string `json:"babyAddress"`
 }
 
+// PoPValidation is the result of the validation of a PoPExport
+type PoPValidation struct {
+	Valid bool `json:"valid"`
+}
+
+func (v PoPValidation) Text() string {
+	return "Proof of Possession is valid!"
+}
+
 // PoPExportDelete the data needed to delete an ownership previously created.
 type PoPExportDelete struct {
 	// Btc public key is the EOTS PK *bbntypes.BIP340PubKey marshal hex
@@ -79,6 +89,7 @@ func NewPopCmd() *cobra.Command {
 		NewPopExportCmd(),
 		NewPopDeleteCmd(),
 		NewPopValidateExportCmd(),
+		NewPopSignRegistrationCmd(),
 	)
 
 	return cmd
@@ -176,9 +187,7 @@ func validatePop(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid pop %+v", pop)
 	}
 
-	cmd.Println("Proof of Possession is valid!")
-
-	return nil
+	return output.Print(cmd, PoPValidation{Valid: true})
 }
 
 func exportPop(cmd *cobra.Command, _ []string) error {
@@ -349,18 +358,20 @@ func getInterpretedMessage(cmd *cobra.Command) (string, error) {
 	return interpretedMsg, nil
 }
 
+// handleOutputJSON prints the output in the output format of the command and
+// writes its JSON to the output file, if any
 func handleOutputJSON(cmd *cobra.Command, out any) error {
-	jsonBz, err := json.MarshalIndent(out, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal output: %w", err)
-	}
-
 	outputFilePath, err := cmd.Flags().GetString(flagOutputFile)
 	if err != nil {
 		return fmt.Errorf("failed to get output file path: %w", err)
 	}
 
 	if len(outputFilePath) > 0 {
+		jsonBz, err := output.Marshal(out, output.FormatJSON)
+		if err != nil {
+			return err
+		}
+
 		// Add path validation
 		cleanPath, err := filepath.Abs(filepath.Clean(outputFilePath))
 		if err != nil {
@@ -378,9 +389,7 @@ func handleOutputJSON(cmd *cobra.Command, out any) error {
 		}
 	}
 
-	cmd.Println(string(jsonBz))
-
-	return nil
+	return output.Print(cmd, out)
 }
 
 func babyKeyring(
@@ -601,7 +610,7 @@ func cmdCloseEots(
 ) {
 	err := eotsManager.Close()
 	if err != nil {
-		cmd.Printf("error closing eots manager: %s", err.Error())
+		cmd.PrintErrf("error closing eots manager: %s\n", err.Error())
 	}
 }
 
@@ -0,0 +1,177 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	bbnparams "github.com/babylonlabs-io/babylon/v4/app/params"
+	bbntypes "github.com/babylonlabs-io/babylon/v4/types"
+	bstypes "github.com/babylonlabs-io/babylon/v4/x/btcstaking/types"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/std"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+)
+
+func NewPopSignRegistrationCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign-registration [unsigned-tx-file]",
+		Short: "Fills the Proof of Possession of an unsigned finality provider registration tx.",
+		Long: `Receives as an argument the file path of the unsigned tx generated by fpd tx build-register.
+		The finality provider address of the MsgCreateFinalityProvider is hashed with sha256 and signed
+		with the EOTS key of the msg, or the one associated with the key-name or eots-pk flag, which
+		must match the msg. The signature is set as the BIP340 Proof of Possession of the msg and the
+		unsigned tx is exported again, ready to be signed offline with fpd tx sign.`,
+		Example: `eotsd pop sign-registration /path/to/register-unsigned.json --output-file /path/to/register-pop.json`,
+		RunE:    signRegistrationPop,
+		Args:    cobra.ExactArgs(1),
+	}
+
+	f := cmd.Flags()
+
+	f.String(sdkflags.FlagHome, config.DefaultEOTSDir, "EOTS home directory")
+	f.String(keyNameFlag, "", "EOTS key name")
+	f.String(eotsPkFlag, "", "EOTS public key of the finality-provider, defaults to the one in the tx")
+	f.String(sdkflags.FlagKeyringBackend, keyring.BackendTest, "EOTS backend of the keyring")
+
+	f.String(flagOutputFile, "", "Path to output JSON file")
+
+	return cmd
+}
+
+// signRegistrationPop reads and writes the unsigned tx rather than a PoPExport,
+// as the registration PoP signs the hash of the address bytes instead of the
+// bech32 address, no BABY signature can be made on the EOTS host, and the
+// output is signed as is by fpd tx sign
+func signRegistrationPop(cmd *cobra.Command, args []string) error {
+	eotsHomePath, eotsKeyName, eotsFpPubKeyStr, eotsKeyringBackend, err := eotsFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	// #nosec G304 - The file path is provided by the user and not externally
+	bzTx, err := os.ReadFile(filepath.Clean(args[0]))
+	if err != nil {
+		return fmt.Errorf("failed to read unsigned tx file: %w", err)
+	}
+
+	txCfg := registrationEncodingConfig().TxConfig
+	unsignedTx, err := txCfg.TxJSONDecoder()(bzTx)
+	if err != nil {
+		return fmt.Errorf("failed to decode unsigned tx: %w", err)
+	}
+
+	msgs := unsignedTx.GetMsgs()
+	msg, err := registrationMsg(msgs)
+	if err != nil {
+		return err
+	}
+
+	if len(eotsFpPubKeyStr) == 0 && len(eotsKeyName) == 0 {
+		eotsFpPubKeyStr = msg.BtcPk.MarshalHex()
+	}
+
+	eotsManager, err := loadEotsManager(eotsHomePath, eotsFpPubKeyStr, eotsKeyName, eotsKeyringBackend)
+	if err != nil {
+		return err
+	}
+	defer cmdCloseEots(cmd, eotsManager)
+
+	if err := SignRegistrationPop(eotsManager, eotsKeyName, eotsFpPubKeyStr, msg); err != nil {
+		return err
+	}
+
+	txBuilder, err := txCfg.WrapTxBuilder(unsignedTx)
+	if err != nil {
+		return fmt.Errorf("failed to wrap unsigned tx: %w", err)
+	}
+
+	// msgs are cached as packed Any, so they need to be set again
+	if err := txBuilder.SetMsgs(msgs...); err != nil {
+		return fmt.Errorf("failed to set msgs with the proof of possession: %w", err)
+	}
+
+	bzOut, err := txCfg.TxJSONEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return fmt.Errorf("failed to encode unsigned tx: %w", err)
+	}
+
+	return handleOutputJSON(cmd, json.RawMessage(bzOut))
+}
+
+// SignRegistrationPop sets the BIP340 Proof of Possession of the msg, signing
+// the hash of the finality provider address bytes with the EOTS key of the msg.
+func SignRegistrationPop(
+	eotsManager *eotsmanager.LocalEOTSManager,
+	eotsKeyName, eotsFpPubKeyStr string,
+	msg *bstypes.MsgCreateFinalityProvider,
+) error {
+	fpAddr, err := sdk.AccAddressFromBech32(msg.Addr)
+	if err != nil {
+		return fmt.Errorf("invalid finality provider address %s: %w", msg.Addr, err)
+	}
+
+	schnorrSig, eotsPk, err := eotsSignMsg(eotsManager, eotsKeyName, eotsFpPubKeyStr, tmhash.Sum(fpAddr.Bytes()))
+	if err != nil {
+		return fmt.Errorf("failed to sign address %s: %w", msg.Addr, err)
+	}
+
+	if msg.BtcPk == nil || !eotsPk.Equals(msg.BtcPk) {
+		return fmt.Errorf("the EOTS key %s does not match the one of the registration msg", eotsPk.MarshalHex())
+	}
+
+	msg.Pop = &bstypes.ProofOfPossessionBTC{
+		BtcSigType: bstypes.BTCSigType_BIP340,
+		BtcSig:     bbntypes.NewBIP340SignatureFromBTCSig(schnorrSig).MustMarshal(),
+	}
+
+	if err := msg.Pop.VerifyBIP340(fpAddr, msg.BtcPk); err != nil {
+		return fmt.Errorf("failed to verify the proof of possession: %w", err)
+	}
+
+	if err := msg.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid registration msg: %w", err)
+	}
+
+	return nil
+}
+
+// registrationMsg returns the single MsgCreateFinalityProvider of the msgs
+func registrationMsg(msgs []sdk.Msg) (*bstypes.MsgCreateFinalityProvider, error) {
+	var found *bstypes.MsgCreateFinalityProvider
+	for _, m := range msgs {
+		msg, ok := m.(*bstypes.MsgCreateFinalityProvider)
+		if !ok {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("the tx contains more than one MsgCreateFinalityProvider")
+		}
+		found = msg
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("the tx does not contain a MsgCreateFinalityProvider")
+	}
+
+	if found.BtcPk == nil {
+		return nil, fmt.Errorf("the MsgCreateFinalityProvider has no EOTS public key")
+	}
+
+	return found, nil
+}
+
+func registrationEncodingConfig() *bbnparams.EncodingConfig {
+	encCfg := bbnparams.DefaultEncodingConfig()
+	std.RegisterInterfaces(encCfg.InterfaceRegistry)
+	bstypes.RegisterInterfaces(encCfg.InterfaceRegistry)
+
+	return encCfg
+}
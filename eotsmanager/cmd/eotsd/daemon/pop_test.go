@@ -4,11 +4,25 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"cosmossdk.io/math"
+	bbnparams "github.com/babylonlabs-io/babylon/v4/app/params"
+	bbntypes "github.com/babylonlabs-io/babylon/v4/types"
+	bstypes "github.com/babylonlabs-io/babylon/v4/x/btcstaking/types"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/cmd/eotsd/daemon"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	fplog "github.com/babylonlabs-io/finality-provider/log"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -95,3 +109,72 @@ func TestPoPValidate(t *testing.T) {
 		require.Equal(t, writer.String(), "Proof of Possession is valid!\n")
 	}
 }
+
+func TestPoPSignRegistration(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	tempHome := filepath.Join(t.TempDir(), "homeeots")
+	homeFlagFilled := fmt.Sprintf("--%s=%s", sdkflags.FlagHome, tempHome)
+	keyringBackendFlagFilled := fmt.Sprintf("--%s=%s", sdkflags.FlagKeyringBackend, keyring.BackendTest)
+
+	root := daemon.NewRootCmd()
+	root.SetOut(bytes.NewBuffer([]byte{}))
+	root.SetArgs([]string{"init", homeFlagFilled})
+	require.NoError(t, root.Execute())
+
+	// create the EOTS key and release the db before running the command
+	cfg := config.DefaultConfigWithHomePath(tempHome)
+	dbBackend, err := cfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	logger, err := fplog.NewDevLogger()
+	require.NoError(t, err)
+	eotsManager, err := eotsmanager.NewLocalEOTSManager(tempHome, keyring.BackendTest, dbBackend, logger)
+	require.NoError(t, err)
+	eotsPkBz, err := eotsManager.CreateKey(testutil.GenRandomHexStr(r, 5), "")
+	require.NoError(t, err)
+	require.NoError(t, eotsManager.Close())
+	require.NoError(t, dbBackend.Close())
+
+	eotsPk, err := bbntypes.NewBIP340PubKey(eotsPkBz)
+	require.NoError(t, err)
+
+	fpAddr := sdk.AccAddress(testutil.GenRandomByteArray(r, 20))
+	encCfg := bbnparams.DefaultEncodingConfig()
+	bstypes.RegisterInterfaces(encCfg.InterfaceRegistry)
+
+	txBuilder := encCfg.TxConfig.NewTxBuilder()
+	require.NoError(t, txBuilder.SetMsgs(&bstypes.MsgCreateFinalityProvider{
+		Addr:        fpAddr.String(),
+		Description: &stakingtypes.Description{Moniker: "offline-fp"},
+		Commission:  bstypes.NewCommissionRates(math.LegacyMustNewDecFromStr("0.05"), math.LegacyMustNewDecFromStr("0.2"), math.LegacyMustNewDecFromStr("0.01")),
+		BtcPk:       eotsPk,
+	}))
+	unsignedTx, err := encCfg.TxConfig.TxJSONEncoder()(txBuilder.GetTx())
+	require.NoError(t, err)
+
+	unsignedTxPath := filepath.Join(t.TempDir(), "register-unsigned.json")
+	require.NoError(t, os.WriteFile(unsignedTxPath, unsignedTx, 0600))
+	popTxPath := filepath.Join(t.TempDir(), "register-pop.json")
+
+	root = daemon.NewRootCmd()
+	root.SetOut(bytes.NewBuffer([]byte{}))
+	root.SetArgs([]string{
+		"pop", "sign-registration", unsignedTxPath,
+		homeFlagFilled, keyringBackendFlagFilled,
+		fmt.Sprintf("--output-file=%s", popTxPath),
+	})
+	require.NoError(t, root.Execute())
+
+	popTx, err := os.ReadFile(popTxPath)
+	require.NoError(t, err)
+	decodedTx, err := encCfg.TxConfig.TxJSONDecoder()(popTx)
+	require.NoError(t, err)
+	require.Len(t, decodedTx.GetMsgs(), 1)
+
+	msg, ok := decodedTx.GetMsgs()[0].(*bstypes.MsgCreateFinalityProvider)
+	require.True(t, ok)
+	require.NotNil(t, msg.Pop)
+	require.NoError(t, msg.ValidateBasic())
+	require.NoError(t, msg.Pop.VerifyBIP340(fpAddr, eotsPk))
+}
@@ -0,0 +1,40 @@
+package daemon
+
+import (
+	"fmt"
+
+	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	eotsservice "github.com/babylonlabs-io/finality-provider/eotsmanager/service"
+	"github.com/babylonlabs-io/finality-provider/preflight"
+)
+
+func NewPreflightCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "preflight",
+		Short: "Check that eotsd can start with its config, without starting it",
+		Long: `Check the config, the permissions of the home, the RPC and metrics listeners, the database,
+the keys of the database and of readykeys in the keyring, the HMAC key, the TLS certificates and
+the policy file, and print whether each check passes. eotsd must be stopped, as its database is
+opened and its listeners are bound. The command fails if any check fails.`,
+		Example: `eotsd preflight --home /home/user/.eotsd --output json`,
+		Args:    cobra.NoArgs,
+		RunE:    preflightFn,
+	}
+
+	cmd.Flags().String(sdkflags.FlagHome, config.DefaultEOTSDir, "The path to the eotsd home directory")
+	preflight.AddFlags(cmd)
+
+	return cmd
+}
+
+func preflightFn(cmd *cobra.Command, _ []string) error {
+	homePath, err := getHomePath(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to load home flag: %w", err)
+	}
+
+	return preflight.RunCommand(cmd, eotsservice.PreflightChecks(homePath))
+}
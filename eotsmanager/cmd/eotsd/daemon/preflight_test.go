@@ -0,0 +1,75 @@
+package daemon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/output"
+	"github.com/babylonlabs-io/finality-provider/preflight"
+)
+
+func TestPreflightCmd(t *testing.T) {
+	tempHome := filepath.Join(t.TempDir(), "homeeots")
+	homeFlagFilled := fmt.Sprintf("--%s=%s", sdkflags.FlagHome, tempHome)
+	keyringBackendFlagFilled := fmt.Sprintf("--%s=%s", sdkflags.FlagKeyringBackend, keyring.BackendTest)
+	keyName := "preflight-key"
+
+	// the config is missing before the home is initialized
+	report, err := runPreflight(t, homeFlagFilled)
+	require.Error(t, err)
+	require.Equal(t, preflight.StatusFail, checkStatus(t, report, "config"))
+	require.Equal(t, preflight.StatusSkip, checkStatus(t, report, "database"))
+
+	_, _ = exec(t, NewRootCmd(), "init", homeFlagFilled)
+	_, _ = exec(t, NewRootCmd(), "keys", "add", keyName, homeFlagFilled, keyringBackendFlagFilled)
+
+	// the listeners are not checked, as the default ports may be in use
+	report, _ = runPreflight(t, homeFlagFilled)
+	for _, name := range []string{"config", "permissions", "database", "keys", "hmac_key", "tls", "policy"} {
+		require.Equal(t, preflight.StatusPass, checkStatus(t, report, name), name)
+	}
+
+	// the key of the database is missing from the keyring
+	require.NoError(t, os.Remove(filepath.Join(tempHome, "keyring-test", keyName+".info")))
+	report, err = runPreflight(t, homeFlagFilled)
+	require.Error(t, err)
+	require.False(t, report.Passed)
+	require.Equal(t, preflight.StatusFail, checkStatus(t, report, "keys"))
+}
+
+func runPreflight(t *testing.T, args ...string) (*preflight.Report, error) {
+	t.Helper()
+
+	root := NewRootCmd()
+	stdoutBuf := new(bytes.Buffer)
+	root.SetOut(stdoutBuf)
+	root.SetErr(new(bytes.Buffer))
+	root.SetArgs(append([]string{"preflight", "--output", output.FormatJSON}, args...))
+	err := root.Execute()
+
+	var report preflight.Report
+	require.NoError(t, json.Unmarshal(stdoutBuf.Bytes(), &report), stdoutBuf.String())
+
+	return &report, err
+}
+
+func checkStatus(t *testing.T, report *preflight.Report, name string) string {
+	t.Helper()
+
+	for _, c := range report.Checks {
+		if c.Name == name {
+			return c.Status
+		}
+	}
+	require.Failf(t, "missing check", "check %s not found", name)
+
+	return ""
+}
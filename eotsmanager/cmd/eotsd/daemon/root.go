@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/output"
 )
 
 // NewRootCmd creates a new root command for fpd. It is called once in the main function.
@@ -19,16 +20,24 @@ func NewRootCmd() *cobra.Command {
 	}
 
 	rootCmd.PersistentFlags().String(sdkflags.FlagHome, config.DefaultEOTSDir, "The application home directory")
+	output.AddFlag(rootCmd)
 
 	rootCmd.AddCommand(
 		NewInitCmd(),
 		NewKeysCmd(),
 		NewStartCmd(),
+		NewPreflightCmd(),
 		version.CommandVersion("eotsd"),
 		NewPopCmd(),
 		NewSignStoreRollbackCmd(),
 		NewBackupCmd(),
 		NewUnlockKeyringCmd(),
+		NewLockKeyringCmd(),
+		NewListUnlockedKeysCmd(),
+		NewAuditCmd(),
+		NewHMACKeysCmd(),
+		NewThresholdCmd(),
+		NewSignStoreCmd(),
 	)
 
 	return rootCmd
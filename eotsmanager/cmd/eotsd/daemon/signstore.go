@@ -0,0 +1,277 @@
+package daemon
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+)
+
+const (
+	flagHeight      = "height"
+	flagStartHeight = "start-height"
+	flagEndHeight   = "end-height"
+	flagLimit       = "limit"
+	flagAll         = "all"
+)
+
+// SignRecordOutput describes a record of the sign store of a running eotsd
+type SignRecordOutput struct {
+	Height   uint64 `json:"height"`
+	Msg      string `json:"msg"`
+	Sig      string `json:"sig"`
+	SignedAt string `json:"signed_at"`
+}
+
+// SignRecordsOutput describes a page of records of the sign store of a running eotsd
+type SignRecordsOutput struct {
+	Records []SignRecordOutput `json:"records"`
+	// NextHeight is the start height of the next page, if any
+	NextHeight *uint64 `json:"next_height,omitempty"`
+}
+
+// LastSignedHeightOutput describes the last height signed with a key for a chain
+type LastSignedHeightOutput struct {
+	Found  bool   `json:"found"`
+	Height uint64 `json:"height,omitempty"`
+}
+
+func NewSignStoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign-store",
+		Short: "Inspect the sign store of a running eotsd",
+	}
+
+	cmd.AddCommand(NewSignStoreQueryCmd())
+
+	return cmd
+}
+
+func NewSignStoreQueryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query",
+		Short: "Query the records of the messages signed by a running eotsd",
+		Long: `Query the records of the messages signed by a running eotsd, for instance to check what was
+signed before rolling back the sign store with "eotsd unsafe-rollback" or migrating eotsd.
+The queries are read-only. The HMAC key is provided as for "eotsd unlock".`,
+	}
+
+	cmd.AddCommand(
+		newSignStoreQueryRecordCmd(),
+		newSignStoreQueryListCmd(),
+		newSignStoreQueryLastHeightCmd(),
+	)
+
+	return cmd
+}
+
+func newSignStoreQueryRecordCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "record",
+		Short:   "Show the message signed with an EOTS key for a chain at a height",
+		Example: `eotsd sign-store query record --eots-pk <eots-pk> --chain-id <chain-id> --height 100 --rpc-client 127.0.0.1:12582`,
+		Args:    cobra.NoArgs,
+		RunE:    querySignRecord,
+	}
+
+	addSignStoreQueryFlags(cmd)
+	cmd.Flags().Uint64(flagHeight, 0, "The height of the record")
+
+	if err := cmd.MarkFlagRequired(flagHeight); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
+
+func newSignStoreQueryListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the messages signed with an EOTS key for a chain in a range of heights",
+		Long: `List the messages signed with an EOTS key for a chain in a range of heights, by ascending height.
+A page holds at most --limit records, and next_height is the start height of the next page if the
+range has more records. --all fetches all the pages.`,
+		Example: `eotsd sign-store query list --eots-pk <eots-pk> --chain-id <chain-id> --start-height 100 --end-height 200 --rpc-client 127.0.0.1:12582`,
+		Args:    cobra.NoArgs,
+		RunE:    listSignRecords,
+	}
+
+	addSignStoreQueryFlags(cmd)
+	f := cmd.Flags()
+	f.Uint64(flagStartHeight, 0, "The first height of the range")
+	f.Uint64(flagEndHeight, 0, "The last height of the range, inclusive; the range has no upper bound if 0")
+	f.Uint32(flagLimit, 100, "The maximum number of records of a page, at most 1000")
+	f.Bool(flagAll, false, "Fetch all the pages of the range")
+
+	return cmd
+}
+
+func newSignStoreQueryLastHeightCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "last-height",
+		Short:   "Show the highest height signed with an EOTS key for a chain",
+		Example: `eotsd sign-store query last-height --eots-pk <eots-pk> --chain-id <chain-id> --rpc-client 127.0.0.1:12582`,
+		Args:    cobra.NoArgs,
+		RunE:    queryLastSignedHeight,
+	}
+
+	addSignStoreQueryFlags(cmd)
+
+	return cmd
+}
+
+func addSignStoreQueryFlags(cmd *cobra.Command) {
+	f := cmd.Flags()
+	f.String(eotsPkFlag, "", "EOTS public key of the finality-provider")
+	f.String(flagChainID, "", "The identifier of the consumer chain")
+	f.String(flagOutputFile, "", "Path to output JSON file")
+	addHMACKeysClientFlags(cmd)
+
+	for _, flag := range []string{eotsPkFlag, flagChainID} {
+		if err := cmd.MarkFlagRequired(flag); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// getSignStoreQueryArgs returns the EOTS key and the chain ID of the query
+func getSignStoreQueryArgs(cmd *cobra.Command) ([]byte, []byte, error) {
+	eotsPkStr, err := cmd.Flags().GetString(eotsPkFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get %s flag: %w", eotsPkFlag, err)
+	}
+
+	eotsPk, err := hex.DecodeString(eotsPkStr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode eots public key: %w", err)
+	}
+
+	chainID, err := cmd.Flags().GetString(flagChainID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get %s flag: %w", flagChainID, err)
+	}
+
+	return eotsPk, []byte(chainID), nil
+}
+
+func querySignRecord(cmd *cobra.Command, _ []string) error {
+	eotsPk, chainID, err := getSignStoreQueryArgs(cmd)
+	if err != nil {
+		return err
+	}
+
+	height, err := cmd.Flags().GetUint64(flagHeight)
+	if err != nil {
+		return fmt.Errorf("failed to get %s flag: %w", flagHeight, err)
+	}
+
+	eotsdClient, err := newHMACKeysClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = eotsdClient.Close()
+	}()
+
+	record, err := eotsdClient.GetSignRecord(eotsPk, chainID, height)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return fmt.Errorf("no message was signed at height %d", height)
+	}
+
+	return handleOutputJSON(cmd, signRecordOutput(record))
+}
+
+func listSignRecords(cmd *cobra.Command, _ []string) error {
+	eotsPk, chainID, err := getSignStoreQueryArgs(cmd)
+	if err != nil {
+		return err
+	}
+
+	f := cmd.Flags()
+	startHeight, err := f.GetUint64(flagStartHeight)
+	if err != nil {
+		return fmt.Errorf("failed to get %s flag: %w", flagStartHeight, err)
+	}
+	endHeight, err := f.GetUint64(flagEndHeight)
+	if err != nil {
+		return fmt.Errorf("failed to get %s flag: %w", flagEndHeight, err)
+	}
+	limit, err := f.GetUint32(flagLimit)
+	if err != nil {
+		return fmt.Errorf("failed to get %s flag: %w", flagLimit, err)
+	}
+	all, err := f.GetBool(flagAll)
+	if err != nil {
+		return fmt.Errorf("failed to get %s flag: %w", flagAll, err)
+	}
+
+	eotsdClient, err := newHMACKeysClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = eotsdClient.Close()
+	}()
+
+	out := SignRecordsOutput{Records: []SignRecordOutput{}}
+	for {
+		page, err := eotsdClient.ListSignRecords(eotsPk, chainID, startHeight, endHeight, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range page.Records {
+			out.Records = append(out.Records, signRecordOutput(record))
+		}
+
+		if !page.HasMore {
+			break
+		}
+		if !all {
+			next := page.NextHeight
+			out.NextHeight = &next
+
+			break
+		}
+		startHeight = page.NextHeight
+	}
+
+	return handleOutputJSON(cmd, out)
+}
+
+func queryLastSignedHeight(cmd *cobra.Command, _ []string) error {
+	eotsPk, chainID, err := getSignStoreQueryArgs(cmd)
+	if err != nil {
+		return err
+	}
+
+	eotsdClient, err := newHMACKeysClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = eotsdClient.Close()
+	}()
+
+	height, found, err := eotsdClient.GetLastSignedHeight(eotsPk, chainID)
+	if err != nil {
+		return err
+	}
+
+	return handleOutputJSON(cmd, LastSignedHeightOutput{Found: found, Height: height})
+}
+
+func signRecordOutput(record *proto.SignRecord) SignRecordOutput {
+	return SignRecordOutput{
+		Height:   record.Height,
+		Msg:      hex.EncodeToString(record.Msg),
+		Sig:      hex.EncodeToString(record.Sig),
+		SignedAt: time.UnixMilli(record.Timestamp).UTC().Format(time.RFC3339),
+	}
+}
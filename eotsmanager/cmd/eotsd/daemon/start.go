@@ -13,6 +13,7 @@ import (
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
 	eotsservice "github.com/babylonlabs-io/finality-provider/eotsmanager/service"
 	"github.com/babylonlabs-io/finality-provider/log"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 )
 
 func NewStartCmd() *cobra.Command {
@@ -56,17 +57,40 @@ func startFn(cmd *cobra.Command, _ []string) error {
 		cfg.RPCListener = rpcListener
 	}
 
+	// configs created before the audit log was introduced do not set its path
+	if cfg.AuditLogPath == "" {
+		cfg.AuditLogPath = config.AuditLogFile(homePath)
+	}
+
 	logger, err := log.NewRootLoggerWithFile(config.LogFile(homePath), cfg.LogLevel)
 	if err != nil {
 		return fmt.Errorf("failed to load the logger: %w", err)
 	}
 
+	stopTracing, err := tracing.Start(cmd.Context(), cfg.Tracing, "eotsd", logger)
+	if err != nil {
+		return fmt.Errorf("failed to start tracing: %w", err)
+	}
+	defer stopTracing()
+
 	dbBackend, err := cfg.DatabaseConfig.GetDBBackend()
 	if err != nil {
 		return fmt.Errorf("failed to create db backend: %w", err)
 	}
 
-	eotsManager, err := eotsmanager.NewLocalEOTSManager(homePath, cfg.KeyringBackend, dbBackend, logger)
+	opts := []eotsmanager.LocalEOTSManagerOption{
+		eotsmanager.WithUnlockTTL(cfg.UnlockTTL),
+		eotsmanager.WithUnlockIdleTimeout(cfg.UnlockIdleTimeout),
+	}
+	if guard := cfg.SigningGuard; guard != nil {
+		opts = append(opts,
+			eotsmanager.WithSignRateLimit(guard.MaxSignaturesPerSecond, guard.MaxSignatureBurst),
+			eotsmanager.WithMaxHeightJump(guard.MaxHeightJump),
+			eotsmanager.WithMaxHeightBeyondRandomness(guard.MaxHeightBeyondRandomness),
+		)
+	}
+
+	eotsManager, err := eotsmanager.NewLocalEOTSManager(homePath, cfg.KeyringBackend, dbBackend, logger, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to create EOTS manager: %w", err)
 	}
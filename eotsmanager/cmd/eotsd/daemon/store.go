@@ -6,6 +6,7 @@ import (
 	bbntypes "github.com/babylonlabs-io/babylon/v4/types"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/store"
+	"github.com/babylonlabs-io/finality-provider/output"
 	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/spf13/cobra"
 )
@@ -15,6 +16,18 @@ const (
 	flagChainID    = "chain-id"
 )
 
+// SignStoreRollbackOutput describes the records deleted from the sign store
+type SignStoreRollbackOutput struct {
+	EotsPk  string `json:"eots_pk"`
+	ChainID string `json:"chain_id"`
+	// Height is the height from which the records were deleted
+	Height uint64 `json:"height"`
+}
+
+func (o SignStoreRollbackOutput) Text() string {
+	return fmt.Sprintf("Successfully deleted sign store records from height %d", o.Height)
+}
+
 func NewSignStoreRollbackCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "unsafe-rollback",
@@ -94,7 +107,7 @@ func rollbackSignStore(cmd *cobra.Command, _ []string) error {
 
 	defer func() {
 		if err := es.Close(); err != nil {
-			fmt.Printf("Error closing EOTS store: %v\n", err)
+			cmd.PrintErrf("Error closing EOTS store: %v\n", err)
 		}
 	}()
 
@@ -107,7 +120,5 @@ func rollbackSignStore(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to delete sign store records: %w", err)
 	}
 
-	cmd.Printf("Successfully deleted sign store records from height %d\n", height)
-
-	return nil
+	return output.Print(cmd, SignStoreRollbackOutput{EotsPk: eotsFpPubKeyStr, ChainID: chainID, Height: height})
 }
@@ -0,0 +1,215 @@
+package daemon
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/threshold"
+	"github.com/babylonlabs-io/finality-provider/output"
+)
+
+const (
+	flagThreshold = "threshold"
+	flagParties   = "parties"
+	flagOutputDir = "output-dir"
+)
+
+// ThresholdDealOutput describes the threshold EOTS key generated by the dealer
+type ThresholdDealOutput struct {
+	EotsPk     string   `json:"eots_pk"`
+	Threshold  uint32   `json:"threshold"`
+	Parties    uint32   `json:"parties"`
+	ShareFiles []string `json:"share_files"`
+}
+
+// KeyShareImportOutput describes the share of a threshold EOTS key imported
+// into the keyring
+type KeyShareImportOutput struct {
+	EotsPk    string `json:"eots_pk"`
+	Index     uint32 `json:"index"`
+	Parties   uint32 `json:"parties"`
+	Threshold uint32 `json:"threshold"`
+}
+
+func (o KeyShareImportOutput) Text() string {
+	return fmt.Sprintf("Successfully imported share %d of %d (threshold %d) of the EOTS key %s",
+		o.Index, o.Parties, o.Threshold, o.EotsPk)
+}
+
+func NewThresholdCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "threshold",
+		Short: "Manage threshold EOTS keys, whose shares are held by several eotsd",
+		Long: `Manage threshold EOTS keys, whose shares are held by several eotsd, so that no single host
+holds the EOTS key or the randomness. fpd combines the partial signatures of a threshold of them,
+as configured in its [thresholdeotsmanager] section.`,
+	}
+
+	cmd.AddCommand(
+		NewThresholdDealCmd(),
+		NewThresholdImportCmd(),
+	)
+
+	return cmd
+}
+
+func NewThresholdDealCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deal",
+		Short: "Generate a threshold EOTS key and write its shares",
+		Long: `Generate a threshold EOTS key and write one share file per eotsd in the output directory.
+Run it on an offline host: the host holds the whole key while it runs. Move each share file to its
+eotsd, import it with "eotsd threshold import", and delete all the share files afterwards.`,
+		Example: `eotsd threshold deal --threshold 2 --parties 3 --output-dir /path/to/shares`,
+		Args:    cobra.NoArgs,
+		RunE:    dealThresholdKey,
+	}
+
+	f := cmd.Flags()
+	f.Uint32(flagThreshold, 0, "The number of eotsd needed to sign, more than half of the parties")
+	f.Uint32(flagParties, 0, fmt.Sprintf("The number of eotsd holding a share, at most %d", threshold.MaxParties))
+	f.String(flagOutputDir, "", "The directory to write the share files to")
+	f.String(flagOutputFile, "", "Path to output JSON file")
+
+	for _, flag := range []string{flagThreshold, flagParties, flagOutputDir} {
+		if err := cmd.MarkFlagRequired(flag); err != nil {
+			panic(err)
+		}
+	}
+
+	return cmd
+}
+
+func NewThresholdImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [share-file]",
+		Short: "Import the share of a threshold EOTS key into the eotsd database",
+		Long: `Import the share of a threshold EOTS key into the eotsd database, after which eotsd
+produces partial randomness and partial signatures with it. eotsd must be stopped. The share is
+stored unencrypted in the database, so protect it as a key of the "test" keyring.`,
+		Example: `eotsd threshold import /path/to/share-1.json --home /path/to/eotsd/home`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    importThresholdKeyShare,
+	}
+
+	cmd.Flags().String(sdkflags.FlagHome, config.DefaultEOTSDir, "EOTS home directory")
+
+	return cmd
+}
+
+func dealThresholdKey(cmd *cobra.Command, _ []string) error {
+	f := cmd.Flags()
+
+	t, err := f.GetUint32(flagThreshold)
+	if err != nil {
+		return fmt.Errorf("failed to get %s flag: %w", flagThreshold, err)
+	}
+
+	parties, err := f.GetUint32(flagParties)
+	if err != nil {
+		return fmt.Errorf("failed to get %s flag: %w", flagParties, err)
+	}
+
+	outputDir, err := getCleanPath(cmd, flagOutputDir)
+	if err != nil {
+		return err
+	}
+
+	groupPk, shares, err := threshold.Deal(t, parties)
+	if err != nil {
+		return fmt.Errorf("failed to deal threshold key: %w", err)
+	}
+	defer func() {
+		for _, s := range shares {
+			s.Zero()
+		}
+	}()
+
+	if err := os.MkdirAll(outputDir, 0700); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	res := ThresholdDealOutput{
+		EotsPk:    hex.EncodeToString(schnorr.SerializePubKey(groupPk)),
+		Threshold: t,
+		Parties:   parties,
+	}
+	for _, s := range shares {
+		data, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode share %d: %w", s.Index, err)
+		}
+
+		path := filepath.Join(outputDir, fmt.Sprintf("share-%d.json", s.Index))
+		err = os.WriteFile(path, data, 0600)
+		clear(data)
+		if err != nil {
+			return fmt.Errorf("failed to write share %d: %w", s.Index, err)
+		}
+		res.ShareFiles = append(res.ShareFiles, path)
+	}
+
+	return handleOutputJSON(cmd, res)
+}
+
+func importThresholdKeyShare(cmd *cobra.Command, args []string) error {
+	homePath, err := getHomePath(cmd)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(filepath.Clean(args[0]))
+	if err != nil {
+		return fmt.Errorf("failed to read share file: %w", err)
+	}
+	defer clear(data)
+
+	var share threshold.KeyShare
+	if err := json.Unmarshal(data, &share); err != nil {
+		return fmt.Errorf("invalid share file %s: %w", args[0], err)
+	}
+	defer share.Zero()
+
+	cfg, err := config.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load config at %s: %w", homePath, err)
+	}
+
+	dbBackend, err := cfg.DatabaseConfig.GetDBBackend()
+	if err != nil {
+		return fmt.Errorf("failed to create db backend: %w", err)
+	}
+
+	em, err := eotsmanager.NewLocalEOTSManager(homePath, cfg.KeyringBackend, dbBackend, zap.NewNop())
+	if err != nil {
+		_ = dbBackend.Close()
+
+		return fmt.Errorf("failed to create EOTS manager: %w", err)
+	}
+	defer func() {
+		if err := em.Close(); err != nil {
+			cmd.PrintErrf("Error closing EOTS manager: %v\n", err)
+		}
+	}()
+
+	if err := em.ImportThresholdKeyShare(&share); err != nil {
+		return fmt.Errorf("failed to import threshold key share: %w", err)
+	}
+
+	return output.Print(cmd, KeyShareImportOutput{
+		EotsPk:    hex.EncodeToString(schnorr.SerializePubKey(share.GroupPubKey)),
+		Index:     share.Index,
+		Parties:   share.Parties,
+		Threshold: share.Threshold,
+	})
+}
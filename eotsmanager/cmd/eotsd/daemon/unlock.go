@@ -4,8 +4,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	eotsclient "github.com/babylonlabs-io/finality-provider/eotsmanager/client"
-	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
-	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/babylonlabs-io/finality-provider/output"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 	"io"
@@ -32,9 +31,10 @@ func passwordReader(cmd *cobra.Command) (string, error) {
 	}
 
 	// TTY: interactive prompt
-	cmd.Print("Enter password to unlock keyring: ")
+	// the prompt goes to the standard error to keep the output parseable
+	cmd.PrintErr("Enter password to unlock keyring: ")
 	passphrase, err := term.ReadPassword(syscall.Stdin)
-	cmd.Println()
+	cmd.PrintErrln()
 	if err != nil {
 		return "", fmt.Errorf("failed to read passphrase from terminal: %w", err)
 	}
@@ -48,13 +48,18 @@ func NewUnlockKeyringCmd() *cobra.Command {
 		Short: `Unlocks the "file" based keyring to load the EOTS private key in memory for signing`,
 		Long: `Unlocks the "file"-based keyring to load the EOTS private key into memory for signing operations.
 
-The keyring password can be provided in two ways:
+The keyring password can be provided in three ways:
+  - By specifying the "passphrase-ref" flag with a reference to the password in a secret store, e.g.,
+    file:///run/secrets/keyring, env://KEYRING_PASSWORD, exec://pass show eotsd,
+    arn:aws:secretsmanager:..., projects/.../secrets/... or https://<vault>.vault.azure.net/secrets/...
   - By setting the "EOTSD_KEYRING_PASSWORD" environment variable.
-  - By entering it interactively when prompted (if the environment variable is not set).
+  - By entering it interactively when prompted (if neither of the above is set).
 
 The HMAC key can also be provided in two ways:
   - By specifying the "home" flag, which points to the eotsd home directory containing the config.
-  - By setting the "HMAC_KEY" environment variable, which takes precedence over the "home" flag.`,
+  - By setting the "HMAC_KEY" environment variable, which takes precedence over the "home" flag.
+The "hmac-key-id" flag selects a key added with "eotsd hmac-keys add" instead of the key of the config.
+Both accept the secret references supported by the "passphrase-ref" flag.`,
 
 		RunE: unlockKeyring,
 	}
@@ -63,7 +68,9 @@ The HMAC key can also be provided in two ways:
 
 	f.String(eotsPkFlag, "", "EOTS public key of the finality-provider")
 	f.String(rpcClientFlag, "", "The RPC address of a running eotsd")
-	f.String(sdkflags.FlagHome, "", "The path to the eotsd home directory")
+	f.String(flagPassphraseRef, "", "A reference to the keyring password in a secret store, e.g., file:///run/secrets/keyring")
+	addRPCClientTLSFlags(cmd)
+	addRPCClientHMACFlags(cmd)
 
 	if err := cmd.MarkFlagRequired(eotsPkFlag); err != nil {
 		panic(err)
@@ -88,24 +95,22 @@ func unlockKeyring(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to get %s flag: %w", rpcClientFlag, err)
 	}
 
-	hmac, exists := os.LookupEnv("HMAC_KEY")
-	if !exists {
-		var err error
-		hmac, err = getHMACFromConfig(cmd)
-		if err != nil {
-			return err
-		}
+	hmac, hmacOpts, err := rpcClientHMAC(cmd)
+	if err != nil {
+		return err
 	}
 
-	passphrase, exists := os.LookupEnv("EOTSD_KEYRING_PASSWORD")
-	if !exists {
-		passphrase, err = UnlockCmdPasswordReader(cmd)
-		if err != nil {
-			return fmt.Errorf("failed to read passphrase: %w", err)
-		}
+	passphrase, err := getPassphrase(cmd)
+	if err != nil {
+		return err
+	}
+
+	dialOpts, err := rpcClientDialOptions(cmd)
+	if err != nil {
+		return err
 	}
 
-	eotsdClient, err := eotsclient.NewEOTSManagerGRPCClient(rpcListener, hmac)
+	eotsdClient, err := eotsclient.NewEOTSManagerGRPCClient(rpcListener, hmac, append(dialOpts, hmacOpts...)...)
 	if err != nil {
 		return fmt.Errorf("failed to create eotsd client: %w", err)
 	}
@@ -119,30 +124,34 @@ func unlockKeyring(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to unlock keyring: %w", err)
 	}
 
-	cmd.Printf("Successfully unlocked keystore to load the EOTS private key for %s in memory of eotsd\n", eotsFpPubKeyStr)
-
-	return nil
+	return output.Print(cmd, KeyLockOutput{EotsPk: eotsFpPubKeyStr, Unlocked: true})
 }
 
-func getHMACFromConfig(cmd *cobra.Command) (string, error) {
-	flagHome, err := cmd.Flags().GetString(sdkflags.FlagHome)
+// getPassphrase returns the keyring password of the passphrase-ref flag,
+// the EOTSD_KEYRING_PASSWORD environment variable or the prompt, in that order
+func getPassphrase(cmd *cobra.Command) (string, error) {
+	passphraseRef, err := cmd.Flags().GetString(flagPassphraseRef)
 	if err != nil {
-		return "", fmt.Errorf("failed to get %s flag: %w", sdkflags.FlagHome, err)
+		return "", fmt.Errorf("failed to get %s flag: %w", flagPassphraseRef, err)
 	}
 
-	if flagHome == "" {
-		return "", nil
+	if passphraseRef != "" {
+		passphrase, err := eotsclient.GetSecretValue(passphraseRef)
+		if err != nil {
+			return "", fmt.Errorf("failed to get the passphrase: %w", err)
+		}
+
+		return passphrase, nil
 	}
 
-	homePath, err := getHomePath(cmd)
-	if err != nil {
-		return "", fmt.Errorf("failed to load home flag: %w", err)
+	if passphrase, exists := os.LookupEnv("EOTSD_KEYRING_PASSWORD"); exists {
+		return passphrase, nil
 	}
 
-	cfg, err := config.LoadConfig(homePath)
+	passphrase, err := UnlockCmdPasswordReader(cmd)
 	if err != nil {
-		return "", fmt.Errorf("failed to load config at %s: %w", homePath, err)
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
 	}
 
-	return cfg.HMACKey, nil
+	return passphrase, nil
 }
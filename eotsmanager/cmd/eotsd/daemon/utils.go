@@ -10,7 +10,12 @@ import (
 	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/std"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
+	eotsclient "github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	eotsservice "github.com/babylonlabs-io/finality-provider/eotsmanager/service"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
 
@@ -32,6 +37,128 @@ func getCleanPath(cmd *cobra.Command, flag string) (string, error) {
 	return util.CleanAndExpandPath(cleanPath), nil
 }
 
+// addRPCClientTLSFlags adds the flags to connect to an eotsd serving TLS
+func addRPCClientTLSFlags(cmd *cobra.Command) {
+	f := cmd.Flags()
+	f.String(flagTLSCAFile, "", "The path to the CA bundle used to verify the certificate of eotsd; TLS is disabled if empty")
+	f.String(flagTLSCertFile, "", "The path to the client certificate presented to eotsd, required if it enables mutual TLS")
+	f.String(flagTLSKeyFile, "", "The path to the private key of the client certificate")
+	f.String(flagTLSServerName, "", "The name expected in the certificate of eotsd")
+}
+
+// rpcClientDialOptions returns the options to connect to eotsd with TLS if the
+// TLS flags are set
+func rpcClientDialOptions(cmd *cobra.Command) ([]grpc.DialOption, error) {
+	values := make(map[string]string)
+	for _, flag := range []string{flagTLSCAFile, flagTLSCertFile, flagTLSKeyFile, flagTLSServerName} {
+		v, err := cmd.Flags().GetString(flag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s flag: %w", flag, err)
+		}
+		values[flag] = v
+	}
+
+	if values[flagTLSCAFile] == "" {
+		return nil, nil
+	}
+
+	tlsCfg, err := util.LoadClientTLSConfig(
+		values[flagTLSCAFile],
+		values[flagTLSCertFile],
+		values[flagTLSKeyFile],
+		values[flagTLSServerName],
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS config: %w", err)
+	}
+
+	return []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg))}, nil
+}
+
+// addRPCClientHMACFlags adds the flags to authenticate to eotsd with HMAC
+func addRPCClientHMACFlags(cmd *cobra.Command) {
+	f := cmd.Flags()
+	f.String(sdkflags.FlagHome, "", "The path to the eotsd home directory, whose config holds the HMAC key")
+	f.String(flagHMACKeyID, "", "The ID of the HMAC key, if it is not the key of the eotsd config")
+}
+
+// rpcClientHMAC returns the HMAC key of the HMAC_KEY environment variable or of
+// the config in the home directory, and the options to send the ID of the key
+func rpcClientHMAC(cmd *cobra.Command) (string, []grpc.DialOption, error) {
+	hmacKey, exists := os.LookupEnv("HMAC_KEY")
+	if !exists {
+		var err error
+		hmacKey, err = getHMACFromConfig(cmd)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	keyID, err := cmd.Flags().GetString(flagHMACKeyID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get %s flag: %w", flagHMACKeyID, err)
+	}
+	if keyID == "" {
+		return hmacKey, nil, nil
+	}
+
+	return hmacKey, []grpc.DialOption{eotsclient.WithHMACKeyID(keyID)}, nil
+}
+
+// rpcClientAdminHMAC returns the admin HMAC key of the HMAC_ADMIN_KEY environment
+// variable or of the config in the home directory, and the options to send its ID
+func rpcClientAdminHMAC(cmd *cobra.Command) (string, []grpc.DialOption, error) {
+	adminKey, exists := os.LookupEnv("HMAC_ADMIN_KEY")
+	if !exists {
+		cfg, err := loadHomeConfig(cmd)
+		if err != nil {
+			return "", nil, err
+		}
+		if cfg != nil {
+			adminKey = cfg.HMACAdminKey
+		}
+	}
+
+	if adminKey == "" {
+		return "", nil, fmt.Errorf("the admin HMAC key is not set in HMAC_ADMIN_KEY nor in the config")
+	}
+
+	return adminKey, []grpc.DialOption{eotsclient.WithHMACKeyID(eotsservice.AdminHMACKeyID)}, nil
+}
+
+func getHMACFromConfig(cmd *cobra.Command) (string, error) {
+	cfg, err := loadHomeConfig(cmd)
+	if err != nil || cfg == nil {
+		return "", err
+	}
+
+	return cfg.HMACKey, nil
+}
+
+// loadHomeConfig loads the config of the home flag, or returns nil if it is not set
+func loadHomeConfig(cmd *cobra.Command) (*config.Config, error) {
+	flagHome, err := cmd.Flags().GetString(sdkflags.FlagHome)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s flag: %w", sdkflags.FlagHome, err)
+	}
+
+	if flagHome == "" {
+		return nil, nil
+	}
+
+	homePath, err := getHomePath(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load home flag: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(homePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config at %s: %w", homePath, err)
+	}
+
+	return cfg, nil
+}
+
 // PersistClientCtx persist some vars from the cmd or config to the client context.
 // It gives preferences to flags over the values in the config. If the flag is not set
 // and exists a value in the config that could be used, it will be set in the ctx.
@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"net"
 	"path/filepath"
+	"time"
 
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/jessevdk/go-flags"
 
 	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
 
@@ -18,11 +20,15 @@ const (
 	defaultDataDirname          = "data"
 	defaultLogDirname           = "logs"
 	defaultLogFilename          = "eotsd.log"
+	defaultAuditLogFilename     = "audit.log"
 	defaultConfigFileName       = "eotsd.conf"
 	DefaultRPCPort              = 12582
 	DefaultRPCHost              = "127.0.0.1"
 	defaultKeyringBackend       = keyring.BackendTest
 	defaultMaxGRPCContentLength = 16 * 1024 * 1024 // 16 MB
+
+	DefaultHMACMaxClockSkew   = 30 * time.Second
+	DefaultHMACNonceCacheSize = 100000
 )
 
 var (
@@ -40,10 +46,25 @@ type Config struct {
 	LogLevel               string          `long:"loglevel" description:"Logging level for all subsystems" choice:"trace" choice:"debug" choice:"info" choice:"warn" choice:"error" choice:"fatal"`
 	KeyringBackend         string          `long:"keyring-type" description:"Type of keyring to use"`
 	RPCListener            string          `long:"rpclistener" description:"the listener for RPC connections, e.g., 127.0.0.1:1234"`
-	HMACKey                string          `long:"hmackey" description:"The HMAC key for authentication with FPD. If not provided, will use HMAC_KEY environment variable."`
+	HMACKey                string          `long:"hmackey" description:"The HMAC key for authentication with FPD, or a reference to it: file://, env://, exec://, an AWS secret ARN, a Google Cloud secret name or an Azure Key Vault secret URL. If not provided, will use HMAC_KEY environment variable."`
+	HMACMaxClockSkew       time.Duration   `long:"hmacmaxclockskew" description:"The maximum difference between the timestamp of an HMAC authenticated request and the server time. Older requests are rejected as replays."`
+	HMACNonceCacheSize     int             `long:"hmacnoncecachesize" description:"The maximum number of request nonces remembered within the clock skew window to reject replayed requests."`
+	HMACLegacyCompat       bool            `long:"hmaclegacycompat" description:"Accept requests with an HMAC over the request body only, as sent by fpd versions without replay protection. Only enable it during rolling upgrades, as such requests can be replayed."`
+	HMACAdminKey           string          `long:"hmacadminkey" description:"The HMAC key of the operator, the only one allowed to add and retire HMAC keys with eotsd hmac-keys, or a reference to it as for hmackey. It is only accepted for managing the HMAC keys, must differ from the key of FPD, and encrypts the keys added at runtime in the database. The HMAC keys cannot be added nor retired at runtime if it is not set."`
 	DisableUnsafeEndpoints *bool           `long:"disable-unsafe-endpoints" description:"Disable unsafe RPC endpoints (e.g., UnsafeSignEOTS) that bypass slashing protection. Defaults to true (disabled) if not set."`
 	Metrics                *metrics.Config `group:"metrics" namespace:"metrics"`
 	GRPCMaxContentLength   int             `long:"grpcmaxcontentlength" description:"The maximum size of the gRPC message in bytes."`
+	AuditLogPath           string          `long:"auditlogpath" description:"The path to the hash-chained audit log of the signing requests. Defaults to the audit.log in the data directory of the home."`
+	UnlockTTL              time.Duration   `long:"unlockttl" description:"The time after which a key of the file keyring is locked again once unlocked, regardless of its use. The keys stay unlocked if zero."`
+	UnlockIdleTimeout      time.Duration   `long:"unlockidletimeout" description:"The time after which a key of the file keyring is locked again if it is not used for signing. The keys stay unlocked if zero."`
+	PolicyFile             string          `long:"policyfile" description:"The path to the YAML or JSON policy restricting the EOTS keys, chain IDs, RPC methods and heights each client is allowed to use. All the authenticated clients are allowed everything if empty."`
+	ReadyKeys              []string        `long:"readykeys" description:"The names of the EOTS keys which must be able to sign for eotsd to be ready at /readyz of the metrics server, i.e., exist and be unlocked for the file keyring. Repeat the option for each key."`
+
+	TLS *TLSConfig `group:"tls" namespace:"tls"`
+
+	Tracing *tracing.Config `group:"tracing" namespace:"tracing"`
+
+	SigningGuard *SigningGuardConfig `group:"signingguard" namespace:"signingguard"`
 
 	DatabaseConfig *DBConfig `group:"dbconfig" namespace:"dbconfig"`
 }
@@ -110,6 +131,46 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("invalid grpcmaxcontentlength %d", cfg.GRPCMaxContentLength)
 	}
 
+	if cfg.HMACMaxClockSkew < 0 {
+		return fmt.Errorf("invalid hmacmaxclockskew %s", cfg.HMACMaxClockSkew)
+	}
+
+	if cfg.HMACNonceCacheSize < 0 {
+		return fmt.Errorf("invalid hmacnoncecachesize %d", cfg.HMACNonceCacheSize)
+	}
+
+	if cfg.UnlockTTL < 0 {
+		return fmt.Errorf("invalid unlockttl %s", cfg.UnlockTTL)
+	}
+
+	if cfg.UnlockIdleTimeout < 0 {
+		return fmt.Errorf("invalid unlockidletimeout %s", cfg.UnlockIdleTimeout)
+	}
+
+	// the TLS config is optional, so configs
+	// written before it was introduced keep working
+	if cfg.TLS != nil {
+		if err := cfg.TLS.Validate(); err != nil {
+			return fmt.Errorf("invalid TLS config: %w", err)
+		}
+	}
+
+	// tracing is optional, so configs
+	// written before it was introduced keep working
+	if cfg.Tracing != nil {
+		if err := cfg.Tracing.Validate(); err != nil {
+			return fmt.Errorf("invalid tracing config: %w", err)
+		}
+	}
+
+	// the signing guards are optional, so configs
+	// written before they were introduced keep working
+	if cfg.SigningGuard != nil {
+		if err := cfg.SigningGuard.Validate(); err != nil {
+			return fmt.Errorf("invalid signing guard config: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -129,6 +190,10 @@ func DataDir(homePath string) string {
 	return filepath.Join(homePath, defaultDataDirname)
 }
 
+func AuditLogFile(homePath string) string {
+	return filepath.Join(DataDir(homePath), defaultAuditLogFilename)
+}
+
 // IsUnsafeEndpointsDisabled returns true if unsafe endpoints should be disabled.
 // Defaults to true (safe) if not explicitly set.
 func (cfg *Config) IsUnsafeEndpointsDisabled() bool {
@@ -155,8 +220,14 @@ func DefaultConfigWithHomePathAndPorts(homePath string, rpcPort, metricsPort int
 		DatabaseConfig:         DefaultDBConfigWithHomePath(homePath),
 		RPCListener:            defaultRpcListener,
 		Metrics:                metrics.DefaultEotsConfig(),
+		Tracing:                tracing.DefaultConfig(),
 		GRPCMaxContentLength:   defaultMaxGRPCContentLength,
 		DisableUnsafeEndpoints: &disableUnsafe,
+		AuditLogPath:           AuditLogFile(homePath),
+		HMACMaxClockSkew:       DefaultHMACMaxClockSkew,
+		HMACNonceCacheSize:     DefaultHMACNonceCacheSize,
+		TLS:                    &TLSConfig{},
+		SigningGuard:           &SigningGuardConfig{},
 	}
 	cfg.RPCListener = fmt.Sprintf("%s:%d", DefaultRPCHost, rpcPort)
 	cfg.Metrics.Port = metricsPort
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"fmt"
+)
+
+// SigningGuardConfig defines the limits on the EOTS signing requests, so that
+// a faulty or compromised fpd cannot sign far ahead of the chain and burn the
+// future heights of its finality provider. Each limit is disabled if zero.
+type SigningGuardConfig struct {
+	MaxSignaturesPerSecond    float64 `long:"maxsignaturespersecond" description:"The maximum number of new EOTS signatures per second for each key; 0 disables the limit"`
+	MaxSignatureBurst         int     `long:"maxsignatureburst" description:"The maximum number of new EOTS signatures at once for each key, including in a batch; defaults to the rate per second"`
+	MaxHeightJump             uint64  `long:"maxheightjump" description:"The maximum distance between a height to sign and the last height signed with the key for the chain; 0 disables the limit"`
+	MaxHeightBeyondRandomness uint64  `long:"maxheightbeyondrandomness" description:"The maximum distance between a height to sign and the last height of the randomness generated with the key for the chain; 0 disables the limit"`
+}
+
+func (c *SigningGuardConfig) Validate() error {
+	if c.MaxSignaturesPerSecond < 0 {
+		return fmt.Errorf("invalid maxsignaturespersecond %v", c.MaxSignaturesPerSecond)
+	}
+
+	if c.MaxSignatureBurst < 0 {
+		return fmt.Errorf("invalid maxsignatureburst %d", c.MaxSignatureBurst)
+	}
+
+	return nil
+}
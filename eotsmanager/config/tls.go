@@ -0,0 +1,82 @@
+package config
+
+import (
+	"crypto/tls"
+	"fmt"
+	"path/filepath"
+
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+const (
+	defaultTLSDirname = "tls"
+
+	TLSCACertFilename     = "ca.crt"
+	TLSCAKeyFilename      = "ca.key"
+	TLSServerCertFilename = "server.crt"
+	TLSServerKeyFilename  = "server.key"
+	TLSClientCertFilename = "client.crt"
+	TLSClientKeyFilename  = "client.key"
+)
+
+// TLSConfig defines the transport security of the RPC server. TLS is disabled
+// if no certificate is set, and the clients are authenticated with their
+// certificate if a client CA is set.
+type TLSConfig struct {
+	CertFile          string   `long:"certfile" description:"The path to the TLS certificate of the RPC server; TLS is disabled if empty"`
+	KeyFile           string   `long:"keyfile" description:"The path to the private key of the TLS certificate of the RPC server"`
+	ClientCAFile      string   `long:"clientcafile" description:"The path to the CA bundle used to verify the client certificates; if set, the clients must present a certificate signed by one of its CAs"`
+	PinnedClientCerts []string `long:"pinnedclientcert" description:"The SHA-256 fingerprint of a client certificate allowed to connect, to pin the identity of the finality providers; can be repeated, any certificate signed by the client CA is allowed if none is set"`
+}
+
+// IsEnabled returns whether the RPC server serves TLS
+func (c *TLSConfig) IsEnabled() bool {
+	return c != nil && c.CertFile != ""
+}
+
+// IsMutual returns whether the clients are required to present a certificate
+func (c *TLSConfig) IsMutual() bool {
+	return c.IsEnabled() && c.ClientCAFile != ""
+}
+
+func (c *TLSConfig) Validate() error {
+	if c.CertFile == "" {
+		if c.KeyFile != "" || c.ClientCAFile != "" || len(c.PinnedClientCerts) > 0 {
+			return fmt.Errorf("the TLS certificate should be set to enable TLS")
+		}
+
+		return nil
+	}
+
+	if c.KeyFile == "" {
+		return fmt.Errorf("the TLS key should be set along with the certificate")
+	}
+
+	if len(c.PinnedClientCerts) > 0 && c.ClientCAFile == "" {
+		return fmt.Errorf("pinning client certificates requires a client CA")
+	}
+
+	for _, fp := range c.PinnedClientCerts {
+		if err := util.ValidateCertFingerprint(fp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ServerTLSConfig loads the certificates of the RPC server
+func (c *TLSConfig) ServerTLSConfig() (*tls.Config, error) {
+	tlsCfg, err := util.LoadServerTLSConfig(c.CertFile, c.KeyFile, c.ClientCAFile, c.PinnedClientCerts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS config: %w", err)
+	}
+
+	return tlsCfg, nil
+}
+
+// TLSDir returns the directory of the certificates generated by
+// `eotsd init --generate-tls`
+func TLSDir(homePath string) string {
+	return filepath.Join(homePath, defaultTLSDirname)
+}
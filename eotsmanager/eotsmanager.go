@@ -1,6 +1,8 @@
 package eotsmanager
 
 import (
+	"context"
+
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 )
@@ -55,7 +57,8 @@ type EOTSManager interface {
 
 	// SignBatchEOTS same as SignEOTS but for a batch of messages
 	// In case of double sign error that signature in batch is not returned
-	SignBatchEOTS(req *SignBatchEOTSRequest) ([]SignDataResponse, error)
+	// The context carries the trace of the request to a remote EOTS manager
+	SignBatchEOTS(ctx context.Context, req *SignBatchEOTSRequest) ([]SignDataResponse, error)
 
 	// Backup performs a hot backup of the database using a read-only transaction, eotsd can be running
 	// when this function is called, but writing to the db is blocked until the backup is done
@@ -2,10 +2,13 @@ package eotsmanager
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/babylonlabs-io/finality-provider/metrics"
 
@@ -23,6 +26,7 @@ import (
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/randgenerator"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/store"
 	eotstypes "github.com/babylonlabs-io/finality-provider/eotsmanager/types"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
 
@@ -39,11 +43,26 @@ type LocalEOTSManager struct {
 	es          *store.EOTSStore
 	logger      *zap.Logger
 	input       *strings.Reader // to send passphrase to the keyring
-	privateKeys map[string]*btcec.PrivateKey
+	privateKeys map[string]*unlockSession
 	metrics     *metrics.EotsMetrics
+
+	// unlockTTL and unlockIdleTimeout end the unlock sessions
+	// of the file keyring, if they are set
+	unlockTTL         time.Duration
+	unlockIdleTimeout time.Duration
+	// guard limits the signing requests, if configured
+	guard     signingGuard
+	quit      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
 }
 
-func NewLocalEOTSManager(homeDir, keyringBackend string, dbbackend kvdb.Backend, logger *zap.Logger) (*LocalEOTSManager, error) {
+func NewLocalEOTSManager(
+	homeDir, keyringBackend string,
+	dbbackend kvdb.Backend,
+	logger *zap.Logger,
+	opts ...LocalEOTSManagerOption,
+) (*LocalEOTSManager, error) {
 	es, err := store.NewEOTSStore(dbbackend)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize store: %w", err)
@@ -58,14 +77,25 @@ func NewLocalEOTSManager(homeDir, keyringBackend string, dbbackend kvdb.Backend,
 
 	eotsMetrics := metrics.NewEotsMetrics()
 
-	return &LocalEOTSManager{
+	lm := &LocalEOTSManager{
 		kr:          kr,
 		es:          es,
 		logger:      logger,
 		metrics:     eotsMetrics,
 		input:       inputReader,
-		privateKeys: make(map[string]*btcec.PrivateKey), // key name -> private key
-	}, nil
+		privateKeys: make(map[string]*unlockSession), // key name -> unlock session
+		quit:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(lm)
+	}
+
+	if lm.unlockTTL > 0 || lm.unlockIdleTimeout > 0 {
+		lm.wg.Add(1)
+		go lm.sweepUnlockSessions()
+	}
+
+	return lm, nil
 }
 
 func InitKeyring(homeDir, keyringBackend string, input *strings.Reader) (keyring.Keyring, error) {
@@ -212,6 +242,7 @@ func (lm *LocalEOTSManager) CreateRandomnessPairList(fpPk []byte, chainID []byte
 	if err != nil {
 		return nil, fmt.Errorf("failed to get EOTS private key: %w", err)
 	}
+	defer privKey.Zero()
 
 	prList := make([]*btcec.FieldVal, 0, num)
 
@@ -231,6 +262,17 @@ func (lm *LocalEOTSManager) CreateRandomnessPairList(fpPk []byte, chainID []byte
 
 		prList = append(prList, pubRand)
 	}
+
+	if num > 0 {
+		lastHeight := startHeight + uint64(num-1)
+		if cfg.Interval != nil {
+			lastHeight = startHeight + uint64(num-1)*(*cfg.Interval)
+		}
+		if err := lm.recordRandomness(fpPk, chainID, lastHeight); err != nil {
+			return nil, err
+		}
+	}
+
 	lm.metrics.IncrementEotsFpTotalGeneratedRandomnessCounter(hex.EncodeToString(fpPk))
 	lm.metrics.SetEotsFpLastGeneratedRandomnessHeight(hex.EncodeToString(fpPk), float64(startHeight))
 
@@ -274,6 +316,10 @@ func (lm *LocalEOTSManager) SignEOTS(eotsPk []byte, chainID []byte, msg []byte,
 		return nil, eotstypes.ErrDoubleSign
 	}
 
+	if err := lm.checkSigningGuards(eotsPk, chainID, []uint64{height}); err != nil {
+		return nil, err
+	}
+
 	keyName, err := lm.es.GetEOTSKeyName(eotsPk)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get EOTS key name: %w", err)
@@ -312,11 +358,7 @@ func (lm *LocalEOTSManager) SignEOTS(eotsPk []byte, chainID []byte, msg []byte,
 	return signedBytes, nil
 }
 
-func (lm *LocalEOTSManager) SignBatchEOTS(req *SignBatchEOTSRequest) ([]SignDataResponse, error) {
-	// Lock to prevent race conditions that could lead to double signing
-	lm.mu.Lock()
-	defer lm.mu.Unlock()
-
+func (lm *LocalEOTSManager) SignBatchEOTS(ctx context.Context, req *SignBatchEOTSRequest) (_ []SignDataResponse, err error) {
 	eotsPk, chainID := req.UID, req.ChainID
 
 	heights := make([]uint64, 0, len(req.SignRequest))
@@ -324,6 +366,23 @@ func (lm *LocalEOTSManager) SignBatchEOTS(req *SignBatchEOTSRequest) ([]SignData
 		heights = append(heights, request.Height)
 	}
 
+	_, span := tracing.StartSpan(ctx, "eotsmanager.SignBatchEOTS",
+		tracing.FpPkKey.String(hex.EncodeToString(eotsPk)),
+		tracing.ChainIDKey.String(string(chainID)),
+		tracing.NumBlocksKey.Int(len(heights)),
+	)
+	if len(heights) > 0 {
+		span.SetAttributes(tracing.HeightRange(slices.Min(heights), slices.Max(heights))...)
+	}
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	// Lock to prevent race conditions that could lead to double signing
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	span.AddEvent("acquired the signing lock")
+
 	if err := util.ValidateNoDuplicateHeights(heights); err != nil {
 		return nil, fmt.Errorf("%w: %w", eotstypes.ErrDuplicateHeight, err)
 	}
@@ -350,6 +409,16 @@ func (lm *LocalEOTSManager) SignBatchEOTS(req *SignBatchEOTSRequest) ([]SignData
 		return nil, fmt.Errorf("failed to get existing sign records: %w", err)
 	}
 
+	newHeights := make([]uint64, 0, len(heights))
+	for _, height := range heights {
+		if _, found := existingRecords[height]; !found {
+			newHeights = append(newHeights, height)
+		}
+	}
+	if err := lm.checkSigningGuards(eotsPk, chainID, newHeights); err != nil {
+		return nil, err
+	}
+
 	response := make([]SignDataResponse, 0, len(req.SignRequest))
 	var recordsToSave []store.BatchSignRecord
 
@@ -437,6 +506,7 @@ func (lm *LocalEOTSManager) UnsafeSignEOTS(fpPk []byte, chainID []byte, msg []by
 	if err != nil {
 		return nil, fmt.Errorf("failed to get EOTS private key: %w", err)
 	}
+	defer privKey.Zero()
 
 	privRand, _, err := lm.getRandomnessPair(privKey, chainID, height)
 	if err != nil {
@@ -460,6 +530,7 @@ func (lm *LocalEOTSManager) SignSchnorrSig(fpPk []byte, msg []byte) (*schnorr.Si
 	if err != nil {
 		return nil, fmt.Errorf("failed to get EOTS private key: %w", err)
 	}
+	defer privKey.Zero()
 
 	return lm.signSchnorrSigFromPrivKey(privKey, fpPk, msg)
 }
@@ -500,6 +571,8 @@ func (lm *LocalEOTSManager) SignSchnorrSigFromKeyname(keyName string, msg []byte
 }
 
 func (lm *LocalEOTSManager) Close() error {
+	lm.Stop()
+
 	if err := lm.es.Close(); err != nil {
 		return fmt.Errorf("failed to close EOTS store: %w", err)
 	}
@@ -507,6 +580,16 @@ func (lm *LocalEOTSManager) Close() error {
 	return nil
 }
 
+// Stop ends the unlock sessions, zeroing the private keys held in memory,
+// without closing the store
+func (lm *LocalEOTSManager) Stop() {
+	lm.closeOnce.Do(func() {
+		close(lm.quit)
+		lm.wg.Wait()
+	})
+	lm.LockAllKeys()
+}
+
 // getRandomnessPair returns a randomness pair generated based on the given private key, chainID and height
 func (lm *LocalEOTSManager) getRandomnessPair(privKey *btcec.PrivateKey, chainID []byte, height uint64) (*eots.PrivateRand, *eots.PublicRand, error) {
 	privRand, pubRand, err := randgenerator.GenerateRandomness(privKey.Serialize(), chainID, height)
@@ -533,6 +616,8 @@ func (lm *LocalEOTSManager) KeyRecord(fpPk []byte) (*eotstypes.KeyRecord, error)
 	}, nil
 }
 
+// getEOTSPrivKey returns a copy of the private key of the EOTS key, which
+// the caller must zero once done with it
 func (lm *LocalEOTSManager) getEOTSPrivKey(fpPk []byte) (*btcec.PrivateKey, error) {
 	lm.mu.Lock()
 	defer lm.mu.Unlock()
@@ -552,7 +637,13 @@ func (lm *LocalEOTSManager) getEOTSPrivKey(fpPk []byte) (*btcec.PrivateKey, erro
 		return nil, fmt.Errorf("public key mismatch: requested key does not match stored key")
 	}
 
-	return privKey, nil
+	// the key of the unlock session is zeroed when the key is locked, which
+	// can happen as soon as the lock is released, so a copy is returned
+	privKeyBytes := privKey.Key.Bytes()
+	privKeyCopy, _ := btcec.PrivKeyFromBytes(privKeyBytes[:])
+	clear(privKeyBytes[:])
+
+	return privKeyCopy, nil
 }
 
 func (lm *LocalEOTSManager) eotsPrivKeyFromKeyName(keyName string) (*btcec.PrivateKey, error) {
@@ -591,27 +682,46 @@ func (lm *LocalEOTSManager) Unlock(fpPk []byte, passphrase string) error {
 		return fmt.Errorf("failed to unlock the key ring: %w", err)
 	}
 
-	if _, ok := lm.privateKeys[keyName]; ok {
-		return fmt.Errorf("private key already unlocked for key name: %s, fpPk: %s", keyName, hex.EncodeToString(fpPk))
+	now := time.Now()
+	if s, ok := lm.privateKeys[keyName]; ok {
+		if !lm.sessionExpired(s, now) {
+			privKey.Zero()
+
+			return fmt.Errorf("private key already unlocked for key name: %s, fpPk: %s", keyName, hex.EncodeToString(fpPk))
+		}
+		lm.lockKey(keyName, "expired")
 	}
 
-	lm.privateKeys[keyName] = privKey
+	lm.privateKeys[keyName] = &unlockSession{
+		eotsPk:     bytes.Clone(fpPk),
+		privKey:    privKey,
+		unlockedAt: now,
+		lastUsedAt: now,
+	}
 
 	return nil
 }
 
 func (lm *LocalEOTSManager) getKeyFromMap(keyName string) (*btcec.PrivateKey, error) {
 	// we don't call the lock here because we are already in the lock in caller function
-	privKey, ok := lm.privateKeys[keyName]
+	s, ok := lm.privateKeys[keyName]
 	if !ok {
 		return nil, fmt.Errorf("private key not found in map for key name: %s", keyName)
 	}
 
-	if privKey == nil {
+	now := time.Now()
+	if lm.sessionExpired(s, now) {
+		lm.lockKey(keyName, "expired")
+
+		return nil, fmt.Errorf("unlock session expired for key name: %s", keyName)
+	}
+
+	if s.privKey == nil {
 		return nil, fmt.Errorf("private key is nil for key name: %s", keyName)
 	}
+	s.lastUsedAt = now
 
-	return privKey, nil
+	return s.privKey, nil
 }
 
 func (lm *LocalEOTSManager) getKeyFromKeyring(keyName, passphrase string) (*btcec.PrivateKey, error) {
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/babylonlabs-io/babylon/v4/crypto/eots"
 	"github.com/babylonlabs-io/babylon/v4/testutil/datagen"
@@ -17,6 +18,7 @@ import (
 	"github.com/babylonlabs-io/finality-provider/testutil"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/stretchr/testify/require"
 )
@@ -308,9 +310,251 @@ func TestKeyAliasingAttackPreventedBatchSign(t *testing.T) {
 			{Msg: []byte("msg3"), Height: 102},
 		},
 	}
-	_, err = lm.SignBatchEOTS(req)
+	_, err = lm.SignBatchEOTS(t.Context(), req)
 
 	require.Error(t, err)
 	require.True(t, strings.Contains(err.Error(), "public key mismatch"),
 		"Expected 'public key mismatch' error, got: %v", err)
 }
+
+// TestUnlockSessions verifies that the keys of the file keyring are locked
+// on request and once their unlock session expires
+func TestUnlockSessions(t *testing.T) {
+	t.Parallel()
+
+	homeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(homeDir)
+	eotsCfg.KeyringBackend = keyring.BackendFile
+	dbBackend, err := eotsCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	defer func() {
+		dbBackend.Close()
+		os.RemoveAll(homeDir)
+	}()
+
+	logger, err := fplog.NewDevLogger()
+	require.NoError(t, err)
+	idleTimeout := 2 * time.Second
+	lm, err := eotsmanager.NewLocalEOTSManager(homeDir, eotsCfg.KeyringBackend, dbBackend, logger,
+		eotsmanager.WithUnlockIdleTimeout(idleTimeout))
+	require.NoError(t, err)
+	defer lm.Stop()
+
+	passphrase := "testpassphrase"
+	fpPk, err := lm.CreateKey("unlock-session-key", passphrase)
+	require.NoError(t, err)
+	msg := chainhash.HashB([]byte("msg"))
+
+	// locked keys cannot sign
+	require.Empty(t, lm.ListUnlockedKeys())
+	_, err = lm.SignSchnorrSig(fpPk, msg)
+	require.Error(t, err)
+	require.Error(t, lm.LockKey(fpPk))
+	require.Error(t, lm.CheckKeyReady("unlock-session-key"))
+	require.Error(t, lm.CheckKeyReady("unknown-key"))
+
+	require.NoError(t, lm.Unlock(fpPk, passphrase))
+	require.Error(t, lm.Unlock(fpPk, passphrase))
+	require.NoError(t, lm.CheckKeyReady("unlock-session-key"))
+	_, err = lm.SignSchnorrSig(fpPk, msg)
+	require.NoError(t, err)
+
+	keys := lm.ListUnlockedKeys()
+	require.Len(t, keys, 1)
+	require.Equal(t, "unlock-session-key", keys[0].KeyName)
+	require.Equal(t, fpPk, keys[0].EotsPk)
+	require.Equal(t, keys[0].LastUsedAt.Add(idleTimeout), keys[0].ExpiresAt)
+
+	// explicit lock
+	require.NoError(t, lm.LockKey(fpPk))
+	require.Empty(t, lm.ListUnlockedKeys())
+	require.Error(t, lm.CheckKeyReady("unlock-session-key"))
+	_, err = lm.SignSchnorrSig(fpPk, msg)
+	require.Error(t, err)
+
+	// idle timeout
+	require.NoError(t, lm.Unlock(fpPk, passphrase))
+	require.Eventually(t, func() bool {
+		return len(lm.ListUnlockedKeys()) == 0
+	}, 10*time.Second, 100*time.Millisecond)
+	_, err = lm.SignSchnorrSig(fpPk, msg)
+	require.Error(t, err)
+
+	// the key can be unlocked again once its session expired
+	require.NoError(t, lm.Unlock(fpPk, passphrase))
+	_, err = lm.SignSchnorrSig(fpPk, msg)
+	require.NoError(t, err)
+
+	// stopping the manager locks all the keys
+	lm.Stop()
+	require.Empty(t, lm.ListUnlockedKeys())
+}
+
+// TestLockKeyWhileSigning checks that locking a key, which zeroes its private
+// key, does not affect the signatures and randomness already being computed
+func TestLockKeyWhileSigning(t *testing.T) {
+	t.Parallel()
+
+	homeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(homeDir)
+	eotsCfg.KeyringBackend = keyring.BackendFile
+	dbBackend, err := eotsCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	defer func() {
+		dbBackend.Close()
+		os.RemoveAll(homeDir)
+	}()
+
+	logger, err := fplog.NewDevLogger()
+	require.NoError(t, err)
+	lm, err := eotsmanager.NewLocalEOTSManager(homeDir, eotsCfg.KeyringBackend, dbBackend, logger)
+	require.NoError(t, err)
+	defer lm.Stop()
+
+	passphrase := "testpassphrase"
+	fpPk, err := lm.CreateKey("lock-while-signing-key", passphrase)
+	require.NoError(t, err)
+	pk, err := schnorr.ParsePubKey(fpPk)
+	require.NoError(t, err)
+	require.NoError(t, lm.Unlock(fpPk, passphrase))
+
+	chainID := []byte("test-chain")
+	height := uint64(100)
+	msg := chainhash.HashB([]byte("msg"))
+	pubRands, err := lm.CreateRandomnessPairList(fpPk, chainID, height, 1)
+	require.NoError(t, err)
+
+	// the key is locked and unlocked while the requests are served,
+	// which fail while the key is locked but otherwise use the right key
+	done := make(chan struct{})
+	signed := make(chan int)
+	go func() {
+		n := 0
+		defer func() {
+			signed <- n
+		}()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			sig, err := lm.SignSchnorrSig(fpPk, msg)
+			if err == nil {
+				if !sig.Verify(msg, pk) {
+					t.Errorf("invalid schnorr signature")
+
+					return
+				}
+				n++
+			}
+
+			prList, err := lm.CreateRandomnessPairList(fpPk, chainID, height, 1)
+			if err == nil && !pubRands[0].Equals(prList[0]) {
+				t.Errorf("invalid public randomness")
+
+				return
+			}
+
+			eotsSig, err := lm.UnsafeSignEOTS(fpPk, chainID, msg, height)
+			if err == nil && eots.Verify(pk, pubRands[0], msg, eotsSig) != nil {
+				t.Errorf("invalid EOTS signature")
+
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < 5; i++ {
+		time.Sleep(20 * time.Millisecond)
+		require.NoError(t, lm.LockKey(fpPk))
+		require.NoError(t, lm.Unlock(fpPk, passphrase))
+	}
+	close(done)
+	require.Positive(t, <-signed)
+}
+
+func TestSigningGuards(t *testing.T) {
+	t.Parallel()
+
+	homeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(homeDir)
+	dbBackend, err := eotsCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	defer func() {
+		dbBackend.Close()
+		os.RemoveAll(homeDir)
+	}()
+
+	logger, err := fplog.NewDevLogger()
+	require.NoError(t, err)
+	lm, err := eotsmanager.NewLocalEOTSManager(homeDir, eotsCfg.KeyringBackend, dbBackend, logger,
+		eotsmanager.WithSignRateLimit(0.01, 4),
+		eotsmanager.WithMaxHeightJump(10),
+		eotsmanager.WithMaxHeightBeyondRandomness(3),
+	)
+	require.NoError(t, err)
+	defer lm.Stop()
+
+	fpPk, err := lm.CreateKey("signing-guard-key", "")
+	require.NoError(t, err)
+	chainID := []byte("chain-a")
+
+	_, err = lm.CreateRandomnessPairList(fpPk, chainID, 100, 5)
+	require.NoError(t, err)
+
+	// nothing was signed yet, so the first height is not bounded by a jump
+	_, err = lm.SignEOTS(fpPk, chainID, []byte("msg 100"), 100)
+	require.NoError(t, err)
+
+	// too far above the last signed height
+	_, err = lm.SignEOTS(fpPk, chainID, []byte("msg 111"), 111)
+	require.ErrorIs(t, err, types.ErrSigningHeightOutOfRange)
+
+	// too far above the last randomness height 104
+	_, err = lm.SignEOTS(fpPk, chainID, []byte("msg 108"), 108)
+	require.ErrorIs(t, err, types.ErrSigningHeightOutOfRange)
+
+	_, err = lm.SignEOTS(fpPk, chainID, []byte("msg 107"), 107)
+	require.NoError(t, err)
+
+	// signing the same message again does not count
+	_, err = lm.SignEOTS(fpPk, chainID, []byte("msg 107"), 107)
+	require.NoError(t, err)
+
+	// the heights are bounded for each chain
+	_, err = lm.SignEOTS(fpPk, []byte("chain-b"), []byte("msg 5000"), 5000)
+	require.NoError(t, err)
+
+	// a single signature is left in the burst
+	batch := func(heights ...uint64) error {
+		req := &eotsmanager.SignBatchEOTSRequest{UID: fpPk, ChainID: chainID}
+		for _, h := range heights {
+			req.SignRequest = append(req.SignRequest, &eotsmanager.SignDataRequest{
+				Msg:    []byte("batch msg"),
+				Height: h,
+			})
+		}
+		_, err := lm.SignBatchEOTS(t.Context(), req)
+
+		return err
+	}
+	require.ErrorIs(t, batch(101, 102), types.ErrSigningRateLimited)
+	require.NoError(t, batch(101))
+	require.ErrorIs(t, batch(102), types.ErrSigningRateLimited)
+
+	// a batch larger than the burst takes the whole burst
+	otherPk, err := lm.CreateKey("signing-guard-key-2", "")
+	require.NoError(t, err)
+	_, err = lm.CreateRandomnessPairList(otherPk, chainID, 100, 10)
+	require.NoError(t, err)
+	req := &eotsmanager.SignBatchEOTSRequest{UID: otherPk, ChainID: chainID}
+	for h := uint64(100); h < 106; h++ {
+		req.SignRequest = append(req.SignRequest, &eotsmanager.SignDataRequest{Msg: []byte("batch msg"), Height: h})
+	}
+	_, err = lm.SignBatchEOTS(t.Context(), req)
+	require.NoError(t, err)
+	_, err = lm.SignEOTS(otherPk, chainID, []byte("msg 106"), 106)
+	require.ErrorIs(t, err, types.ErrSigningRateLimited)
+}
@@ -0,0 +1,318 @@
+package policy
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// Reasons of the denials, used as metric labels
+const (
+	ReasonIdentity = "identity"
+	ReasonMethod   = "method"
+	ReasonEotsPk   = "eots_pk"
+	ReasonChainID  = "chain_id"
+	ReasonHeight   = "height"
+)
+
+var ErrDenied = errors.New("denied by policy")
+
+// Methods are the RPC methods which can be allowed by a rule. Ping is
+// always allowed to keep health checks working.
+var Methods = []string{
+	path.Base(proto.EOTSManager_CreateRandomnessPairList_FullMethodName),
+	path.Base(proto.EOTSManager_SignEOTS_FullMethodName),
+	path.Base(proto.EOTSManager_UnsafeSignEOTS_FullMethodName),
+	path.Base(proto.EOTSManager_SignSchnorrSig_FullMethodName),
+	path.Base(proto.EOTSManager_SignBatchEOTS_FullMethodName),
+	path.Base(proto.EOTSManager_SaveEOTSKeyName_FullMethodName),
+	path.Base(proto.EOTSManager_UnlockKey_FullMethodName),
+	path.Base(proto.EOTSManager_LockKey_FullMethodName),
+	path.Base(proto.EOTSManager_ListUnlockedKeys_FullMethodName),
+	path.Base(proto.EOTSManager_Backup_FullMethodName),
+	path.Base(proto.EOTSManager_AddHMACKey_FullMethodName),
+	path.Base(proto.EOTSManager_RetireHMACKey_FullMethodName),
+	path.Base(proto.EOTSManager_ListHMACKeys_FullMethodName),
+	path.Base(proto.EOTSManager_GetThresholdKeyShareInfo_FullMethodName),
+	path.Base(proto.EOTSManager_CreatePartialRandomnessList_FullMethodName),
+	path.Base(proto.EOTSManager_GetPartialEOTSNonce_FullMethodName),
+	path.Base(proto.EOTSManager_SignPartialEOTS_FullMethodName),
+	path.Base(proto.EOTSManager_CreatePartialSchnorrNonce_FullMethodName),
+	path.Base(proto.EOTSManager_SignPartialSchnorrSig_FullMethodName),
+	path.Base(proto.EOTSManager_GetSignRecord_FullMethodName),
+	path.Base(proto.EOTSManager_ListSignRecords_FullMethodName),
+	path.Base(proto.EOTSManager_GetLastSignedHeight_FullMethodName),
+}
+
+// keyMethods are the methods using an EOTS key, and chainMethods the
+// methods for a chain ID, to which the EOTS keys and the chain IDs of the
+// rules apply even if the request leaves them empty
+var (
+	keyMethods = []string{
+		path.Base(proto.EOTSManager_CreateRandomnessPairList_FullMethodName),
+		path.Base(proto.EOTSManager_SignEOTS_FullMethodName),
+		path.Base(proto.EOTSManager_UnsafeSignEOTS_FullMethodName),
+		path.Base(proto.EOTSManager_SignSchnorrSig_FullMethodName),
+		path.Base(proto.EOTSManager_SignBatchEOTS_FullMethodName),
+		path.Base(proto.EOTSManager_SaveEOTSKeyName_FullMethodName),
+		path.Base(proto.EOTSManager_UnlockKey_FullMethodName),
+		path.Base(proto.EOTSManager_LockKey_FullMethodName),
+		path.Base(proto.EOTSManager_GetThresholdKeyShareInfo_FullMethodName),
+		path.Base(proto.EOTSManager_CreatePartialRandomnessList_FullMethodName),
+		path.Base(proto.EOTSManager_GetPartialEOTSNonce_FullMethodName),
+		path.Base(proto.EOTSManager_SignPartialEOTS_FullMethodName),
+		path.Base(proto.EOTSManager_CreatePartialSchnorrNonce_FullMethodName),
+		path.Base(proto.EOTSManager_SignPartialSchnorrSig_FullMethodName),
+		path.Base(proto.EOTSManager_GetSignRecord_FullMethodName),
+		path.Base(proto.EOTSManager_ListSignRecords_FullMethodName),
+		path.Base(proto.EOTSManager_GetLastSignedHeight_FullMethodName),
+	}
+	chainMethods = []string{
+		path.Base(proto.EOTSManager_CreateRandomnessPairList_FullMethodName),
+		path.Base(proto.EOTSManager_SignEOTS_FullMethodName),
+		path.Base(proto.EOTSManager_UnsafeSignEOTS_FullMethodName),
+		path.Base(proto.EOTSManager_SignBatchEOTS_FullMethodName),
+		path.Base(proto.EOTSManager_CreatePartialRandomnessList_FullMethodName),
+		path.Base(proto.EOTSManager_GetPartialEOTSNonce_FullMethodName),
+		path.Base(proto.EOTSManager_SignPartialEOTS_FullMethodName),
+		path.Base(proto.EOTSManager_GetSignRecord_FullMethodName),
+		path.Base(proto.EOTSManager_ListSignRecords_FullMethodName),
+		path.Base(proto.EOTSManager_GetLastSignedHeight_FullMethodName),
+	}
+)
+
+// DeniedError is returned when no rule of the policy allows a request
+type DeniedError struct {
+	Reason string
+	Msg    string
+}
+
+func (e *DeniedError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrDenied, e.Msg)
+}
+
+func (e *DeniedError) Is(target error) bool {
+	return target == ErrDenied
+}
+
+// Identity is the authenticated identity of a caller
+type Identity struct {
+	// CertFingerprint and CertName identify the verified TLS client certificate
+	CertFingerprint string
+	CertName        string
+	// HMACKeyID identifies the HMAC key which authenticated the request
+	HMACKeyID string
+}
+
+func (id Identity) IsEmpty() bool {
+	return id.CertFingerprint == "" && id.HMACKeyID == ""
+}
+
+func (id Identity) String() string {
+	var parts []string
+	if id.CertFingerprint != "" {
+		parts = append(parts, fmt.Sprintf("cert %s (sha256:%s)", id.CertName, id.CertFingerprint))
+	}
+	if id.HMACKeyID != "" {
+		parts = append(parts, fmt.Sprintf("hmac key %s", id.HMACKeyID))
+	}
+	if len(parts) == 0 {
+		return "anonymous"
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// Request is the part of an RPC request the policy applies to
+type Request struct {
+	Method string
+	// EotsPk is the hex encoded BIP-340 public key of the EOTS key
+	EotsPk  string
+	ChainID string
+	Heights []uint64
+}
+
+// Rule allows the clients matching any of its identities to call the RPC
+// methods with the EOTS keys, chain IDs and heights of the rule. An empty
+// list or a zero height does not restrict the requests.
+type Rule struct {
+	Name string `json:"name"`
+
+	ClientCerts []string `json:"client_certs,omitempty"`
+	ClientNames []string `json:"client_names,omitempty"`
+	HMACKeyIDs  []string `json:"hmac_key_ids,omitempty"`
+
+	EotsPks   []string `json:"eots_pks,omitempty"`
+	ChainIDs  []string `json:"chain_ids,omitempty"`
+	Methods   []string `json:"methods,omitempty"`
+	MinHeight uint64   `json:"min_height,omitempty"`
+	MaxHeight uint64   `json:"max_height,omitempty"`
+}
+
+// Policy is a set of rules. A request is denied unless a rule allows it.
+type Policy struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Load reads the policy file, in YAML or JSON
+func Load(file string) (*Policy, error) {
+	bz, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file %s: %w", file, err)
+	}
+
+	var p Policy
+	if err := yaml.UnmarshalStrict(bz, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", file, err)
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", file, err)
+	}
+
+	return &p, nil
+}
+
+// Validate checks the rules and normalizes the fingerprints and public keys
+func (p *Policy) Validate() error {
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("the policy has no rule")
+	}
+
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		if r.Name == "" {
+			r.Name = fmt.Sprintf("#%d", i)
+		}
+
+		if len(r.ClientCerts) == 0 && len(r.ClientNames) == 0 && len(r.HMACKeyIDs) == 0 {
+			return fmt.Errorf("rule %s: no client identity, set client_certs, client_names or hmac_key_ids", r.Name)
+		}
+
+		for j, fp := range r.ClientCerts {
+			if err := util.ValidateCertFingerprint(fp); err != nil {
+				return fmt.Errorf("rule %s: %w", r.Name, err)
+			}
+			r.ClientCerts[j] = util.NormalizeCertFingerprint(fp)
+		}
+
+		for j, pk := range r.EotsPks {
+			pk = strings.ToLower(pk)
+			bz, err := hex.DecodeString(pk)
+			if err != nil || len(bz) != 32 {
+				return fmt.Errorf("rule %s: invalid EOTS public key %s, expected the hex of a BIP-340 public key", r.Name, pk)
+			}
+			r.EotsPks[j] = pk
+		}
+
+		for _, m := range r.Methods {
+			if !slices.Contains(Methods, m) {
+				return fmt.Errorf("rule %s: unknown method %s, expected one of %s", r.Name, m, strings.Join(Methods, ", "))
+			}
+		}
+
+		if r.MaxHeight != 0 && r.MinHeight > r.MaxHeight {
+			return fmt.Errorf("rule %s: min_height %d is above max_height %d", r.Name, r.MinHeight, r.MaxHeight)
+		}
+	}
+
+	return nil
+}
+
+// Authorize returns nil if a rule allows the caller to make the request, and
+// a *DeniedError otherwise
+func (p *Policy) Authorize(id Identity, req *Request) error {
+	if id.IsEmpty() {
+		return &DeniedError{
+			Reason: ReasonIdentity,
+			Msg:    fmt.Sprintf("%s requires the caller to be identified by a TLS client certificate or an HMAC key", req.Method),
+		}
+	}
+
+	var denied *DeniedError
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		if !r.matches(id) {
+			continue
+		}
+
+		err := r.allows(req)
+		if err == nil {
+			return nil
+		}
+		// report the first rule of the caller, which
+		// is usually the only one
+		if denied == nil {
+			denied = err
+		}
+	}
+
+	if denied == nil {
+		return &DeniedError{
+			Reason: ReasonIdentity,
+			Msg:    fmt.Sprintf("no rule for %s", id),
+		}
+	}
+
+	denied.Msg = fmt.Sprintf("%s for %s", denied.Msg, id)
+
+	return denied
+}
+
+func (r *Rule) matches(id Identity) bool {
+	if id.CertFingerprint != "" &&
+		(slices.Contains(r.ClientCerts, id.CertFingerprint) || slices.Contains(r.ClientNames, id.CertName)) {
+		return true
+	}
+
+	return id.HMACKeyID != "" && slices.Contains(r.HMACKeyIDs, id.HMACKeyID)
+}
+
+func (r *Rule) allows(req *Request) *DeniedError {
+	if len(r.Methods) > 0 && !slices.Contains(r.Methods, req.Method) {
+		return &DeniedError{
+			Reason: ReasonMethod,
+			Msg:    fmt.Sprintf("method %s is not allowed by rule %s", req.Method, r.Name),
+		}
+	}
+
+	if len(r.EotsPks) > 0 && slices.Contains(keyMethods, req.Method) && !slices.Contains(r.EotsPks, req.EotsPk) {
+		return &DeniedError{
+			Reason: ReasonEotsPk,
+			Msg:    fmt.Sprintf("EOTS key %s is not allowed by rule %s", req.EotsPk, r.Name),
+		}
+	}
+
+	if len(r.ChainIDs) > 0 && slices.Contains(chainMethods, req.Method) && !slices.Contains(r.ChainIDs, req.ChainID) {
+		return &DeniedError{
+			Reason: ReasonChainID,
+			Msg:    fmt.Sprintf("chain ID %s is not allowed by rule %s", req.ChainID, r.Name),
+		}
+	}
+
+	for _, h := range req.Heights {
+		if h < r.MinHeight {
+			return &DeniedError{
+				Reason: ReasonHeight,
+				Msg:    fmt.Sprintf("height %d is below the min height %d of rule %s", h, r.MinHeight, r.Name),
+			}
+		}
+		if r.MaxHeight != 0 && h > r.MaxHeight {
+			return &DeniedError{
+				Reason: ReasonHeight,
+				Msg:    fmt.Sprintf("height %d is above the max height %d of rule %s", h, r.MaxHeight, r.Name),
+			}
+		}
+	}
+
+	return nil
+}
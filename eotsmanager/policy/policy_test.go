@@ -0,0 +1,131 @@
+package policy_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/policy"
+)
+
+var (
+	pkA    = strings.Repeat("a", 64)
+	pkB    = strings.Repeat("b", 64)
+	certFp = strings.Repeat("c", 64)
+)
+
+func TestPolicyAuthorize(t *testing.T) {
+	t.Parallel()
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+rules:
+  - name: fp-chain-a
+    client_certs: ["`+strings.ToUpper(certFp)+`"]
+    eots_pks: ["`+pkA+`"]
+    chain_ids: ["chain-a"]
+    methods: ["SignEOTS", "SignBatchEOTS", "CreateRandomnessPairList"]
+    min_height: 100
+    max_height: 200
+  - name: operator
+    hmac_key_ids: ["ops"]
+    methods: ["UnlockKey"]
+`), 0600))
+
+	p, err := policy.Load(file)
+	require.NoError(t, err)
+
+	fp := policy.Identity{CertFingerprint: certFp, CertName: "fpd"}
+	ops := policy.Identity{HMACKeyID: "ops"}
+
+	tests := []struct {
+		name   string
+		id     policy.Identity
+		req    *policy.Request
+		reason string
+	}{
+		{
+			name: "allowed",
+			id:   fp,
+			req:  &policy.Request{Method: "SignEOTS", EotsPk: pkA, ChainID: "chain-a", Heights: []uint64{150}},
+		},
+		{
+			name:   "other chain",
+			id:     fp,
+			req:    &policy.Request{Method: "SignEOTS", EotsPk: pkA, ChainID: "chain-b", Heights: []uint64{150}},
+			reason: policy.ReasonChainID,
+		},
+		{
+			name:   "other key",
+			id:     fp,
+			req:    &policy.Request{Method: "SignEOTS", EotsPk: pkB, ChainID: "chain-a", Heights: []uint64{150}},
+			reason: policy.ReasonEotsPk,
+		},
+		{
+			name:   "other method",
+			id:     fp,
+			req:    &policy.Request{Method: "UnlockKey", EotsPk: pkA},
+			reason: policy.ReasonMethod,
+		},
+		{
+			name:   "height above range",
+			id:     fp,
+			req:    &policy.Request{Method: "SignBatchEOTS", EotsPk: pkA, ChainID: "chain-a", Heights: []uint64{199, 201}},
+			reason: policy.ReasonHeight,
+		},
+		{
+			name:   "empty chain ID",
+			id:     fp,
+			req:    &policy.Request{Method: "SignEOTS", EotsPk: pkA, Heights: []uint64{150}},
+			reason: policy.ReasonChainID,
+		},
+		{
+			name: "second rule",
+			id:   ops,
+			req:  &policy.Request{Method: "UnlockKey", EotsPk: pkB},
+		},
+		{
+			name:   "unknown client",
+			id:     policy.Identity{HMACKeyID: "default"},
+			req:    &policy.Request{Method: "UnlockKey", EotsPk: pkB},
+			reason: policy.ReasonIdentity,
+		},
+		{
+			name:   "anonymous",
+			id:     policy.Identity{},
+			req:    &policy.Request{Method: "SaveEOTSKeyName", EotsPk: pkA},
+			reason: policy.ReasonIdentity,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := p.Authorize(tc.id, tc.req)
+			if tc.reason == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, policy.ErrDenied)
+			var denied *policy.DeniedError
+			require.True(t, errors.As(err, &denied))
+			require.Equal(t, tc.reason, denied.Reason)
+		})
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	t.Parallel()
+
+	require.Error(t, (&policy.Policy{}).Validate())
+	require.Error(t, (&policy.Policy{Rules: []policy.Rule{{Name: "no identity", Methods: []string{"SignEOTS"}}}}).Validate())
+	require.Error(t, (&policy.Policy{Rules: []policy.Rule{{HMACKeyIDs: []string{"k"}, Methods: []string{"Sign"}}}}).Validate())
+	require.Error(t, (&policy.Policy{Rules: []policy.Rule{{HMACKeyIDs: []string{"k"}, EotsPks: []string{"abcd"}}}}).Validate())
+	require.Error(t, (&policy.Policy{Rules: []policy.Rule{{HMACKeyIDs: []string{"k"}, MinHeight: 10, MaxHeight: 5}}}).Validate())
+	require.NoError(t, (&policy.Policy{Rules: []policy.Rule{{HMACKeyIDs: []string{"k"}, MinHeight: 10}}}).Validate())
+}
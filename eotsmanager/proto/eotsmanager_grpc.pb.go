@@ -19,15 +19,29 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	EOTSManager_Ping_FullMethodName                     = "/proto.EOTSManager/Ping"
-	EOTSManager_CreateRandomnessPairList_FullMethodName = "/proto.EOTSManager/CreateRandomnessPairList"
-	EOTSManager_SignEOTS_FullMethodName                 = "/proto.EOTSManager/SignEOTS"
-	EOTSManager_UnsafeSignEOTS_FullMethodName           = "/proto.EOTSManager/UnsafeSignEOTS"
-	EOTSManager_SignSchnorrSig_FullMethodName           = "/proto.EOTSManager/SignSchnorrSig"
-	EOTSManager_SignBatchEOTS_FullMethodName            = "/proto.EOTSManager/SignBatchEOTS"
-	EOTSManager_SaveEOTSKeyName_FullMethodName          = "/proto.EOTSManager/SaveEOTSKeyName"
-	EOTSManager_UnlockKey_FullMethodName                = "/proto.EOTSManager/UnlockKey"
-	EOTSManager_Backup_FullMethodName                   = "/proto.EOTSManager/Backup"
+	EOTSManager_Ping_FullMethodName                        = "/proto.EOTSManager/Ping"
+	EOTSManager_CreateRandomnessPairList_FullMethodName    = "/proto.EOTSManager/CreateRandomnessPairList"
+	EOTSManager_SignEOTS_FullMethodName                    = "/proto.EOTSManager/SignEOTS"
+	EOTSManager_UnsafeSignEOTS_FullMethodName              = "/proto.EOTSManager/UnsafeSignEOTS"
+	EOTSManager_SignSchnorrSig_FullMethodName              = "/proto.EOTSManager/SignSchnorrSig"
+	EOTSManager_SignBatchEOTS_FullMethodName               = "/proto.EOTSManager/SignBatchEOTS"
+	EOTSManager_SaveEOTSKeyName_FullMethodName             = "/proto.EOTSManager/SaveEOTSKeyName"
+	EOTSManager_UnlockKey_FullMethodName                   = "/proto.EOTSManager/UnlockKey"
+	EOTSManager_LockKey_FullMethodName                     = "/proto.EOTSManager/LockKey"
+	EOTSManager_ListUnlockedKeys_FullMethodName            = "/proto.EOTSManager/ListUnlockedKeys"
+	EOTSManager_Backup_FullMethodName                      = "/proto.EOTSManager/Backup"
+	EOTSManager_AddHMACKey_FullMethodName                  = "/proto.EOTSManager/AddHMACKey"
+	EOTSManager_RetireHMACKey_FullMethodName               = "/proto.EOTSManager/RetireHMACKey"
+	EOTSManager_ListHMACKeys_FullMethodName                = "/proto.EOTSManager/ListHMACKeys"
+	EOTSManager_GetThresholdKeyShareInfo_FullMethodName    = "/proto.EOTSManager/GetThresholdKeyShareInfo"
+	EOTSManager_CreatePartialRandomnessList_FullMethodName = "/proto.EOTSManager/CreatePartialRandomnessList"
+	EOTSManager_GetPartialEOTSNonce_FullMethodName         = "/proto.EOTSManager/GetPartialEOTSNonce"
+	EOTSManager_SignPartialEOTS_FullMethodName             = "/proto.EOTSManager/SignPartialEOTS"
+	EOTSManager_CreatePartialSchnorrNonce_FullMethodName   = "/proto.EOTSManager/CreatePartialSchnorrNonce"
+	EOTSManager_SignPartialSchnorrSig_FullMethodName       = "/proto.EOTSManager/SignPartialSchnorrSig"
+	EOTSManager_GetSignRecord_FullMethodName               = "/proto.EOTSManager/GetSignRecord"
+	EOTSManager_ListSignRecords_FullMethodName             = "/proto.EOTSManager/ListSignRecords"
+	EOTSManager_GetLastSignedHeight_FullMethodName         = "/proto.EOTSManager/GetLastSignedHeight"
 )
 
 // EOTSManagerClient is the client API for EOTSManager service.
@@ -51,8 +65,44 @@ type EOTSManagerClient interface {
 	SaveEOTSKeyName(ctx context.Context, in *SaveEOTSKeyNameRequest, opts ...grpc.CallOption) (*SaveEOTSKeyNameResponse, error)
 	// UnlockKey unlocks the keyring with the given passphrase
 	UnlockKey(ctx context.Context, in *UnlockKeyRequest, opts ...grpc.CallOption) (*UnlockKeyResponse, error)
+	// LockKey locks an unlocked key of the file keyring, zeroing its private key
+	LockKey(ctx context.Context, in *LockKeyRequest, opts ...grpc.CallOption) (*LockKeyResponse, error)
+	// ListUnlockedKeys returns the unlocked keys of the file keyring
+	ListUnlockedKeys(ctx context.Context, in *ListUnlockedKeysRequest, opts ...grpc.CallOption) (*ListUnlockedKeysResponse, error)
 	// Backup - etosd db
 	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error)
+	// AddHMACKey adds an HMAC key accepted to authenticate the requests
+	AddHMACKey(ctx context.Context, in *AddHMACKeyRequest, opts ...grpc.CallOption) (*AddHMACKeyResponse, error)
+	// RetireHMACKey stops accepting an HMAC key from the given time
+	RetireHMACKey(ctx context.Context, in *RetireHMACKeyRequest, opts ...grpc.CallOption) (*RetireHMACKeyResponse, error)
+	// ListHMACKeys returns the HMAC keys, without their secret
+	ListHMACKeys(ctx context.Context, in *ListHMACKeysRequest, opts ...grpc.CallOption) (*ListHMACKeysResponse, error)
+	// GetThresholdKeyShareInfo returns the public information of the share of
+	// a threshold EOTS key
+	GetThresholdKeyShareInfo(ctx context.Context, in *GetThresholdKeyShareInfoRequest, opts ...grpc.CallOption) (*GetThresholdKeyShareInfoResponse, error)
+	// CreatePartialRandomnessList returns the public nonces of the share of a
+	// threshold EOTS key, from which the public randomness is combined
+	CreatePartialRandomnessList(ctx context.Context, in *CreatePartialRandomnessListRequest, opts ...grpc.CallOption) (*CreatePartialRandomnessListResponse, error)
+	// GetPartialEOTSNonce returns the public nonce of the share of a threshold
+	// EOTS key at a height, without recording it as generated randomness
+	GetPartialEOTSNonce(ctx context.Context, in *GetPartialEOTSNonceRequest, opts ...grpc.CallOption) (*GetPartialEOTSNonceResponse, error)
+	// SignPartialEOTS signs a partial EOTS with the share of a threshold EOTS key
+	SignPartialEOTS(ctx context.Context, in *SignPartialEOTSRequest, opts ...grpc.CallOption) (*SignPartialEOTSResponse, error)
+	// CreatePartialSchnorrNonce returns the public nonce of the share of a
+	// threshold EOTS key for the Schnorr signature of a message
+	CreatePartialSchnorrNonce(ctx context.Context, in *CreatePartialSchnorrNonceRequest, opts ...grpc.CallOption) (*CreatePartialSchnorrNonceResponse, error)
+	// SignPartialSchnorrSig signs a partial Schnorr sig with the share of a
+	// threshold EOTS key
+	SignPartialSchnorrSig(ctx context.Context, in *SignPartialSchnorrSigRequest, opts ...grpc.CallOption) (*SignPartialSchnorrSigResponse, error)
+	// GetSignRecord returns the sign record of an EOTS key for a chain at a
+	// height, if any
+	GetSignRecord(ctx context.Context, in *GetSignRecordRequest, opts ...grpc.CallOption) (*GetSignRecordResponse, error)
+	// ListSignRecords returns the sign records of an EOTS key for a chain in a
+	// range of heights, by ascending height
+	ListSignRecords(ctx context.Context, in *ListSignRecordsRequest, opts ...grpc.CallOption) (*ListSignRecordsResponse, error)
+	// GetLastSignedHeight returns the highest height signed with an EOTS key
+	// for a chain, if any
+	GetLastSignedHeight(ctx context.Context, in *GetLastSignedHeightRequest, opts ...grpc.CallOption) (*GetLastSignedHeightResponse, error)
 }
 
 type eOTSManagerClient struct {
@@ -135,6 +185,24 @@ func (c *eOTSManagerClient) UnlockKey(ctx context.Context, in *UnlockKeyRequest,
 	return out, nil
 }
 
+func (c *eOTSManagerClient) LockKey(ctx context.Context, in *LockKeyRequest, opts ...grpc.CallOption) (*LockKeyResponse, error) {
+	out := new(LockKeyResponse)
+	err := c.cc.Invoke(ctx, EOTSManager_LockKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eOTSManagerClient) ListUnlockedKeys(ctx context.Context, in *ListUnlockedKeysRequest, opts ...grpc.CallOption) (*ListUnlockedKeysResponse, error) {
+	out := new(ListUnlockedKeysResponse)
+	err := c.cc.Invoke(ctx, EOTSManager_ListUnlockedKeys_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *eOTSManagerClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error) {
 	out := new(BackupResponse)
 	err := c.cc.Invoke(ctx, EOTSManager_Backup_FullMethodName, in, out, opts...)
@@ -144,6 +212,114 @@ func (c *eOTSManagerClient) Backup(ctx context.Context, in *BackupRequest, opts
 	return out, nil
 }
 
+func (c *eOTSManagerClient) AddHMACKey(ctx context.Context, in *AddHMACKeyRequest, opts ...grpc.CallOption) (*AddHMACKeyResponse, error) {
+	out := new(AddHMACKeyResponse)
+	err := c.cc.Invoke(ctx, EOTSManager_AddHMACKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eOTSManagerClient) RetireHMACKey(ctx context.Context, in *RetireHMACKeyRequest, opts ...grpc.CallOption) (*RetireHMACKeyResponse, error) {
+	out := new(RetireHMACKeyResponse)
+	err := c.cc.Invoke(ctx, EOTSManager_RetireHMACKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eOTSManagerClient) ListHMACKeys(ctx context.Context, in *ListHMACKeysRequest, opts ...grpc.CallOption) (*ListHMACKeysResponse, error) {
+	out := new(ListHMACKeysResponse)
+	err := c.cc.Invoke(ctx, EOTSManager_ListHMACKeys_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eOTSManagerClient) GetThresholdKeyShareInfo(ctx context.Context, in *GetThresholdKeyShareInfoRequest, opts ...grpc.CallOption) (*GetThresholdKeyShareInfoResponse, error) {
+	out := new(GetThresholdKeyShareInfoResponse)
+	err := c.cc.Invoke(ctx, EOTSManager_GetThresholdKeyShareInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eOTSManagerClient) CreatePartialRandomnessList(ctx context.Context, in *CreatePartialRandomnessListRequest, opts ...grpc.CallOption) (*CreatePartialRandomnessListResponse, error) {
+	out := new(CreatePartialRandomnessListResponse)
+	err := c.cc.Invoke(ctx, EOTSManager_CreatePartialRandomnessList_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eOTSManagerClient) GetPartialEOTSNonce(ctx context.Context, in *GetPartialEOTSNonceRequest, opts ...grpc.CallOption) (*GetPartialEOTSNonceResponse, error) {
+	out := new(GetPartialEOTSNonceResponse)
+	err := c.cc.Invoke(ctx, EOTSManager_GetPartialEOTSNonce_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eOTSManagerClient) SignPartialEOTS(ctx context.Context, in *SignPartialEOTSRequest, opts ...grpc.CallOption) (*SignPartialEOTSResponse, error) {
+	out := new(SignPartialEOTSResponse)
+	err := c.cc.Invoke(ctx, EOTSManager_SignPartialEOTS_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eOTSManagerClient) CreatePartialSchnorrNonce(ctx context.Context, in *CreatePartialSchnorrNonceRequest, opts ...grpc.CallOption) (*CreatePartialSchnorrNonceResponse, error) {
+	out := new(CreatePartialSchnorrNonceResponse)
+	err := c.cc.Invoke(ctx, EOTSManager_CreatePartialSchnorrNonce_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eOTSManagerClient) SignPartialSchnorrSig(ctx context.Context, in *SignPartialSchnorrSigRequest, opts ...grpc.CallOption) (*SignPartialSchnorrSigResponse, error) {
+	out := new(SignPartialSchnorrSigResponse)
+	err := c.cc.Invoke(ctx, EOTSManager_SignPartialSchnorrSig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eOTSManagerClient) GetSignRecord(ctx context.Context, in *GetSignRecordRequest, opts ...grpc.CallOption) (*GetSignRecordResponse, error) {
+	out := new(GetSignRecordResponse)
+	err := c.cc.Invoke(ctx, EOTSManager_GetSignRecord_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eOTSManagerClient) ListSignRecords(ctx context.Context, in *ListSignRecordsRequest, opts ...grpc.CallOption) (*ListSignRecordsResponse, error) {
+	out := new(ListSignRecordsResponse)
+	err := c.cc.Invoke(ctx, EOTSManager_ListSignRecords_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eOTSManagerClient) GetLastSignedHeight(ctx context.Context, in *GetLastSignedHeightRequest, opts ...grpc.CallOption) (*GetLastSignedHeightResponse, error) {
+	out := new(GetLastSignedHeightResponse)
+	err := c.cc.Invoke(ctx, EOTSManager_GetLastSignedHeight_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EOTSManagerServer is the server API for EOTSManager service.
 // All implementations must embed UnimplementedEOTSManagerServer
 // for forward compatibility
@@ -165,8 +341,44 @@ type EOTSManagerServer interface {
 	SaveEOTSKeyName(context.Context, *SaveEOTSKeyNameRequest) (*SaveEOTSKeyNameResponse, error)
 	// UnlockKey unlocks the keyring with the given passphrase
 	UnlockKey(context.Context, *UnlockKeyRequest) (*UnlockKeyResponse, error)
+	// LockKey locks an unlocked key of the file keyring, zeroing its private key
+	LockKey(context.Context, *LockKeyRequest) (*LockKeyResponse, error)
+	// ListUnlockedKeys returns the unlocked keys of the file keyring
+	ListUnlockedKeys(context.Context, *ListUnlockedKeysRequest) (*ListUnlockedKeysResponse, error)
 	// Backup - etosd db
 	Backup(context.Context, *BackupRequest) (*BackupResponse, error)
+	// AddHMACKey adds an HMAC key accepted to authenticate the requests
+	AddHMACKey(context.Context, *AddHMACKeyRequest) (*AddHMACKeyResponse, error)
+	// RetireHMACKey stops accepting an HMAC key from the given time
+	RetireHMACKey(context.Context, *RetireHMACKeyRequest) (*RetireHMACKeyResponse, error)
+	// ListHMACKeys returns the HMAC keys, without their secret
+	ListHMACKeys(context.Context, *ListHMACKeysRequest) (*ListHMACKeysResponse, error)
+	// GetThresholdKeyShareInfo returns the public information of the share of
+	// a threshold EOTS key
+	GetThresholdKeyShareInfo(context.Context, *GetThresholdKeyShareInfoRequest) (*GetThresholdKeyShareInfoResponse, error)
+	// CreatePartialRandomnessList returns the public nonces of the share of a
+	// threshold EOTS key, from which the public randomness is combined
+	CreatePartialRandomnessList(context.Context, *CreatePartialRandomnessListRequest) (*CreatePartialRandomnessListResponse, error)
+	// GetPartialEOTSNonce returns the public nonce of the share of a threshold
+	// EOTS key at a height, without recording it as generated randomness
+	GetPartialEOTSNonce(context.Context, *GetPartialEOTSNonceRequest) (*GetPartialEOTSNonceResponse, error)
+	// SignPartialEOTS signs a partial EOTS with the share of a threshold EOTS key
+	SignPartialEOTS(context.Context, *SignPartialEOTSRequest) (*SignPartialEOTSResponse, error)
+	// CreatePartialSchnorrNonce returns the public nonce of the share of a
+	// threshold EOTS key for the Schnorr signature of a message
+	CreatePartialSchnorrNonce(context.Context, *CreatePartialSchnorrNonceRequest) (*CreatePartialSchnorrNonceResponse, error)
+	// SignPartialSchnorrSig signs a partial Schnorr sig with the share of a
+	// threshold EOTS key
+	SignPartialSchnorrSig(context.Context, *SignPartialSchnorrSigRequest) (*SignPartialSchnorrSigResponse, error)
+	// GetSignRecord returns the sign record of an EOTS key for a chain at a
+	// height, if any
+	GetSignRecord(context.Context, *GetSignRecordRequest) (*GetSignRecordResponse, error)
+	// ListSignRecords returns the sign records of an EOTS key for a chain in a
+	// range of heights, by ascending height
+	ListSignRecords(context.Context, *ListSignRecordsRequest) (*ListSignRecordsResponse, error)
+	// GetLastSignedHeight returns the highest height signed with an EOTS key
+	// for a chain, if any
+	GetLastSignedHeight(context.Context, *GetLastSignedHeightRequest) (*GetLastSignedHeightResponse, error)
 	mustEmbedUnimplementedEOTSManagerServer()
 }
 
@@ -198,9 +410,51 @@ func (UnimplementedEOTSManagerServer) SaveEOTSKeyName(context.Context, *SaveEOTS
 func (UnimplementedEOTSManagerServer) UnlockKey(context.Context, *UnlockKeyRequest) (*UnlockKeyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UnlockKey not implemented")
 }
+func (UnimplementedEOTSManagerServer) LockKey(context.Context, *LockKeyRequest) (*LockKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LockKey not implemented")
+}
+func (UnimplementedEOTSManagerServer) ListUnlockedKeys(context.Context, *ListUnlockedKeysRequest) (*ListUnlockedKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUnlockedKeys not implemented")
+}
 func (UnimplementedEOTSManagerServer) Backup(context.Context, *BackupRequest) (*BackupResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Backup not implemented")
 }
+func (UnimplementedEOTSManagerServer) AddHMACKey(context.Context, *AddHMACKeyRequest) (*AddHMACKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddHMACKey not implemented")
+}
+func (UnimplementedEOTSManagerServer) RetireHMACKey(context.Context, *RetireHMACKeyRequest) (*RetireHMACKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetireHMACKey not implemented")
+}
+func (UnimplementedEOTSManagerServer) ListHMACKeys(context.Context, *ListHMACKeysRequest) (*ListHMACKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHMACKeys not implemented")
+}
+func (UnimplementedEOTSManagerServer) GetThresholdKeyShareInfo(context.Context, *GetThresholdKeyShareInfoRequest) (*GetThresholdKeyShareInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetThresholdKeyShareInfo not implemented")
+}
+func (UnimplementedEOTSManagerServer) CreatePartialRandomnessList(context.Context, *CreatePartialRandomnessListRequest) (*CreatePartialRandomnessListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePartialRandomnessList not implemented")
+}
+func (UnimplementedEOTSManagerServer) GetPartialEOTSNonce(context.Context, *GetPartialEOTSNonceRequest) (*GetPartialEOTSNonceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPartialEOTSNonce not implemented")
+}
+func (UnimplementedEOTSManagerServer) SignPartialEOTS(context.Context, *SignPartialEOTSRequest) (*SignPartialEOTSResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignPartialEOTS not implemented")
+}
+func (UnimplementedEOTSManagerServer) CreatePartialSchnorrNonce(context.Context, *CreatePartialSchnorrNonceRequest) (*CreatePartialSchnorrNonceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePartialSchnorrNonce not implemented")
+}
+func (UnimplementedEOTSManagerServer) SignPartialSchnorrSig(context.Context, *SignPartialSchnorrSigRequest) (*SignPartialSchnorrSigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignPartialSchnorrSig not implemented")
+}
+func (UnimplementedEOTSManagerServer) GetSignRecord(context.Context, *GetSignRecordRequest) (*GetSignRecordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSignRecord not implemented")
+}
+func (UnimplementedEOTSManagerServer) ListSignRecords(context.Context, *ListSignRecordsRequest) (*ListSignRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSignRecords not implemented")
+}
+func (UnimplementedEOTSManagerServer) GetLastSignedHeight(context.Context, *GetLastSignedHeightRequest) (*GetLastSignedHeightResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLastSignedHeight not implemented")
+}
 func (UnimplementedEOTSManagerServer) mustEmbedUnimplementedEOTSManagerServer() {}
 
 // UnsafeEOTSManagerServer may be embedded to opt out of forward compatibility for this service.
@@ -358,6 +612,42 @@ func _EOTSManager_UnlockKey_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EOTSManager_LockKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EOTSManagerServer).LockKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EOTSManager_LockKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EOTSManagerServer).LockKey(ctx, req.(*LockKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EOTSManager_ListUnlockedKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUnlockedKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EOTSManagerServer).ListUnlockedKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EOTSManager_ListUnlockedKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EOTSManagerServer).ListUnlockedKeys(ctx, req.(*ListUnlockedKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _EOTSManager_Backup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BackupRequest)
 	if err := dec(in); err != nil {
@@ -376,6 +666,222 @@ func _EOTSManager_Backup_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EOTSManager_AddHMACKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddHMACKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EOTSManagerServer).AddHMACKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EOTSManager_AddHMACKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EOTSManagerServer).AddHMACKey(ctx, req.(*AddHMACKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EOTSManager_RetireHMACKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetireHMACKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EOTSManagerServer).RetireHMACKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EOTSManager_RetireHMACKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EOTSManagerServer).RetireHMACKey(ctx, req.(*RetireHMACKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EOTSManager_ListHMACKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHMACKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EOTSManagerServer).ListHMACKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EOTSManager_ListHMACKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EOTSManagerServer).ListHMACKeys(ctx, req.(*ListHMACKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EOTSManager_GetThresholdKeyShareInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThresholdKeyShareInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EOTSManagerServer).GetThresholdKeyShareInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EOTSManager_GetThresholdKeyShareInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EOTSManagerServer).GetThresholdKeyShareInfo(ctx, req.(*GetThresholdKeyShareInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EOTSManager_CreatePartialRandomnessList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePartialRandomnessListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EOTSManagerServer).CreatePartialRandomnessList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EOTSManager_CreatePartialRandomnessList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EOTSManagerServer).CreatePartialRandomnessList(ctx, req.(*CreatePartialRandomnessListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EOTSManager_GetPartialEOTSNonce_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPartialEOTSNonceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EOTSManagerServer).GetPartialEOTSNonce(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EOTSManager_GetPartialEOTSNonce_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EOTSManagerServer).GetPartialEOTSNonce(ctx, req.(*GetPartialEOTSNonceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EOTSManager_SignPartialEOTS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignPartialEOTSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EOTSManagerServer).SignPartialEOTS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EOTSManager_SignPartialEOTS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EOTSManagerServer).SignPartialEOTS(ctx, req.(*SignPartialEOTSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EOTSManager_CreatePartialSchnorrNonce_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePartialSchnorrNonceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EOTSManagerServer).CreatePartialSchnorrNonce(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EOTSManager_CreatePartialSchnorrNonce_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EOTSManagerServer).CreatePartialSchnorrNonce(ctx, req.(*CreatePartialSchnorrNonceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EOTSManager_SignPartialSchnorrSig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignPartialSchnorrSigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EOTSManagerServer).SignPartialSchnorrSig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EOTSManager_SignPartialSchnorrSig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EOTSManagerServer).SignPartialSchnorrSig(ctx, req.(*SignPartialSchnorrSigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EOTSManager_GetSignRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSignRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EOTSManagerServer).GetSignRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EOTSManager_GetSignRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EOTSManagerServer).GetSignRecord(ctx, req.(*GetSignRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EOTSManager_ListSignRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSignRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EOTSManagerServer).ListSignRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EOTSManager_ListSignRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EOTSManagerServer).ListSignRecords(ctx, req.(*ListSignRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EOTSManager_GetLastSignedHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLastSignedHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EOTSManagerServer).GetLastSignedHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EOTSManager_GetLastSignedHeight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EOTSManagerServer).GetLastSignedHeight(ctx, req.(*GetLastSignedHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // EOTSManager_ServiceDesc is the grpc.ServiceDesc for EOTSManager service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -415,10 +921,66 @@ var EOTSManager_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UnlockKey",
 			Handler:    _EOTSManager_UnlockKey_Handler,
 		},
+		{
+			MethodName: "LockKey",
+			Handler:    _EOTSManager_LockKey_Handler,
+		},
+		{
+			MethodName: "ListUnlockedKeys",
+			Handler:    _EOTSManager_ListUnlockedKeys_Handler,
+		},
 		{
 			MethodName: "Backup",
 			Handler:    _EOTSManager_Backup_Handler,
 		},
+		{
+			MethodName: "AddHMACKey",
+			Handler:    _EOTSManager_AddHMACKey_Handler,
+		},
+		{
+			MethodName: "RetireHMACKey",
+			Handler:    _EOTSManager_RetireHMACKey_Handler,
+		},
+		{
+			MethodName: "ListHMACKeys",
+			Handler:    _EOTSManager_ListHMACKeys_Handler,
+		},
+		{
+			MethodName: "GetThresholdKeyShareInfo",
+			Handler:    _EOTSManager_GetThresholdKeyShareInfo_Handler,
+		},
+		{
+			MethodName: "CreatePartialRandomnessList",
+			Handler:    _EOTSManager_CreatePartialRandomnessList_Handler,
+		},
+		{
+			MethodName: "GetPartialEOTSNonce",
+			Handler:    _EOTSManager_GetPartialEOTSNonce_Handler,
+		},
+		{
+			MethodName: "SignPartialEOTS",
+			Handler:    _EOTSManager_SignPartialEOTS_Handler,
+		},
+		{
+			MethodName: "CreatePartialSchnorrNonce",
+			Handler:    _EOTSManager_CreatePartialSchnorrNonce_Handler,
+		},
+		{
+			MethodName: "SignPartialSchnorrSig",
+			Handler:    _EOTSManager_SignPartialSchnorrSig_Handler,
+		},
+		{
+			MethodName: "GetSignRecord",
+			Handler:    _EOTSManager_GetSignRecord_Handler,
+		},
+		{
+			MethodName: "ListSignRecords",
+			Handler:    _EOTSManager_ListSignRecords_Handler,
+		},
+		{
+			MethodName: "GetLastSignedHeight",
+			Handler:    _EOTSManager_GetLastSignedHeight_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "eotsmanager.proto",
@@ -0,0 +1,171 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        (unknown)
+// source: hmackeystore.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// HMACKeyRecord represents an HMAC key added at runtime.
+// it is keyed by its id
+type HMACKeyRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// key is the HMAC key, encrypted with a key derived from the admin HMAC key.
+	// It is empty for the record retiring the key of the config.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// not_before is the time from which the key is accepted, in Unix seconds
+	NotBefore int64 `protobuf:"varint,2,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	// not_after is the time from which the key is no longer accepted, in Unix seconds
+	NotAfter int64 `protobuf:"varint,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+}
+
+func (x *HMACKeyRecord) Reset() {
+	*x = HMACKeyRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hmackeystore_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HMACKeyRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HMACKeyRecord) ProtoMessage() {}
+
+func (x *HMACKeyRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_hmackeystore_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HMACKeyRecord.ProtoReflect.Descriptor instead.
+func (*HMACKeyRecord) Descriptor() ([]byte, []int) {
+	return file_hmackeystore_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HMACKeyRecord) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *HMACKeyRecord) GetNotBefore() int64 {
+	if x != nil {
+		return x.NotBefore
+	}
+	return 0
+}
+
+func (x *HMACKeyRecord) GetNotAfter() int64 {
+	if x != nil {
+		return x.NotAfter
+	}
+	return 0
+}
+
+var File_hmackeystore_proto protoreflect.FileDescriptor
+
+var file_hmackeystore_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x68, 0x6d, 0x61, 0x63, 0x6b, 0x65, 0x79, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x5d, 0x0a, 0x0d, 0x48,
+	0x4d, 0x41, 0x43, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6e, 0x6f, 0x74, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x6e, 0x6f, 0x74, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x6e, 0x6f, 0x74, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x6e, 0x6f, 0x74, 0x41, 0x66, 0x74, 0x65, 0x72, 0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x61, 0x62, 0x79, 0x6c, 0x6f, 0x6e,
+	0x6c, 0x61, 0x62, 0x73, 0x2d, 0x69, 0x6f, 0x2f, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79,
+	0x2d, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2f, 0x65, 0x6f, 0x74, 0x73, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_hmackeystore_proto_rawDescOnce sync.Once
+	file_hmackeystore_proto_rawDescData = file_hmackeystore_proto_rawDesc
+)
+
+func file_hmackeystore_proto_rawDescGZIP() []byte {
+	file_hmackeystore_proto_rawDescOnce.Do(func() {
+		file_hmackeystore_proto_rawDescData = protoimpl.X.CompressGZIP(file_hmackeystore_proto_rawDescData)
+	})
+	return file_hmackeystore_proto_rawDescData
+}
+
+var file_hmackeystore_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_hmackeystore_proto_goTypes = []interface{}{
+	(*HMACKeyRecord)(nil), // 0: proto.HMACKeyRecord
+}
+var file_hmackeystore_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_hmackeystore_proto_init() }
+func file_hmackeystore_proto_init() {
+	if File_hmackeystore_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_hmackeystore_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HMACKeyRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_hmackeystore_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_hmackeystore_proto_goTypes,
+		DependencyIndexes: file_hmackeystore_proto_depIdxs,
+		MessageInfos:      file_hmackeystore_proto_msgTypes,
+	}.Build()
+	File_hmackeystore_proto = out.File
+	file_hmackeystore_proto_rawDesc = nil
+	file_hmackeystore_proto_goTypes = nil
+	file_hmackeystore_proto_depIdxs = nil
+}
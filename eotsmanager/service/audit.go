@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/audit"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+const (
+	callerAuthHMAC = "hmac"
+	callerAuthMTLS = "mtls"
+	callerAuthNone = "none"
+)
+
+// recordAudit appends the outcome of a request to the audit log. Failing to
+// write the audit log does not fail the request, but it is reported as an
+// error. The signing requests use beginSigningAudit and endSigningAudit instead.
+func (r *rpcServer) recordAudit(ctx context.Context, e *audit.Entry, err error) {
+	if r.auditLog == nil {
+		return
+	}
+
+	if err := r.appendAudit(ctx, e, err); err != nil {
+		r.logger.Error("failed to write the audit log",
+			zap.String("method", e.Method),
+			zap.Error(err),
+		)
+	}
+}
+
+// beginSigningAudit appends a pending entry of a signing request to the audit
+// log before the signing. The request must fail if it cannot be written, so
+// that no signature is produced without a record in the audit log.
+func (r *rpcServer) beginSigningAudit(ctx context.Context, e *audit.Entry) error {
+	if r.auditLog == nil {
+		return nil
+	}
+
+	pending := *e
+	pending.Outcome = audit.OutcomePending
+	setAuditCaller(ctx, &pending)
+	if err := r.auditLog.Append(&pending); err != nil {
+		r.logger.Error("failed to write the audit log",
+			zap.String("method", e.Method),
+			zap.Error(err),
+		)
+
+		return status.Errorf(codes.Unavailable, "failed to write the audit log: %v", err)
+	}
+
+	return nil
+}
+
+// endSigningAudit appends the outcome of a signing request to the audit log.
+// It returns an error if a signature would be returned without its outcome
+// being written, in which case the signature must be withheld.
+func (r *rpcServer) endSigningAudit(ctx context.Context, e *audit.Entry, err error) error {
+	if r.auditLog == nil {
+		return nil
+	}
+
+	auditErr := r.appendAudit(ctx, e, err)
+	if auditErr == nil {
+		return nil
+	}
+
+	r.logger.Error("failed to write the audit log",
+		zap.String("method", e.Method),
+		zap.Error(auditErr),
+	)
+	if err != nil {
+		// the request failed anyway
+		return nil
+	}
+
+	return status.Errorf(codes.Unavailable, "failed to write the audit log: %v", auditErr)
+}
+
+// appendAudit appends the outcome of a request to the audit log
+func (r *rpcServer) appendAudit(ctx context.Context, e *audit.Entry, err error) error {
+	setAuditCaller(ctx, e)
+	e.Outcome = audit.OutcomeSuccess
+	if err != nil {
+		e.Outcome = audit.OutcomeFailure
+		e.Error = err.Error()
+	}
+
+	return r.auditLog.Append(e)
+}
+
+// setAuditCaller sets the caller of the request in the audit entry
+func setAuditCaller(ctx context.Context, e *audit.Entry) {
+	// requests only reach the handlers once the TLS handshake
+	// and the HMAC interceptor authenticated them
+	var auths []string
+	if p, ok := peer.FromContext(ctx); ok {
+		if p.Addr != nil {
+			e.CallerAddr = p.Addr.String()
+		}
+		if cert := verifiedClientCert(p); cert != nil {
+			e.CallerCert = fmt.Sprintf("%s sha256:%s", cert.Subject.CommonName, util.CertFingerprint(cert.Raw))
+			auths = append(auths, callerAuthMTLS)
+		}
+	}
+	if keyID := HMACKeyIDFromContext(ctx); keyID != "" {
+		e.CallerKeyID = keyID
+		auths = append(auths, callerAuthHMAC)
+	}
+
+	e.CallerAuth = callerAuthNone
+	if len(auths) > 0 {
+		e.CallerAuth = strings.Join(auths, "+")
+	}
+}
+
+// verifiedClientCert returns the client certificate of the peer if it was
+// verified during the TLS handshake
+func verifiedClientCert(p *peer.Peer) *x509.Certificate {
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	return tlsInfo.State.VerifiedChains[0][0]
+}
+
+func auditPk(uid []byte) string {
+	return hex.EncodeToString(uid)
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/audit"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+)
+
+func TestUnsafeSignEOTSAudit(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "audit.log")
+	auditLog, err := audit.Open(path)
+	require.NoError(t, err)
+
+	r := &rpcServer{
+		cfg:      config.DefaultConfig(),
+		logger:   zap.NewNop(),
+		metrics:  metrics.NewEotsMetrics(),
+		auditLog: auditLog,
+	}
+
+	// the requests to the disabled endpoint are recorded as well
+	uid := []byte(strings.Repeat("\xab", 32))
+	_, err = r.UnsafeSignEOTS(t.Context(), &proto.SignEOTSRequest{
+		Uid: uid, ChainId: []byte("chain"), Msg: []byte("msg"), Height: 10,
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.NoError(t, auditLog.Close())
+
+	res, err := audit.Verify(path)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), res.Entries)
+
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(bz), `"method":"UnsafeSignEOTS"`)
+	require.Contains(t, string(bz), `"heights":[10]`)
+	require.Contains(t, string(bz), `"outcome":"failure"`)
+}
+
+func TestSigningAuditFailsClosed(t *testing.T) {
+	t.Parallel()
+	homeDir := filepath.Join(t.TempDir(), "eots-home")
+	cfg := config.DefaultConfigWithHomePath(homeDir)
+	disableUnsafeEndpoints := false
+	cfg.DisableUnsafeEndpoints = &disableUnsafeEndpoints
+	db, err := cfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	em, err := eotsmanager.NewLocalEOTSManager(homeDir, cfg.KeyringBackend, db, zap.NewNop())
+	require.NoError(t, err)
+	uid, err := em.CreateKey("eots-key", "")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	auditLog, err := audit.Open(path)
+	require.NoError(t, err)
+
+	r := &rpcServer{
+		em:       em,
+		cfg:      cfg,
+		logger:   zap.NewNop(),
+		metrics:  metrics.NewEotsMetrics(),
+		auditLog: auditLog,
+	}
+
+	// the signing request is recorded before and after the signing
+	res, err := r.SignSchnorrSig(t.Context(), &proto.SignSchnorrSigRequest{Uid: uid, Msg: make([]byte, 32)})
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Sig)
+
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(bz)), "\n")
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], `"outcome":"pending"`)
+	require.Contains(t, lines[1], `"outcome":"success"`)
+
+	// once the audit log cannot be written, nothing is signed
+	require.NoError(t, auditLog.Close())
+
+	_, err = r.SignSchnorrSig(t.Context(), &proto.SignSchnorrSigRequest{Uid: uid, Msg: make([]byte, 32)})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+
+	signReq := &proto.SignEOTSRequest{Uid: uid, ChainId: []byte("chain"), Msg: []byte("msg"), Height: 10}
+	_, err = r.SignEOTS(t.Context(), signReq)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	_, err = r.UnsafeSignEOTS(t.Context(), signReq)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	_, err = r.SignBatchEOTS(t.Context(), &proto.SignBatchEOTSRequest{
+		Uid:          uid,
+		ChainId:      []byte("chain"),
+		SignRequests: []*proto.SignDataRequest{{Msg: []byte("msg"), Height: 10}},
+	})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+
+	// the height was not signed
+	signed, err := em.IsRecordInDB(uid, []byte("chain"), 10)
+	require.NoError(t, err)
+	require.False(t, signed)
+}
@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/base64"
+	"strconv"
+	"sync"
+	"time"
+
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -12,11 +14,117 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
 )
 
+// DefaultHMACKeyID identifies the HMAC key of the config, which is used
+// for the requests which do not set the ID of their key
+const DefaultHMACKeyID = "default"
+
+// AdminHMACKeyID identifies the admin HMAC key of the config, which is the
+// only key allowed to add and retire HMAC keys
+const AdminHMACKeyID = "admin"
+
+var (
+	// adminMethods can only be called with the admin HMAC key
+	adminMethods = map[string]bool{
+		"/proto.EOTSManager/AddHMACKey":    true,
+		"/proto.EOTSManager/RetireHMACKey": true,
+	}
+	// hmacKeyMethods are the only methods the admin HMAC key can call
+	hmacKeyMethods = map[string]bool{
+		"/proto.EOTSManager/AddHMACKey":    true,
+		"/proto.EOTSManager/RetireHMACKey": true,
+		"/proto.EOTSManager/ListHMACKeys":  true,
+	}
+)
+
+type hmacKeyIDCtxKey struct{}
+
+// HMACKeyIDFromContext returns the ID of the HMAC key which authenticated the
+// request, or an empty string if it was not authenticated with HMAC
+func HMACKeyIDFromContext(ctx context.Context) string {
+	keyID, _ := ctx.Value(hmacKeyIDCtxKey{}).(string)
+
+	return keyID
+}
+
+type hmacOptions struct {
+	maxClockSkew   time.Duration
+	nonceCacheSize int
+	legacyCompat   bool
+	adminKey       string
+}
+
+// HMACOption configures the replay protection of the HMAC server interceptor
+type HMACOption func(*hmacOptions)
+
+// WithMaxClockSkew sets how far the timestamp of a request can be from the
+// server time. Requests outside of the window are rejected as replays.
+func WithMaxClockSkew(d time.Duration) HMACOption {
+	return func(o *hmacOptions) {
+		if d > 0 {
+			o.maxClockSkew = d
+		}
+	}
+}
+
+// WithNonceCacheSize sets the number of nonces remembered within the clock skew window
+func WithNonceCacheSize(n int) HMACOption {
+	return func(o *hmacOptions) {
+		if n > 0 {
+			o.nonceCacheSize = n
+		}
+	}
+}
+
+// WithLegacyHMAC accepts requests authenticated with an HMAC over the request
+// body only, as sent by clients without replay protection. It is meant for rolling
+// upgrades only, as such requests can be replayed.
+func WithLegacyHMAC(enabled bool) HMACOption {
+	return func(o *hmacOptions) {
+		o.legacyCompat = enabled
+	}
+}
+
+// WithAdminHMACKey sets the admin HMAC key, identified as AdminHMACKeyID,
+// which is required to add and retire HMAC keys. The HMAC keys cannot be
+// added nor retired if it is empty.
+func WithAdminHMACKey(key string) HMACOption {
+	return func(o *hmacOptions) {
+		o.adminKey = key
+	}
+}
+
 // HMACUnaryServerInterceptor creates a gRPC server interceptor that verifies HMAC
-// on incoming requests. It bypasses authentication for the Ping method and SaveEOTSKeyName.
-func HMACUnaryServerInterceptor(hmacKey string) grpc.UnaryServerInterceptor {
+// on incoming requests with a single key, which is identified as DefaultHMACKeyID.
+func HMACUnaryServerInterceptor(hmacKey string, opts ...HMACOption) grpc.UnaryServerInterceptor {
+	ring := NewHMACKeyRing()
+	if hmacKey != "" {
+		ring = NewHMACKeyRing(&HMACKey{ID: DefaultHMACKeyID, Secret: hmacKey})
+	}
+
+	return HMACKeyRingUnaryServerInterceptor(ring, opts...)
+}
+
+// HMACKeyRingUnaryServerInterceptor creates a gRPC server interceptor that verifies
+// HMAC on incoming requests. It bypasses authentication for the Ping method and SaveEOTSKeyName.
+// The HMAC is checked with the key of the ID sent by the client, or DefaultHMACKeyID if
+// it is not set, which must be active at the time of the request. Adding and retiring
+// HMAC keys requires the admin HMAC key, which is accepted for nothing else.
+// The HMAC covers the method, timestamp and nonce of the request, which are checked
+// against the clock skew window and the nonce cache to reject replayed requests.
+func HMACKeyRingUnaryServerInterceptor(ring *HMACKeyRing, opts ...HMACOption) grpc.UnaryServerInterceptor {
+	o := &hmacOptions{
+		maxClockSkew:   config.DefaultHMACMaxClockSkew,
+		nonceCacheSize: config.DefaultHMACNonceCacheSize,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	nonces := newNonceCache(o.nonceCacheSize)
+
 	return func(
 		ctx context.Context,
 		req interface{},
@@ -32,8 +140,10 @@ func HMACUnaryServerInterceptor(hmacKey string) grpc.UnaryServerInterceptor {
 			return handler(ctx, req)
 		}
 
-		// If HMAC key is empty, skip authentication
-		if hmacKey == "" {
+		// If no HMAC key is configured, skip authentication,
+		// except for the methods requiring the admin key
+		admin := adminMethods[info.FullMethod]
+		if ring.IsEmpty() && !admin {
 			return handler(ctx, req)
 		}
 
@@ -42,6 +152,33 @@ func HMACUnaryServerInterceptor(hmacKey string) grpc.UnaryServerInterceptor {
 			return nil, status.Errorf(codes.Unauthenticated, "metadata is not provided")
 		}
 
+		keyID := DefaultHMACKeyID
+		if ids := md.Get(client.HMACKeyIDHeaderKey); len(ids) > 0 && ids[0] != "" {
+			keyID = ids[0]
+		}
+
+		var hmacKey string
+		switch {
+		case admin && o.adminKey == "":
+			return nil, status.Errorf(codes.PermissionDenied, "the admin HMAC key is not configured")
+		case admin && keyID != AdminHMACKeyID:
+			return nil, status.Errorf(codes.PermissionDenied, "the HMAC keys can only be managed with the admin HMAC key")
+		case keyID == AdminHMACKeyID:
+			if o.adminKey == "" {
+				return nil, status.Errorf(codes.Unauthenticated, "HMAC key %q is unknown or not active", keyID)
+			}
+			if !hmacKeyMethods[info.FullMethod] {
+				return nil, status.Errorf(codes.PermissionDenied, "the admin HMAC key can only manage the HMAC keys")
+			}
+			hmacKey = o.adminKey
+		default:
+			secret, ok := ring.secret(keyID, time.Now())
+			if !ok {
+				return nil, status.Errorf(codes.Unauthenticated, "HMAC key %q is unknown or not active", keyID)
+			}
+			hmacKey = secret
+		}
+
 		// Get HMAC from metadata
 		values := md.Get(client.HMACHeaderKey)
 		if len(values) == 0 {
@@ -59,16 +196,92 @@ func HMACUnaryServerInterceptor(hmacKey string) grpc.UnaryServerInterceptor {
 			return nil, status.Errorf(codes.Internal, "failed to marshal request: %v", err)
 		}
 
-		// Generate HMAC using SHA-256
-		h := hmac.New(sha256.New, []byte(hmacKey))
-		h.Write(data)
-		expectedHMAC := base64.StdEncoding.EncodeToString(h.Sum(nil))
+		timestamps := md.Get(client.HMACTimestampHeaderKey)
+		if len(timestamps) == 0 {
+			if !o.legacyCompat {
+				return nil, status.Errorf(codes.Unauthenticated, "HMAC timestamp not provided")
+			}
+
+			expectedHMAC := client.ComputeLegacyHMAC(hmacKey, data)
+			if !hmac.Equal([]byte(receivedHMAC), []byte(expectedHMAC)) {
+				return nil, status.Errorf(codes.Unauthenticated, "invalid HMAC")
+			}
+
+			return handler(context.WithValue(ctx, hmacKeyIDCtxKey{}, keyID), req)
+		}
+		timestamp := timestamps[0]
+
+		nonceValues := md.Get(client.HMACNonceHeaderKey)
+		if len(nonceValues) == 0 || nonceValues[0] == "" {
+			return nil, status.Errorf(codes.Unauthenticated, "HMAC nonce not provided")
+		}
+		nonce := nonceValues[0]
 
 		// Compare HMACs using constant-time comparison to avoid timing attacks
+		expectedHMAC := client.ComputeHMAC(hmacKey, info.FullMethod, timestamp, nonce, data)
 		if !hmac.Equal([]byte(receivedHMAC), []byte(expectedHMAC)) {
 			return nil, status.Errorf(codes.Unauthenticated, "invalid HMAC")
 		}
 
-		return handler(ctx, req)
+		// the timestamp and nonce are only checked once the HMAC is valid,
+		// so that unauthenticated requests cannot fill the nonce cache
+		ms, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid HMAC timestamp")
+		}
+
+		signedAt := time.UnixMilli(ms)
+		now := time.Now()
+		if signedAt.Before(now.Add(-o.maxClockSkew)) || signedAt.After(now.Add(o.maxClockSkew)) {
+			return nil, status.Errorf(codes.Unauthenticated, "HMAC timestamp is outside of the allowed clock skew")
+		}
+
+		if err := nonces.add(nonce, signedAt.Add(o.maxClockSkew), now); err != nil {
+			return nil, err
+		}
+
+		return handler(context.WithValue(ctx, hmacKeyIDCtxKey{}, keyID), req)
+	}
+}
+
+// nonceCache remembers the nonces of the requests until their timestamp
+// leaves the clock skew window, after which they are rejected anyway
+type nonceCache struct {
+	mu       sync.Mutex
+	size     int
+	expiries map[string]time.Time
+	order    []string
+}
+
+func newNonceCache(size int) *nonceCache {
+	return &nonceCache{
+		size:     size,
+		expiries: make(map[string]time.Time, size),
+	}
+}
+
+func (c *nonceCache) add(nonce string, expiry, now time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if exp, ok := c.expiries[nonce]; ok && now.Before(exp) {
+		return status.Errorf(codes.Unauthenticated, "HMAC nonce was already used")
+	}
+
+	// nonces are mostly added in the order of their expiry
+	for len(c.order) > 0 && !now.Before(c.expiries[c.order[0]]) {
+		delete(c.expiries, c.order[0])
+		c.order = c.order[1:]
 	}
+
+	// evicting a nonce before its expiry would allow replaying
+	// its request, so the request is rejected instead
+	if len(c.order) >= c.size {
+		return status.Errorf(codes.ResourceExhausted, "too many requests within the HMAC clock skew window")
+	}
+
+	c.expiries[nonce] = expiry
+	c.order = append(c.order, nonce)
+
+	return nil
 }
@@ -5,7 +5,9 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/service"
 
@@ -30,24 +32,14 @@ func TestHMACVerification(t *testing.T) {
 		Msg:     []byte("test-message"),
 		Height:  100,
 	}
-	data, err := protobuf.Marshal(testReq)
-	require.NoError(t, err)
-
-	h := hmac.New(sha256.New, []byte(testKey))
-	h.Write(data)
-	validHMAC := base64.StdEncoding.EncodeToString(h.Sum(nil))
-
-	md := metadata.New(map[string]string{
-		client.HMACHeaderKey: validHMAC,
-	})
-	ctx := metadata.NewIncomingContext(t.Context(), md)
+	ctx := hmacCtx(t, testKey, "/proto.EOTSManager/SignEOTS", time.Now(), "nonce", testReq)
 
 	handler := func(_ context.Context, req interface{}) (interface{}, error) {
 		return req, nil
 	}
 
 	interceptor := service.HMACUnaryServerInterceptor(testKey)
-	_, err = interceptor(
+	_, err := interceptor(
 		ctx,
 		testReq,
 		&grpc.UnaryServerInfo{FullMethod: "/proto.EOTSManager/SignEOTS"},
@@ -142,3 +134,220 @@ func TestConfigHMACKey(t *testing.T) {
 	}
 	require.Equal(t, testKey, cfg.HMACKey)
 }
+
+// hmacCtx returns the incoming context of a request signed at the given time
+func hmacCtx(t *testing.T, key, method string, signedAt time.Time, nonce string, req protobuf.Message) context.Context {
+	t.Helper()
+
+	data, err := protobuf.Marshal(req)
+	require.NoError(t, err)
+
+	timestamp := strconv.FormatInt(signedAt.UnixMilli(), 10)
+	md := metadata.New(map[string]string{
+		client.HMACHeaderKey:          client.ComputeHMAC(key, method, timestamp, nonce, data),
+		client.HMACTimestampHeaderKey: timestamp,
+		client.HMACNonceHeaderKey:     nonce,
+	})
+
+	return metadata.NewIncomingContext(t.Context(), md)
+}
+
+func TestHMACReplayProtection(t *testing.T) {
+	t.Parallel()
+	testKey := "test-hmac-key"
+	signEOTS := "/proto.EOTSManager/SignEOTS"
+	testReq := &proto.SignEOTSRequest{
+		Uid:     []byte("test-uid"),
+		ChainId: []byte("test-chain"),
+		Msg:     []byte("test-message"),
+		Height:  100,
+	}
+	handler := func(_ context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	}
+	call := func(ctx context.Context, interceptor grpc.UnaryServerInterceptor, method string) error {
+		_, err := interceptor(ctx, testReq, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+
+		return err
+	}
+
+	interceptor := service.HMACUnaryServerInterceptor(testKey,
+		service.WithMaxClockSkew(time.Minute),
+		service.WithNonceCacheSize(2),
+	)
+
+	// the same request cannot be replayed
+	ctx := hmacCtx(t, testKey, signEOTS, time.Now(), "nonce-1", testReq)
+	require.NoError(t, call(ctx, interceptor, signEOTS))
+	err := call(ctx, interceptor, signEOTS)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// the request cannot be used for another method
+	ctx = hmacCtx(t, testKey, signEOTS, time.Now(), "nonce-2", testReq)
+	err = call(ctx, interceptor, "/proto.EOTSManager/UnlockKey")
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// requests outside of the clock skew window are rejected
+	ctx = hmacCtx(t, testKey, signEOTS, time.Now().Add(-2*time.Minute), "nonce-3", testReq)
+	err = call(ctx, interceptor, signEOTS)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	ctx = hmacCtx(t, testKey, signEOTS, time.Now().Add(2*time.Minute), "nonce-4", testReq)
+	err = call(ctx, interceptor, signEOTS)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// nonces are not evicted before they expire
+	ctx = hmacCtx(t, testKey, signEOTS, time.Now(), "nonce-5", testReq)
+	require.NoError(t, call(ctx, interceptor, signEOTS))
+	ctx = hmacCtx(t, testKey, signEOTS, time.Now(), "nonce-6", testReq)
+	err = call(ctx, interceptor, signEOTS)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// expired nonces are evicted
+	interceptor = service.HMACUnaryServerInterceptor(testKey,
+		service.WithMaxClockSkew(time.Second),
+		service.WithNonceCacheSize(1),
+	)
+	ctx = hmacCtx(t, testKey, signEOTS, time.Now().Add(-900*time.Millisecond), "nonce-7", testReq)
+	require.NoError(t, call(ctx, interceptor, signEOTS))
+	require.Eventually(t, func() bool {
+		ctx := hmacCtx(t, testKey, signEOTS, time.Now(), "nonce-8", testReq)
+
+		return call(ctx, interceptor, signEOTS) == nil
+	}, 2*time.Second, 50*time.Millisecond)
+}
+
+func TestHMACLegacyCompat(t *testing.T) {
+	t.Parallel()
+	testKey := "test-hmac-key"
+	testReq := &proto.SignEOTSRequest{
+		Uid:     []byte("test-uid"),
+		ChainId: []byte("test-chain"),
+		Msg:     []byte("test-message"),
+		Height:  100,
+	}
+	data, err := protobuf.Marshal(testReq)
+	require.NoError(t, err)
+
+	h := hmac.New(sha256.New, []byte(testKey))
+	h.Write(data)
+	legacyHMAC := base64.StdEncoding.EncodeToString(h.Sum(nil))
+
+	ctx := metadata.NewIncomingContext(t.Context(), metadata.New(map[string]string{
+		client.HMACHeaderKey: legacyHMAC,
+	}))
+	handler := func(_ context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/proto.EOTSManager/SignEOTS"}
+
+	// legacy requests are rejected by default
+	_, err = service.HMACUnaryServerInterceptor(testKey)(ctx, testReq, info, handler)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	interceptor := service.HMACUnaryServerInterceptor(testKey, service.WithLegacyHMAC(true))
+	_, err = interceptor(ctx, testReq, info, handler)
+	require.NoError(t, err)
+
+	// requests with replay protection are still verified
+	_, err = interceptor(hmacCtx(t, testKey, info.FullMethod, time.Now(), "nonce", testReq), testReq, info, handler)
+	require.NoError(t, err)
+	_, err = interceptor(hmacCtx(t, "wrong-key", info.FullMethod, time.Now(), "nonce-2", testReq), testReq, info, handler)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestHMACKeyRotation(t *testing.T) {
+	t.Parallel()
+	signEOTS := "/proto.EOTSManager/SignEOTS"
+	testReq := &proto.SignEOTSRequest{
+		Uid:     []byte("test-uid"),
+		ChainId: []byte("test-chain"),
+		Msg:     []byte("test-message"),
+		Height:  100,
+	}
+	handler := func(_ context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	}
+
+	ring := service.NewHMACKeyRing(&service.HMACKey{ID: service.DefaultHMACKeyID, Secret: "old-key"})
+	interceptor := service.HMACKeyRingUnaryServerInterceptor(ring)
+
+	nonce := 0
+	call := func(key, keyID string) error {
+		nonce++
+		ctx := hmacCtx(t, key, signEOTS, time.Now(), strconv.Itoa(nonce), testReq)
+		if keyID != "" {
+			md, _ := metadata.FromIncomingContext(ctx)
+			md = md.Copy()
+			md.Set(client.HMACKeyIDHeaderKey, keyID)
+			ctx = metadata.NewIncomingContext(t.Context(), md)
+		}
+		_, err := interceptor(ctx, testReq, &grpc.UnaryServerInfo{FullMethod: signEOTS}, handler)
+
+		return err
+	}
+
+	// requests without a key ID use the default key
+	require.NoError(t, call("old-key", ""))
+	require.Equal(t, codes.Unauthenticated, status.Code(call("new-key", "new")))
+
+	// both keys are accepted during the rotation
+	require.NoError(t, ring.Add(&service.HMACKey{ID: "new", Secret: "new-key"}))
+	require.NoError(t, call("new-key", "new"))
+	require.NoError(t, call("old-key", service.DefaultHMACKeyID))
+	require.Equal(t, codes.Unauthenticated, status.Code(call("old-key", "new")))
+
+	// a key is no longer accepted once retired
+	require.NoError(t, ring.Retire(service.DefaultHMACKeyID, time.Now()))
+	require.Equal(t, codes.Unauthenticated, status.Code(call("old-key", "")))
+	require.NoError(t, call("new-key", "new"))
+
+	// nor before its validity window
+	require.NoError(t, ring.Add(&service.HMACKey{ID: "next", Secret: "next-key", NotBefore: time.Now().Add(time.Hour)}))
+	require.Equal(t, codes.Unauthenticated, status.Code(call("next-key", "next")))
+}
+
+func TestHMACAdminKey(t *testing.T) {
+	t.Parallel()
+	addHMACKey := "/proto.EOTSManager/AddHMACKey"
+	listHMACKeys := "/proto.EOTSManager/ListHMACKeys"
+	signEOTS := "/proto.EOTSManager/SignEOTS"
+	testReq := &proto.AddHMACKeyRequest{Id: "new", Key: "new-key"}
+	handler := func(_ context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	}
+
+	ring := service.NewHMACKeyRing(&service.HMACKey{ID: service.DefaultHMACKeyID, Secret: "fpd-key"})
+
+	nonce := 0
+	call := func(interceptor grpc.UnaryServerInterceptor, method, key, keyID string) error {
+		nonce++
+		ctx := hmacCtx(t, key, method, time.Now(), strconv.Itoa(nonce), testReq)
+		if keyID != "" {
+			md, _ := metadata.FromIncomingContext(ctx)
+			md = md.Copy()
+			md.Set(client.HMACKeyIDHeaderKey, keyID)
+			ctx = metadata.NewIncomingContext(t.Context(), md)
+		}
+		_, err := interceptor(ctx, testReq, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+
+		return err
+	}
+
+	// the HMAC keys cannot be managed without the admin key,
+	// even when the requests are not authenticated
+	noAdmin := service.HMACKeyRingUnaryServerInterceptor(ring)
+	require.Equal(t, codes.PermissionDenied, status.Code(call(noAdmin, addHMACKey, "fpd-key", "")))
+	unauthenticated := service.HMACUnaryServerInterceptor("")
+	_, err := unauthenticated(t.Context(), testReq, &grpc.UnaryServerInfo{FullMethod: addHMACKey}, handler)
+	require.Error(t, err)
+
+	interceptor := service.HMACKeyRingUnaryServerInterceptor(ring, service.WithAdminHMACKey("admin-key"))
+	require.Equal(t, codes.PermissionDenied, status.Code(call(interceptor, addHMACKey, "fpd-key", "")))
+	require.NoError(t, call(interceptor, addHMACKey, "admin-key", service.AdminHMACKeyID))
+	require.NoError(t, call(interceptor, listHMACKeys, "admin-key", service.AdminHMACKeyID))
+	require.Equal(t, codes.Unauthenticated, status.Code(call(interceptor, addHMACKey, "fpd-key", service.AdminHMACKeyID)))
+
+	// the admin key cannot sign
+	require.Equal(t, codes.PermissionDenied, status.Code(call(interceptor, signEOTS, "admin-key", service.AdminHMACKeyID)))
+	require.NoError(t, call(interceptor, signEOTS, "fpd-key", ""))
+}
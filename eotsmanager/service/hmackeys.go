@@ -0,0 +1,390 @@
+package service
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/audit"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/store"
+)
+
+// HMACKey is an HMAC key accepted by the server within its validity window
+type HMACKey struct {
+	ID     string
+	Secret string
+	// NotBefore and NotAfter bound the validity of the key.
+	// A zero time leaves the bound open.
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// ActiveAt returns whether the key is accepted at t
+func (k *HMACKey) ActiveAt(t time.Time) bool {
+	if !k.NotBefore.IsZero() && t.Before(k.NotBefore) {
+		return false
+	}
+
+	return k.NotAfter.IsZero() || t.Before(k.NotAfter)
+}
+
+// HMACKeyRing is the set of HMAC keys accepted by the server. Keys can be
+// added and retired at runtime, so that the clients can move to a new key
+// without restarting the server.
+type HMACKeyRing struct {
+	mu   sync.RWMutex
+	keys map[string]*HMACKey
+}
+
+// NewHMACKeyRing creates a key ring with the given keys
+func NewHMACKeyRing(keys ...*HMACKey) *HMACKeyRing {
+	r := &HMACKeyRing{keys: make(map[string]*HMACKey, len(keys))}
+	for _, k := range keys {
+		r.keys[k.ID] = k
+	}
+
+	return r
+}
+
+// IsEmpty returns whether no key was ever added, in which
+// case the requests are not authenticated
+func (r *HMACKeyRing) IsEmpty() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.keys) == 0
+}
+
+func (r *HMACKeyRing) has(id string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.keys[id]
+
+	return ok
+}
+
+// secret returns the secret of the key if it is active at t
+func (r *HMACKeyRing) secret(id string, t time.Time) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	k, ok := r.keys[id]
+	if !ok || !k.ActiveAt(t) {
+		return "", false
+	}
+
+	return k.Secret, true
+}
+
+// Add adds a key, failing if a key with the same ID exists
+func (r *HMACKeyRing) Add(k *HMACKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.keys[k.ID]; ok {
+		return fmt.Errorf("HMAC key %s already exists", k.ID)
+	}
+	r.keys[k.ID] = k
+
+	return nil
+}
+
+// Retire stops accepting the key from the given time
+func (r *HMACKeyRing) Retire(id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k, ok := r.keys[id]
+	if !ok {
+		return fmt.Errorf("HMAC key %s not found", id)
+	}
+
+	retired := *k
+	retired.NotAfter = at
+	r.keys[id] = &retired
+
+	return nil
+}
+
+// Keys returns the keys sorted by ID
+func (r *HMACKeyRing) Keys() []HMACKey {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]HMACKey, 0, len(r.keys))
+	for _, k := range r.keys {
+		keys = append(keys, *k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+
+	return keys
+}
+
+// resolveHMACKeys returns the HMAC key and the admin HMAC key of the config,
+// resolving them if they are secret references
+func resolveHMACKeys(cfg *config.Config) (string, string, error) {
+	hmacKey, err := client.ProcessHMACKey(cfg.HMACKey)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get the HMAC key: %w", err)
+	}
+
+	adminKey, err := client.ProcessHMACKey(cfg.HMACAdminKey)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get the admin HMAC key: %w", err)
+	}
+
+	if adminKey != "" && adminKey == hmacKey {
+		return "", "", fmt.Errorf("the admin HMAC key must differ from the HMAC key")
+	}
+
+	return hmacKey, adminKey, nil
+}
+
+// loadHMACKeyRing returns the key ring of the HMAC key of the config, under
+// DefaultHMACKeyID, and of the keys added at runtime, whose secrets are
+// decrypted with the admin HMAC key. The secrets of the keys which are
+// retired for good are not decrypted, as they are no longer needed.
+func loadHMACKeyRing(configKey, adminKey string, es *store.EOTSStore, now time.Time) (*HMACKeyRing, error) {
+	records, err := es.GetHMACKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	ring := NewHMACKeyRing()
+	if configKey != "" {
+		ring.keys[DefaultHMACKeyID] = &HMACKey{ID: DefaultHMACKeyID, Secret: configKey}
+	}
+
+	for _, rec := range records {
+		k := hmacKeyFromRecord(rec)
+
+		switch {
+		case rec.ID == DefaultHMACKeyID:
+			// the record only retires the key of the config
+			if configKey == "" {
+				continue
+			}
+			k.Secret = configKey
+		case k.NotAfter.IsZero() || now.Before(k.NotAfter):
+			if adminKey == "" {
+				return nil, fmt.Errorf("the HMAC key %s cannot be decrypted without the admin HMAC key", rec.ID)
+			}
+			k.Secret, err = openHMACKey(adminKey, rec.ID, rec.Key)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get HMAC key %s: %w", rec.ID, err)
+			}
+		}
+
+		ring.keys[k.ID] = k
+	}
+
+	return ring, nil
+}
+
+// hmacKeyCipher returns the cipher of the HMAC keys added at runtime, whose
+// key is derived from the admin HMAC key
+func hmacKeyCipher(adminKey string) (cipher.AEAD, error) {
+	mac := hmac.New(sha256.New, []byte(adminKey))
+	mac.Write([]byte("eotsd HMAC key encryption"))
+
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return aead, nil
+}
+
+// sealHMACKey encrypts the secret of the HMAC key, bound to its ID so that
+// it cannot be moved to another key
+func sealHMACKey(adminKey, id, secret string) (string, error) {
+	aead, err := hmacKeyCipher(adminKey)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(secret), []byte(id))), nil
+}
+
+// openHMACKey decrypts the secret of the HMAC key sealed by sealHMACKey
+func openHMACKey(adminKey, id, sealed string) (string, error) {
+	aead, err := hmacKeyCipher(adminKey)
+	if err != nil {
+		return "", err
+	}
+
+	bz, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil || len(bz) < aead.NonceSize() {
+		return "", fmt.Errorf("the HMAC key is not encrypted")
+	}
+
+	secret, err := aead.Open(nil, bz[:aead.NonceSize()], bz[aead.NonceSize():], []byte(id))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt the HMAC key, the admin HMAC key may have changed: %w", err)
+	}
+
+	return string(secret), nil
+}
+
+func hmacKeyFromRecord(rec *store.HMACKeyRecord) *HMACKey {
+	return &HMACKey{
+		ID:        rec.ID,
+		NotBefore: unixTime(rec.NotBefore),
+		NotAfter:  unixTime(rec.NotAfter),
+	}
+}
+
+func unixTime(secs int64) time.Time {
+	if secs == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(secs, 0)
+}
+
+// AddHMACKey adds an HMAC key accepted to authenticate the requests. The key
+// is taken as is, as resolving secret references sent by the clients would let
+// them read the files and run the commands of the server.
+func (r *rpcServer) AddHMACKey(ctx context.Context, req *proto.AddHMACKeyRequest) (_ *proto.AddHMACKeyResponse, err error) {
+	defer func() {
+		r.recordAudit(ctx, &audit.Entry{
+			Method:  "AddHMACKey",
+			Details: fmt.Sprintf("id=%s not_before=%d not_after=%d", req.Id, req.NotBefore, req.NotAfter),
+		}, err)
+	}()
+
+	switch {
+	case req.Id == "":
+		return nil, status.Error(codes.InvalidArgument, "the HMAC key ID is empty")
+	case req.Id == DefaultHMACKeyID, req.Id == AdminHMACKeyID:
+		return nil, status.Errorf(codes.InvalidArgument, "the HMAC key ID %s is reserved for the keys of the config", req.Id)
+	case req.Key == "":
+		return nil, status.Error(codes.InvalidArgument, "the HMAC key is empty")
+	case client.IsSecretReference(req.Key):
+		return nil, status.Error(codes.InvalidArgument, "the HMAC key is a secret reference, which must be resolved by the client")
+	case req.NotBefore != 0 && req.NotAfter != 0 && req.NotAfter <= req.NotBefore:
+		return nil, status.Error(codes.InvalidArgument, "the HMAC key must be valid for some time")
+	}
+
+	if r.hmacAdminKey == "" {
+		return nil, status.Error(codes.FailedPrecondition, "the admin HMAC key is not configured")
+	}
+
+	sealed, err := sealHMACKey(r.hmacAdminKey, req.Id, req.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt HMAC key: %w", err)
+	}
+
+	rec := &store.HMACKeyRecord{
+		ID:        req.Id,
+		Key:       sealed,
+		NotBefore: req.NotBefore,
+		NotAfter:  req.NotAfter,
+	}
+	if err := r.es.AddHMACKey(rec); err != nil {
+		if errors.Is(err, store.ErrDuplicateHMACKey) {
+			return nil, status.Errorf(codes.AlreadyExists, "HMAC key %s already exists", req.Id)
+		}
+
+		return nil, fmt.Errorf("failed to save HMAC key: %w", err)
+	}
+
+	k := hmacKeyFromRecord(rec)
+	k.Secret = req.Key
+	if err := r.hmacKeys.Add(k); err != nil {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+
+	return &proto.AddHMACKeyResponse{}, nil
+}
+
+// RetireHMACKey stops accepting an HMAC key from the given time
+func (r *rpcServer) RetireHMACKey(ctx context.Context, req *proto.RetireHMACKeyRequest) (_ *proto.RetireHMACKeyResponse, err error) {
+	retireAt := req.RetireAt
+	if retireAt == 0 {
+		retireAt = time.Now().Unix()
+	}
+
+	defer func() {
+		r.recordAudit(ctx, &audit.Entry{
+			Method:  "RetireHMACKey",
+			Details: fmt.Sprintf("id=%s retire_at=%d", req.Id, retireAt),
+		}, err)
+	}()
+
+	if !r.hmacKeys.has(req.Id) {
+		return nil, status.Errorf(codes.NotFound, "HMAC key %s not found", req.Id)
+	}
+
+	records, err := r.es.GetHMACKeys()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HMAC keys: %w", err)
+	}
+
+	// the key of the config has no record until it is retired
+	rec := &store.HMACKeyRecord{ID: req.Id}
+	for _, existing := range records {
+		if existing.ID == req.Id {
+			rec = existing
+
+			break
+		}
+	}
+
+	rec.NotAfter = retireAt
+	if err := r.es.SaveHMACKey(rec); err != nil {
+		return nil, fmt.Errorf("failed to save HMAC key: %w", err)
+	}
+
+	if err := r.hmacKeys.Retire(req.Id, unixTime(retireAt)); err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return &proto.RetireHMACKeyResponse{}, nil
+}
+
+// ListHMACKeys returns the HMAC keys, without their secret
+func (r *rpcServer) ListHMACKeys(_ context.Context, _ *proto.ListHMACKeysRequest) (*proto.ListHMACKeysResponse, error) {
+	now := time.Now()
+	keys := r.hmacKeys.Keys()
+
+	res := &proto.ListHMACKeysResponse{Keys: make([]*proto.HMACKeyInfo, 0, len(keys))}
+	for _, k := range keys {
+		info := &proto.HMACKeyInfo{Id: k.ID, Active: k.ActiveAt(now)}
+		if !k.NotBefore.IsZero() {
+			info.NotBefore = k.NotBefore.Unix()
+		}
+		if !k.NotAfter.IsZero() {
+			info.NotAfter = k.NotAfter.Unix()
+		}
+		res.Keys = append(res.Keys, info)
+	}
+
+	return res, nil
+}
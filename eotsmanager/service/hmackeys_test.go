@@ -0,0 +1,100 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/store"
+)
+
+func TestHMACKeysRPC(t *testing.T) {
+	t.Parallel()
+
+	dbBackend, err := config.DefaultDBConfigWithHomePath(t.TempDir()).GetDBBackend()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, dbBackend.Close())
+	}()
+	es, err := store.NewEOTSStore(dbBackend)
+	require.NoError(t, err)
+
+	cfg := config.DefaultConfig()
+	cfg.HMACKey = "config-key"
+	cfg.HMACAdminKey = "admin-key"
+	ring, err := loadHMACKeyRing(cfg.HMACKey, cfg.HMACAdminKey, es, time.Now())
+	require.NoError(t, err)
+
+	r := &rpcServer{cfg: cfg, logger: zap.NewNop(), hmacKeys: ring, hmacAdminKey: cfg.HMACAdminKey, es: es}
+	ctx := t.Context()
+
+	_, err = r.AddHMACKey(ctx, &proto.AddHMACKeyRequest{Id: DefaultHMACKeyID, Key: "key"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = r.AddHMACKey(ctx, &proto.AddHMACKeyRequest{Id: AdminHMACKeyID, Key: "key"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// the secret references of the clients are not resolved
+	for _, ref := range []string{"file:///etc/passwd", "exec://touch /tmp/pwned", "https://vault.vault.azure.net/secrets/hmac"} {
+		_, err = r.AddHMACKey(ctx, &proto.AddHMACKeyRequest{Id: "new", Key: ref})
+		require.Equal(t, codes.InvalidArgument, status.Code(err), ref)
+	}
+
+	_, err = r.AddHMACKey(ctx, &proto.AddHMACKeyRequest{Id: "new", Key: "new-key"})
+	require.NoError(t, err)
+	_, err = r.AddHMACKey(ctx, &proto.AddHMACKeyRequest{Id: "new", Key: "other-key"})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	_, err = r.RetireHMACKey(ctx, &proto.RetireHMACKeyRequest{Id: "unknown"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = r.RetireHMACKey(ctx, &proto.RetireHMACKeyRequest{Id: DefaultHMACKeyID})
+	require.NoError(t, err)
+
+	secret, ok := r.hmacKeys.secret("new", time.Now())
+	require.True(t, ok)
+	require.Equal(t, "new-key", secret)
+	_, ok = r.hmacKeys.secret(DefaultHMACKeyID, time.Now().Add(time.Second))
+	require.False(t, ok)
+
+	res, err := r.ListHMACKeys(ctx, &proto.ListHMACKeysRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.Keys, 2)
+	require.Equal(t, DefaultHMACKeyID, res.Keys[0].Id)
+	require.NotZero(t, res.Keys[0].NotAfter)
+	require.Equal(t, "new", res.Keys[1].Id)
+	require.True(t, res.Keys[1].Active)
+
+	// the secret is stored encrypted
+	records, err := es.GetHMACKeys()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, "new", records[1].ID)
+	require.NotContains(t, records[1].Key, "new-key")
+
+	// the keys survive a restart, but cannot be
+	// decrypted without the same admin key
+	reloaded, err := loadHMACKeyRing(cfg.HMACKey, cfg.HMACAdminKey, es, time.Now())
+	require.NoError(t, err)
+	secret, ok = reloaded.secret("new", time.Now())
+	require.True(t, ok)
+	require.Equal(t, "new-key", secret)
+	_, err = loadHMACKeyRing(cfg.HMACKey, "other-admin-key", es, time.Now())
+	require.Error(t, err)
+	_, err = loadHMACKeyRing(cfg.HMACKey, "", es, time.Now())
+	require.Error(t, err)
+
+	// the retired keys are not decrypted
+	_, err = r.RetireHMACKey(ctx, &proto.RetireHMACKeyRequest{Id: "new"})
+	require.NoError(t, err)
+
+	reloaded, err = loadHMACKeyRing(cfg.HMACKey, "", es, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	require.Len(t, reloaded.Keys(), 2)
+	_, ok = reloaded.secret(DefaultHMACKeyID, time.Now().Add(time.Second))
+	require.False(t, ok)
+}
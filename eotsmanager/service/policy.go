@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"path"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/audit"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/policy"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// PolicyUnaryServerInterceptor creates a gRPC server interceptor that denies
+// the requests which are not allowed by the policy for the identity of the
+// caller. It must run after the HMAC interceptor, which sets the HMAC identity.
+func (r *rpcServer) PolicyUnaryServerInterceptor(p *policy.Policy) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		// NOTE: pings are always allowed for health checks
+		if info.FullMethod == proto.EOTSManager_Ping_FullMethodName {
+			return handler(ctx, req)
+		}
+
+		id := callerIdentity(ctx)
+		policyReq := policyRequest(info.FullMethod, req)
+
+		err := p.Authorize(id, policyReq)
+		if err == nil {
+			return handler(ctx, req)
+		}
+
+		reason := policy.ReasonIdentity
+		var denied *policy.DeniedError
+		if errors.As(err, &denied) {
+			reason = denied.Reason
+		}
+
+		r.metrics.IncrementEotsPolicyDeniedCounter(policyReq.Method, reason)
+		r.logger.Warn("request denied by policy",
+			zap.String("method", policyReq.Method),
+			zap.String("caller", id.String()),
+			zap.String("reason", reason),
+			zap.Error(err),
+		)
+
+		err = status.Error(codes.PermissionDenied, err.Error())
+		r.recordAudit(ctx, &audit.Entry{
+			Method:  policyReq.Method,
+			EotsPk:  policyReq.EotsPk,
+			ChainID: policyReq.ChainID,
+			Heights: policyReq.Heights,
+		}, err)
+
+		return nil, err
+	}
+}
+
+// callerIdentity returns the identity the caller was authenticated with,
+// by the TLS handshake and the HMAC interceptor
+func callerIdentity(ctx context.Context) policy.Identity {
+	id := policy.Identity{HMACKeyID: HMACKeyIDFromContext(ctx)}
+	if p, ok := peer.FromContext(ctx); ok {
+		if cert := verifiedClientCert(p); cert != nil {
+			id.CertFingerprint = util.CertFingerprint(cert.Raw)
+			id.CertName = cert.Subject.CommonName
+		}
+	}
+
+	return id
+}
+
+// policyRequest extracts the fields the policy applies to from the request
+func policyRequest(fullMethod string, req interface{}) *policy.Request {
+	r := &policy.Request{Method: path.Base(fullMethod)}
+
+	switch req := req.(type) {
+	case *proto.CreateRandomnessPairListRequest:
+		r.EotsPk = auditPk(req.Uid)
+		r.ChainID = string(req.ChainId)
+		// the first and the last heights bound the randomness
+		interval := uint64(1)
+		if req.GetInterval() > 0 {
+			interval = req.GetInterval()
+		}
+		r.Heights = []uint64{req.StartHeight}
+		if req.Num > 1 {
+			r.Heights = append(r.Heights, req.StartHeight+uint64(req.Num-1)*interval)
+		}
+	case *proto.SignEOTSRequest:
+		r.EotsPk = auditPk(req.Uid)
+		r.ChainID = string(req.ChainId)
+		r.Heights = []uint64{req.Height}
+	case *proto.SignBatchEOTSRequest:
+		r.EotsPk = auditPk(req.Uid)
+		r.ChainID = string(req.ChainId)
+		for _, signReq := range req.SignRequests {
+			r.Heights = append(r.Heights, signReq.Height)
+		}
+	case *proto.SignSchnorrSigRequest:
+		r.EotsPk = auditPk(req.Uid)
+	case *proto.UnlockKeyRequest:
+		r.EotsPk = auditPk(req.Uid)
+	case *proto.LockKeyRequest:
+		r.EotsPk = auditPk(req.Uid)
+	case *proto.GetThresholdKeyShareInfoRequest:
+		r.EotsPk = auditPk(req.Uid)
+	case *proto.CreatePartialRandomnessListRequest:
+		r.EotsPk = auditPk(req.Uid)
+		r.ChainID = string(req.ChainId)
+		interval := uint64(1)
+		if req.GetInterval() > 0 {
+			interval = req.GetInterval()
+		}
+		r.Heights = []uint64{req.StartHeight}
+		if req.Num > 1 {
+			r.Heights = append(r.Heights, req.StartHeight+uint64(req.Num-1)*interval)
+		}
+	case *proto.GetPartialEOTSNonceRequest:
+		r.EotsPk = auditPk(req.Uid)
+		r.ChainID = string(req.ChainId)
+		r.Heights = []uint64{req.Height}
+	case *proto.SignPartialEOTSRequest:
+		r.EotsPk = auditPk(req.Uid)
+		r.ChainID = string(req.ChainId)
+		r.Heights = []uint64{req.Height}
+	case *proto.CreatePartialSchnorrNonceRequest:
+		r.EotsPk = auditPk(req.Uid)
+	case *proto.SignPartialSchnorrSigRequest:
+		r.EotsPk = auditPk(req.Uid)
+	case *proto.GetSignRecordRequest:
+		r.EotsPk = auditPk(req.Uid)
+		r.ChainID = string(req.ChainId)
+	case *proto.ListSignRecordsRequest:
+		r.EotsPk = auditPk(req.Uid)
+		r.ChainID = string(req.ChainId)
+	case *proto.GetLastSignedHeightRequest:
+		r.EotsPk = auditPk(req.Uid)
+		r.ChainID = string(req.ChainId)
+	case *proto.SaveEOTSKeyNameRequest:
+		// the key is in the compressed or uncompressed format,
+		// the policy uses the BIP-340 x-only format
+		pk, err := btcec.ParsePubKey(req.EotsPk)
+		if err != nil {
+			r.EotsPk = hex.EncodeToString(req.EotsPk)
+
+			break
+		}
+		r.EotsPk = hex.EncodeToString(schnorr.SerializePubKey(pk))
+	}
+
+	return r
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/policy"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+)
+
+func TestPolicyInterceptor(t *testing.T) {
+	t.Parallel()
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	uid := schnorr.SerializePubKey(privKey.PubKey())
+	pk := hex.EncodeToString(uid)
+	p := &policy.Policy{Rules: []policy.Rule{{
+		Name:       "chain-a",
+		HMACKeyIDs: []string{DefaultHMACKeyID},
+		EotsPks:    []string{pk},
+		ChainIDs:   []string{"chain-a"},
+	}}}
+	require.NoError(t, p.Validate())
+
+	r := &rpcServer{
+		cfg:     config.DefaultConfig(),
+		logger:  zap.NewNop(),
+		metrics: metrics.NewEotsMetrics(),
+	}
+	interceptor := r.PolicyUnaryServerInterceptor(p)
+	handler := func(_ context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	}
+	call := func(ctx context.Context, method string, req interface{}) error {
+		_, err := interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+
+		return err
+	}
+
+	hmacCtx := context.WithValue(t.Context(), hmacKeyIDCtxKey{}, DefaultHMACKeyID)
+
+	require.NoError(t, call(hmacCtx, proto.EOTSManager_SignEOTS_FullMethodName,
+		&proto.SignEOTSRequest{Uid: uid, ChainId: []byte("chain-a"), Height: 10}))
+
+	err = call(hmacCtx, proto.EOTSManager_SignEOTS_FullMethodName,
+		&proto.SignEOTSRequest{Uid: uid, ChainId: []byte("chain-b"), Height: 10})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Contains(t, err.Error(), "chain ID chain-b")
+
+	// the key name mapping is not authenticated with HMAC,
+	// so it requires a TLS client certificate
+	err = call(t.Context(), proto.EOTSManager_SaveEOTSKeyName_FullMethodName,
+		&proto.SaveEOTSKeyNameRequest{KeyName: "key", EotsPk: privKey.PubKey().SerializeUncompressed()})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// the key of the mapping is normalized to the BIP-340 format
+	req := policyRequest(proto.EOTSManager_SaveEOTSKeyName_FullMethodName,
+		&proto.SaveEOTSKeyNameRequest{KeyName: "key", EotsPk: privKey.PubKey().SerializeUncompressed()})
+	require.Equal(t, pk, req.EotsPk)
+	req = policyRequest(proto.EOTSManager_SaveEOTSKeyName_FullMethodName,
+		&proto.SaveEOTSKeyNameRequest{KeyName: "key", EotsPk: privKey.PubKey().SerializeCompressed()})
+	require.Equal(t, pk, req.EotsPk)
+
+	require.NoError(t, call(t.Context(), proto.EOTSManager_Ping_FullMethodName, &proto.PingRequest{}))
+
+	// the range of the randomness is bounded by its first and last heights
+	interval := uint64(5)
+	req = policyRequest(proto.EOTSManager_CreateRandomnessPairList_FullMethodName,
+		&proto.CreateRandomnessPairListRequest{Uid: uid, ChainId: []byte("chain-a"), StartHeight: 100, Num: 3, Interval: &interval})
+	require.Equal(t, []uint64{100, 110}, req.Heights)
+	require.Equal(t, pk, req.EotsPk)
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/policy"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/store"
+	"github.com/babylonlabs-io/finality-provider/preflight"
+)
+
+// preflightDBTimeout bounds the time to open the database, which is locked
+// while eotsd runs
+const preflightDBTimeout = 2 * time.Second
+
+// eotsdPreflight holds what the preflight checks of eotsd set up for the
+// checks depending on them
+type eotsdPreflight struct {
+	homePath string
+	cfg      *config.Config
+	keyNames map[string][]byte
+}
+
+// PreflightChecks returns the checks of whether eotsd can start with the
+// config of the home, which run without starting eotsd. eotsd must be
+// stopped, as its database is opened and its listeners are bound.
+func PreflightChecks(homePath string) []preflight.Check {
+	p := &eotsdPreflight{homePath: homePath}
+
+	return []preflight.Check{
+		{Name: "config", Run: p.checkConfig},
+		{Name: "permissions", DependsOn: []string{"config"}, Run: p.checkPermissions},
+		{Name: "listeners", DependsOn: []string{"config"}, Run: p.checkListeners},
+		{Name: "database", DependsOn: []string{"config"}, Run: p.checkDatabase},
+		{Name: "keys", DependsOn: []string{"database"}, Run: p.checkKeys},
+		{Name: "hmac_key", DependsOn: []string{"config"}, Run: p.checkHMACKey},
+		{Name: "tls", DependsOn: []string{"config"}, Run: p.checkTLS},
+		{Name: "policy", DependsOn: []string{"config"}, Run: p.checkPolicy},
+	}
+}
+
+func (p *eotsdPreflight) checkConfig(_ context.Context) error {
+	cfg, err := config.LoadConfig(p.homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load config at %s: %w", p.homePath, err)
+	}
+
+	// configs created before the audit log was introduced do not set its path
+	if cfg.AuditLogPath == "" {
+		cfg.AuditLogPath = config.AuditLogFile(p.homePath)
+	}
+	p.cfg = cfg
+
+	return nil
+}
+
+func (p *eotsdPreflight) checkPermissions(_ context.Context) error {
+	dirs := []string{
+		p.homePath,
+		p.cfg.DatabaseConfig.DBPath,
+		config.LogDir(p.homePath),
+		filepath.Dir(p.cfg.AuditLogPath),
+	}
+	for _, dir := range dirs {
+		if err := preflight.WritableDir(dir); err != nil {
+			return err
+		}
+	}
+
+	return preflight.PrivateDir(keyringDir(p.homePath, p.cfg.KeyringBackend))
+}
+
+func (p *eotsdPreflight) checkListeners(_ context.Context) error {
+	metricsAddr, err := p.cfg.Metrics.Address()
+	if err != nil {
+		return fmt.Errorf("failed to get prometheus address: %w", err)
+	}
+
+	for _, addr := range []string{p.cfg.RPCListener, metricsAddr} {
+		if err := preflight.Bindable(addr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *eotsdPreflight) checkDatabase(_ context.Context) error {
+	dbCfg := *p.cfg.DatabaseConfig
+	dbCfg.DBTimeout = preflightDBTimeout
+	db, err := dbCfg.GetDBBackend()
+	if err != nil {
+		return fmt.Errorf("failed to open the database, which is locked while eotsd runs: %w", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	es, err := store.NewEOTSStore(db)
+	if err != nil {
+		return fmt.Errorf("failed to open the EOTS store: %w", err)
+	}
+
+	keyNames, err := es.GetAllEOTSKeyNames()
+	if err != nil {
+		return fmt.Errorf("failed to get all EOTS key names: %w", err)
+	}
+	if _, err := es.GetHMACKeys(); err != nil {
+		return fmt.Errorf("failed to get the HMAC keys: %w", err)
+	}
+	p.keyNames = keyNames
+
+	return nil
+}
+
+// checkKeys checks that the keys of the database and the keys which must be
+// ready are in the keyring. The keys of the file keyring cannot be read
+// without their passphrase, so only their records are looked for.
+func (p *eotsdPreflight) checkKeys(_ context.Context) error {
+	kr, err := eotsmanager.InitKeyring(p.homePath, p.cfg.KeyringBackend, strings.NewReader(""))
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(p.keyNames)+len(p.cfg.ReadyKeys))
+	for name := range p.keyNames {
+		names = append(names, name)
+	}
+	names = append(names, p.cfg.ReadyKeys...)
+
+	var missing []string
+	for _, name := range names {
+		if !p.keyringHasKey(kr, name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the keys %s do not exist in the %s keyring", strings.Join(missing, ", "), p.cfg.KeyringBackend)
+	}
+
+	return nil
+}
+
+func (p *eotsdPreflight) keyringHasKey(kr keyring.Keyring, name string) bool {
+	if p.cfg.KeyringBackend != keyring.BackendFile {
+		_, err := kr.Key(name)
+
+		return err == nil
+	}
+
+	_, err := os.Stat(filepath.Join(keyringDir(p.homePath, p.cfg.KeyringBackend), name+".info"))
+
+	return err == nil
+}
+
+func (p *eotsdPreflight) checkHMACKey(_ context.Context) error {
+	_, _, err := resolveHMACKeys(p.cfg)
+
+	return err
+}
+
+func (p *eotsdPreflight) checkTLS(_ context.Context) error {
+	if !p.cfg.TLS.IsEnabled() {
+		return nil
+	}
+
+	_, err := p.cfg.TLS.ServerTLSConfig()
+
+	return err
+}
+
+func (p *eotsdPreflight) checkPolicy(_ context.Context) error {
+	if p.cfg.PolicyFile == "" {
+		return nil
+	}
+
+	_, err := policy.Load(p.cfg.PolicyFile)
+
+	return err
+}
+
+// keyringDir returns the directory of the keyring of the backend in the home
+func keyringDir(homePath, backend string) string {
+	return filepath.Join(homePath, "keyring-"+backend)
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+
+	"github.com/babylonlabs-io/finality-provider/metrics"
+)
+
+// readinessChecks returns the checks of whether eotsd can sign, which are
+// served at /readyz of the metrics server
+func (s *Server) readinessChecks() []metrics.ReadinessCheck {
+	checks := []metrics.ReadinessCheck{
+		{Name: "database", Check: s.checkDBOpen},
+	}
+	for _, keyName := range s.cfg.ReadyKeys {
+		checks = append(checks, metrics.ReadinessCheck{
+			Name: "key_" + keyName,
+			Check: func(_ context.Context) error {
+				return s.rpcServer.em.CheckKeyReady(keyName)
+			},
+		})
+	}
+
+	return checks
+}
+
+// checkDBOpen returns an error if the database cannot be read, e.g., as it
+// is closed
+func (s *Server) checkDBOpen(_ context.Context) error {
+	if err := kvdb.View(s.db, func(_ kvdb.RTx) error { return nil }, func() {}); err != nil {
+		return fmt.Errorf("failed to read the database: %w", err)
+	}
+
+	return nil
+}
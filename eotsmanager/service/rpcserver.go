@@ -7,13 +7,17 @@ import (
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/gogo/status"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/audit"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/store"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/types"
+	"github.com/babylonlabs-io/finality-provider/metrics"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
 
@@ -22,18 +26,34 @@ import (
 type rpcServer struct {
 	proto.UnimplementedEOTSManagerServer
 
-	em  *eotsmanager.LocalEOTSManager
-	cfg *config.Config
+	em      *eotsmanager.LocalEOTSManager
+	cfg     *config.Config
+	logger  *zap.Logger
+	metrics *metrics.EotsMetrics
+
+	// auditLog is nil if the audit log is disabled
+	auditLog *audit.Logger
+
+	// hmacKeys are the HMAC keys accepted by the server, and es
+	// persists the keys added and retired at runtime, encrypted
+	// with hmacAdminKey
+	hmacKeys     *HMACKeyRing
+	hmacAdminKey string
+	es           *store.EOTSStore
 }
 
 // newRPCServer creates a new RPC sever from the set of input dependencies.
 func newRPCServer(
 	em *eotsmanager.LocalEOTSManager,
 	cfg *config.Config,
+	logger *zap.Logger,
 ) *rpcServer {
 	return &rpcServer{
-		em:  em,
-		cfg: cfg,
+		em:       em,
+		cfg:      cfg,
+		logger:   logger,
+		metrics:  metrics.NewEotsMetrics(),
+		hmacKeys: NewHMACKeyRing(),
 	}
 }
 
@@ -51,8 +71,18 @@ func (r *rpcServer) Ping(_ context.Context, _ *proto.PingRequest) (*proto.PingRe
 }
 
 // CreateRandomnessPairList returns a list of Schnorr randomness pairs
-func (r *rpcServer) CreateRandomnessPairList(_ context.Context, req *proto.CreateRandomnessPairListRequest) (
-	*proto.CreateRandomnessPairListResponse, error) {
+func (r *rpcServer) CreateRandomnessPairList(ctx context.Context, req *proto.CreateRandomnessPairListRequest) (
+	_ *proto.CreateRandomnessPairListResponse, err error) {
+	defer func() {
+		r.recordAudit(ctx, &audit.Entry{
+			Method:      "CreateRandomnessPairList",
+			EotsPk:      auditPk(req.Uid),
+			ChainID:     string(req.ChainId),
+			StartHeight: req.StartHeight,
+			Num:         req.Num,
+		}, err)
+	}()
+
 	var options []eotsmanager.RandomnessOption
 	if req.Interval != nil && *req.Interval > 0 {
 		options = append(options, eotsmanager.WithInterval(*req.Interval))
@@ -75,15 +105,28 @@ func (r *rpcServer) CreateRandomnessPairList(_ context.Context, req *proto.Creat
 }
 
 // SignEOTS signs an EOTS with the EOTS private key and the relevant randomness
-func (r *rpcServer) SignEOTS(_ context.Context, req *proto.SignEOTSRequest) (
-	*proto.SignEOTSResponse, error) {
-	sig, err := r.em.SignEOTS(req.Uid, req.ChainId, req.Msg, req.Height)
-	if err != nil {
-		if errors.Is(err, types.ErrDoubleSign) {
-			return nil, status.Error(codes.FailedPrecondition, err.Error()) //nolint:wrapcheck
+func (r *rpcServer) SignEOTS(ctx context.Context, req *proto.SignEOTSRequest) (
+	res *proto.SignEOTSResponse, err error) {
+	entry := &audit.Entry{
+		Method:    "SignEOTS",
+		EotsPk:    auditPk(req.Uid),
+		ChainID:   string(req.ChainId),
+		Heights:   []uint64{req.Height},
+		MsgHashes: []string{audit.MsgHash(req.Msg)},
+	}
+	defer func() {
+		if auditErr := r.endSigningAudit(ctx, entry, err); auditErr != nil {
+			res, err = nil, auditErr
 		}
+	}()
 
-		return nil, fmt.Errorf("failed to sign EOTS: %w", err)
+	if err := r.beginSigningAudit(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	sig, err := r.em.SignEOTS(req.Uid, req.ChainId, req.Msg, req.Height)
+	if err != nil {
+		return nil, signError("failed to sign EOTS", err)
 	}
 
 	sigBytes := sig.Bytes()
@@ -92,13 +135,30 @@ func (r *rpcServer) SignEOTS(_ context.Context, req *proto.SignEOTSRequest) (
 }
 
 // UnsafeSignEOTS only used for testing purposes. Doesn't offer slashing protection!
-func (r *rpcServer) UnsafeSignEOTS(_ context.Context, req *proto.SignEOTSRequest) (
-	*proto.SignEOTSResponse, error) {
+func (r *rpcServer) UnsafeSignEOTS(ctx context.Context, req *proto.SignEOTSRequest) (
+	res *proto.SignEOTSResponse, err error) {
+	entry := &audit.Entry{
+		Method:    "UnsafeSignEOTS",
+		EotsPk:    auditPk(req.Uid),
+		ChainID:   string(req.ChainId),
+		Heights:   []uint64{req.Height},
+		MsgHashes: []string{audit.MsgHash(req.Msg)},
+	}
+	defer func() {
+		if auditErr := r.endSigningAudit(ctx, entry, err); auditErr != nil {
+			res, err = nil, auditErr
+		}
+	}()
+
 	if r.cfg.IsUnsafeEndpointsDisabled() {
 		return nil, status.Error(codes.PermissionDenied, //nolint:wrapcheck
 			"UnsafeSignEOTS endpoint is disabled in configuration for security reasons")
 	}
 
+	if err := r.beginSigningAudit(ctx, entry); err != nil {
+		return nil, err
+	}
+
 	sig, err := r.em.UnsafeSignEOTS(req.Uid, req.ChainId, req.Msg, req.Height)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign EOTS: %w", err)
@@ -110,8 +170,23 @@ func (r *rpcServer) UnsafeSignEOTS(_ context.Context, req *proto.SignEOTSRequest
 }
 
 // SignSchnorrSig signs a Schnorr sig with the EOTS private key
-func (r *rpcServer) SignSchnorrSig(_ context.Context, req *proto.SignSchnorrSigRequest) (
-	*proto.SignSchnorrSigResponse, error) {
+func (r *rpcServer) SignSchnorrSig(ctx context.Context, req *proto.SignSchnorrSigRequest) (
+	res *proto.SignSchnorrSigResponse, err error) {
+	entry := &audit.Entry{
+		Method:    "SignSchnorrSig",
+		EotsPk:    auditPk(req.Uid),
+		MsgHashes: []string{audit.MsgHash(req.Msg)},
+	}
+	defer func() {
+		if auditErr := r.endSigningAudit(ctx, entry, err); auditErr != nil {
+			res, err = nil, auditErr
+		}
+	}()
+
+	if err := r.beginSigningAudit(ctx, entry); err != nil {
+		return nil, err
+	}
+
 	sig, err := r.em.SignSchnorrSig(req.Uid, req.Msg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign EOTS: %w", err)
@@ -121,13 +196,28 @@ func (r *rpcServer) SignSchnorrSig(_ context.Context, req *proto.SignSchnorrSigR
 }
 
 // SignBatchEOTS signs multiple EOTS in batch
-func (r *rpcServer) SignBatchEOTS(_ context.Context, req *proto.SignBatchEOTSRequest) (
-	*proto.SignBatchEOTSResponse, error) {
+func (r *rpcServer) SignBatchEOTS(ctx context.Context, req *proto.SignBatchEOTSRequest) (
+	res *proto.SignBatchEOTSResponse, err error) {
 	// Validate no duplicate heights before forwarding to manager (defense-in-depth)
 	heights := make([]uint64, len(req.SignRequests))
+	msgHashes := make([]string, len(req.SignRequests))
 	for i, signReq := range req.SignRequests {
 		heights[i] = signReq.Height
+		msgHashes[i] = audit.MsgHash(signReq.Msg)
+	}
+
+	entry := &audit.Entry{
+		Method:    "SignBatchEOTS",
+		EotsPk:    auditPk(req.Uid),
+		ChainID:   string(req.ChainId),
+		Heights:   heights,
+		MsgHashes: msgHashes,
 	}
+	defer func() {
+		if auditErr := r.endSigningAudit(ctx, entry, err); auditErr != nil {
+			res, err = nil, auditErr
+		}
+	}()
 
 	if err := util.ValidateNoDuplicateHeights(heights); err != nil {
 		return nil, status.Error(codes.InvalidArgument, //nolint:wrapcheck
@@ -148,13 +238,13 @@ func (r *rpcServer) SignBatchEOTS(_ context.Context, req *proto.SignBatchEOTSReq
 		SignRequest: signRequests,
 	}
 
-	responses, err := r.em.SignBatchEOTS(batchReq)
-	if err != nil {
-		if errors.Is(err, types.ErrDoubleSign) {
-			return nil, status.Error(codes.FailedPrecondition, err.Error()) //nolint:wrapcheck
-		}
+	if err := r.beginSigningAudit(ctx, entry); err != nil {
+		return nil, err
+	}
 
-		return nil, fmt.Errorf("failed to sign batch EOTS: %w", err)
+	responses, err := r.em.SignBatchEOTS(ctx, batchReq)
+	if err != nil {
+		return nil, signError("failed to sign batch EOTS", err)
 	}
 
 	protoResponses := make([]*proto.SignDataResponse, len(responses))
@@ -185,8 +275,16 @@ func (r *rpcServer) SaveEOTSKeyName(
 	return &proto.SaveEOTSKeyNameResponse{}, nil
 }
 
-func (r *rpcServer) Backup(_ context.Context, req *proto.BackupRequest) (*proto.BackupResponse, error) {
-	backupName, err := r.em.Backup(req.DbPath, req.BackupDir)
+func (r *rpcServer) Backup(ctx context.Context, req *proto.BackupRequest) (_ *proto.BackupResponse, err error) {
+	var backupName string
+	defer func() {
+		r.recordAudit(ctx, &audit.Entry{
+			Method:  "Backup",
+			Details: fmt.Sprintf("db_path=%s backup_dir=%s backup_name=%s", req.DbPath, req.BackupDir, backupName),
+		}, err)
+	}()
+
+	backupName, err = r.em.Backup(req.DbPath, req.BackupDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to backup: %w", err)
 	}
@@ -196,11 +294,68 @@ func (r *rpcServer) Backup(_ context.Context, req *proto.BackupRequest) (*proto.
 	}, nil
 }
 
-func (r *rpcServer) UnlockKey(_ context.Context, req *proto.UnlockKeyRequest) (*proto.UnlockKeyResponse, error) {
-	err := r.em.Unlock(req.Uid, req.Passphrase)
+func (r *rpcServer) UnlockKey(ctx context.Context, req *proto.UnlockKeyRequest) (_ *proto.UnlockKeyResponse, err error) {
+	defer func() {
+		r.recordAudit(ctx, &audit.Entry{
+			Method: "UnlockKey",
+			EotsPk: auditPk(req.Uid),
+		}, err)
+	}()
+
+	err = r.em.Unlock(req.Uid, req.Passphrase)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unlock key: %w", err)
 	}
 
 	return &proto.UnlockKeyResponse{}, nil
 }
+
+func (r *rpcServer) LockKey(ctx context.Context, req *proto.LockKeyRequest) (_ *proto.LockKeyResponse, err error) {
+	defer func() {
+		r.recordAudit(ctx, &audit.Entry{
+			Method: "LockKey",
+			EotsPk: auditPk(req.Uid),
+		}, err)
+	}()
+
+	if err := r.em.LockKey(req.Uid); err != nil {
+		return nil, fmt.Errorf("failed to lock key: %w", err)
+	}
+
+	return &proto.LockKeyResponse{}, nil
+}
+
+func (r *rpcServer) ListUnlockedKeys(_ context.Context, _ *proto.ListUnlockedKeysRequest) (*proto.ListUnlockedKeysResponse, error) {
+	keys := r.em.ListUnlockedKeys()
+
+	res := &proto.ListUnlockedKeysResponse{Keys: make([]*proto.UnlockedKey, 0, len(keys))}
+	for _, k := range keys {
+		unlocked := &proto.UnlockedKey{
+			Uid:        k.EotsPk,
+			KeyName:    k.KeyName,
+			UnlockedAt: k.UnlockedAt.Unix(),
+			LastUsedAt: k.LastUsedAt.Unix(),
+		}
+		if !k.ExpiresAt.IsZero() {
+			unlocked.ExpiresAt = k.ExpiresAt.Unix()
+		}
+		res.Keys = append(res.Keys, unlocked)
+	}
+
+	return res, nil
+}
+
+// signError maps the refusals to sign to gRPC status codes, so that the
+// clients can tell them from failures
+func signError(msg string, err error) error {
+	switch {
+	case errors.Is(err, types.ErrDoubleSign):
+		return status.Error(codes.FailedPrecondition, err.Error()) //nolint:wrapcheck
+	case errors.Is(err, types.ErrSigningRateLimited):
+		return status.Error(codes.ResourceExhausted, err.Error()) //nolint:wrapcheck
+	case errors.Is(err, types.ErrSigningHeightOutOfRange):
+		return status.Error(codes.OutOfRange, err.Error()) //nolint:wrapcheck
+	}
+
+	return fmt.Errorf("%s: %w", msg, err)
+}
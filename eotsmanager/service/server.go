@@ -6,15 +6,21 @@ import (
 	"net"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/babylonlabs-io/finality-provider/metrics"
 
 	"github.com/lightningnetwork/lnd/kvdb"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/audit"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/policy"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/store"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 )
 
 // Server is the main daemon construct for the EOTS manager server. It handles
@@ -37,7 +43,7 @@ func NewEOTSManagerServer(cfg *config.Config, l *zap.Logger, em *eotsmanager.Loc
 	return &Server{
 		cfg:       cfg,
 		logger:    l,
-		rpcServer: newRPCServer(em, cfg),
+		rpcServer: newRPCServer(em, cfg, l),
 		db:        db,
 		quit:      make(chan struct{}, 1),
 	}
@@ -55,13 +61,16 @@ func (s *Server) RunUntilShutdown(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to get prometheus address: %w", err)
 	}
-	metricsServer := metrics.Start(promAddr, s.logger)
+	metricsServer := metrics.Start(promAddr, s.logger, s.readinessChecks()...)
 
 	defer func() {
 		s.logger.Info("Shutdown complete")
 	}()
 
 	defer func() {
+		// zero the private keys of the file keyring held in memory
+		s.rpcServer.em.Stop()
+
 		s.logger.Info("Closing database...")
 		if err := s.db.Close(); err != nil {
 			s.logger.Error("Failed to close database", zap.Error(err))
@@ -72,6 +81,23 @@ func (s *Server) RunUntilShutdown(ctx context.Context) error {
 		s.logger.Info("Metrics server stopped")
 	}()
 
+	if s.cfg.AuditLogPath != "" {
+		auditLog, err := audit.Open(s.cfg.AuditLogPath)
+		if err != nil {
+			return fmt.Errorf("failed to open audit log: %w", err)
+		}
+		defer func() {
+			if err := auditLog.Close(); err != nil {
+				s.logger.Error("Failed to close audit log", zap.Error(err))
+			}
+		}()
+
+		s.rpcServer.auditLog = auditLog
+		s.logger.Info("Audit log enabled", zap.String("path", s.cfg.AuditLogPath))
+	} else {
+		s.logger.Warn("Audit log path not configured. Requests will not be audited.")
+	}
+
 	listenAddr := s.cfg.RPCListener
 	// we create listeners from the RPCListeners defined
 	// in the config.
@@ -83,20 +109,88 @@ func (s *Server) RunUntilShutdown(ctx context.Context) error {
 		_ = lis.Close()
 	}()
 
-	// Get HMAC key from config
-	hmacKey := s.cfg.HMACKey
-	if hmacKey == "" {
-		s.logger.Warn("HMAC key not configured in config. Authentication will not be enabled.")
+	// Get HMAC keys from config, resolving them if they are secret references
+	hmacKey, adminKey, err := resolveHMACKeys(s.cfg)
+	if err != nil {
+		return err
+	}
+
+	// the keys added at runtime are persisted along with the EOTS keys
+	es, err := store.NewEOTSStore(s.db)
+	if err != nil {
+		return fmt.Errorf("failed to open the EOTS store: %w", err)
+	}
+	hmacKeys, err := loadHMACKeyRing(hmacKey, adminKey, es, time.Now())
+	if err != nil {
+		return err
+	}
+	s.rpcServer.hmacKeys = hmacKeys
+	s.rpcServer.hmacAdminKey = adminKey
+	s.rpcServer.es = es
+
+	var (
+		opts         []grpc.ServerOption
+		interceptors []grpc.UnaryServerInterceptor
+	)
+	if s.cfg.TLS.IsEnabled() {
+		tlsCfg, err := s.cfg.TLS.ServerTLSConfig()
+		if err != nil {
+			return err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+
+		if s.cfg.TLS.IsMutual() {
+			s.logger.Info("Mutual TLS enabled for gRPC server",
+				zap.Int("pinned_client_certs", len(s.cfg.TLS.PinnedClientCerts)))
+		} else {
+			s.logger.Info("TLS enabled for gRPC server. Clients are not authenticated with a certificate.")
+		}
+	} else {
+		s.logger.Warn("TLS not enabled for gRPC server. The signing requests are not encrypted.")
 	}
 
-	var opts []grpc.ServerOption
-	if hmacKey != "" {
-		s.logger.Info("HMAC authentication enabled for gRPC server")
-		opts = append(opts, grpc.UnaryInterceptor(HMACUnaryServerInterceptor(hmacKey)))
+	// the interceptor is installed even without any key,
+	// as keys can be added at runtime
+	interceptors = append(interceptors, HMACKeyRingUnaryServerInterceptor(hmacKeys,
+		WithMaxClockSkew(s.cfg.HMACMaxClockSkew),
+		WithNonceCacheSize(s.cfg.HMACNonceCacheSize),
+		WithLegacyHMAC(s.cfg.HMACLegacyCompat),
+		WithAdminHMACKey(adminKey),
+	))
+	if !hmacKeys.IsEmpty() {
+		s.logger.Info("HMAC authentication enabled for gRPC server",
+			zap.Int("keys", len(hmacKeys.Keys())))
+		if s.cfg.HMACLegacyCompat {
+			s.logger.Warn("HMAC legacy compatibility enabled. Requests without replay protection are accepted, " +
+				"disable it once all the fpd instances are upgraded.")
+		}
 	} else {
 		s.logger.Warn("HMAC authentication not enabled. This is insecure.")
 	}
 
+	// the policy runs after the HMAC interceptor,
+	// which sets the HMAC identity of the caller
+	if s.cfg.PolicyFile != "" {
+		p, err := policy.Load(s.cfg.PolicyFile)
+		if err != nil {
+			return err
+		}
+		if hmacKeys.IsEmpty() && !s.cfg.TLS.IsMutual() {
+			s.logger.Warn("Policy enabled without HMAC nor mutual TLS. All the requests will be denied, " +
+				"as the clients cannot be identified.")
+		}
+		interceptors = append(interceptors, s.rpcServer.PolicyUnaryServerInterceptor(p))
+		s.logger.Info("Authorization policy enabled",
+			zap.String("path", s.cfg.PolicyFile),
+			zap.Int("rules", len(p.Rules)))
+	}
+	if len(interceptors) > 0 {
+		opts = append(opts, grpc.ChainUnaryInterceptor(interceptors...))
+	}
+	if s.cfg.Tracing.IsEnabled() {
+		opts = append(opts, tracing.ServerOption())
+	}
+
 	if s.cfg.GRPCMaxContentLength > 0 {
 		s.logger.Info("Setting max content length for gRPC server",
 			zap.Int("max_content_length", s.cfg.GRPCMaxContentLength))
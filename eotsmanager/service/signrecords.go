@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/store"
+)
+
+const (
+	defaultListSignRecordsLimit = 100
+	maxListSignRecordsLimit     = 1000
+)
+
+// GetSignRecord returns the sign record of an EOTS key for a chain at a height
+func (r *rpcServer) GetSignRecord(_ context.Context, req *proto.GetSignRecordRequest) (*proto.GetSignRecordResponse, error) {
+	record, found, err := r.em.SignRecord(req.Uid, req.ChainId, req.Height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sign record: %w", err)
+	}
+	if !found {
+		return &proto.GetSignRecordResponse{}, nil
+	}
+
+	return &proto.GetSignRecordResponse{
+		Found:  true,
+		Record: signRecordToProto(req.Height, record),
+	}, nil
+}
+
+// ListSignRecords returns the sign records of an EOTS key for a chain in a
+// range of heights
+func (r *rpcServer) ListSignRecords(_ context.Context, req *proto.ListSignRecordsRequest) (*proto.ListSignRecordsResponse, error) {
+	if req.EndHeight != 0 && req.EndHeight < req.StartHeight {
+		return nil, status.Errorf(codes.InvalidArgument, "end height %d is lower than start height %d", req.EndHeight, req.StartHeight)
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultListSignRecordsLimit
+	}
+	if limit > maxListSignRecordsLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit %d exceeds the maximum %d", limit, maxListSignRecordsLimit)
+	}
+
+	records, hasMore, err := r.em.ListSignRecords(req.Uid, req.ChainId, req.StartHeight, req.EndHeight, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sign records: %w", err)
+	}
+
+	res := &proto.ListSignRecordsResponse{
+		Records: make([]*proto.SignRecord, 0, len(records)),
+		HasMore: hasMore,
+	}
+	for i := range records {
+		res.Records = append(res.Records, signRecordToProto(records[i].Height, &records[i].SigningRecord))
+	}
+	if hasMore {
+		res.NextHeight = records[len(records)-1].Height + 1
+	}
+
+	return res, nil
+}
+
+// GetLastSignedHeight returns the highest height signed with an EOTS key for
+// a chain
+func (r *rpcServer) GetLastSignedHeight(_ context.Context, req *proto.GetLastSignedHeightRequest) (*proto.GetLastSignedHeightResponse, error) {
+	height, found, err := r.em.LastSignedHeight(req.Uid, req.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last signed height: %w", err)
+	}
+
+	return &proto.GetLastSignedHeightResponse{Found: found, Height: height}, nil
+}
+
+func signRecordToProto(height uint64, record *store.SigningRecord) *proto.SignRecord {
+	return &proto.SignRecord{
+		Height:    height,
+		Msg:       record.Msg,
+		Sig:       record.Signature,
+		Timestamp: record.Timestamp,
+	}
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/audit"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+)
+
+// GetThresholdKeyShareInfo returns the public information of the share of a
+// threshold EOTS key
+func (r *rpcServer) GetThresholdKeyShareInfo(_ context.Context, req *proto.GetThresholdKeyShareInfoRequest) (*proto.GetThresholdKeyShareInfoResponse, error) {
+	share, err := r.em.ThresholdKeyShare(req.Uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get threshold key share: %w", err)
+	}
+	defer share.Zero()
+
+	res := &proto.GetThresholdKeyShareInfoResponse{
+		Index:     share.Index,
+		Threshold: share.Threshold,
+		Parties:   share.Parties,
+	}
+	for _, vs := range share.VerificationShares {
+		res.VerificationShares = append(res.VerificationShares, vs.SerializeCompressed())
+	}
+
+	return res, nil
+}
+
+// CreatePartialRandomnessList returns the public nonces of the share of a
+// threshold EOTS key
+func (r *rpcServer) CreatePartialRandomnessList(ctx context.Context, req *proto.CreatePartialRandomnessListRequest) (
+	_ *proto.CreatePartialRandomnessListResponse, err error) {
+	defer func() {
+		r.recordAudit(ctx, &audit.Entry{
+			Method:      "CreatePartialRandomnessList",
+			EotsPk:      auditPk(req.Uid),
+			ChainID:     string(req.ChainId),
+			StartHeight: req.StartHeight,
+			Num:         req.Num,
+		}, err)
+	}()
+
+	var options []eotsmanager.RandomnessOption
+	if req.Interval != nil && *req.Interval > 0 {
+		options = append(options, eotsmanager.WithInterval(*req.Interval))
+	}
+
+	nonces, err := r.em.CreatePartialRandomnessList(req.Uid, req.ChainId, req.StartHeight, req.Num, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create partial randomness list: %w", err)
+	}
+
+	res := &proto.CreatePartialRandomnessListResponse{PubNonces: make([][]byte, 0, len(nonces))}
+	for _, n := range nonces {
+		res.PubNonces = append(res.PubNonces, n.SerializeCompressed())
+	}
+
+	return res, nil
+}
+
+// GetPartialEOTSNonce returns the public nonce of the share of a threshold
+// EOTS key at a height
+func (r *rpcServer) GetPartialEOTSNonce(_ context.Context, req *proto.GetPartialEOTSNonceRequest) (*proto.GetPartialEOTSNonceResponse, error) {
+	nonce, err := r.em.PartialEOTSNonce(req.Uid, req.ChainId, req.Height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get partial EOTS nonce: %w", err)
+	}
+
+	return &proto.GetPartialEOTSNonceResponse{PubNonce: nonce.SerializeCompressed()}, nil
+}
+
+// SignPartialEOTS signs a partial EOTS with the share of a threshold EOTS key
+func (r *rpcServer) SignPartialEOTS(ctx context.Context, req *proto.SignPartialEOTSRequest) (
+	res *proto.SignPartialEOTSResponse, err error) {
+	entry := &audit.Entry{
+		Method:    "SignPartialEOTS",
+		EotsPk:    auditPk(req.Uid),
+		ChainID:   string(req.ChainId),
+		Heights:   []uint64{req.Height},
+		MsgHashes: []string{audit.MsgHash(req.Msg)},
+	}
+	defer func() {
+		if auditErr := r.endSigningAudit(ctx, entry, err); auditErr != nil {
+			res, err = nil, auditErr
+		}
+	}()
+
+	groupNonce, err := btcec.ParsePubKey(req.GroupPubNonce)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid group public nonce: %v", err)
+	}
+
+	if err := r.beginSigningAudit(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	sig, err := r.em.SignPartialEOTS(req.Uid, req.ChainId, req.Msg, req.Height, groupNonce)
+	if err != nil {
+		return nil, signError("failed to sign partial EOTS", err)
+	}
+
+	sigBytes := sig.Bytes()
+
+	return &proto.SignPartialEOTSResponse{Sig: sigBytes[:]}, nil
+}
+
+// CreatePartialSchnorrNonce returns the public nonce of the share of a
+// threshold EOTS key for the Schnorr signature of a message
+func (r *rpcServer) CreatePartialSchnorrNonce(_ context.Context, req *proto.CreatePartialSchnorrNonceRequest) (*proto.CreatePartialSchnorrNonceResponse, error) {
+	nonce, err := r.em.PartialSchnorrNonce(req.Uid, req.Msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create partial schnorr nonce: %w", err)
+	}
+
+	return &proto.CreatePartialSchnorrNonceResponse{PubNonce: nonce.SerializeCompressed()}, nil
+}
+
+// SignPartialSchnorrSig signs a partial Schnorr sig with the share of a
+// threshold EOTS key
+func (r *rpcServer) SignPartialSchnorrSig(ctx context.Context, req *proto.SignPartialSchnorrSigRequest) (
+	res *proto.SignPartialSchnorrSigResponse, err error) {
+	entry := &audit.Entry{
+		Method:    "SignPartialSchnorrSig",
+		EotsPk:    auditPk(req.Uid),
+		MsgHashes: []string{audit.MsgHash(req.Msg)},
+	}
+	defer func() {
+		if auditErr := r.endSigningAudit(ctx, entry, err); auditErr != nil {
+			res, err = nil, auditErr
+		}
+	}()
+
+	groupNonce, err := btcec.ParsePubKey(req.GroupPubNonce)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid group public nonce: %v", err)
+	}
+
+	if err := r.beginSigningAudit(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	sig, err := r.em.SignPartialSchnorrSig(req.Uid, req.Msg, groupNonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign partial schnorr sig: %w", err)
+	}
+
+	sigBytes := sig.Bytes()
+
+	return &proto.SignPartialSchnorrSigResponse{Sig: sigBytes[:]}, nil
+}
@@ -0,0 +1,212 @@
+package eotsmanager
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	eotstypes "github.com/babylonlabs-io/finality-provider/eotsmanager/types"
+)
+
+// WithSignRateLimit limits the new EOTS signatures of each key to perSecond,
+// in bursts of at most burst signatures, including the signatures of a batch.
+// A batch larger than the burst is charged the burst, so that it is not
+// rejected forever. The burst defaults to the rate per second. Zero disables
+// the limit.
+func WithSignRateLimit(perSecond float64, burst int) LocalEOTSManagerOption {
+	return func(lm *LocalEOTSManager) {
+		if burst <= 0 {
+			burst = int(math.Max(1, math.Ceil(perSecond)))
+		}
+		lm.guard.rateLimit = rate.Limit(perSecond)
+		lm.guard.burst = burst
+	}
+}
+
+// WithMaxHeightJump refuses to sign a height more than maxJump above the last
+// height signed with the key for the chain. Zero disables the limit.
+func WithMaxHeightJump(maxJump uint64) LocalEOTSManagerOption {
+	return func(lm *LocalEOTSManager) {
+		lm.guard.maxHeightJump = maxJump
+	}
+}
+
+// WithMaxHeightBeyondRandomness refuses to sign a height more than
+// maxDistance above the last height of the randomness generated with the key
+// for the chain. Zero disables the limit.
+func WithMaxHeightBeyondRandomness(maxDistance uint64) LocalEOTSManagerOption {
+	return func(lm *LocalEOTSManager) {
+		lm.guard.maxHeightBeyondRandomness = maxDistance
+	}
+}
+
+// signingGuard holds the limits on the EOTS signing requests, so that a
+// faulty or compromised client cannot sign far ahead of the chain
+type signingGuard struct {
+	rateLimit                 rate.Limit
+	burst                     int
+	maxHeightJump             uint64
+	maxHeightBeyondRandomness uint64
+
+	mu sync.Mutex
+	// limiters are the signature rate limiters of the keys
+	limiters map[string]*rate.Limiter
+	// knownChains are the pairs of key and chain ID which were requested
+	// before, to alert on the first request for a chain
+	knownChains map[string]struct{}
+}
+
+func (g *signingGuard) limiter(eotsPkHex string) *rate.Limiter {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.limiters == nil {
+		g.limiters = make(map[string]*rate.Limiter)
+	}
+	l, ok := g.limiters[eotsPkHex]
+	if !ok {
+		l = rate.NewLimiter(g.rateLimit, g.burst)
+		g.limiters[eotsPkHex] = l
+	}
+
+	return l
+}
+
+// noteChain alerts on the first request for the chain with the key, that is
+// when nothing was signed and no randomness was generated for it before
+func (lm *LocalEOTSManager) noteChain(eotsPk, chainID []byte) {
+	eotsPkHex := hex.EncodeToString(eotsPk)
+	id := eotsPkHex + "/" + string(chainID)
+
+	lm.guard.mu.Lock()
+	defer lm.guard.mu.Unlock()
+
+	if _, ok := lm.guard.knownChains[id]; ok {
+		return
+	}
+	if lm.guard.knownChains == nil {
+		lm.guard.knownChains = make(map[string]struct{})
+	}
+
+	_, signed, err := lm.es.GetLastSignedHeight(eotsPk, chainID)
+	if err != nil {
+		lm.logger.Error("failed to get the last signed height", zap.Error(err))
+
+		return
+	}
+	_, generated, err := lm.es.GetLastRandomnessHeight(eotsPk, chainID)
+	if err != nil {
+		lm.logger.Error("failed to get the last randomness height", zap.Error(err))
+
+		return
+	}
+
+	lm.guard.knownChains[id] = struct{}{}
+	if signed || generated {
+		return
+	}
+
+	lm.logger.Warn(
+		"first request for a chain ID with the EOTS key",
+		zap.String("eots_pk", eotsPkHex),
+		zap.String("chainID", string(chainID)),
+	)
+	lm.metrics.IncrementEotsNewChainIDCounter(eotsPkHex, chainIDLabel(chainID))
+}
+
+// chainIDLabel returns the chain ID as a valid metric label value
+func chainIDLabel(chainID []byte) string {
+	if utf8.Valid(chainID) {
+		return string(chainID)
+	}
+
+	return hex.EncodeToString(chainID)
+}
+
+// recordRandomness records the last height of the randomness generated with
+// the key for the chain, which bounds the heights it can sign
+func (lm *LocalEOTSManager) recordRandomness(eotsPk, chainID []byte, lastHeight uint64) error {
+	lm.noteChain(eotsPk, chainID)
+
+	if err := lm.es.SaveLastRandomnessHeight(eotsPk, chainID, lastHeight); err != nil {
+		return fmt.Errorf("failed to record randomness height: %w", err)
+	}
+
+	return nil
+}
+
+// checkSigningGuards refuses to sign the heights with the key for the chain
+// if they exceed the limits of the signing guards. The heights are the ones
+// with no sign record, and lm.mu must be held.
+func (lm *LocalEOTSManager) checkSigningGuards(eotsPk, chainID []byte, heights []uint64) error {
+	if len(heights) == 0 {
+		return nil
+	}
+
+	lm.noteChain(eotsPk, chainID)
+
+	eotsPkHex := hex.EncodeToString(eotsPk)
+	sorted := slices.Sorted(slices.Values(heights))
+
+	if lm.guard.maxHeightJump > 0 {
+		last, found, err := lm.es.GetLastSignedHeight(eotsPk, chainID)
+		if err != nil {
+			return err
+		}
+
+		// the heights are checked in order, so that a batch can catch up
+		// with the chain as long as no gap exceeds the limit
+		for _, h := range sorted {
+			if !found || h <= last {
+				continue
+			}
+			if h-last > lm.guard.maxHeightJump {
+				return lm.rejectSign(eotsPkHex, chainID, "height_jump", fmt.Errorf(
+					"%w: height %d is %d above the last signed height %d, the maximum is %d",
+					eotstypes.ErrSigningHeightOutOfRange, h, h-last, last, lm.guard.maxHeightJump))
+			}
+			last = h
+		}
+	}
+
+	if lm.guard.maxHeightBeyondRandomness > 0 {
+		last, found, err := lm.es.GetLastRandomnessHeight(eotsPk, chainID)
+		if err != nil {
+			return err
+		}
+
+		if h := sorted[len(sorted)-1]; found && h > last && h-last > lm.guard.maxHeightBeyondRandomness {
+			return lm.rejectSign(eotsPkHex, chainID, "randomness", fmt.Errorf(
+				"%w: height %d is %d above the last randomness height %d, the maximum is %d",
+				eotstypes.ErrSigningHeightOutOfRange, h, h-last, last, lm.guard.maxHeightBeyondRandomness))
+		}
+	}
+
+	if lm.guard.rateLimit > 0 && !lm.guard.limiter(eotsPkHex).AllowN(time.Now(), min(len(heights), lm.guard.burst)) {
+		return lm.rejectSign(eotsPkHex, chainID, "rate", fmt.Errorf(
+			"%w: %d signatures exceed %v per second in bursts of %d",
+			eotstypes.ErrSigningRateLimited, len(heights), float64(lm.guard.rateLimit), lm.guard.burst))
+	}
+
+	return nil
+}
+
+func (lm *LocalEOTSManager) rejectSign(eotsPkHex string, chainID []byte, reason string, err error) error {
+	lm.logger.Warn(
+		"signing request rejected by the signing guards",
+		zap.String("eots_pk", eotsPkHex),
+		zap.String("chainID", string(chainID)),
+		zap.String("reason", reason),
+		zap.Error(err),
+	)
+	lm.metrics.IncrementEotsSigningGuardRejectedCounter(eotsPkHex, reason)
+
+	return err
+}
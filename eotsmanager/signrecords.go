@@ -0,0 +1,25 @@
+package eotsmanager
+
+import (
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/store"
+)
+
+// SignRecord returns the sign record of the key for the chain at the height,
+// if any
+func (lm *LocalEOTSManager) SignRecord(eotsPk []byte, chainID []byte, height uint64) (*store.SigningRecord, bool, error) {
+	return lm.es.GetSignRecord(eotsPk, chainID, height)
+}
+
+// ListSignRecords returns at most limit sign records of the key for the chain
+// from startHeight to endHeight inclusive, by ascending height, and whether
+// more records follow in the range. The range has no upper bound if
+// endHeight is 0.
+func (lm *LocalEOTSManager) ListSignRecords(eotsPk []byte, chainID []byte, startHeight, endHeight uint64, limit int) ([]store.HeightSigningRecord, bool, error) {
+	return lm.es.ListSignRecords(eotsPk, chainID, startHeight, endHeight, limit)
+}
+
+// LastSignedHeight returns the highest height signed with the key for the
+// chain, if any
+func (lm *LocalEOTSManager) LastSignedHeight(eotsPk []byte, chainID []byte) (uint64, bool, error) {
+	return lm.es.GetLastSignedHeight(eotsPk, chainID)
+}
@@ -22,8 +22,12 @@ import (
 )
 
 var (
-	eotsBucketName       = []byte("fpKeyNames")
-	signRecordBucketName = []byte("signRecord")
+	eotsBucketName                = []byte("fpKeyNames")
+	signRecordBucketName          = []byte("signRecord")
+	hmacKeyBucketName             = []byte("hmacKeys")
+	thresholdKeyShareBucketName   = []byte("thresholdKeyShares")
+	thresholdSchnorrSigBucketName = []byte("thresholdSchnorrSigs")
+	lastRandomnessBucketName      = []byte("lastRandomnessHeights")
 )
 
 type EOTSStore struct {
@@ -52,6 +56,26 @@ func (s *EOTSStore) initBuckets() error {
 			return fmt.Errorf("failed to create sign record bucket: %w", err)
 		}
 
+		_, err = tx.CreateTopLevelBucket(hmacKeyBucketName)
+		if err != nil {
+			return fmt.Errorf("failed to create hmac key bucket: %w", err)
+		}
+
+		_, err = tx.CreateTopLevelBucket(thresholdKeyShareBucketName)
+		if err != nil {
+			return fmt.Errorf("failed to create threshold key share bucket: %w", err)
+		}
+
+		_, err = tx.CreateTopLevelBucket(thresholdSchnorrSigBucketName)
+		if err != nil {
+			return fmt.Errorf("failed to create threshold schnorr sig bucket: %w", err)
+		}
+
+		_, err = tx.CreateTopLevelBucket(lastRandomnessBucketName)
+		if err != nil {
+			return fmt.Errorf("failed to create last randomness bucket: %w", err)
+		}
+
 		return nil
 	}); err != nil {
 		return fmt.Errorf("failed to initialize buckets: %w", err)
@@ -815,3 +815,79 @@ func TestSaveSignRecordsBatchWithGetSignRecordsBatch(t *testing.T) {
 		require.Equal(t, individualResult.Timestamp, batchResult.Timestamp)
 	}
 }
+
+// FuzzListSignRecords tests listing the sign records of a key for a chain by
+// pages, and getting its last signed height, among records of other chains
+func FuzzListSignRecords(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 5)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		r := rand.New(rand.NewSource(seed))
+
+		homePath := t.TempDir()
+		cfg := config.DefaultDBConfigWithHomePath(homePath)
+
+		dbBackend, err := cfg.GetDBBackend()
+		require.NoError(t, err)
+
+		vs, err := store.NewEOTSStore(dbBackend)
+		require.NoError(t, err)
+
+		defer func() {
+			if err := dbBackend.Close(); err != nil {
+				t.Errorf("Error closing database: %v", err)
+			}
+		}()
+
+		_, btcPk, err := datagen.GenRandomBTCKeyPair(r)
+		require.NoError(t, err)
+		pk := schnorr.SerializePubKey(btcPk)
+		chainID := []byte("chain-a")
+
+		_, found, err := vs.GetLastSignedHeight(pk, chainID)
+		require.NoError(t, err)
+		require.False(t, found)
+
+		height := uint64(r.Int63n(1000)) + 1
+		numRecords := r.Intn(50) + 1
+		var heights []uint64
+		for i := 0; i < numRecords; i++ {
+			height += uint64(r.Intn(3) + 1)
+			heights = append(heights, height)
+			require.NoError(t, vs.SaveSignRecord(height, chainID, datagen.GenRandomByteArray(r, 32), pk, datagen.GenRandomByteArray(r, 32)))
+		}
+		// records of another chain with a longer ID and of another key
+		require.NoError(t, vs.SaveSignRecord(heights[len(heights)-1]+1, []byte("chain-ab"), []byte("msg"), pk, []byte("sig")))
+		require.NoError(t, vs.SaveSignRecord(heights[len(heights)-1]+2, chainID, []byte("msg"), datagen.GenRandomByteArray(r, 32), []byte("sig")))
+
+		last, found, err := vs.GetLastSignedHeight(pk, chainID)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, heights[len(heights)-1], last)
+
+		// page through all the records
+		limit := r.Intn(10) + 1
+		var listed []uint64
+		from := uint64(0)
+		for {
+			records, hasMore, err := vs.ListSignRecords(pk, chainID, from, 0, limit)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(records), limit)
+			for _, record := range records {
+				listed = append(listed, record.Height)
+			}
+			if !hasMore {
+				break
+			}
+			from = records[len(records)-1].Height + 1
+		}
+		require.Equal(t, heights, listed)
+
+		// the end of the range is inclusive
+		records, hasMore, err := vs.ListSignRecords(pk, chainID, heights[0], heights[0], limit)
+		require.NoError(t, err)
+		require.False(t, hasMore)
+		require.Len(t, records, 1)
+		require.Equal(t, heights[0], records[0].Height)
+	})
+}
@@ -20,4 +20,13 @@ var (
 
 	// ErrDuplicateEOTSKeyRecord The EOTS key and key name we try to add already exists in db
 	ErrDuplicateEOTSKeyRecord = errors.New("EOTS key with the same key name already exists")
+
+	// ErrDuplicateHMACKey The HMAC key ID we try to add already exists in db
+	ErrDuplicateHMACKey = errors.New("HMAC key ID already exists")
+
+	// ErrDuplicateThresholdKeyShare A share of the threshold key we try to add already exists in db
+	ErrDuplicateThresholdKeyShare = errors.New("threshold key share already exists")
+
+	// ErrThresholdKeyShareNotFound The share of the threshold key we try to fetch is not found in db
+	ErrThresholdKeyShareNotFound = errors.New("threshold key share not found")
 )
@@ -0,0 +1,113 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+// GetLastSignedHeight returns the highest height signed with the key for
+// the chain, if any
+func (s *EOTSStore) GetLastSignedHeight(eotsPk, chainID []byte) (uint64, bool, error) {
+	prefix := getSignRecordKey(chainID, eotsPk, 0)
+	prefix = prefix[:len(prefix)-8]
+
+	var (
+		height uint64
+		found  bool
+	)
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(signRecordBucketName)
+		if bucket == nil {
+			return ErrCorruptedEOTSDb
+		}
+
+		// the sign records of the key for the chain are sorted by
+		// height, so the last one is right before the highest height
+		c := bucket.ReadCursor()
+		last := getSignRecordKey(chainID, eotsPk, ^uint64(0))
+		k, _ := c.Seek(last)
+		if !bytes.Equal(k, last) {
+			if k == nil {
+				k, _ = c.Last()
+			} else {
+				k, _ = c.Prev()
+			}
+		}
+
+		if k == nil || len(k) != len(prefix)+8 || !bytes.HasPrefix(k, prefix) {
+			return nil
+		}
+
+		height = sdk.BigEndianToUint64(k[len(prefix):])
+		found = true
+
+		return nil
+	}, func() {
+		height, found = 0, false
+	})
+
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get last signed height: %w", err)
+	}
+
+	return height, found, nil
+}
+
+// GetLastRandomnessHeight returns the highest height of the randomness
+// generated with the key for the chain, if any
+func (s *EOTSStore) GetLastRandomnessHeight(eotsPk, chainID []byte) (uint64, bool, error) {
+	var (
+		height uint64
+		found  bool
+	)
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(lastRandomnessBucketName)
+		if bucket == nil {
+			return ErrCorruptedEOTSDb
+		}
+
+		if v := bucket.Get(lastRandomnessKey(eotsPk, chainID)); v != nil {
+			height = sdk.BigEndianToUint64(v)
+			found = true
+		}
+
+		return nil
+	}, func() {
+		height, found = 0, false
+	})
+
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get last randomness height: %w", err)
+	}
+
+	return height, found, nil
+}
+
+// SaveLastRandomnessHeight records the height of randomness generated with
+// the key for the chain, if it is higher than the recorded one
+func (s *EOTSStore) SaveLastRandomnessHeight(eotsPk, chainID []byte, height uint64) error {
+	if err := kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(lastRandomnessBucketName)
+		if bucket == nil {
+			return ErrCorruptedEOTSDb
+		}
+
+		key := lastRandomnessKey(eotsPk, chainID)
+		if v := bucket.Get(key); v != nil && sdk.BigEndianToUint64(v) >= height {
+			return nil
+		}
+
+		return bucket.Put(key, sdk.Uint64ToBigEndian(height))
+	}); err != nil {
+		return fmt.Errorf("failed to save last randomness height: %w", err)
+	}
+
+	return nil
+}
+
+func lastRandomnessKey(eotsPk, chainID []byte) []byte {
+	return append(append([]byte(nil), eotsPk...), chainID...)
+}
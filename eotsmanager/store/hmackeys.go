@@ -0,0 +1,123 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+	pm "google.golang.org/protobuf/proto"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+)
+
+// HMACKeyRecord is an HMAC key added at runtime
+type HMACKeyRecord struct {
+	ID string
+	// Key is the HMAC key, encrypted with a key derived from the admin HMAC key.
+	// It is empty for the record retiring the key of the config.
+	Key string
+	// NotBefore and NotAfter bound the validity of the key, in Unix seconds.
+	// A zero value leaves the bound open.
+	NotBefore int64
+	NotAfter  int64
+}
+
+func (r *HMACKeyRecord) toProto() *proto.HMACKeyRecord {
+	return &proto.HMACKeyRecord{
+		Key:       r.Key,
+		NotBefore: r.NotBefore,
+		NotAfter:  r.NotAfter,
+	}
+}
+
+func (r *HMACKeyRecord) fromProto(id string, pr *proto.HMACKeyRecord) {
+	r.ID = id
+	r.Key = pr.Key
+	r.NotBefore = pr.NotBefore
+	r.NotAfter = pr.NotAfter
+}
+
+// AddHMACKey saves a new HMAC key, failing if a key with the same ID exists
+func (s *EOTSStore) AddHMACKey(record *HMACKeyRecord) error {
+	if err := kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(hmacKeyBucketName)
+		if bucket == nil {
+			return ErrCorruptedEOTSDb
+		}
+
+		if bucket.Get([]byte(record.ID)) != nil {
+			return ErrDuplicateHMACKey
+		}
+
+		return putHMACKey(bucket, record)
+	}); err != nil {
+		return fmt.Errorf("failed to add HMAC key %s: %w", record.ID, err)
+	}
+
+	return nil
+}
+
+// SaveHMACKey saves the HMAC key, replacing the key with the same ID if any
+func (s *EOTSStore) SaveHMACKey(record *HMACKeyRecord) error {
+	if err := kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(hmacKeyBucketName)
+		if bucket == nil {
+			return ErrCorruptedEOTSDb
+		}
+
+		return putHMACKey(bucket, record)
+	}); err != nil {
+		return fmt.Errorf("failed to save HMAC key %s: %w", record.ID, err)
+	}
+
+	return nil
+}
+
+func putHMACKey(bucket kvdb.RwBucket, record *HMACKeyRecord) error {
+	if record.ID == "" {
+		return fmt.Errorf("cannot save HMAC key with empty ID")
+	}
+
+	marshalled, err := pm.Marshal(record.toProto())
+	if err != nil {
+		return fmt.Errorf("failed to marshal HMAC key record: %w", err)
+	}
+
+	if err := bucket.Put([]byte(record.ID), marshalled); err != nil {
+		return fmt.Errorf("failed to put HMAC key in bucket: %w", err)
+	}
+
+	return nil
+}
+
+// GetHMACKeys returns all the HMAC keys sorted by ID
+func (s *EOTSStore) GetHMACKeys() ([]*HMACKeyRecord, error) {
+	var records []*HMACKeyRecord
+
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(hmacKeyBucketName)
+		if bucket == nil {
+			return ErrCorruptedEOTSDb
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			protoRes := &proto.HMACKeyRecord{}
+			if err := pm.Unmarshal(v, protoRes); err != nil {
+				return fmt.Errorf("failed to unmarshal HMAC key %s: %w", k, err)
+			}
+
+			record := &HMACKeyRecord{}
+			record.fromProto(string(k), protoRes)
+			records = append(records, record)
+
+			return nil
+		})
+	}, func() {
+		records = nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HMAC keys: %w", err)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+	pm "google.golang.org/protobuf/proto"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+)
+
+// ListSignRecords returns at most limit sign records of the key for the
+// chain from startHeight to endHeight inclusive, by ascending height, and
+// whether more records follow in the range. The range has no upper bound
+// if endHeight is 0.
+func (s *EOTSStore) ListSignRecords(eotsPk, chainID []byte, startHeight, endHeight uint64, limit int) ([]HeightSigningRecord, bool, error) {
+	if limit <= 0 {
+		return nil, false, fmt.Errorf("invalid limit %d", limit)
+	}
+	if endHeight == 0 {
+		endHeight = ^uint64(0)
+	}
+
+	prefix := getSignRecordKey(chainID, eotsPk, 0)
+	prefix = prefix[:len(prefix)-8]
+
+	var (
+		records []HeightSigningRecord
+		hasMore bool
+	)
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(signRecordBucketName)
+		if bucket == nil {
+			return ErrCorruptedEOTSDb
+		}
+
+		c := bucket.ReadCursor()
+		for k, v := c.Seek(getSignRecordKey(chainID, eotsPk, startHeight)); k != nil; k, v = c.Next() {
+			if !bytes.HasPrefix(k, prefix) {
+				break
+			}
+			// skip the keys of other chain IDs starting with the same bytes
+			if len(k) != len(prefix)+8 {
+				continue
+			}
+
+			height, err := ExtractHeightFromKey(k)
+			if err != nil {
+				return err
+			}
+			if height > endHeight {
+				break
+			}
+			if len(records) == limit {
+				hasMore = true
+
+				break
+			}
+
+			protoRes := &proto.SigningRecord{}
+			if err := pm.Unmarshal(v, protoRes); err != nil {
+				return fmt.Errorf("failed to unmarshal sign record at height %d: %w", height, err)
+			}
+			record := HeightSigningRecord{Height: height}
+			record.FromProto(protoRes)
+			records = append(records, record)
+		}
+
+		return nil
+	}, func() {
+		records, hasMore = nil, false
+	})
+
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list sign records: %w", err)
+	}
+
+	return records, hasMore, nil
+}
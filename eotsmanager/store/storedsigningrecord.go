@@ -9,7 +9,13 @@ import (
 type SigningRecord struct {
 	Msg       []byte // The message that the signature is signed over.
 	Signature []byte
-	Timestamp int64 // The timestamp of the signing operation, in Unix seconds.
+	Timestamp int64 // The timestamp of the signing operation, in Unix milliseconds.
+}
+
+// HeightSigningRecord is a signing record with the height it was signed at
+type HeightSigningRecord struct {
+	Height uint64
+	SigningRecord
 }
 
 type BatchSignRecord struct {
@@ -0,0 +1,104 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+// AddThresholdKeyShare saves the encoded share of the threshold key with the
+// given public key, failing if a share of the key exists
+func (s *EOTSStore) AddThresholdKeyShare(pk []byte, share []byte) error {
+	if err := kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(thresholdKeyShareBucketName)
+		if bucket == nil {
+			return ErrCorruptedEOTSDb
+		}
+
+		if bucket.Get(pk) != nil {
+			return ErrDuplicateThresholdKeyShare
+		}
+
+		return bucket.Put(pk, share)
+	}); err != nil {
+		return fmt.Errorf("failed to add threshold key share: %w", err)
+	}
+
+	return nil
+}
+
+// GetThresholdKeyShare returns the encoded share of the threshold key with
+// the given public key
+func (s *EOTSStore) GetThresholdKeyShare(pk []byte) ([]byte, error) {
+	var share []byte
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(thresholdKeyShareBucketName)
+		if bucket == nil {
+			return ErrCorruptedEOTSDb
+		}
+
+		v := bucket.Get(pk)
+		if v == nil {
+			return ErrThresholdKeyShareNotFound
+		}
+		share = append([]byte(nil), v...)
+
+		return nil
+	}, func() {
+		share = nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get threshold key share: %w", err)
+	}
+
+	return share, nil
+}
+
+// GetThresholdSchnorrSig returns the partial Schnorr signature of the message
+// with the share of the threshold key, if any
+func (s *EOTSStore) GetThresholdSchnorrSig(pk []byte, msg []byte) ([]byte, bool, error) {
+	var sig []byte
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(thresholdSchnorrSigBucketName)
+		if bucket == nil {
+			return ErrCorruptedEOTSDb
+		}
+
+		if v := bucket.Get(thresholdSchnorrSigKey(pk, msg)); v != nil {
+			sig = append([]byte(nil), v...)
+		}
+
+		return nil
+	}, func() {
+		sig = nil
+	})
+
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get threshold schnorr sig: %w", err)
+	}
+
+	return sig, sig != nil, nil
+}
+
+// SaveThresholdSchnorrSig saves the partial Schnorr signature of the message
+// with the share of the threshold key, which is returned for the message
+// from then on, so that the randomness of the message is never used twice
+func (s *EOTSStore) SaveThresholdSchnorrSig(pk []byte, msg []byte, sig []byte) error {
+	if err := kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(thresholdSchnorrSigBucketName)
+		if bucket == nil {
+			return ErrCorruptedEOTSDb
+		}
+
+		return bucket.Put(thresholdSchnorrSigKey(pk, msg), sig)
+	}); err != nil {
+		return fmt.Errorf("failed to save threshold schnorr sig: %w", err)
+	}
+
+	return nil
+}
+
+func thresholdSchnorrSigKey(pk []byte, msg []byte) []byte {
+	return append(append([]byte(nil), pk...), msg...)
+}
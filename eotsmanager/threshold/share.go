@@ -0,0 +1,304 @@
+// Package threshold implements threshold EOTS signing, in which the EOTS key is
+// secret-shared across n parties, so that any t of them sign together while
+// fewer learn nothing about the key.
+//
+// The key is Shamir-shared by a dealer. The per-height randomness is shared
+// non-interactively with pseudo-random secret sharing: for every set B of t-1
+// parties, the parties outside of B hold a key r_B, and the share of party i
+// for a given domain (chain ID and height) is
+//
+//	k_i = sum over B not containing i of PRF(r_B, domain) * f_B(i)
+//
+// where f_B is the polynomial of degree t-1 with f_B(0) = 1 and f_B(j) = 0 for
+// j in B. The shares lie on a polynomial of degree t-1 whose value at 0 is the
+// sum of all the PRF outputs, which no set of t-1 parties can compute since
+// they miss the key of their own set. The randomness stays deterministic, so
+// that each party keeps refusing to sign a second message at the same height.
+package threshold
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+)
+
+// MaxParties is the maximum number of parties, which bounds the number of
+// nonce keys, i.e., of sets of t-1 parties
+const MaxParties = 10
+
+const nonceKeySize = 32
+
+// nonceKey is the key of a set of t-1 parties, held by the other parties
+type nonceKey struct {
+	set []uint32
+	key []byte
+}
+
+// KeyShare is the share of the EOTS key held by a party
+type KeyShare struct {
+	// Index is the index of the party, from 1 to Parties
+	Index     uint32
+	Threshold uint32
+	Parties   uint32
+	// GroupPubKey is the EOTS public key, whose y coordinate is even
+	GroupPubKey *btcec.PublicKey
+	// VerificationShares are the public keys of the shares of all the
+	// parties, to check their partial signatures
+	VerificationShares []*btcec.PublicKey
+
+	secret    btcec.ModNScalar
+	nonceKeys []nonceKey
+}
+
+// ValidThreshold returns whether t of n parties can sign. The threshold must
+// be more than half of the parties, so that any two sets of t parties share
+// a party which refuses to sign two messages with the same randomness,
+// otherwise two disjoint sets could sign two messages at the same height and
+// leak the key.
+func ValidThreshold(t, n uint32) bool {
+	return t >= 2 && t <= n && 2*t > n
+}
+
+// Deal generates an EOTS key and splits it into shares for the given number
+// of parties, any threshold of which can sign. The dealer must discard the
+// key and the shares once they are handed to the parties.
+func Deal(threshold, parties uint32) (*btcec.PublicKey, []*KeyShare, error) {
+	if !ValidThreshold(threshold, parties) {
+		return nil, nil, fmt.Errorf("the threshold must be more than half of the parties and at most their number, got %d of %d", threshold, parties)
+	}
+	if parties > MaxParties {
+		return nil, nil, fmt.Errorf("the number of parties must be at most %d, got %d", MaxParties, parties)
+	}
+
+	// the coefficients of the polynomial sharing the key
+	coeffs := make([]btcec.ModNScalar, threshold)
+	for i := range coeffs {
+		k, err := btcec.NewPrivateKey()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate the key: %w", err)
+		}
+		coeffs[i].Set(&k.Key)
+		k.Zero()
+	}
+	defer func() {
+		for i := range coeffs {
+			coeffs[i].Zero()
+		}
+	}()
+
+	// BIP-340 public keys have an even y coordinate, so the key is
+	// negated if needed for the parties not to care about it
+	groupPk := scalarBaseMult(&coeffs[0])
+	if isOdd(groupPk) {
+		coeffs[0].Negate()
+		groupPk = scalarBaseMult(&coeffs[0])
+	}
+
+	shares := make([]*KeyShare, parties)
+	vShares := make([]*btcec.PublicKey, parties)
+	for i := range shares {
+		index := uint32(i + 1)
+		s := &KeyShare{
+			Index:       index,
+			Threshold:   threshold,
+			Parties:     parties,
+			GroupPubKey: groupPk,
+		}
+		s.secret = evalPoly(coeffs, index)
+		vShares[i] = scalarBaseMult(&s.secret)
+		shares[i] = s
+	}
+
+	for _, set := range subsets(parties, threshold-1) {
+		key := make([]byte, nonceKeySize)
+		if _, err := rand.Read(key); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate the nonce keys: %w", err)
+		}
+
+		for _, s := range shares {
+			if !contains(set, s.Index) {
+				s.nonceKeys = append(s.nonceKeys, nonceKey{set: set, key: key})
+			}
+		}
+	}
+
+	for _, s := range shares {
+		s.VerificationShares = vShares
+	}
+
+	return groupPk, shares, nil
+}
+
+// Zero zeroes the secrets of the share
+func (s *KeyShare) Zero() {
+	s.secret.Zero()
+	for _, nk := range s.nonceKeys {
+		clear(nk.key)
+	}
+}
+
+// Validate checks that the share is consistent, in particular that its
+// secret matches its verification share and that it holds the nonce keys of
+// all the sets of t-1 parties it does not belong to
+func (s *KeyShare) Validate() error {
+	if !ValidThreshold(s.Threshold, s.Parties) || s.Parties > MaxParties {
+		return fmt.Errorf("invalid threshold %d of %d parties", s.Threshold, s.Parties)
+	}
+	if s.Index < 1 || s.Index > s.Parties {
+		return fmt.Errorf("invalid index %d of %d parties", s.Index, s.Parties)
+	}
+	if s.GroupPubKey == nil || isOdd(s.GroupPubKey) {
+		return fmt.Errorf("invalid group public key")
+	}
+	if len(s.VerificationShares) != int(s.Parties) {
+		return fmt.Errorf("expected %d verification shares, got %d", s.Parties, len(s.VerificationShares))
+	}
+	if !scalarBaseMult(&s.secret).IsEqual(s.VerificationShares[s.Index-1]) {
+		return fmt.Errorf("the secret does not match the verification share of party %d", s.Index)
+	}
+
+	expected := make(map[string]bool)
+	for _, set := range subsets(s.Parties, s.Threshold-1) {
+		if !contains(set, s.Index) {
+			expected[fmt.Sprint(set)] = true
+		}
+	}
+	for _, nk := range s.nonceKeys {
+		if !expected[fmt.Sprint(nk.set)] || len(nk.key) != nonceKeySize {
+			return fmt.Errorf("invalid nonce key for parties %v", nk.set)
+		}
+		delete(expected, fmt.Sprint(nk.set))
+	}
+	if len(expected) != 0 {
+		return fmt.Errorf("missing %d nonce keys", len(expected))
+	}
+
+	return nil
+}
+
+type nonceKeyJSON struct {
+	Set []uint32 `json:"set"`
+	Key string   `json:"key"`
+}
+
+type keyShareJSON struct {
+	Index              uint32         `json:"index"`
+	Threshold          uint32         `json:"threshold"`
+	Parties            uint32         `json:"parties"`
+	GroupPubKey        string         `json:"group_pub_key"`
+	VerificationShares []string       `json:"verification_shares"`
+	Secret             string         `json:"secret"`
+	NonceKeys          []nonceKeyJSON `json:"nonce_keys"`
+}
+
+// MarshalJSON encodes the share, including its secrets
+func (s *KeyShare) MarshalJSON() ([]byte, error) {
+	secret := s.secret.Bytes()
+	res := keyShareJSON{
+		Index:       s.Index,
+		Threshold:   s.Threshold,
+		Parties:     s.Parties,
+		GroupPubKey: hex.EncodeToString(schnorr.SerializePubKey(s.GroupPubKey)),
+		Secret:      hex.EncodeToString(secret[:]),
+	}
+	for _, vs := range s.VerificationShares {
+		res.VerificationShares = append(res.VerificationShares, hex.EncodeToString(vs.SerializeCompressed()))
+	}
+	for _, nk := range s.nonceKeys {
+		res.NonceKeys = append(res.NonceKeys, nonceKeyJSON{Set: nk.set, Key: hex.EncodeToString(nk.key)})
+	}
+
+	return json.Marshal(res)
+}
+
+// UnmarshalJSON decodes and validates the share
+func (s *KeyShare) UnmarshalJSON(data []byte) error {
+	var res keyShareJSON
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+
+	pkBytes, err := hex.DecodeString(res.GroupPubKey)
+	if err != nil {
+		return fmt.Errorf("invalid group public key: %w", err)
+	}
+	groupPk, err := schnorr.ParsePubKey(pkBytes)
+	if err != nil {
+		return fmt.Errorf("invalid group public key: %w", err)
+	}
+
+	secret, err := hex.DecodeString(res.Secret)
+	if err != nil || len(secret) != 32 {
+		return fmt.Errorf("invalid secret")
+	}
+
+	share := KeyShare{
+		Index:       res.Index,
+		Threshold:   res.Threshold,
+		Parties:     res.Parties,
+		GroupPubKey: groupPk,
+	}
+	if overflow := share.secret.SetByteSlice(secret); overflow {
+		return fmt.Errorf("invalid secret")
+	}
+	clear(secret)
+
+	for i, vs := range res.VerificationShares {
+		b, err := hex.DecodeString(vs)
+		if err != nil {
+			return fmt.Errorf("invalid verification share %d: %w", i+1, err)
+		}
+		pk, err := btcec.ParsePubKey(b)
+		if err != nil {
+			return fmt.Errorf("invalid verification share %d: %w", i+1, err)
+		}
+		share.VerificationShares = append(share.VerificationShares, pk)
+	}
+
+	for _, nk := range res.NonceKeys {
+		key, err := hex.DecodeString(nk.Key)
+		if err != nil {
+			return fmt.Errorf("invalid nonce key for parties %v: %w", nk.Set, err)
+		}
+		share.nonceKeys = append(share.nonceKeys, nonceKey{set: nk.Set, key: key})
+	}
+
+	if err := share.Validate(); err != nil {
+		return err
+	}
+
+	*s = share
+
+	return nil
+}
+
+// subsets returns the sorted subsets of size k of {1, ..., n}
+func subsets(n, k uint32) [][]uint32 {
+	var res [][]uint32
+
+	var rec func(start uint32, cur []uint32)
+	rec = func(start uint32, cur []uint32) {
+		if uint32(len(cur)) == k {
+			res = append(res, append([]uint32(nil), cur...))
+
+			return
+		}
+		for i := start; i <= n; i++ {
+			rec(i+1, append(cur, i))
+		}
+	}
+	rec(1, nil)
+
+	return res
+}
+
+func contains(set []uint32, i uint32) bool {
+	idx := sort.Search(len(set), func(j int) bool { return set[j] >= i })
+
+	return idx < len(set) && set[idx] == i
+}
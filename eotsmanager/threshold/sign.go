@@ -0,0 +1,289 @@
+package threshold
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+const nonceTag = "finality-provider/threshold-eots/nonce"
+
+const (
+	eotsDomain    byte = 0
+	schnorrDomain byte = 1
+)
+
+// EOTSNonceDomain returns the domain of the randomness of the given chain at
+// the given height
+func EOTSNonceDomain(chainID []byte, height uint64) []byte {
+	domain := make([]byte, 0, 1+8+len(chainID)+8)
+	domain = append(domain, eotsDomain)
+	domain = binary.BigEndian.AppendUint64(domain, uint64(len(chainID)))
+	domain = append(domain, chainID...)
+
+	return binary.BigEndian.AppendUint64(domain, height)
+}
+
+// SchnorrNonceDomain returns the domain of the randomness of the Schnorr
+// signature of the given message, so that a message is always signed with
+// the same randomness
+func SchnorrNonceDomain(msg []byte) []byte {
+	return append([]byte{schnorrDomain}, msg...)
+}
+
+// EOTSMsgHash returns the hash of the message signed by EOTS
+func EOTSMsgHash(msg []byte) []byte {
+	h := sha256.Sum256(msg)
+
+	return h[:]
+}
+
+// nonceShare returns the share of the party of the randomness of the domain
+func (s *KeyShare) nonceShare(domain []byte) *btcec.ModNScalar {
+	var k btcec.ModNScalar
+	for _, nk := range s.nonceKeys {
+		mac := hmac.New(sha256.New, nk.key)
+		mac.Write([]byte(nonceTag))
+		mac.Write(domain)
+
+		var v btcec.ModNScalar
+		v.SetByteSlice(mac.Sum(nil))
+		f := zeroPolyAt(nk.set, s.Index)
+		k.Add(v.Mul(&f))
+	}
+
+	return &k
+}
+
+// PublicNonce returns the public share of the party of the randomness of the
+// domain, from which the public randomness is combined
+func (s *KeyShare) PublicNonce(domain []byte) *btcec.PublicKey {
+	k := s.nonceShare(domain)
+	defer k.Zero()
+
+	return scalarBaseMult(k)
+}
+
+// PartialSign returns the partial signature of the party of the message hash
+// with the randomness of the domain. groupNonce is the public randomness
+// combined from the public nonces of the parties.
+func (s *KeyShare) PartialSign(domain []byte, groupNonce *btcec.PublicKey, msgHash []byte) (*btcec.ModNScalar, error) {
+	e, err := challenge(groupNonce, s.GroupPubKey, msgHash)
+	if err != nil {
+		return nil, err
+	}
+
+	k := s.nonceShare(domain)
+	defer k.Zero()
+	if isOdd(groupNonce) {
+		k.Negate()
+	}
+
+	// s_i = k_i + e * x_i
+	sig := new(btcec.ModNScalar).Mul2(e, &s.secret).Add(k)
+
+	return sig, nil
+}
+
+// VerifyPartialSig checks the partial signature of a party against its
+// verification share and its public nonce
+func VerifyPartialSig(
+	groupPk, verificationShare, publicNonce, groupNonce *btcec.PublicKey,
+	msgHash []byte,
+	sig *btcec.ModNScalar,
+) error {
+	e, err := challenge(groupNonce, groupPk, msgHash)
+	if err != nil {
+		return err
+	}
+
+	// s_i * G == R_i + e * X_i, with R_i negated if R is odd
+	var nonce, x, ex, expected, actual btcec.JacobianPoint
+	publicNonce.AsJacobian(&nonce)
+	if isOdd(groupNonce) {
+		nonce.Y.Negate(1).Normalize()
+	}
+	verificationShare.AsJacobian(&x)
+	btcec.ScalarMultNonConst(e, &x, &ex)
+	btcec.AddNonConst(&nonce, &ex, &expected)
+	btcec.ScalarBaseMultNonConst(sig, &actual)
+
+	expected.ToAffine()
+	actual.ToAffine()
+	if !expected.X.Equals(&actual.X) || !expected.Y.Equals(&actual.Y) {
+		return fmt.Errorf("invalid partial signature")
+	}
+
+	return nil
+}
+
+// CombineNonces combines the public nonces of at least threshold parties,
+// indexed by party, into the public randomness
+func CombineNonces(threshold uint32, nonces map[uint32]*btcec.PublicKey) (*btcec.PublicKey, error) {
+	indices, err := quorum(threshold, slices.Collect(maps.Keys(nonces)))
+	if err != nil {
+		return nil, err
+	}
+
+	var res btcec.JacobianPoint
+	for _, i := range indices {
+		lambda := lagrangeAtZero(indices, i)
+
+		var p, term, sum btcec.JacobianPoint
+		nonces[i].AsJacobian(&p)
+		btcec.ScalarMultNonConst(&lambda, &p, &term)
+		btcec.AddNonConst(&res, &term, &sum)
+		res = sum
+	}
+
+	res.ToAffine()
+	if (res.X.IsZero() && res.Y.IsZero()) || res.Z.IsZero() {
+		return nil, fmt.Errorf("the public randomness is the point at infinity")
+	}
+
+	return btcec.NewPublicKey(&res.X, &res.Y), nil
+}
+
+// CombineSigs combines the partial signatures of at least threshold
+// parties, indexed by party, into the signature
+func CombineSigs(threshold uint32, sigs map[uint32]*btcec.ModNScalar) (*btcec.ModNScalar, error) {
+	indices, err := quorum(threshold, slices.Collect(maps.Keys(sigs)))
+	if err != nil {
+		return nil, err
+	}
+
+	var res btcec.ModNScalar
+	for _, i := range indices {
+		lambda := lagrangeAtZero(indices, i)
+		res.Add(lambda.Mul(sigs[i]))
+	}
+
+	return &res, nil
+}
+
+// SchnorrSig returns the BIP-340 Schnorr signature from the public
+// randomness and the combined signature
+func SchnorrSig(groupNonce *btcec.PublicKey, sig *btcec.ModNScalar) *schnorr.Signature {
+	return schnorr.NewSignature(PublicRand(groupNonce), sig)
+}
+
+// PublicRand returns the x coordinate of the public randomness, which is
+// what is committed and verified
+func PublicRand(groupNonce *btcec.PublicKey) *btcec.FieldVal {
+	var p btcec.JacobianPoint
+	groupNonce.AsJacobian(&p)
+
+	return &p.X
+}
+
+// quorum returns the sorted indices of the first threshold parties
+func quorum(threshold uint32, indices []uint32) ([]uint32, error) {
+	if threshold == 0 || len(indices) < int(threshold) {
+		return nil, fmt.Errorf("expected at least %d shares, got %d", threshold, len(indices))
+	}
+
+	slices.Sort(indices)
+
+	for _, i := range indices {
+		if i == 0 || i > MaxParties {
+			return nil, fmt.Errorf("invalid party index %d", i)
+		}
+	}
+
+	return indices[:threshold], nil
+}
+
+// challenge returns e = tagged_hash("BIP0340/challenge", bytes(R) || bytes(P) || m)
+func challenge(groupNonce, groupPk *btcec.PublicKey, msgHash []byte) (*btcec.ModNScalar, error) {
+	if len(msgHash) != 32 {
+		return nil, fmt.Errorf("the message hash must be 32 bytes, got %d", len(msgHash))
+	}
+
+	commitment := chainhash.TaggedHash(
+		chainhash.TagBIP0340Challenge,
+		groupNonce.SerializeCompressed()[1:],
+		schnorr.SerializePubKey(groupPk),
+		msgHash,
+	)
+
+	var e btcec.ModNScalar
+	if overflow := e.SetBytes((*[32]byte)(commitment)); overflow != 0 {
+		return nil, fmt.Errorf("hash of (r || P || m) too big")
+	}
+
+	return &e, nil
+}
+
+// evalPoly returns the value at x of the polynomial with the coefficients
+func evalPoly(coeffs []btcec.ModNScalar, x uint32) btcec.ModNScalar {
+	var xs, res btcec.ModNScalar
+	xs.SetInt(x)
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		res.Mul(&xs).Add(&coeffs[i])
+	}
+
+	return res
+}
+
+// zeroPolyAt returns f(x) for the polynomial f with f(0) = 1 and f(j) = 0
+// for j in the set, i.e., the product of (j - x) / j
+func zeroPolyAt(set []uint32, x uint32) btcec.ModNScalar {
+	var num, den, xs btcec.ModNScalar
+	num.SetInt(1)
+	den.SetInt(1)
+	xs.SetInt(x)
+	xs.Negate()
+
+	for _, j := range set {
+		var js btcec.ModNScalar
+		js.SetInt(j)
+		den.Mul(&js)
+		num.Mul(js.Add(&xs))
+	}
+
+	return *num.Mul(den.InverseNonConst())
+}
+
+// lagrangeAtZero returns the Lagrange coefficient of the party i for the
+// interpolation at 0 from the parties of the indices, i.e., the product of
+// j / (j - i) over the other parties j
+func lagrangeAtZero(indices []uint32, i uint32) btcec.ModNScalar {
+	var num, den, is btcec.ModNScalar
+	num.SetInt(1)
+	den.SetInt(1)
+	is.SetInt(i)
+	is.Negate()
+
+	for _, j := range indices {
+		if j == i {
+			continue
+		}
+
+		var js btcec.ModNScalar
+		js.SetInt(j)
+		num.Mul(&js)
+		den.Mul(js.Add(&is))
+	}
+
+	return *num.Mul(den.InverseNonConst())
+}
+
+func scalarBaseMult(k *btcec.ModNScalar) *btcec.PublicKey {
+	var p btcec.JacobianPoint
+	btcec.ScalarBaseMultNonConst(k, &p)
+	p.ToAffine()
+
+	return btcec.NewPublicKey(&p.X, &p.Y)
+}
+
+func isOdd(pk *btcec.PublicKey) bool {
+	return pk.SerializeCompressed()[0] == secp256k1.PubKeyFormatCompressedOdd
+}
@@ -0,0 +1,135 @@
+package threshold_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/babylonlabs-io/babylon/v4/crypto/eots"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/threshold"
+)
+
+// sign runs the signing protocol with the given parties
+func sign(t *testing.T, shares []*threshold.KeyShare, parties []uint32, domain, msgHash []byte) (*btcec.PublicKey, *btcec.ModNScalar) {
+	t.Helper()
+
+	nonces := make(map[uint32]*btcec.PublicKey)
+	for _, i := range parties {
+		nonces[i] = shares[i-1].PublicNonce(domain)
+	}
+	groupNonce, err := threshold.CombineNonces(shares[0].Threshold, nonces)
+	require.NoError(t, err)
+
+	sigs := make(map[uint32]*btcec.ModNScalar)
+	for _, i := range parties {
+		share := shares[i-1]
+		sig, err := share.PartialSign(domain, groupNonce, msgHash)
+		require.NoError(t, err)
+		require.NoError(t, threshold.VerifyPartialSig(share.GroupPubKey, share.VerificationShares[i-1], nonces[i], groupNonce, msgHash, sig))
+		sigs[i] = sig
+	}
+	sig, err := threshold.CombineSigs(shares[0].Threshold, sigs)
+	require.NoError(t, err)
+
+	return groupNonce, sig
+}
+
+func TestThresholdEOTS(t *testing.T) {
+	t.Parallel()
+
+	groupPk, shares, err := threshold.Deal(3, 5)
+	require.NoError(t, err)
+	require.Len(t, shares, 5)
+
+	chainID := []byte("chain-test")
+	domain := threshold.EOTSNonceDomain(chainID, 100)
+	msg := []byte("block hash at height 100")
+	msgHash := threshold.EOTSMsgHash(msg)
+
+	// any quorum combines the same public randomness and a valid signature
+	var pubRand *btcec.FieldVal
+	for _, parties := range [][]uint32{{1, 2, 3}, {2, 4, 5}, {1, 3, 5}, {1, 2, 3, 4, 5}} {
+		groupNonce, sig := sign(t, shares, parties, domain, msgHash)
+		if pubRand == nil {
+			pubRand = threshold.PublicRand(groupNonce)
+		}
+		require.True(t, pubRand.Equals(threshold.PublicRand(groupNonce)))
+		require.NoError(t, eots.Verify(groupPk, pubRand, msg, sig))
+	}
+
+	// the randomness differs at other heights
+	otherNonce, _ := sign(t, shares, []uint32{1, 2, 3}, threshold.EOTSNonceDomain(chainID, 101), msgHash)
+	require.False(t, pubRand.Equals(threshold.PublicRand(otherNonce)))
+
+	// signing two messages at the same height still exposes the group key
+	msg2 := []byte("conflicting block hash at height 100")
+	groupNonce, sig1 := sign(t, shares, []uint32{1, 2, 3}, domain, msgHash)
+	_, sig2 := sign(t, shares, []uint32{3, 4, 5}, domain, threshold.EOTSMsgHash(msg2))
+	sk, err := eots.Extract(groupPk, threshold.PublicRand(groupNonce), msg, sig1, msg2, sig2)
+	require.NoError(t, err)
+	require.True(t, sk.PubKey().IsEqual(groupPk))
+
+	// fewer than threshold partial signatures cannot be combined
+	_, err = threshold.CombineSigs(3, map[uint32]*btcec.ModNScalar{1: sig1, 2: sig2})
+	require.Error(t, err)
+
+	// a partial signature of the wrong party is detected
+	share := shares[0]
+	partial, err := share.PartialSign(domain, groupNonce, msgHash)
+	require.NoError(t, err)
+	require.Error(t, threshold.VerifyPartialSig(share.GroupPubKey, share.VerificationShares[1], share.PublicNonce(domain), groupNonce, msgHash, partial))
+}
+
+func TestThresholdSchnorr(t *testing.T) {
+	t.Parallel()
+
+	groupPk, shares, err := threshold.Deal(2, 3)
+	require.NoError(t, err)
+
+	msg := chainhash.HashB([]byte("commit public randomness"))
+	groupNonce, s := sign(t, shares, []uint32{1, 3}, threshold.SchnorrNonceDomain(msg), msg)
+	sig := threshold.SchnorrSig(groupNonce, s)
+	require.True(t, sig.Verify(msg, groupPk))
+
+	parsed, err := schnorr.ParseSignature(sig.Serialize())
+	require.NoError(t, err)
+	require.True(t, parsed.Verify(msg, groupPk))
+}
+
+func TestKeyShareJSON(t *testing.T) {
+	t.Parallel()
+
+	_, shares, err := threshold.Deal(3, 4)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(shares[1])
+	require.NoError(t, err)
+
+	var share threshold.KeyShare
+	require.NoError(t, json.Unmarshal(data, &share))
+	require.Equal(t, uint32(2), share.Index)
+	require.True(t, share.GroupPubKey.IsEqual(shares[1].GroupPubKey))
+
+	domain := threshold.EOTSNonceDomain([]byte("chain-test"), 1)
+	require.True(t, share.PublicNonce(domain).IsEqual(shares[1].PublicNonce(domain)))
+
+	// a share whose secret does not match its verification share is rejected
+	share.Index = 3
+	data, err = json.Marshal(&share)
+	require.NoError(t, err)
+	require.Error(t, json.Unmarshal(data, &threshold.KeyShare{}))
+
+	_, _, err = threshold.Deal(1, 3)
+	require.Error(t, err)
+	_, _, err = threshold.Deal(4, 3)
+	require.Error(t, err)
+	// two disjoint sets of 2 of 4 parties could sign with the same nonce
+	_, _, err = threshold.Deal(2, 4)
+	require.Error(t, err)
+	_, _, err = threshold.Deal(2, threshold.MaxParties+1)
+	require.Error(t, err)
+}
@@ -0,0 +1,217 @@
+package eotsmanager
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/threshold"
+	eotstypes "github.com/babylonlabs-io/finality-provider/eotsmanager/types"
+)
+
+// ImportThresholdKeyShare saves the share of a threshold EOTS key, with which
+// the manager produces partial randomness and partial signatures
+func (lm *LocalEOTSManager) ImportThresholdKeyShare(share *threshold.KeyShare) error {
+	if err := share.Validate(); err != nil {
+		return fmt.Errorf("invalid threshold key share: %w", err)
+	}
+
+	data, err := json.Marshal(share)
+	if err != nil {
+		return fmt.Errorf("failed to encode threshold key share: %w", err)
+	}
+
+	return lm.es.AddThresholdKeyShare(schnorr.SerializePubKey(share.GroupPubKey), data)
+}
+
+// ThresholdKeyShare returns the share of the threshold EOTS key. The caller
+// should zero it once done.
+func (lm *LocalEOTSManager) ThresholdKeyShare(eotsPk []byte) (*threshold.KeyShare, error) {
+	data, err := lm.es.GetThresholdKeyShare(eotsPk)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(data)
+
+	var share threshold.KeyShare
+	if err := json.Unmarshal(data, &share); err != nil {
+		return nil, fmt.Errorf("failed to decode threshold key share: %w", err)
+	}
+
+	if !bytes.Equal(schnorr.SerializePubKey(share.GroupPubKey), eotsPk) {
+		return nil, fmt.Errorf("public key mismatch: requested key does not match stored key share")
+	}
+
+	return &share, nil
+}
+
+// CreatePartialRandomnessList returns the public nonces of the share of the
+// threshold EOTS key at the given heights, from which the coordinator
+// combines the public randomness
+func (lm *LocalEOTSManager) CreatePartialRandomnessList(eotsPk []byte, chainID []byte, startHeight uint64, num uint32, options ...RandomnessOption) ([]*btcec.PublicKey, error) {
+	cfg := &RandomnessConfig{}
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	interval := uint64(1)
+	if cfg.Interval != nil {
+		if *cfg.Interval == 0 {
+			return nil, fmt.Errorf("interval must be greater than 0")
+		}
+		interval = *cfg.Interval
+	}
+
+	share, err := lm.ThresholdKeyShare(eotsPk)
+	if err != nil {
+		return nil, err
+	}
+	defer share.Zero()
+
+	nonces := make([]*btcec.PublicKey, 0, num)
+	for i := uint32(0); i < num; i++ {
+		height := startHeight + uint64(i)*interval
+		nonces = append(nonces, share.PublicNonce(threshold.EOTSNonceDomain(chainID, height)))
+	}
+
+	if num > 0 {
+		if err := lm.recordRandomness(eotsPk, chainID, startHeight+uint64(num-1)*interval); err != nil {
+			return nil, err
+		}
+	}
+
+	lm.metrics.IncrementEotsFpTotalGeneratedRandomnessCounter(hex.EncodeToString(eotsPk))
+	lm.metrics.SetEotsFpLastGeneratedRandomnessHeight(hex.EncodeToString(eotsPk), float64(startHeight))
+
+	return nonces, nil
+}
+
+// SignPartialEOTS returns the partial EOTS signature of the share of the
+// threshold EOTS key. As SignEOTS, it refuses to sign another message at the
+// same height, and returns the saved partial signature for the same message
+// whatever the public randomness, so that the randomness share is never used
+// in two different signatures.
+func (lm *LocalEOTSManager) SignPartialEOTS(eotsPk []byte, chainID []byte, msg []byte, height uint64, groupNonce *btcec.PublicKey) (*btcec.ModNScalar, error) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	record, found, err := lm.es.GetSignRecord(eotsPk, chainID, height)
+	if err != nil {
+		return nil, fmt.Errorf("error getting sign record: %w", err)
+	}
+
+	if found {
+		if bytes.Equal(msg, record.Msg) {
+			var s btcec.ModNScalar
+			s.SetByteSlice(record.Signature)
+
+			return &s, nil
+		}
+
+		lm.logger.Error(
+			"double sign requested",
+			zap.String("eots_pk", hex.EncodeToString(eotsPk)),
+			zap.String("hash", hex.EncodeToString(msg)),
+			zap.Uint64("height", height),
+			zap.String("chainID", string(chainID)),
+		)
+
+		return nil, eotstypes.ErrDoubleSign
+	}
+
+	if err := lm.checkSigningGuards(eotsPk, chainID, []uint64{height}); err != nil {
+		return nil, err
+	}
+
+	share, err := lm.ThresholdKeyShare(eotsPk)
+	if err != nil {
+		return nil, err
+	}
+	defer share.Zero()
+
+	sig, err := share.PartialSign(threshold.EOTSNonceDomain(chainID, height), groupNonce, threshold.EOTSMsgHash(msg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign partial eots: %w", err)
+	}
+
+	lm.metrics.IncrementEotsFpTotalEotsSignCounter(hex.EncodeToString(eotsPk))
+	lm.metrics.SetEotsFpLastEotsSignHeight(hex.EncodeToString(eotsPk), float64(height))
+
+	b := sig.Bytes()
+	if err := lm.es.SaveSignRecord(height, chainID, msg, eotsPk, b[:]); err != nil {
+		return nil, fmt.Errorf("failed to save signing record: %w", err)
+	}
+
+	return sig, nil
+}
+
+// SignPartialSchnorrSig returns the partial Schnorr signature of the share of
+// the threshold EOTS key. The partial signature of a message is saved and
+// returned for the message from then on, so that the randomness of the
+// message is never used in two different signatures.
+func (lm *LocalEOTSManager) SignPartialSchnorrSig(eotsPk []byte, msg []byte, groupNonce *btcec.PublicKey) (*btcec.ModNScalar, error) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	saved, found, err := lm.es.GetThresholdSchnorrSig(eotsPk, msg)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		var s btcec.ModNScalar
+		s.SetByteSlice(saved)
+
+		return &s, nil
+	}
+
+	share, err := lm.ThresholdKeyShare(eotsPk)
+	if err != nil {
+		return nil, err
+	}
+	defer share.Zero()
+
+	sig, err := share.PartialSign(threshold.SchnorrNonceDomain(msg), groupNonce, msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign partial schnorr signature: %w", err)
+	}
+
+	lm.metrics.IncrementEotsFpTotalSchnorrSignCounter(hex.EncodeToString(eotsPk))
+
+	b := sig.Bytes()
+	if err := lm.es.SaveThresholdSchnorrSig(eotsPk, msg, b[:]); err != nil {
+		return nil, err
+	}
+
+	return sig, nil
+}
+
+// PartialEOTSNonce returns the public nonce of the share of the threshold EOTS
+// key at the height. Unlike CreatePartialRandomnessList, it does not record the
+// height as generated randomness, so that fetching the nonces to sign does not
+// raise the bound of the heights which can be signed.
+func (lm *LocalEOTSManager) PartialEOTSNonce(eotsPk []byte, chainID []byte, height uint64) (*btcec.PublicKey, error) {
+	share, err := lm.ThresholdKeyShare(eotsPk)
+	if err != nil {
+		return nil, err
+	}
+	defer share.Zero()
+
+	return share.PublicNonce(threshold.EOTSNonceDomain(chainID, height)), nil
+}
+
+// PartialSchnorrNonce returns the public nonce of the share of the threshold
+// EOTS key for the Schnorr signature of the message
+func (lm *LocalEOTSManager) PartialSchnorrNonce(eotsPk []byte, msg []byte) (*btcec.PublicKey, error) {
+	share, err := lm.ThresholdKeyShare(eotsPk)
+	if err != nil {
+		return nil, err
+	}
+	defer share.Zero()
+
+	return share.PublicNonce(threshold.SchnorrNonceDomain(msg)), nil
+}
@@ -7,4 +7,6 @@ var (
 	ErrFinalityProviderAlreadyExisted = errors.New("the finality provider has already existed")
 	ErrDoubleSign                     = errors.New("double sign")
 	ErrDuplicateHeight                = errors.New("duplicate height in batch request")
+	ErrSigningRateLimited             = errors.New("signing rate limit exceeded")
+	ErrSigningHeightOutOfRange        = errors.New("height out of the allowed signing range")
 )
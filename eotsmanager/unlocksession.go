@@ -0,0 +1,206 @@
+package eotsmanager
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"go.uber.org/zap"
+)
+
+const (
+	minSessionSweepInterval = time.Second
+	maxSessionSweepInterval = time.Minute
+)
+
+// LocalEOTSManagerOption configures the LocalEOTSManager
+type LocalEOTSManagerOption func(*LocalEOTSManager)
+
+// WithUnlockTTL locks the keys of the file keyring once they have been
+// unlocked for ttl, regardless of their use. Zero keeps them unlocked.
+func WithUnlockTTL(ttl time.Duration) LocalEOTSManagerOption {
+	return func(lm *LocalEOTSManager) {
+		lm.unlockTTL = ttl
+	}
+}
+
+// WithUnlockIdleTimeout locks the keys of the file keyring which have not
+// been used for signing for timeout. Zero keeps them unlocked.
+func WithUnlockIdleTimeout(timeout time.Duration) LocalEOTSManagerOption {
+	return func(lm *LocalEOTSManager) {
+		lm.unlockIdleTimeout = timeout
+	}
+}
+
+// unlockSession holds the private key of an unlocked key of the file keyring
+type unlockSession struct {
+	eotsPk     []byte
+	privKey    *btcec.PrivateKey
+	unlockedAt time.Time
+	lastUsedAt time.Time
+}
+
+// UnlockedKey describes the unlock session of a key of the file keyring
+type UnlockedKey struct {
+	KeyName    string
+	EotsPk     []byte
+	UnlockedAt time.Time
+	LastUsedAt time.Time
+	// ExpiresAt is the time the key is locked at unless it is used before,
+	// or the zero time if the key stays unlocked
+	ExpiresAt time.Time
+}
+
+// expiresAt returns the time the session ends at if the key is not used
+// anymore, or the zero time if it does not end
+func (lm *LocalEOTSManager) expiresAt(s *unlockSession) time.Time {
+	var expiry time.Time
+	if lm.unlockTTL > 0 {
+		expiry = s.unlockedAt.Add(lm.unlockTTL)
+	}
+	if lm.unlockIdleTimeout > 0 {
+		idleExpiry := s.lastUsedAt.Add(lm.unlockIdleTimeout)
+		if expiry.IsZero() || idleExpiry.Before(expiry) {
+			expiry = idleExpiry
+		}
+	}
+
+	return expiry
+}
+
+func (lm *LocalEOTSManager) sessionExpired(s *unlockSession, now time.Time) bool {
+	expiry := lm.expiresAt(s)
+
+	return !expiry.IsZero() && !now.Before(expiry)
+}
+
+// lockKey zeroes the private key of the session and removes it.
+// The caller must hold lm.mu.
+func (lm *LocalEOTSManager) lockKey(keyName, reason string) {
+	s, ok := lm.privateKeys[keyName]
+	if !ok {
+		return
+	}
+
+	s.privKey.Zero()
+	delete(lm.privateKeys, keyName)
+
+	lm.logger.Info("locked EOTS key",
+		zap.String("key_name", keyName),
+		zap.String("reason", reason),
+	)
+}
+
+// LockKey locks the key of the file keyring, which must be unlocked
+// again before signing with it
+func (lm *LocalEOTSManager) LockKey(fpPk []byte) error {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	keyName, err := lm.es.GetEOTSKeyName(fpPk)
+	if err != nil {
+		return fmt.Errorf("failed to get EOTS key name: %w", err)
+	}
+
+	if _, ok := lm.privateKeys[keyName]; !ok {
+		return fmt.Errorf("private key is not unlocked for key name: %s", keyName)
+	}
+
+	lm.lockKey(keyName, "requested")
+
+	return nil
+}
+
+// LockAllKeys locks all the keys of the file keyring, e.g., on shutdown
+func (lm *LocalEOTSManager) LockAllKeys() {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	for keyName := range lm.privateKeys {
+		lm.lockKey(keyName, "shutdown")
+	}
+}
+
+// ListUnlockedKeys returns the keys of the file keyring which are unlocked,
+// sorted by key name
+func (lm *LocalEOTSManager) ListUnlockedKeys() []UnlockedKey {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	keys := make([]UnlockedKey, 0, len(lm.privateKeys))
+	for keyName, s := range lm.privateKeys {
+		keys = append(keys, UnlockedKey{
+			KeyName:    keyName,
+			EotsPk:     s.eotsPk,
+			UnlockedAt: s.unlockedAt,
+			LastUsedAt: s.lastUsedAt,
+			ExpiresAt:  lm.expiresAt(s),
+		})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].KeyName < keys[j].KeyName })
+
+	return keys
+}
+
+// CheckKeyReady returns an error if the key cannot sign, i.e., it does not
+// exist or, for the file keyring, it is not unlocked
+func (lm *LocalEOTSManager) CheckKeyReady(keyName string) error {
+	if !lm.keyExists(keyName) {
+		return fmt.Errorf("key %s does not exist in the keyring", keyName)
+	}
+	if lm.kr.Backend() != keyring.BackendFile {
+		return nil
+	}
+
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	s, ok := lm.privateKeys[keyName]
+	if !ok || lm.sessionExpired(s, time.Now()) {
+		return fmt.Errorf("key %s is locked", keyName)
+	}
+
+	return nil
+}
+
+// lockExpiredKeys locks the keys whose session ended, so that their
+// private keys do not stay in memory until they are used again
+func (lm *LocalEOTSManager) lockExpiredKeys(now time.Time) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	for keyName, s := range lm.privateKeys {
+		if lm.sessionExpired(s, now) {
+			lm.lockKey(keyName, "expired")
+		}
+	}
+}
+
+// sweepUnlockSessions locks the expired keys until the manager is closed
+func (lm *LocalEOTSManager) sweepUnlockSessions() {
+	defer lm.wg.Done()
+
+	interval := maxSessionSweepInterval
+	for _, d := range []time.Duration{lm.unlockTTL, lm.unlockIdleTimeout} {
+		if d > 0 && d/4 < interval {
+			interval = d / 4
+		}
+	}
+	if interval < minSessionSweepInterval {
+		interval = minSessionSweepInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			lm.lockExpiredKeys(now)
+		case <-lm.quit:
+			return
+		}
+	}
+}
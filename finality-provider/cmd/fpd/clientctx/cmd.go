@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"cosmossdk.io/x/feegrant"
 	"github.com/babylonlabs-io/babylon/v4/app/params"
 	bstypes "github.com/babylonlabs-io/babylon/v4/x/btcstaking/types"
 	incentivetypes "github.com/babylonlabs-io/babylon/v4/x/incentive/types"
@@ -12,6 +13,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/std"
 	"github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
@@ -28,6 +30,8 @@ func PersistClientCtx(ctx client.Context) func(cmd *cobra.Command, _ []string) e
 		bstypes.RegisterInterfaces(encCfg.InterfaceRegistry)
 		incentivetypes.RegisterInterfaces(encCfg.InterfaceRegistry)
 		types.RegisterInterfaces(encCfg.InterfaceRegistry)
+		authz.RegisterInterfaces(encCfg.InterfaceRegistry)
+		feegrant.RegisterInterfaces(encCfg.InterfaceRegistry)
 
 		ctx = ctx.
 			WithCodec(encCfg.Codec).
@@ -3,11 +3,22 @@ package common
 
 import (
 	"fmt"
+	"path/filepath"
 
-	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
 	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/output"
 )
 
+// BackupOutput describes a backup of the fpd database
+type BackupOutput struct {
+	Path string `json:"path"`
+}
+
+func (o BackupOutput) Text() string {
+	return "Successfully created backup at: " + o.Path
+}
+
 func NewBackupCmd(binaryName string) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "backup",
@@ -55,14 +66,14 @@ func backup(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to get %s flag: %w", FpdDaemonAddressFlag, err)
 	}
 
-	grpcClient, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(rpcListener)
+	grpcClient, cleanUp, err := NewDaemonClient(cmd, rpcListener)
 	if err != nil {
 		return fmt.Errorf("failed to create grpc client: %w", err)
 	}
 
 	defer func() {
 		if err := cleanUp(); err != nil {
-			cmd.Printf("Failed to clean up grpc client: %v\n", err)
+			cmd.PrintErrf("Failed to clean up grpc client: %v\n", err)
 		}
 	}()
 
@@ -71,7 +82,5 @@ func backup(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to backup database: %w", err)
 	}
 
-	cmd.Printf("Successfully created backup at: %s/%s", backupDir, backupName)
-
-	return nil
+	return output.Print(cmd, BackupOutput{Path: filepath.Join(backupDir, backupName)})
 }
@@ -11,8 +11,7 @@ import (
 	clientctx "github.com/babylonlabs-io/finality-provider/finality-provider/cmd/fpd/clientctx"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
-	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
-	fptypes "github.com/babylonlabs-io/finality-provider/types"
+	"github.com/babylonlabs-io/finality-provider/output"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/spf13/cobra"
@@ -22,9 +21,42 @@ var (
 	defaultFpdDaemonAddress = "127.0.0.1:" + strconv.Itoa(fpcfg.DefaultRPCPort)
 )
 
+// UnjailFinalityProviderOutput describes the unjailing of a finality provider
+type UnjailFinalityProviderOutput struct {
+	BtcPk  string `json:"btc_pk"`
+	TxHash string `json:"tx_hash"`
+}
+
+func (o UnjailFinalityProviderOutput) Text() string {
+	return fmt.Sprintf("Successfully unjailed finality provider %s in tx %s", o.BtcPk, o.TxHash)
+}
+
+// EditFinalityProviderOutput describes the edition of a finality provider
+type EditFinalityProviderOutput struct {
+	BtcPk string `json:"btc_pk"`
+}
+
+func (o EditFinalityProviderOutput) Text() string {
+	return fmt.Sprintf("Successfully edited finality provider %s", o.BtcPk)
+}
+
+// PruneMerkleProofOutput describes the pruning of the Merkle proofs of a
+// finality provider
+type PruneMerkleProofOutput struct {
+	BtcPk        string `json:"btc_pk"`
+	ChainID      string `json:"chain_id"`
+	TargetHeight uint64 `json:"target_height"`
+}
+
+func (o PruneMerkleProofOutput) Text() string {
+	return fmt.Sprintf("Successfully pruned the merkle proofs of finality provider %s up to height %d", o.BtcPk, o.TargetHeight)
+}
+
 // AddCommonCommands adds all the common subcommands to the given command.
 // These commands are generic to {Babylon, Cosmos BSN, rollup BSN} finality providers
 func AddCommonCommands(cmd *cobra.Command, binaryName string) {
+	AddDaemonTLSFlags(cmd)
+	AddDaemonAuthFlags(cmd)
 	cmd.AddCommand(
 		CommandGetDaemonInfo(binaryName),
 		CommandUnjailFP(binaryName),
@@ -34,6 +66,8 @@ func AddCommonCommands(cmd *cobra.Command, binaryName string) {
 		CommandEditFinalityDescription(binaryName),
 		CommandUnsafePruneMerkleProof(binaryName),
 		NewBackupCmd(binaryName),
+		NewRotateKeyCmd(binaryName),
+		CommandReloadConfig(binaryName),
 	)
 }
 
@@ -58,13 +92,13 @@ func runCommandGetDaemonInfo(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to read flag %s: %w", FpdDaemonAddressFlag, err)
 	}
 
-	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	client, cleanUp, err := NewDaemonClient(cmd, daemonAddress)
 	if err != nil {
 		return fmt.Errorf("failed to create grpc client: %w", err)
 	}
 	defer func() {
 		if err := cleanUp(); err != nil {
-			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+			cmd.PrintErrf("Failed to clean up grpc client: %v\n", err)
 		}
 	}()
 
@@ -73,9 +107,7 @@ func runCommandGetDaemonInfo(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to get daemon info: %w", err)
 	}
 
-	fptypes.PrintRespJSON(cmd, info)
-
-	return nil
+	return output.Print(cmd, info)
 }
 
 // CommandUnjailFP returns the unjail-finality-provider command by connecting to the fpd daemon.
@@ -102,22 +134,22 @@ func runCommandUnjailFP(_ client.Context, cmd *cobra.Command, args []string) err
 		return fmt.Errorf("failed to read flag %s: %w", FpdDaemonAddressFlag, err)
 	}
 
-	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	client, cleanUp, err := NewDaemonClient(cmd, daemonAddress)
 	if err != nil {
 		return fmt.Errorf("failed to create grpc client: %w", err)
 	}
 	defer func() {
 		if err := cleanUp(); err != nil {
-			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+			cmd.PrintErrf("Failed to clean up grpc client: %v\n", err)
 		}
 	}()
 
-	_, err = client.UnjailFinalityProvider(cmd.Context(), args[0])
+	res, err := client.UnjailFinalityProvider(cmd.Context(), args[0])
 	if err != nil {
 		return fmt.Errorf("failed to unjail finality provider: %w", err)
 	}
 
-	return nil
+	return output.Print(cmd, UnjailFinalityProviderOutput{BtcPk: args[0], TxHash: res.TxHash})
 }
 
 // CommandLsFP returns the list-finality-providers command by connecting to the fpd daemon.
@@ -141,13 +173,13 @@ func runCommandLsFP(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to read flag %s: %w", FpdDaemonAddressFlag, err)
 	}
 
-	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	client, cleanUp, err := NewDaemonClient(cmd, daemonAddress)
 	if err != nil {
 		return fmt.Errorf("failed to create grpc client: %w", err)
 	}
 	defer func() {
 		if err := cleanUp(); err != nil {
-			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+			cmd.PrintErrf("Failed to clean up grpc client: %v\n", err)
 		}
 	}()
 
@@ -155,9 +187,7 @@ func runCommandLsFP(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to query finality provider list: %w", err)
 	}
-	fptypes.PrintRespJSON(cmd, resp)
-
-	return nil
+	return output.Print(cmd, resp)
 }
 
 // CommandInfoFP returns the finality-provider-info command by connecting to the fpd daemon.
@@ -186,13 +216,13 @@ func runCommandInfoFP(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to read flag %s: %w", FpdDaemonAddressFlag, err)
 	}
 
-	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	client, cleanUp, err := NewDaemonClient(cmd, daemonAddress)
 	if err != nil {
 		return fmt.Errorf("failed to create grpc client: %w", err)
 	}
 	defer func() {
 		if err := cleanUp(); err != nil {
-			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+			cmd.PrintErrf("Failed to clean up grpc client: %v\n", err)
 		}
 	}()
 
@@ -200,9 +230,7 @@ func runCommandInfoFP(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to query finality provider info: %w", err)
 	}
-	fptypes.PrintRespJSON(cmd, resp)
-
-	return nil
+	return output.Print(cmd, resp)
 }
 
 // CommandAddFinalitySig returns the add-finality-sig command by connecting to the fpd daemon.
@@ -270,13 +298,13 @@ func runCommandAddFinalitySig(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to read flag %s: %w", CheckDoubleSignFlag, err)
 	}
 
-	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	client, cleanUp, err := NewDaemonClient(cmd, daemonAddress)
 	if err != nil {
 		return fmt.Errorf("failed to create grpc client: %w", err)
 	}
 	defer func() {
 		if err := cleanUp(); err != nil {
-			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+			cmd.PrintErrf("Failed to clean up grpc client: %v\n", err)
 		}
 	}()
 
@@ -284,9 +312,7 @@ func runCommandAddFinalitySig(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to add finality signature: %w", err)
 	}
-	fptypes.PrintRespJSON(cmd, res)
-
-	return nil
+	return output.Print(cmd, res)
 }
 
 // CommandEditFinalityDescription edits description of finality provider
@@ -326,13 +352,13 @@ func runCommandEditFinalityDescription(cmd *cobra.Command, args []string) error
 		return fmt.Errorf("failed to read flag %s: %w", FpdDaemonAddressFlag, err)
 	}
 
-	grpcClient, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	grpcClient, cleanUp, err := NewDaemonClient(cmd, daemonAddress)
 	if err != nil {
 		return fmt.Errorf("failed to create grpc client: %w", err)
 	}
 	defer func() {
 		if err := cleanUp(); err != nil {
-			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+			cmd.PrintErrf("Failed to clean up grpc client: %v\n", err)
 		}
 	}()
 
@@ -355,7 +381,7 @@ func runCommandEditFinalityDescription(cmd *cobra.Command, args []string) error
 		return fmt.Errorf("failed to edit finality provider %v err %w", fpPk.MarshalHex(), err)
 	}
 
-	return nil
+	return output.Print(cmd, EditFinalityProviderOutput{BtcPk: fpPk.MarshalHex()})
 }
 
 // CommandUnsafePruneMerkleProof prunes merkle proof
@@ -398,13 +424,13 @@ func runCommandUnsafePruneMerkleProof(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to read flag %s: %w", FpdDaemonAddressFlag, err)
 	}
 
-	grpcClient, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	grpcClient, cleanUp, err := NewDaemonClient(cmd, daemonAddress)
 	if err != nil {
 		return fmt.Errorf("failed to create grpc client: %w", err)
 	}
 	defer func() {
 		if err := cleanUp(); err != nil {
-			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+			cmd.PrintErrf("Failed to clean up grpc client: %v\n", err)
 		}
 	}()
 
@@ -415,5 +441,5 @@ func runCommandUnsafePruneMerkleProof(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to remove merkle proof %v err %w", fpPk.MarshalHex(), err)
 	}
 
-	return nil
+	return output.Print(cmd, PruneMerkleProofOutput{BtcPk: fpPk.MarshalHex(), ChainID: chainID, TargetHeight: targetHeight})
 }
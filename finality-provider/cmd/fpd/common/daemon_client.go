@@ -0,0 +1,81 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	eotsclient "github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// AddDaemonTLSFlags adds the flags to connect to a daemon serving TLS to all the
+// sub commands of cmd
+func AddDaemonTLSFlags(cmd *cobra.Command) {
+	f := cmd.PersistentFlags()
+	f.String(FpdDaemonTLSCAFileFlag, "", "The path to the CA bundle used to verify the certificate of fpd; TLS is disabled if empty")
+	f.String(FpdDaemonTLSCertFileFlag, "", "The path to the client certificate presented to fpd")
+	f.String(FpdDaemonTLSKeyFileFlag, "", "The path to the private key of the client certificate")
+	f.String(FpdDaemonTLSServerNameFlag, "", "The name expected in the certificate of fpd")
+}
+
+// AddDaemonAuthFlags adds the flags to authenticate with a daemon to all the
+// sub commands of cmd. The secrets can be references such as env://NAME or
+// file:///path, so that they do not show in the process list.
+func AddDaemonAuthFlags(cmd *cobra.Command) {
+	f := cmd.PersistentFlags()
+	f.String(FpdDaemonHMACKeyFlag, "", "The HMAC key presented to fpd, or a reference to it")
+	f.String(FpdDaemonHMACKeyIDFlag, "default", "The ID of the HMAC key, as in the rpcauth section of the fpd config")
+	f.String(FpdDaemonTokenFlag, "", "The bearer token presented to fpd, or a reference to it")
+}
+
+// NewDaemonClient creates a client connecting to the fpd daemon, with TLS if
+// the daemon TLS flags are set, and presenting the credentials of the daemon
+// auth flags
+func NewDaemonClient(cmd *cobra.Command, daemonAddress string) (*dc.FinalityProviderServiceGRpcClient, func() error, error) {
+	flagValues := make(map[string]string)
+	for _, name := range []string{
+		FpdDaemonTLSCAFileFlag, FpdDaemonTLSCertFileFlag, FpdDaemonTLSKeyFileFlag, FpdDaemonTLSServerNameFlag,
+		FpdDaemonHMACKeyFlag, FpdDaemonHMACKeyIDFlag, FpdDaemonTokenFlag,
+	} {
+		// the flags are not registered by every binary
+		if f := cmd.Flags().Lookup(name); f != nil {
+			flagValues[name] = f.Value.String()
+		}
+	}
+
+	var opts []grpc.DialOption
+	if caFile := flagValues[FpdDaemonTLSCAFileFlag]; caFile != "" {
+		tlsCfg, err := util.LoadClientTLSConfig(
+			caFile,
+			flagValues[FpdDaemonTLSCertFileFlag],
+			flagValues[FpdDaemonTLSKeyFileFlag],
+			flagValues[FpdDaemonTLSServerNameFlag],
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load daemon TLS config: %w", err)
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+	}
+
+	hmacKey, err := eotsclient.ProcessHMACKey(flagValues[FpdDaemonHMACKeyFlag])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get the daemon HMAC key: %w", err)
+	}
+	if hmacKey != "" {
+		opts = append(opts, dc.WithHMACKey(hmacKey, flagValues[FpdDaemonHMACKeyIDFlag])...)
+	}
+
+	token, err := eotsclient.GetSecretValue(flagValues[FpdDaemonTokenFlag])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get the daemon token: %w", err)
+	}
+	if token != "" {
+		opts = append(opts, dc.WithBearerToken(token))
+	}
+
+	return dc.NewFinalityProviderServiceGRpcClient(daemonAddress, opts...)
+}
@@ -13,6 +13,8 @@ const (
 	FromFileFlag         = "from-file"
 	UpToHeightFlag       = "up-to-height"
 
+	EnableUnsafeEndpointsFlag = "enable-unsafe-endpoints"
+
 	// flags for description
 	MonikerFlag         = "moniker"
 	IdentityFlag        = "identity"
@@ -25,6 +27,17 @@ const (
 	CommissionMaxRateFlag       = "commission-max-rate"
 	CommissionMaxChangeRateFlag = "commission-max-change-rate"
 
+	// flags for the TLS connection to the daemon
+	FpdDaemonTLSCAFileFlag     = "daemon-tls-ca-file"
+	FpdDaemonTLSCertFileFlag   = "daemon-tls-cert-file"
+	FpdDaemonTLSKeyFileFlag    = "daemon-tls-key-file"
+	FpdDaemonTLSServerNameFlag = "daemon-tls-server-name"
+
+	// flags for the credentials presented to the daemon
+	FpdDaemonHMACKeyFlag   = "daemon-hmac-key"
+	FpdDaemonHMACKeyIDFlag = "daemon-hmac-key-id"
+	FpdDaemonTokenFlag     = "daemon-token"
+
 	flagDBPath    = "db-path"
 	flagBackupDir = "backup-dir"
 )
@@ -0,0 +1,47 @@
+//nolint:revive
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+	"github.com/babylonlabs-io/finality-provider/output"
+	"github.com/spf13/cobra"
+)
+
+// CommandReloadConfig returns the reload-config command by connecting to the fpd daemon.
+func CommandReloadConfig(binaryName string) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "reload-config",
+		Short: "Apply the changes of fpd.conf to the running fpd daemon.",
+		Long: strings.TrimSpace(`
+Read fpd.conf again and apply the changes of the fields below to the running finality
+providers, as sending SIGHUP to the fpd daemon does:
+  - BatchSubmissionSize
+  - SignatureSubmissionInterval
+  - RandomnessCommitInterval
+  - PollInterval of [chainpollerconfig]
+  - GasPrices of [babylon]
+
+The changes of any other field, such as the keys, the chain ID or the database path,
+require a restart, so nothing is applied if the config changes one of them.`),
+		Example: fmt.Sprintf(`%s reload-config --daemon-address %s`, binaryName, defaultFpdDaemonAddress),
+		Args:    cobra.NoArgs,
+		RunE:    runCommandReloadConfig,
+	}
+	cmd.Flags().String(FpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+
+	return cmd
+}
+
+func runCommandReloadConfig(cmd *cobra.Command, _ []string) error {
+	return withDaemonClient(cmd, func(client *dc.FinalityProviderServiceGRpcClient) error {
+		res, err := client.ReloadConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		return output.Print(cmd, res)
+	})
+}
@@ -0,0 +1,159 @@
+//nolint:revive
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+	"github.com/babylonlabs-io/finality-provider/output"
+	"github.com/spf13/cobra"
+)
+
+// NewRotateKeyCmd returns the rotate-key command which migrates a finality
+// provider to a fresh EOTS key by connecting to the fpd daemon.
+func NewRotateKeyCmd(binaryName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate-key",
+		Short: "Rotate the EOTS key of a finality provider.",
+		Long: strings.TrimSpace(`
+EOTS keys cannot be changed on Babylon, so the rotation registers a new finality
+provider with a fresh EOTS key and runs it in parallel with the current one until
+the delegators migrate their stake to it. Then, the old key is retired: it stops
+committing public randomness but keeps voting up to the last height it committed
+randomness for, after which the daemon only runs the new finality provider.
+
+The workflow is:
+  1. "rotate-key start" once the new EOTS key and Babylon key are created
+  2. "rotate-key retire" once the delegators migrated
+  3. "rotate-key status" to follow the rotation until it is RETIRED`),
+	}
+
+	cmd.AddCommand(
+		CommandStartKeyRotation(binaryName),
+		CommandRetireKey(binaryName),
+		CommandKeyRotationStatus(binaryName),
+	)
+
+	return cmd
+}
+
+// CommandStartKeyRotation returns the rotate-key start command
+func CommandStartKeyRotation(binaryName string) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "start [old-eots-pk] [new-eots-pk]",
+		Short: "Register a new finality provider with the new EOTS key and run it in parallel with the old one.",
+		Long: strings.TrimSpace(`
+Register a new finality provider with the new EOTS key, copying the description and
+commission of the finality provider of the old EOTS key, which must be running in the
+daemon. The new finality provider is registered from the Babylon key given by --key-name,
+which must differ from the key of the old finality provider as Babylon allows a single
+finality provider per account. The authz granter and fee granter of the config are not
+used by the new finality provider.`),
+		Example: fmt.Sprintf(`%s rotate-key start [old-eots-pk] [new-eots-pk] --key-name new-fp-key --daemon-address %s`,
+			binaryName, defaultFpdDaemonAddress),
+		Args: cobra.ExactArgs(2),
+		RunE: runCommandStartKeyRotation,
+	}
+
+	f := cmd.Flags()
+	f.String(FpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	f.String(KeyNameFlag, "", "The name of the Babylon key registering the new finality provider")
+
+	if err := cmd.MarkFlagRequired(KeyNameFlag); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
+
+func runCommandStartKeyRotation(cmd *cobra.Command, args []string) error {
+	keyName, err := cmd.Flags().GetString(KeyNameFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", KeyNameFlag, err)
+	}
+
+	return withDaemonClient(cmd, func(client *dc.FinalityProviderServiceGRpcClient) error {
+		res, err := client.StartKeyRotation(cmd.Context(), args[0], args[1], keyName)
+		if err != nil {
+			return err
+		}
+
+		return output.Print(cmd, res)
+	})
+}
+
+// CommandRetireKey returns the rotate-key retire command
+func CommandRetireKey(binaryName string) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "retire [old-eots-pk]",
+		Short: "Stop committing public randomness for the rotated EOTS key.",
+		Long: strings.TrimSpace(`
+Stop committing public randomness for the rotated EOTS key. The finality provider keeps
+voting with the randomness it already committed, and is stopped once it voted up to the
+last committed height or lost its voting power. It is not possible to undo the retirement.`),
+		Example: fmt.Sprintf(`%s rotate-key retire [old-eots-pk] --daemon-address %s`, binaryName, defaultFpdDaemonAddress),
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCommandRetireKey,
+	}
+
+	cmd.Flags().String(FpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+
+	return cmd
+}
+
+func runCommandRetireKey(cmd *cobra.Command, args []string) error {
+	return withDaemonClient(cmd, func(client *dc.FinalityProviderServiceGRpcClient) error {
+		res, err := client.RetireKey(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		return output.Print(cmd, res)
+	})
+}
+
+// CommandKeyRotationStatus returns the rotate-key status command
+func CommandKeyRotationStatus(binaryName string) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:     "status",
+		Short:   "List the EOTS key rotations tracked by the daemon.",
+		Example: fmt.Sprintf(`%s rotate-key status --daemon-address %s`, binaryName, defaultFpdDaemonAddress),
+		Args:    cobra.NoArgs,
+		RunE:    runCommandKeyRotationStatus,
+	}
+
+	cmd.Flags().String(FpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+
+	return cmd
+}
+
+func runCommandKeyRotationStatus(cmd *cobra.Command, _ []string) error {
+	return withDaemonClient(cmd, func(client *dc.FinalityProviderServiceGRpcClient) error {
+		res, err := client.QueryKeyRotationList(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		return output.Print(cmd, res)
+	})
+}
+
+func withDaemonClient(cmd *cobra.Command, run func(client *dc.FinalityProviderServiceGRpcClient) error) error {
+	daemonAddress, err := cmd.Flags().GetString(FpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", FpdDaemonAddressFlag, err)
+	}
+
+	client, cleanUp, err := NewDaemonClient(cmd, daemonAddress)
+	if err != nil {
+		return fmt.Errorf("failed to create grpc client: %w", err)
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			cmd.PrintErrf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	return run(client)
+}
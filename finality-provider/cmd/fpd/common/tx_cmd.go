@@ -0,0 +1,200 @@
+//nolint:revive
+package common
+
+import (
+	"fmt"
+	"time"
+
+	"cosmossdk.io/x/feegrant"
+	finalitytypes "github.com/babylonlabs-io/babylon/v4/x/finality/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authcli "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ExpirationFlag      = "expiration"
+	SpendLimitFlag      = "spend-limit"
+	AllowedMessagesFlag = "allowed-messages"
+)
+
+// HotKeyMsgTypeURLs are the finality provider messages the hot key is allowed
+// to submit on behalf of the finality provider through authz
+var HotKeyMsgTypeURLs = []string{
+	sdk.MsgTypeURL(&finalitytypes.MsgAddFinalitySig{}),
+	sdk.MsgTypeURL(&finalitytypes.MsgCommitPubRandList{}),
+}
+
+// AddTxCommands adds the tx subcommands used to set up a hot key submitting
+// on behalf of a cold finality provider key, and to sign and broadcast the
+// generated transactions. Commands specific to the finality provider
+// implementation, such as building the registration tx, are passed as extraCmds.
+func AddTxCommands(cmd *cobra.Command, binaryName string, extraCmds ...*cobra.Command) {
+	txCmd := &cobra.Command{
+		Use:   "tx",
+		Short: "Generate, sign and broadcast transactions of the finality provider",
+		RunE:  client.ValidateCmd,
+	}
+
+	txCmd.AddCommand(
+		CommandGrantHotKeyAuthz(binaryName),
+		CommandGrantHotKeyFeeAllowance(binaryName),
+		authcli.GetSignCommand(),
+		authcli.GetBroadcastCommand(),
+	)
+	txCmd.AddCommand(extraCmds...)
+
+	cmd.AddCommand(txCmd)
+}
+
+// CommandGrantHotKeyAuthz returns the grant-authz command which allows a hot key
+// to submit finality votes and public randomness commits on behalf of the
+// finality provider.
+func CommandGrantHotKeyAuthz(binaryName string) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "grant-authz [grantee]",
+		Short: "Grant a hot key the permission to submit finality provider messages through authz",
+		Long: `Grant a hot key the permission to submit MsgAddFinalitySig and MsgCommitPubRandList
+on behalf of the finality provider through authz MsgExec.
+The --from is the finality provider address. Use --generate-only to produce the unsigned
+transaction, sign it with "tx sign" on the machine holding the finality provider key,
+and submit it with "tx broadcast".`,
+		Example: fmt.Sprintf(`%s tx grant-authz bbn1grantee... --from bbn1fp... --generate-only > grant.json`, binaryName),
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCommandGrantHotKeyAuthz,
+	}
+
+	cmd.Flags().String(ExpirationFlag, "", "Optional RFC3339 expiration time of the grant, e.g. 2030-01-01T00:00:00Z")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func runCommandGrantHotKeyAuthz(cmd *cobra.Command, args []string) error {
+	clientCtx, err := client.GetClientTxContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get client tx context: %w", err)
+	}
+
+	grantee, err := sdk.AccAddressFromBech32(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid grantee address %s: %w", args[0], err)
+	}
+
+	expiration, err := getExpiration(cmd)
+	if err != nil {
+		return err
+	}
+
+	msgs := make([]sdk.Msg, 0, len(HotKeyMsgTypeURLs))
+	for _, typeURL := range HotKeyMsgTypeURLs {
+		msg, err := authz.NewMsgGrant(clientCtx.GetFromAddress(), grantee, authz.NewGenericAuthorization(typeURL), expiration)
+		if err != nil {
+			return fmt.Errorf("failed to create grant for %s: %w", typeURL, err)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	//nolint:wrapcheck
+	return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msgs...)
+}
+
+// CommandGrantHotKeyFeeAllowance returns the grant-fee-allowance command which
+// allows a hot key to pay the fees of its authz MsgExec txs from the granter balance.
+func CommandGrantHotKeyFeeAllowance(binaryName string) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "grant-fee-allowance [grantee]",
+		Short: "Grant a hot key a fee allowance to pay for the finality provider transactions",
+		Long: `Grant a hot key a feegrant allowance so the fees of the transactions it submits are paid
+by the granter. By default the allowance is restricted to authz MsgExec transactions.
+The --from is the fee granter address. Use --generate-only to produce the unsigned
+transaction, sign it with "tx sign" on the machine holding the granter key,
+and submit it with "tx broadcast".`,
+		Example: fmt.Sprintf(`%s tx grant-fee-allowance bbn1grantee... --from bbn1fp... --spend-limit 1000000ubbn --generate-only > allowance.json`, binaryName),
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCommandGrantHotKeyFeeAllowance,
+	}
+
+	f := cmd.Flags()
+	f.String(ExpirationFlag, "", "Optional RFC3339 expiration time of the allowance, e.g. 2030-01-01T00:00:00Z")
+	f.String(SpendLimitFlag, "", "Optional maximum amount of fees the grantee can spend, e.g. 1000000ubbn")
+	f.StringSlice(AllowedMessagesFlag, []string{sdk.MsgTypeURL(&authz.MsgExec{})}, "Message type URLs the allowance can pay fees for")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func runCommandGrantHotKeyFeeAllowance(cmd *cobra.Command, args []string) error {
+	clientCtx, err := client.GetClientTxContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get client tx context: %w", err)
+	}
+
+	grantee, err := sdk.AccAddressFromBech32(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid grantee address %s: %w", args[0], err)
+	}
+
+	f := cmd.Flags()
+	spendLimitStr, err := f.GetString(SpendLimitFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", SpendLimitFlag, err)
+	}
+
+	allowedMsgs, err := f.GetStringSlice(AllowedMessagesFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", AllowedMessagesFlag, err)
+	}
+
+	expiration, err := getExpiration(cmd)
+	if err != nil {
+		return err
+	}
+
+	basic := feegrant.BasicAllowance{
+		Expiration: expiration,
+	}
+
+	if spendLimitStr != "" {
+		spendLimit, err := sdk.ParseCoinsNormalized(spendLimitStr)
+		if err != nil {
+			return fmt.Errorf("invalid spend limit %s: %w", spendLimitStr, err)
+		}
+		basic.SpendLimit = spendLimit
+	}
+
+	allowance, err := feegrant.NewAllowedMsgAllowance(&basic, allowedMsgs)
+	if err != nil {
+		return fmt.Errorf("failed to create allowed msg allowance: %w", err)
+	}
+
+	msg, err := feegrant.NewMsgGrantAllowance(allowance, clientCtx.GetFromAddress(), grantee)
+	if err != nil {
+		return fmt.Errorf("failed to create grant allowance msg: %w", err)
+	}
+
+	//nolint:wrapcheck
+	return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+}
+
+func getExpiration(cmd *cobra.Command) (*time.Time, error) {
+	expirationStr, err := cmd.Flags().GetString(ExpirationFlag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read flag %s: %w", ExpirationFlag, err)
+	}
+
+	if expirationStr == "" {
+		return nil, nil
+	}
+
+	expiration, err := time.Parse(time.RFC3339, expirationStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expiration %s: %w", expirationStr, err)
+	}
+
+	return &expiration, nil
+}
@@ -0,0 +1,184 @@
+package daemon
+
+import (
+	"fmt"
+	"strings"
+
+	bbntypes "github.com/babylonlabs-io/babylon/v4/types"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/v4/x/btcstaking/types"
+	commoncmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd/fpd/common"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/cosmos/cosmos-sdk/client"
+	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/spf13/cobra"
+)
+
+// CommandBuildRegister returns the build-register command which generates the
+// unsigned registration tx of a finality provider whose keys are kept offline.
+func CommandBuildRegister(binaryName string) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "build-register",
+		Short: "Build the unsigned MsgCreateFinalityProvider tx for an offline registration",
+		Long: strings.TrimSpace(`
+Build the unsigned transaction registering a finality provider without access to
+its EOTS or Babylon keys. The tx contains a MsgCreateFinalityProvider with an empty
+proof of possession, and is always generated as if --generate-only was set.
+
+The offline registration then continues with:
+  1. "eotsd pop sign-registration" on the EOTS host, which fills the proof of possession
+  2. "tx sign --offline" on the host holding the finality provider key
+  3. "tx broadcast" from any host connected to Babylon
+
+The --from is the finality provider address, which does not need to be in the keyring.`),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+%s tx build-register --from bbn1fp... --chain-id bbn-1 --eots-pk <hex> --moniker my-fp \
+  --commission-rate 0.05 --commission-max-rate 0.20 --commission-max-change-rate 0.01 > register-unsigned.json
+
+Or providing the path to the same finality-provider.json used by create-finality-provider:
+%s tx build-register --from bbn1fp... --chain-id bbn-1 --from-file /path/to/finality-provider.json > register-unsigned.json`,
+			binaryName, binaryName)),
+		Args: cobra.NoArgs,
+		RunE: runCommandBuildRegister,
+	}
+
+	f := cmd.Flags()
+	f.String(commoncmd.CommissionRateFlag, "", "The initial commission rate for the finality provider, e.g., 0.05")
+	f.String(commoncmd.CommissionMaxRateFlag, "", "The maximum commission rate percentage for the finality provider, e.g., 0.20")
+	f.String(commoncmd.CommissionMaxChangeRateFlag, "", "The maximum commission change rate percentage (per day) for the finality provider, e.g., 0.01")
+	f.String(commoncmd.MonikerFlag, "", "A human-readable name for the finality provider")
+	f.String(commoncmd.IdentityFlag, "", "An optional identity signature (ex. UPort or Keybase)")
+	f.String(commoncmd.WebsiteFlag, "", "An optional website link")
+	f.String(commoncmd.SecurityContactFlag, "", "An email for security contact")
+	f.String(commoncmd.DetailsFlag, "", "Other optional details")
+	f.String(commoncmd.FpEotsPkFlag, "", "The hex string of the finality provider's EOTS public key")
+	f.String(commoncmd.FromFileFlag, "", "Path to a json file containing finality provider data")
+	sdkflags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func runCommandBuildRegister(cmd *cobra.Command, _ []string) error {
+	// the finality provider key is not expected to be on this host,
+	// so the tx is only generated and --from can be a plain address
+	if err := cmd.Flags().Set(sdkflags.FlagGenerateOnly, "true"); err != nil {
+		return fmt.Errorf("failed to set flag %s: %w", sdkflags.FlagGenerateOnly, err)
+	}
+
+	clientCtx, err := client.GetClientTxContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get client tx context: %w", err)
+	}
+
+	if clientCtx.GetFromAddress().Empty() {
+		return fmt.Errorf("the finality provider address must be set with --%s", sdkflags.FlagFrom)
+	}
+
+	fp, err := parseBuildRegisterInput(cmd)
+	if err != nil {
+		return err
+	}
+
+	msg, err := buildUnsignedRegistrationMsg(clientCtx.GetFromAddress().String(), fp)
+	if err != nil {
+		return err
+	}
+
+	txf, err := tx.NewFactoryCLI(clientCtx, cmd.Flags())
+	if err != nil {
+		return fmt.Errorf("failed to create tx factory: %w", err)
+	}
+
+	// the msg is printed directly, as its ValidateBasic fails until the
+	// proof of possession is filled in by eotsd
+	//nolint:wrapcheck
+	return txf.PrintUnsignedTx(clientCtx, msg)
+}
+
+func parseBuildRegisterInput(cmd *cobra.Command) (*ParsedFinalityProvider, error) {
+	flags := cmd.Flags()
+
+	fpJSONPath, err := flags.GetString(commoncmd.FromFileFlag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read flag %s: %w", commoncmd.FromFileFlag, err)
+	}
+
+	if fpJSONPath != "" {
+		return ParseFinalityProviderJSON(fpJSONPath)
+	}
+
+	commissionRateStr, err := flags.GetString(commoncmd.CommissionRateFlag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read flag %s: %w", commoncmd.CommissionRateFlag, err)
+	}
+
+	commissionMaxRateStr, err := flags.GetString(commoncmd.CommissionMaxRateFlag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read flag %s: %w", commoncmd.CommissionMaxRateFlag, err)
+	}
+
+	commissionMaxChangeRateStr, err := flags.GetString(commoncmd.CommissionMaxChangeRateFlag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read flag %s: %w", commoncmd.CommissionMaxChangeRateFlag, err)
+	}
+
+	commRates, err := getCommissionRates(commissionRateStr, commissionMaxRateStr, commissionMaxChangeRateStr)
+	if err != nil {
+		return nil, err
+	}
+
+	description, err := getDescriptionFromFlags(flags)
+	if err != nil {
+		return nil, fmt.Errorf("invalid description: %w", err)
+	}
+
+	eotsPkHex, err := flags.GetString(commoncmd.FpEotsPkFlag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read flag %s: %w", commoncmd.FpEotsPkFlag, err)
+	}
+
+	if eotsPkHex == "" {
+		return nil, fmt.Errorf("eots-pk cannot be empty")
+	}
+
+	return &ParsedFinalityProvider{
+		EotsPK:          eotsPkHex,
+		Description:     description,
+		CommissionRates: commRates,
+	}, nil
+}
+
+// buildUnsignedRegistrationMsg builds the MsgCreateFinalityProvider of the offline
+// registration. It performs the same checks as the msg ValidateBasic, except for
+// the proof of possession which is left empty.
+func buildUnsignedRegistrationMsg(fpAddr string, fp *ParsedFinalityProvider) (*btcstakingtypes.MsgCreateFinalityProvider, error) {
+	eotsPk, err := bbntypes.NewBIP340PubKeyFromHex(fp.EotsPK)
+	if err != nil {
+		return nil, fmt.Errorf("invalid eots public key %s: %w", fp.EotsPK, err)
+	}
+
+	commission, err := (&proto.CreateFinalityProviderRequest{Commission: fp.CommissionRates}).GetCommissionRates()
+	if err != nil {
+		return nil, fmt.Errorf("invalid commission rates: %w", err)
+	}
+
+	if err := commission.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid commission rates: %w", err)
+	}
+
+	if fp.Description.Moniker == "" {
+		return nil, fmt.Errorf("moniker cannot be empty")
+	}
+
+	description, err := fp.Description.EnsureLength()
+	if err != nil {
+		return nil, fmt.Errorf("invalid description: %w", err)
+	}
+
+	return &btcstakingtypes.MsgCreateFinalityProvider{
+		Addr:        fpAddr,
+		Description: &description,
+		Commission:  commission,
+		BtcPk:       eotsPk,
+	}, nil
+}
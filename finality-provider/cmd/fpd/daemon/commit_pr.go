@@ -14,16 +14,27 @@ import (
 
 	fpcc "github.com/babylonlabs-io/finality-provider/clientcontroller"
 	"github.com/babylonlabs-io/finality-provider/clientcontroller/babylon"
-	eotsclient "github.com/babylonlabs-io/finality-provider/eotsmanager/client"
 	clientctx "github.com/babylonlabs-io/finality-provider/finality-provider/cmd/fpd/clientctx"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
 	"github.com/babylonlabs-io/finality-provider/log"
 	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/output"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
 
+// CommitPubRandOutput describes the public randomness committed for a
+// finality provider
+type CommitPubRandOutput struct {
+	BtcPk        string `json:"btc_pk"`
+	TargetHeight uint64 `json:"target_height"`
+}
+
+func (o CommitPubRandOutput) Text() string {
+	return fmt.Sprintf("Successfully committed public randomness of finality provider %s up to height %d", o.BtcPk, o.TargetHeight)
+}
+
 // CommandCommitPubRand returns the commit-pubrand command by connecting to the fpd daemon.
 func CommandCommitPubRand(binaryName string) *cobra.Command {
 	cmd := CommandCommitPubRandTemplate(binaryName)
@@ -109,7 +120,7 @@ func RunCommandCommitPubRandWithConfig(_ client.Context, cmd *cobra.Command, hom
 	if err != nil {
 		return fmt.Errorf("failed to create rpc client for the consumer chain: %w", err)
 	}
-	em, err := eotsclient.NewEOTSManagerGRPCClient(cfg.EOTSManagerAddress, cfg.HMACKey)
+	em, err := service.NewEOTSManagerFromConfig(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create EOTS manager client: %w", err)
 	}
@@ -145,12 +156,12 @@ func RunCommandCommitPubRandWithConfig(_ client.Context, cmd *cobra.Command, hom
 			return fmt.Errorf("failed to commit pubrand: %w", err)
 		}
 
-		return nil
+		return output.Print(cmd, CommitPubRandOutput{BtcPk: fpPk.MarshalHex(), TargetHeight: targetHeight})
 	}
 
 	if err := fpTester.CommitPubRandWithStartHeight(cmd.Context(), startHeight, targetHeight); err != nil {
 		return fmt.Errorf("failed to commit pubrand with start height: %w", err)
 	}
 
-	return nil
+	return output.Print(cmd, CommitPubRandOutput{BtcPk: fpPk.MarshalHex(), TargetHeight: targetHeight})
 }
@@ -12,8 +12,7 @@ import (
 	commoncmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd/fpd/common"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
-	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
-	"github.com/babylonlabs-io/finality-provider/types"
+	"github.com/babylonlabs-io/finality-provider/output"
 	"github.com/cosmos/cosmos-sdk/client"
 	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
@@ -154,13 +153,13 @@ func runCommandCreateFP(ctx client.Context, cmd *cobra.Command, _ []string) erro
 		return fmt.Errorf("failed to read flag %s: %w", commoncmd.FpdDaemonAddressFlag, err)
 	}
 
-	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	client, cleanUp, err := commoncmd.NewDaemonClient(cmd, daemonAddress)
 	if err != nil {
 		return fmt.Errorf("failed to create finality provider service grpc client: %w", err)
 	}
 	defer func() {
 		if err := cleanUp(); err != nil {
-			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+			cmd.PrintErrf("Failed to clean up grpc client: %v\n", err)
 		}
 	}()
 
@@ -176,9 +175,11 @@ func runCommandCreateFP(ctx client.Context, cmd *cobra.Command, _ []string) erro
 		return fmt.Errorf("failed to create finality provider: %w", err)
 	}
 
-	types.PrintRespJSON(cmd, res)
+	if err := output.Print(cmd, res); err != nil {
+		return err
+	}
 
-	cmd.Println("Finality provider created successfully. Please restart the fpd.")
+	output.Notef(cmd, "Finality provider created successfully. Please restart the fpd.")
 
 	return nil
 }
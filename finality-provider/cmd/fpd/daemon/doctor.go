@@ -0,0 +1,205 @@
+package daemon
+
+import (
+	"fmt"
+	"path/filepath"
+
+	bbntypes "github.com/babylonlabs-io/babylon/v4/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/api"
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/babylon"
+	eotsclient "github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	clientctx "github.com/babylonlabs-io/finality-provider/finality-provider/cmd/fpd/clientctx"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/log"
+	"github.com/babylonlabs-io/finality-provider/output"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// CommandDoctor returns the doctor command, which reads the fpd database directly.
+func CommandDoctor(binaryName string) *cobra.Command {
+	cmd := CommandDoctorTemplate(binaryName)
+	cmd.RunE = clientctx.RunEWithClientCtx(runCommandDoctor)
+
+	return cmd
+}
+
+// CommandDoctorTemplate returns the doctor command template
+// One needs to set the RunE function to the command after creating it
+func CommandDoctorTemplate(binaryName string) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "doctor [fp-eots-pk-hex]",
+		Short: "Check the consistency of the fpd database, the eotsd sign store and the votes on chain",
+		Long: `Check the consistency of the state of finality providers in the fpd database, in the sign store
+of eotsd and on the consumer chain, and report the gaps, inconsistencies, missing Merkle proofs and
+unsafe conditions with the actions to recover from them. All the finality providers of the fpd
+database are checked unless one is given. fpd must be stopped, while eotsd must be running.
+The command fails if any finding is an error.`,
+		Example: fmt.Sprintf(`%s doctor --home /home/user/.fpd [fp-eots-pk-hex]`, binaryName),
+		Args:    cobra.MaximumNArgs(1),
+	}
+	cmd.Flags().String(flags.FlagHome, fpcfg.DefaultFpdDir, "The application home directory")
+
+	return cmd
+}
+
+func runCommandDoctor(ctx client.Context, cmd *cobra.Command, args []string) error {
+	homePath, err := filepath.Abs(ctx.HomeDir)
+	if err != nil {
+		return fmt.Errorf("failed to get home path: %w", err)
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	cfg, err := fpcfg.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	logger, err := log.NewRootLoggerWithFile(fpcfg.LogFile(homePath), cfg.LogLevel)
+	if err != nil {
+		return fmt.Errorf("failed to initialize the logger: %w", err)
+	}
+
+	bcc, err := babylon.NewBabylonConsumerController(cfg.BabylonConfig, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create Babylon rpc client: %w", err)
+	}
+
+	db, err := cfg.DatabaseConfig.GetDBBackend()
+	if err != nil {
+		return fmt.Errorf("failed to create db backend: %w", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			cmd.PrintErrf("Error closing db: %v\n", err)
+		}
+	}()
+
+	fpStore, err := store.NewFinalityProviderStore(db)
+	if err != nil {
+		return fmt.Errorf("failed to initiate finality provider store: %w", err)
+	}
+
+	pubRandStore, err := store.NewPubRandProofStore(db)
+	if err != nil {
+		return fmt.Errorf("failed to initiate public randomness store: %w", err)
+	}
+
+	return RunCommandDoctorWithConfig(ctx, cmd, cfg, bcc, fpStore, pubRandStore, args)
+}
+
+func RunCommandDoctorWithConfig(_ client.Context, cmd *cobra.Command, cfg *fpcfg.Config,
+	consumerCtrl api.ConsumerController, fpStore *store.FinalityProviderStore,
+	pubRandStore *store.PubRandProofStore, args []string,
+) error {
+	var fps []*store.StoredFinalityProvider
+	if len(args) > 0 {
+		fpPk, err := bbntypes.NewBIP340PubKeyFromHex(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to parse EOTS public key: %w", err)
+		}
+
+		fp, err := fpStore.GetFinalityProvider(fpPk.MustToBTCPK())
+		if err != nil {
+			return fmt.Errorf("failed to get finality provider %s: %w", fpPk.MarshalHex(), err)
+		}
+		fps = append(fps, fp)
+	} else {
+		all, err := fpStore.GetAllStoredFinalityProviders()
+		if err != nil {
+			return fmt.Errorf("failed to get finality providers: %w", err)
+		}
+		fps = all
+	}
+
+	signStores, err := dialSignStores(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, s := range signStores {
+			if c, ok := s.Querier.(*eotsclient.EOTSManagerGRPCClient); ok {
+				_ = c.Close()
+			}
+		}
+	}()
+
+	var threshold uint32
+	if cfg.ThresholdEOTSManager.IsEnabled() {
+		threshold = cfg.ThresholdEOTSManager.Threshold
+	}
+	doctor := service.NewDoctor(consumerCtrl, pubRandStore, signStores, threshold)
+
+	reports := make([]*service.DoctorReport, 0, len(fps))
+	unhealthy := 0
+	for _, fp := range fps {
+		report, err := doctor.Diagnose(cmd.Context(), fp)
+		if err != nil {
+			return fmt.Errorf("failed to check finality provider %s: %w",
+				bbntypes.NewBIP340PubKeyFromBTCPK(fp.BtcPk).MarshalHex(), err)
+		}
+		if !report.Healthy() {
+			unhealthy++
+		}
+		reports = append(reports, report)
+	}
+
+	if err := output.Print(cmd, reports); err != nil {
+		return err
+	}
+
+	if unhealthy > 0 {
+		return output.WithCode(output.CodeFailedPrecondition,
+			fmt.Errorf("found errors for %d of %d finality providers", unhealthy, len(reports)))
+	}
+
+	return nil
+}
+
+// dialSignStores connects to the eotsd of the config, each of which keeps
+// its own sign store. The eotsd which cannot be reached are reported by the
+// doctor rather than failing the command.
+func dialSignStores(cfg *fpcfg.Config) ([]service.EOTSSignStore, error) {
+	dialOpts, err := service.EOTSManagerDialOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(
+		grpc.MaxCallRecvMsgSize(cfg.GRPCMaxContentLength),
+		grpc.MaxCallSendMsgSize(cfg.GRPCMaxContentLength)),
+	)
+
+	addrs := []string{cfg.EOTSManagerAddress}
+	if cfg.ThresholdEOTSManager.IsEnabled() {
+		addrs = cfg.ThresholdEOTSManager.Addresses
+	}
+
+	signStores := make([]service.EOTSSignStore, 0, len(addrs))
+	for _, addr := range addrs {
+		var querier service.SignStoreQuerier
+		c, err := eotsclient.NewEOTSManagerGRPCClient(addr, cfg.HMACKey, dialOpts...)
+		if err != nil {
+			querier = unreachableSignStore{err: err}
+		} else {
+			querier = c
+		}
+		signStores = append(signStores, service.EOTSSignStore{Address: addr, Querier: querier})
+	}
+
+	return signStores, nil
+}
+
+// unreachableSignStore is the sign store of an eotsd which cannot be reached
+type unreachableSignStore struct {
+	err error
+}
+
+func (s unreachableSignStore) GetLastSignedHeight(_, _ []byte) (uint64, bool, error) {
+	return 0, false, s.err
+}
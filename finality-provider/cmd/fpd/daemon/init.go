@@ -7,6 +7,7 @@ import (
 	clientctx "github.com/babylonlabs-io/finality-provider/finality-provider/cmd/fpd/clientctx"
 	commoncmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd/fpd/common"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/output"
 	"github.com/babylonlabs-io/finality-provider/util"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/jessevdk/go-flags"
@@ -21,6 +22,16 @@ func CommandInit(binaryName string) *cobra.Command {
 	return cmd
 }
 
+// InitOutput describes the initialized home directory
+type InitOutput struct {
+	Home   string `json:"home"`
+	Config string `json:"config"`
+}
+
+func (o InitOutput) Text() string {
+	return fmt.Sprintf("Initialized the finality-provider home directory %s with the config %s", o.Home, o.Config)
+}
+
 func CommandInitTemplate(binaryName string) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:     "init",
@@ -68,5 +79,5 @@ func runInitCmd(ctx client.Context, cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
-	return nil
+	return output.Print(cmd, InitOutput{Home: homePath, Config: fpcfg.CfgFile(homePath)})
 }
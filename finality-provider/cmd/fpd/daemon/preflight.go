@@ -0,0 +1,46 @@
+package daemon
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+
+	clientctx "github.com/babylonlabs-io/finality-provider/finality-provider/cmd/fpd/clientctx"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/preflight"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// CommandPreflight returns the preflight command, which checks that fpd can start.
+func CommandPreflight(binaryName string) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "preflight",
+		Short: "Check that fpd can start with its config, without starting it",
+		Long: `Check the config, the permissions of the home, the RPC and metrics listeners, the database,
+the key of the keyring, the chain ID and tx indexing of the Babylon node, the finality parameters
+of the chain, and the connection and HMAC key to eotsd, and print whether each check passes.
+No finality provider is started. fpd must be stopped, while eotsd and the Babylon node must be
+running. The command fails if any check fails.`,
+		Example: fmt.Sprintf(`%s preflight --home /home/user/.fpd --output json`, binaryName),
+		Args:    cobra.NoArgs,
+		RunE:    clientctx.RunEWithClientCtx(runCommandPreflight),
+	}
+	cmd.Flags().String(flags.FlagHome, fpcfg.DefaultFpdDir, "The application home directory")
+	preflight.AddFlags(cmd)
+
+	return cmd
+}
+
+func runCommandPreflight(ctx client.Context, cmd *cobra.Command, _ []string) error {
+	homePath, err := filepath.Abs(ctx.HomeDir)
+	if err != nil {
+		return fmt.Errorf("failed to get home path: %w", err)
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	return preflight.RunCommand(cmd, service.PreflightChecks(homePath))
+}
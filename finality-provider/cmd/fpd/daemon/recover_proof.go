@@ -16,15 +16,30 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/babylonlabs-io/finality-provider/clientcontroller/babylon"
-	eotsclient "github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	clientctx "github.com/babylonlabs-io/finality-provider/finality-provider/cmd/fpd/clientctx"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
 	"github.com/babylonlabs-io/finality-provider/log"
+	"github.com/babylonlabs-io/finality-provider/output"
 	"github.com/babylonlabs-io/finality-provider/types"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
 
+// RecoverProofOutput describes the Merkle proofs of the public randomness
+// recovered for a finality provider
+type RecoverProofOutput struct {
+	BtcPk   string `json:"btc_pk"`
+	ChainID string `json:"chain_id"`
+	// Commits is the number of the public randomness commits whose proofs
+	// were recovered
+	Commits int `json:"commits"`
+}
+
+func (o RecoverProofOutput) Text() string {
+	return fmt.Sprintf("Successfully recovered the proofs of %d public randomness commits of finality provider %s", o.Commits, o.BtcPk)
+}
+
 func CommandRecoverProof(binaryName string) *cobra.Command {
 	cmd := CommandRecoverProofTemplate(binaryName)
 	cmd.RunE = clientctx.RunEWithClientCtx(runCommandRecoverProof)
@@ -94,7 +109,7 @@ func runCommandRecoverProof(ctx client.Context, cmd *cobra.Command, args []strin
 			}
 
 			return nil
-		}, func(em *eotsclient.EOTSManagerGRPCClient, fpPk []byte, chainID []byte, commit types.PubRandCommit) ([]*btcec.FieldVal, error) {
+		}, func(em eotsmanager.EOTSManager, fpPk []byte, chainID []byte, commit types.PubRandCommit) ([]*btcec.FieldVal, error) {
 			return em.CreateRandomnessPairList(fpPk, chainID, commit.GetStartHeight(), uint32(commit.GetNumPubRand())) // #nosec G115 - already checked by caller
 		})
 }
@@ -122,7 +137,7 @@ func RunCommandRecoverProofWithConfig(_ client.Context, cmd *cobra.Command, cfg
 		return fmt.Errorf("failed to get start height flag: %w", err)
 	}
 
-	em, err := eotsclient.NewEOTSManagerGRPCClient(cfg.EOTSManagerAddress, cfg.HMACKey)
+	em, err := service.NewEOTSManagerFromConfig(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create EOTS manager client: %w", err)
 	}
@@ -155,5 +170,5 @@ func RunCommandRecoverProofWithConfig(_ client.Context, cmd *cobra.Command, cfg
 		}
 	}
 
-	return nil
+	return output.Print(cmd, RecoverProofOutput{BtcPk: fpPk.MarshalHex(), ChainID: chainID, Commits: len(commitList)})
 }
@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
 
 	"github.com/babylonlabs-io/babylon/v4/types"
 	clientctx "github.com/babylonlabs-io/finality-provider/finality-provider/cmd/fpd/clientctx"
@@ -12,10 +15,13 @@ import (
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
 	"github.com/babylonlabs-io/finality-provider/log"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 	"github.com/babylonlabs-io/finality-provider/util"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
 )
 
 // CommandStart returns the start command of fpd daemon.
@@ -37,6 +43,9 @@ func CommandStartTemplate(binaryName string) *cobra.Command {
 	cmd.Flags().String(commoncmd.FpEotsPkFlag, "", "The EOTS public key of the finality-provider to start")
 	cmd.Flags().String(commoncmd.RPCListenerFlag, "", "The address that the RPC server listens to")
 	cmd.Flags().String(flags.FlagHome, fpcfg.DefaultFpdDir, "The application home directory")
+	cmd.Flags().Duration(commoncmd.EnableUnsafeEndpointsFlag, 0,
+		fmt.Sprintf("UNSAFE: serve the RPC endpoints bypassing the safety checks, such as unsafe-add-finality-sig, "+
+			"for the given time after the start, at most %s. Overrides the config", fpcfg.MaxUnsafeEndpointsTTL))
 
 	return cmd
 }
@@ -47,40 +56,31 @@ func runStartCmd(ctx client.Context, cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to get home path: %w", err)
 	}
 	homePath = util.CleanAndExpandPath(homePath)
-	cfg, err := fpcfg.LoadConfig(homePath)
+	cfg, err := loadStartConfig(homePath, cmd.Flags())
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+		return err
 	}
 
-	flags := cmd.Flags()
-
-	fpStr, err := flags.GetString(commoncmd.FpEotsPkFlag)
+	fpStr, err := cmd.Flags().GetString(commoncmd.FpEotsPkFlag)
 	if err != nil {
 		return fmt.Errorf("failed to read flag %s: %w", commoncmd.FpEotsPkFlag, err)
 	}
 
-	rpcListener, err := flags.GetString(commoncmd.RPCListenerFlag)
-	if err != nil {
-		return fmt.Errorf("failed to read flag %s: %w", commoncmd.RPCListenerFlag, err)
-	}
-
 	if cfg.BabylonConfig.KeyringBackend != "test" {
 		return fmt.Errorf("the keyring backend in config must be `test` for automatic signing, got %s", cfg.BabylonConfig.KeyringBackend)
 	}
 
-	if rpcListener != "" {
-		_, err := net.ResolveTCPAddr("tcp", rpcListener)
-		if err != nil {
-			return fmt.Errorf("invalid RPC listener address %s, %w", rpcListener, err)
-		}
-		cfg.RPCListener = rpcListener
-	}
-
 	logger, err := log.NewRootLoggerWithFile(fpcfg.LogFile(homePath), cfg.LogLevel)
 	if err != nil {
 		return fmt.Errorf("failed to initialize the logger: %w", err)
 	}
 
+	stopTracing, err := tracing.Start(cmd.Context(), cfg.Tracing, "fpd", logger)
+	if err != nil {
+		return fmt.Errorf("failed to start tracing: %w", err)
+	}
+	defer stopTracing()
+
 	dbBackend, err := cfg.DatabaseConfig.GetDBBackend()
 	if err != nil {
 		return fmt.Errorf("failed to create db backend: %w", err)
@@ -91,10 +91,16 @@ func runStartCmd(ctx client.Context, cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to create finality-provider app: %w", err)
 	}
 
+	fpApp.SetConfigLoader(func() (*fpcfg.Config, error) {
+		return loadStartConfig(homePath, cmd.Flags())
+	})
+
 	if err := StartApp(cmd.Context(), fpApp, fpStr); err != nil {
 		return fmt.Errorf("failed to start app: %w", err)
 	}
 
+	go reloadConfigOnSighup(cmd.Context(), fpApp)
+
 	fpServer := service.NewFinalityProviderServer(cfg, logger, fpApp, dbBackend)
 
 	if err := fpServer.RunUntilShutdown(cmd.Context()); err != nil {
@@ -104,6 +110,61 @@ func runStartCmd(ctx client.Context, cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// loadStartConfig loads the config from the home directory and applies the
+// overrides of the flags of the start command
+func loadStartConfig(homePath string, flags *pflag.FlagSet) (*fpcfg.Config, error) {
+	cfg, err := fpcfg.LoadConfig(homePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	rpcListener, err := flags.GetString(commoncmd.RPCListenerFlag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read flag %s: %w", commoncmd.RPCListenerFlag, err)
+	}
+
+	unsafeTTL, err := flags.GetDuration(commoncmd.EnableUnsafeEndpointsFlag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read flag %s: %w", commoncmd.EnableUnsafeEndpointsFlag, err)
+	}
+	if flags.Changed(commoncmd.EnableUnsafeEndpointsFlag) {
+		cfg.EnableUnsafeEndpoints(unsafeTTL)
+		if err := cfg.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid flag %s: %w", commoncmd.EnableUnsafeEndpointsFlag, err)
+		}
+	}
+
+	if rpcListener != "" {
+		_, err := net.ResolveTCPAddr("tcp", rpcListener)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RPC listener address %s, %w", rpcListener, err)
+		}
+		cfg.RPCListener = rpcListener
+	}
+
+	return cfg, nil
+}
+
+// reloadConfigOnSighup reloads the config of the app on each SIGHUP until
+// the context is done
+func reloadConfigOnSighup(ctx context.Context, fpApp *service.FinalityProviderApp) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sighup:
+			fpApp.Logger().Info("received SIGHUP, reloading the config")
+			if _, err := fpApp.ReloadConfig(); err != nil {
+				fpApp.Logger().Error("failed to reload the config", zap.Error(err))
+			}
+		}
+	}
+}
+
 // StartApp starts the app and the handle of finality providers if needed based on flags.
 func StartApp(
 	ctx context.Context,
@@ -11,6 +11,7 @@ import (
 	commoncmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd/fpd/common"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/cmd/fpd/daemon"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/output"
 	"github.com/babylonlabs-io/finality-provider/version"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
@@ -29,6 +30,7 @@ func NewRootCmd() *cobra.Command {
 		PersistentPreRunE: clientctx.PersistClientCtx(client.Context{}),
 	}
 	rootCmd.PersistentFlags().String(flags.FlagHome, fpcfg.DefaultFpdDir, "The application home directory")
+	output.AddFlag(rootCmd)
 
 	return rootCmd
 }
@@ -42,6 +44,8 @@ func main() {
 	commoncmd.AddKeysCommands(cmd)
 	// add all incentive commands
 	commoncmd.AddIncentiveCommands(cmd)
+	// add all tx commands
+	commoncmd.AddTxCommands(cmd, BinaryName, daemon.CommandBuildRegister(BinaryName))
 	// add version commands
 	version.AddVersionCommands(cmd, BinaryName)
 
@@ -52,13 +56,15 @@ func main() {
 		daemon.CommandCreateFP(BinaryName),
 		daemon.CommandCommitPubRand(BinaryName),
 		daemon.CommandRecoverProof(BinaryName),
+		daemon.CommandDoctor(BinaryName),
+		daemon.CommandPreflight(BinaryName),
 	)
 
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	if err := cmd.ExecuteContext(ctx); err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Whoops. There was an error while executing your %s CLI '%s'", BinaryName, err)
+	// the error is printed in the format of the output flag
+	if err := output.Execute(ctx, cmd); err != nil {
 		os.Exit(1) //nolint:gocritic
 	}
 }
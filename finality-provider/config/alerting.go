@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/babylonlabs-io/finality-provider/alerting"
+)
+
+var (
+	defaultAlertEvaluationInterval = 30 * time.Second
+	defaultAlertRepeatInterval     = 4 * time.Hour
+	defaultMissedVoteStreak        = uint64(50)
+	defaultAlertRandomnessRunway   = uint64(500)
+	defaultMaxTimeSinceLastVote    = 5 * time.Minute
+	defaultCriticalErrors          = uint32(1)
+	defaultCriticalErrorWindow     = 10 * time.Minute
+)
+
+// AlertingConfig defines the rules evaluated by fpd and the webhooks
+// notified when they fire
+type AlertingConfig struct {
+	EvaluationInterval  time.Duration `long:"evaluationinterval" description:"The interval between each evaluation of the alerting rules; 0 disables alerting"`
+	RepeatInterval      time.Duration `long:"repeatinterval" description:"The interval after which an alert still firing is notified again; 0 notifies it only once"`
+	Webhooks            []string      `long:"webhook" description:"A webhook the alerts are posted to, as <format>=<url> with the format json, slack or pagerduty; may be repeated" secret:"true"`
+	PagerDutyRoutingKey string        `long:"pagerdutyroutingkey" description:"The routing key of the PagerDuty service of the pagerduty webhooks, or a reference to it: file://, env://, exec://, an AWS secret ARN or a Google Cloud secret name" secret:"true"`
+	Silences            []string      `long:"silence" description:"A rule whose alerts are not notified, as <rule> or <rule>@<RFC3339 time> to silence it until then; may be repeated"`
+
+	MissedVoteStreak     uint64        `long:"missedvotestreak" description:"Alert when an active finality provider has not voted for this many blocks; 0 disables the rule"`
+	MinRandomnessRunway  uint64        `long:"minrandomnessrunway" description:"Alert when the committed public randomness covers fewer blocks after the tip of the chain; 0 disables the rule"`
+	MaxTimeSinceLastVote time.Duration `long:"maxtimesincelastvote" description:"Alert when an active finality provider has not voted for longer; 0 disables the rule"`
+	CriticalErrors       uint32        `long:"criticalerrors" description:"Alert when this many critical errors occur within the critical error window; 0 disables the rule"`
+	CriticalErrorWindow  time.Duration `long:"criticalerrorwindow" description:"The window in which the critical errors are counted"`
+}
+
+func DefaultAlertingConfig() AlertingConfig {
+	return AlertingConfig{
+		EvaluationInterval:   defaultAlertEvaluationInterval,
+		RepeatInterval:       defaultAlertRepeatInterval,
+		MissedVoteStreak:     defaultMissedVoteStreak,
+		MinRandomnessRunway:  defaultAlertRandomnessRunway,
+		MaxTimeSinceLastVote: defaultMaxTimeSinceLastVote,
+		CriticalErrors:       defaultCriticalErrors,
+		CriticalErrorWindow:  defaultCriticalErrorWindow,
+	}
+}
+
+// IsEnabled returns whether the rules should be evaluated, which is
+// only the case if there is a webhook to notify
+func (c *AlertingConfig) IsEnabled() bool {
+	return c != nil && c.EvaluationInterval > 0 && len(c.Webhooks) > 0
+}
+
+func (c *AlertingConfig) Validate() error {
+	if c.EvaluationInterval < 0 {
+		return fmt.Errorf("invalid evaluationinterval: %d", c.EvaluationInterval)
+	}
+	if c.RepeatInterval < 0 {
+		return fmt.Errorf("invalid repeatinterval: %d", c.RepeatInterval)
+	}
+	if c.MaxTimeSinceLastVote < 0 {
+		return fmt.Errorf("invalid maxtimesincelastvote: %d", c.MaxTimeSinceLastVote)
+	}
+	if c.CriticalErrors > 0 && c.CriticalErrorWindow <= 0 {
+		return fmt.Errorf("invalid criticalerrorwindow: %d", c.CriticalErrorWindow)
+	}
+
+	for _, w := range c.Webhooks {
+		webhook, err := alerting.ParseWebhook(w)
+		if err != nil {
+			return err
+		}
+		if webhook.Format == alerting.FormatPagerDuty && c.PagerDutyRoutingKey == "" {
+			return fmt.Errorf("the pagerduty webhook %s requires pagerdutyroutingkey", webhook.URL)
+		}
+	}
+
+	for _, s := range c.Silences {
+		if _, err := alerting.ParseSilence(s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
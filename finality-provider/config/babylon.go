@@ -6,6 +6,7 @@ import (
 	"time"
 
 	bbncfg "github.com/babylonlabs-io/babylon/v4/client/config"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 type BBNConfig struct {
@@ -24,6 +25,8 @@ type BBNConfig struct {
 	OutputFormat                string        `long:"output-format" description:"default output when printint responses"`
 	SignModeStr                 string        `long:"sign-mode" description:"sign mode to use"`
 	MaxRetriesBatchRemovingMsgs uint64        `long:"maxretriesbatchremovingmsgs" description:"The maximum number of retries to send a batch of covenant signatures messages (if some msg fails, remove the failed msgfrom the batch); If set to zero, it tries to send the whole batch, if set to a value larger than zero, the value or the length of the batch whichever is lower"`
+	Granter                     string        `long:"granter" description:"bech32 address of the finality provider that granted authz permissions to the key; if set, finality votes and randomness commits are wrapped in authz MsgExec and submitted by the key as grantee"`
+	FeeGranter                  string        `long:"fee-granter" description:"bech32 address of the account paying transaction fees through a feegrant allowance given to the key; if empty, the key pays its own fees"`
 }
 
 func DefaultBBNConfig() BBNConfig {
@@ -74,6 +77,18 @@ func (cfg *BBNConfig) Validate() error {
 		return fmt.Errorf("gas-prices must not be empty")
 	}
 
+	if cfg.Granter != "" {
+		if _, err := sdk.GetFromBech32(cfg.Granter, cfg.AccountPrefix); err != nil {
+			return fmt.Errorf("granter is not a valid bech32 address: %w", err)
+		}
+	}
+
+	if cfg.FeeGranter != "" {
+		if _, err := sdk.GetFromBech32(cfg.FeeGranter, cfg.AccountPrefix); err != nil {
+			return fmt.Errorf("fee-granter is not a valid bech32 address: %w", err)
+		}
+	}
+
 	return nil
 }
 
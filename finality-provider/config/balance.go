@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+var (
+	defaultBalanceCheckInterval = 5 * time.Minute
+	defaultBalanceDenom         = "ubbn"
+	defaultMinBalance           = uint64(1_000_000) // 1 BABY
+	defaultWarnDaysLeft         = float64(7)
+)
+
+// BalanceMonitorConfig defines the settings of the periodic balance check of
+// the account paying the transaction fees
+type BalanceMonitorConfig struct {
+	CheckInterval time.Duration `long:"checkinterval" description:"The interval between each check of the balance of the account paying the transaction fees; 0 disables the balance monitor"`
+	Denom         string        `long:"denom" description:"The denom of the balance to monitor"`
+	MinBalance    uint64        `long:"minbalance" description:"The balance floor; below it, non-essential transactions such as early randomness commits are paused; 0 disables the floor"`
+	WarnDaysLeft  float64       `long:"warndaysleft" description:"Warn when the projected number of days before the balance runs out, based on the observed fee burn, falls below this value"`
+}
+
+func DefaultBalanceMonitorConfig() BalanceMonitorConfig {
+	return BalanceMonitorConfig{
+		CheckInterval: defaultBalanceCheckInterval,
+		Denom:         defaultBalanceDenom,
+		MinBalance:    defaultMinBalance,
+		WarnDaysLeft:  defaultWarnDaysLeft,
+	}
+}
+
+// IsEnabled returns whether the balance should be checked periodically
+func (c *BalanceMonitorConfig) IsEnabled() bool {
+	return c != nil && c.CheckInterval > 0
+}
+
+func (c *BalanceMonitorConfig) Validate() error {
+	if c.CheckInterval < 0 {
+		return fmt.Errorf("invalid checkinterval: %d", c.CheckInterval)
+	}
+
+	if c.CheckInterval > 0 && c.Denom == "" {
+		return fmt.Errorf("denom must not be empty when the balance monitor is enabled")
+	}
+
+	if c.WarnDaysLeft < 0 {
+		return fmt.Errorf("invalid warndaysleft: %f", c.WarnDaysLeft)
+	}
+
+	return nil
+}
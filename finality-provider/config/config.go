@@ -13,6 +13,7 @@ import (
 
 	eotscfg "github.com/babylonlabs-io/finality-provider/eotsmanager/config"
 	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
 
@@ -33,6 +34,9 @@ const (
 	defaultDataDirname                  = "data"
 	defaultMaxGRPCContentLength         = 16 * 1024 * 1024 // 16 MB
 	defaultAdvancedResetLastVotedHeight = false
+	defaultUnsafeEndpointsTTL           = time.Hour
+	// MaxUnsafeEndpointsTTL bounds the time the unsafe RPC endpoints are served
+	MaxUnsafeEndpointsTTL = 24 * time.Hour
 )
 
 var (
@@ -54,12 +58,17 @@ type Config struct {
 	TimestampingDelayBlocks     uint32        `long:"timestampingdelayblocks" description:"The delay, measured in blocks, between a randomness commit submission and the randomness is BTC-timestamped"`
 	MaxSubmissionRetries        uint32        `long:"maxsubmissionretries" description:"The maximum number of retries to submit finality signature or public randomness"`
 	EOTSManagerAddress          string        `long:"eotsmanageraddress" description:"The address of the remote EOTS manager; Empty if the EOTS manager is running locally"`
-	HMACKey                     string        `long:"hmackey" description:"The HMAC key for authentication with EOTSD. If not provided, will use HMAC_KEY environment variable."`
+	HMACKey                     string        `long:"hmackey" description:"The HMAC key for authentication with EOTSD, or a reference to it: file://, env://, exec://, an AWS secret ARN, a Google Cloud secret name or an Azure Key Vault secret URL. If not provided, will use HMAC_KEY environment variable." secret:"true"`
+	HMACKeyID                   string        `long:"hmackeyid" description:"The ID of the HMAC key, as added to EOTSD with eotsd hmac-keys add. If not set, EOTSD checks the requests against the HMAC key of its config."`
 	BatchSubmissionSize         uint32        `long:"batchsubmissionsize" description:"The size of a batch in one submission"`
 	RandomnessCommitInterval    time.Duration `long:"randomnesscommitinterval" description:"The interval between each attempt to commit public randomness"`
 	SubmissionRetryInterval     time.Duration `long:"submissionretryinterval" description:"The interval between each attempt to submit finality signature or public randomness after a failure"`
 	SignatureSubmissionInterval time.Duration `long:"signaturesubmissioninterval" description:"The interval between each finality signature(s) submission"`
 
+	EOTSManagerTLS *EOTSManagerTLSConfig `group:"eotsmanagertls" namespace:"eotsmanagertls"`
+
+	ThresholdEOTSManager *ThresholdEOTSManagerConfig `group:"thresholdeotsmanager" namespace:"thresholdeotsmanager"`
+
 	PollerConfig *ChainPollerConfig `group:"chainpollerconfig" namespace:"chainpollerconfig"`
 
 	DatabaseConfig *DBConfig `group:"dbconfig" namespace:"dbconfig"`
@@ -68,12 +77,27 @@ type Config struct {
 
 	RPCListener string `long:"rpclistener" description:"the listener for RPC connections, e.g., 127.0.0.1:1234"`
 
+	RPCTLS *RPCTLSConfig `group:"rpctls" namespace:"rpctls"`
+
+	RPCAuth *RPCAuthConfig `group:"rpcauth" namespace:"rpcauth"`
+
 	Metrics *metrics.Config `group:"metrics" namespace:"metrics"`
 
+	Tracing *tracing.Config `group:"tracing" namespace:"tracing"`
+
+	BalanceMonitor *BalanceMonitorConfig `group:"balancemonitor" namespace:"balancemonitor"`
+
+	Readiness *ReadinessConfig `group:"readiness" namespace:"readiness"`
+
+	Alerting *AlertingConfig `group:"alerting" namespace:"alerting"`
+
 	ContextSigningHeight uint64 `long:"contextsigningheight" description:"The height at which the context signing will start"`
 
 	GRPCMaxContentLength int `long:"grpcmaxcontentlength" description:"The maximum size of the gRPC message in bytes."`
 
+	DisableUnsafeEndpoints *bool         `long:"disable-unsafe-endpoints" description:"Disable the unsafe RPC endpoints (AddFinalitySignature and UnsafeRemoveMerkleProof) that bypass the voting and randomness safety checks. Defaults to true (disabled) if not set."`
+	UnsafeEndpointsTTL     time.Duration `long:"unsafe-endpoints-ttl" description:"The time after the start of fpd during which the unsafe RPC endpoints are served once enabled, after which they are disabled again. At most 24h."`
+
 	AdvancedResetLastVotedHeight bool `long:"advancedresetlastvotedheight" description:"WARNING: If set to 'true', resets the finality provider's last voted height to the calculated start height from poller. WARNING"`
 }
 
@@ -82,6 +106,10 @@ func DefaultConfigWithHome(homePath string) Config {
 	bbnCfg.Key = defaultFinalityProviderKeyName
 	bbnCfg.KeyDirectory = homePath
 	pollerCfg := DefaultChainPollerConfig()
+	balanceCfg := DefaultBalanceMonitorConfig()
+	readinessCfg := DefaultReadinessConfig()
+	alertingCfg := DefaultAlertingConfig()
+	disableUnsafe := true
 	cfg := Config{
 		LogLevel:                     defaultLogLevel.String(),
 		DatabaseConfig:               DefaultDBConfigWithHomePath(homePath),
@@ -97,8 +125,18 @@ func DefaultConfigWithHome(homePath string) Config {
 		EOTSManagerAddress:           defaultEOTSManagerAddress,
 		RPCListener:                  DefaultRPCListener,
 		Metrics:                      metrics.DefaultFpConfig(),
+		Tracing:                      tracing.DefaultConfig(),
+		BalanceMonitor:               &balanceCfg,
+		Readiness:                    &readinessCfg,
+		Alerting:                     &alertingCfg,
+		EOTSManagerTLS:               &EOTSManagerTLSConfig{},
+		ThresholdEOTSManager:         &ThresholdEOTSManagerConfig{},
+		RPCTLS:                       &RPCTLSConfig{},
+		RPCAuth:                      &RPCAuthConfig{},
 		GRPCMaxContentLength:         defaultMaxGRPCContentLength,
 		AdvancedResetLastVotedHeight: defaultAdvancedResetLastVotedHeight,
+		DisableUnsafeEndpoints:       &disableUnsafe,
+		UnsafeEndpointsTTL:           defaultUnsafeEndpointsTTL,
 	}
 
 	if err := cfg.Validate(); err != nil {
@@ -112,6 +150,24 @@ func DefaultConfig() Config {
 	return DefaultConfigWithHome(DefaultFpdDir)
 }
 
+// IsUnsafeEndpointsDisabled returns true if unsafe endpoints should be disabled.
+// Defaults to true (safe) if not explicitly set.
+func (cfg *Config) IsUnsafeEndpointsDisabled() bool {
+	if cfg.DisableUnsafeEndpoints == nil {
+		return true // Safe default: disabled
+	}
+
+	return *cfg.DisableUnsafeEndpoints
+}
+
+// EnableUnsafeEndpoints serves the unsafe RPC endpoints for the given time
+// after the start of fpd
+func (cfg *Config) EnableUnsafeEndpoints(ttl time.Duration) {
+	disableUnsafe := false
+	cfg.DisableUnsafeEndpoints = &disableUnsafe
+	cfg.UnsafeEndpointsTTL = ttl
+}
+
 func CfgFile(homePath string) string {
 	return filepath.Join(homePath, defaultConfigFileName)
 }
@@ -181,6 +237,14 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("invalid metrics config: %w", err)
 	}
 
+	// tracing is optional, so configs
+	// written before it was introduced keep working
+	if cfg.Tracing != nil {
+		if err := cfg.Tracing.Validate(); err != nil {
+			return fmt.Errorf("invalid tracing config: %w", err)
+		}
+	}
+
 	if cfg.PollerConfig == nil {
 		return fmt.Errorf("empty poller config")
 	}
@@ -188,6 +252,30 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("invalid poller config: %w", err)
 	}
 
+	// the balance monitor is optional, so configs
+	// written before it was introduced keep working
+	if cfg.BalanceMonitor != nil {
+		if err := cfg.BalanceMonitor.Validate(); err != nil {
+			return fmt.Errorf("invalid balance monitor config: %w", err)
+		}
+	}
+
+	// the readiness thresholds are optional, so configs
+	// written before they were introduced keep working
+	if cfg.Readiness != nil {
+		if err := cfg.Readiness.Validate(); err != nil {
+			return fmt.Errorf("invalid readiness config: %w", err)
+		}
+	}
+
+	// the alerting is optional, so configs
+	// written before it was introduced keep working
+	if cfg.Alerting != nil {
+		if err := cfg.Alerting.Validate(); err != nil {
+			return fmt.Errorf("invalid alerting config: %w", err)
+		}
+	}
+
 	if cfg.BabylonConfig == nil {
 		return fmt.Errorf("empty babylon config")
 	}
@@ -212,6 +300,44 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("invalid max content length: %d", cfg.GRPCMaxContentLength)
 	}
 
+	// the TLS configs are optional, so configs
+	// written before they were introduced keep working
+	if cfg.EOTSManagerTLS != nil {
+		if err := cfg.EOTSManagerTLS.Validate(); err != nil {
+			return fmt.Errorf("invalid EOTS manager TLS config: %w", err)
+		}
+	}
+	if cfg.RPCTLS != nil {
+		if err := cfg.RPCTLS.Validate(); err != nil {
+			return fmt.Errorf("invalid RPC TLS config: %w", err)
+		}
+	}
+
+	// the RPC authentication is optional, so configs
+	// written before it was introduced keep working
+	if cfg.RPCAuth != nil {
+		if err := cfg.RPCAuth.Validate(); err != nil {
+			return fmt.Errorf("invalid RPC auth config: %w", err)
+		}
+		if cfg.RPCAuth.HasClientCerts() && (cfg.RPCTLS == nil || cfg.RPCTLS.ClientCAFile == "") {
+			return fmt.Errorf("invalid RPC auth config: the client certificates require the RPC TLS client CA")
+		}
+	}
+
+	// threshold signing is optional, so configs
+	// written before it was introduced keep working
+	if err := cfg.ThresholdEOTSManager.Validate(); err != nil {
+		return fmt.Errorf("invalid threshold EOTS manager config: %w", err)
+	}
+
+	// the unsafe endpoints are served for a bounded time only
+	if !cfg.IsUnsafeEndpointsDisabled() {
+		if cfg.UnsafeEndpointsTTL <= 0 || cfg.UnsafeEndpointsTTL > MaxUnsafeEndpointsTTL {
+			return fmt.Errorf("invalid unsafe endpoints TTL %s, expected a positive duration of at most %s",
+				cfg.UnsafeEndpointsTTL, MaxUnsafeEndpointsTTL)
+		}
+	}
+
 	if cfg.AdvancedResetLastVotedHeight {
 		// Ensure StaticChainScanningStartHeight is set and > 0
 		// This prevents underflow when setting lastVotedHeight = startHeight - 1
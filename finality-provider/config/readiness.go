@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+)
+
+var (
+	defaultMaxPollerLag        = uint64(50)
+	defaultMinRandomnessRunway = uint64(1)
+)
+
+// ReadinessConfig defines the thresholds of the readiness checks served at
+// /readyz of the metrics server
+type ReadinessConfig struct {
+	MaxPollerLag        uint64 `long:"maxpollerlag" description:"The maximum number of blocks between the tip of the chain and the last block polled for fpd to be ready"`
+	MinRandomnessRunway uint64 `long:"minrandomnessrunway" description:"The minimum number of blocks after the tip of the chain covered by the committed public randomness for fpd to be ready"`
+}
+
+func DefaultReadinessConfig() ReadinessConfig {
+	return ReadinessConfig{
+		MaxPollerLag:        defaultMaxPollerLag,
+		MinRandomnessRunway: defaultMinRandomnessRunway,
+	}
+}
+
+func (c *ReadinessConfig) Validate() error {
+	if c.MaxPollerLag == 0 {
+		return fmt.Errorf("invalid maxpollerlag: %d", c.MaxPollerLag)
+	}
+
+	return nil
+}
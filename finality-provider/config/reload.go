@@ -0,0 +1,171 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// reloadableFields are the fields of the config, as <group>.<field> for the
+// fields of a group, applied to the running finality providers on a reload.
+// Changing any other field requires a restart of fpd.
+var reloadableFields = map[string]bool{
+	"BatchSubmissionSize":            true,
+	"SignatureSubmissionInterval":    true,
+	"RandomnessCommitInterval":       true,
+	"chainpollerconfig.PollInterval": true,
+	"babylon.GasPrices":              true,
+}
+
+// reloadMu guards the reloadable fields, which are written by a reload
+// while the finality providers read them
+var reloadMu sync.RWMutex
+
+// redactedValue replaces the values of the fields tagged as secret in the
+// changes of the config
+const redactedValue = "<redacted>"
+
+// ConfigChange is a field of the config changed by a reload
+type ConfigChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+func (c ConfigChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Field, c.Old, c.New)
+}
+
+// RestartRequiredError is returned when a reload changes fields which
+// require a restart of fpd, in which case nothing is applied
+type RestartRequiredError struct {
+	Changes []ConfigChange
+}
+
+func (e *RestartRequiredError) Error() string {
+	changes := make([]string, 0, len(e.Changes))
+	for _, c := range e.Changes {
+		changes = append(changes, c.String())
+	}
+
+	return "the changes of the following fields require a restart: " + strings.Join(changes, "; ")
+}
+
+// Reload applies the reloadable fields of newCfg to the config and returns
+// their changes. If newCfg is invalid or changes a field requiring a
+// restart, the config is left untouched.
+func (cfg *Config) Reload(newCfg *Config) ([]ConfigChange, error) {
+	if err := newCfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	var reloadable, restartRequired []ConfigChange
+	for _, c := range diffFields("", reflect.ValueOf(cfg).Elem(), reflect.ValueOf(newCfg).Elem()) {
+		if reloadableFields[c.Field] {
+			reloadable = append(reloadable, c)
+		} else {
+			restartRequired = append(restartRequired, c)
+		}
+	}
+	if len(restartRequired) > 0 {
+		return nil, &RestartRequiredError{Changes: restartRequired}
+	}
+
+	cfg.BatchSubmissionSize = newCfg.BatchSubmissionSize
+	cfg.SignatureSubmissionInterval = newCfg.SignatureSubmissionInterval
+	cfg.RandomnessCommitInterval = newCfg.RandomnessCommitInterval
+	cfg.PollerConfig.PollInterval = newCfg.PollerConfig.PollInterval
+	cfg.BabylonConfig.GasPrices = newCfg.BabylonConfig.GasPrices
+
+	return reloadable, nil
+}
+
+// diffFields returns the changes between the fields of two structs of the
+// same type, descending into the groups
+func diffFields(group string, oldVal, newVal reflect.Value) []ConfigChange {
+	var changes []ConfigChange
+	for i := 0; i < oldVal.NumField(); i++ {
+		field := oldVal.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if group != "" {
+			name = group + "." + field.Name
+		}
+
+		oldField, newField := oldVal.Field(i), newVal.Field(i)
+		if groupName, ok := field.Tag.Lookup("group"); ok {
+			changes = append(changes, diffFields(groupName, derefOrZero(oldField), derefOrZero(newField))...)
+
+			continue
+		}
+
+		oldField, newField = derefOrZero(oldField), derefOrZero(newField)
+		if reflect.DeepEqual(oldField.Interface(), newField.Interface()) {
+			continue
+		}
+
+		c := ConfigChange{Field: name, Old: redactedValue, New: redactedValue}
+		if field.Tag.Get("secret") != "true" {
+			c.Old, c.New = fmt.Sprint(oldField.Interface()), fmt.Sprint(newField.Interface())
+		}
+		changes = append(changes, c)
+	}
+
+	return changes
+}
+
+// derefOrZero returns the value a pointer points to, or the zero value of
+// its type if it is nil, so that an unset group compares as an empty one
+func derefOrZero(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.Pointer {
+		return v
+	}
+	if v.IsNil() {
+		return reflect.Zero(v.Type().Elem())
+	}
+
+	return v.Elem()
+}
+
+// GetBatchSubmissionSize returns the batch submission size, which can be
+// changed by a reload
+func (cfg *Config) GetBatchSubmissionSize() uint32 {
+	reloadMu.RLock()
+	defer reloadMu.RUnlock()
+
+	return cfg.BatchSubmissionSize
+}
+
+// GetSignatureSubmissionInterval returns the signature submission interval,
+// which can be changed by a reload
+func (cfg *Config) GetSignatureSubmissionInterval() time.Duration {
+	reloadMu.RLock()
+	defer reloadMu.RUnlock()
+
+	return cfg.SignatureSubmissionInterval
+}
+
+// GetRandomnessCommitInterval returns the randomness commit interval, which
+// can be changed by a reload
+func (cfg *Config) GetRandomnessCommitInterval() time.Duration {
+	reloadMu.RLock()
+	defer reloadMu.RUnlock()
+
+	return cfg.RandomnessCommitInterval
+}
+
+// GetPollInterval returns the poll interval, which can be changed by a reload
+func (c *ChainPollerConfig) GetPollInterval() time.Duration {
+	reloadMu.RLock()
+	defer reloadMu.RUnlock()
+
+	return c.PollInterval
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// Roles of the RPC clients, each of which grants the methods of the roles
+// before it
+const (
+	RPCRoleReadOnly    = "read-only"
+	RPCRoleOperator    = "operator"
+	RPCRoleUnsafeAdmin = "unsafe-admin"
+)
+
+// RPCRoles are the roles of the RPC clients, from the least to the most privileged
+var RPCRoles = []string{RPCRoleReadOnly, RPCRoleOperator, RPCRoleUnsafeAdmin}
+
+// RPCAuthConfig defines the credentials accepted by the RPC server and the
+// roles they grant. Authentication is disabled if no credential is set.
+type RPCAuthConfig struct {
+	HMACKeys      []string `long:"hmackey" description:"An HMAC key of the clients, as <role>:<key-id>:<key>, where the key can be a reference as for hmackey; repeat the option for each key" secret:"true"`
+	Tokens        []string `long:"token" description:"A bearer token of the clients, as <role>:<name>:<token>, where the token can be a reference as for hmackey; repeat the option for each token" secret:"true"`
+	ClientCerts   []string `long:"clientcert" description:"A TLS client certificate, as <role>:<sha256 fingerprint>; requires rpctls.clientcafile; repeat the option for each certificate"`
+	ClientNames   []string `long:"clientname" description:"The common name of TLS client certificates, as <role>:<name>; requires rpctls.clientcafile; repeat the option for each name"`
+	AnonymousRole string   `long:"anonymousrole" description:"The role of the clients without credentials, which are rejected if empty: read-only, operator or unsafe-admin"`
+}
+
+// RPCCredential is a credential of the RPC clients and the role it grants
+type RPCCredential struct {
+	Role string
+	// Name identifies the credential: the ID of an HMAC key, the name of a
+	// token, or the fingerprint or the common name of a client certificate
+	Name string
+	// Secret is the HMAC key or the token, or a reference to it
+	Secret string
+}
+
+// IsEnabled returns whether the RPC server authenticates the clients
+func (c *RPCAuthConfig) IsEnabled() bool {
+	return c != nil && (len(c.HMACKeys) > 0 || len(c.Tokens) > 0 || len(c.ClientCerts) > 0 || len(c.ClientNames) > 0)
+}
+
+// HasClientCerts returns whether clients are authenticated with their TLS certificate
+func (c *RPCAuthConfig) HasClientCerts() bool {
+	return c != nil && (len(c.ClientCerts) > 0 || len(c.ClientNames) > 0)
+}
+
+func (c *RPCAuthConfig) Validate() error {
+	if c.AnonymousRole != "" && !slices.Contains(RPCRoles, c.AnonymousRole) {
+		return fmt.Errorf("invalid anonymousrole %s, expected one of %s", c.AnonymousRole, strings.Join(RPCRoles, ", "))
+	}
+
+	if _, err := c.HMACKeyCredentials(); err != nil {
+		return err
+	}
+	if _, err := c.TokenCredentials(); err != nil {
+		return err
+	}
+	if _, err := c.ClientCertCredentials(); err != nil {
+		return err
+	}
+	if _, err := c.ClientNameCredentials(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// HMACKeyCredentials returns the HMAC keys, named after their ID
+func (c *RPCAuthConfig) HMACKeyCredentials() ([]RPCCredential, error) {
+	return parseRPCCredentials("hmackey", c.HMACKeys, true)
+}
+
+// TokenCredentials returns the bearer tokens
+func (c *RPCAuthConfig) TokenCredentials() ([]RPCCredential, error) {
+	return parseRPCCredentials("token", c.Tokens, true)
+}
+
+// ClientCertCredentials returns the client certificates, named after their
+// normalized fingerprint
+func (c *RPCAuthConfig) ClientCertCredentials() ([]RPCCredential, error) {
+	creds, err := parseRPCCredentials("clientcert", c.ClientCerts, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range creds {
+		if err := util.ValidateCertFingerprint(creds[i].Name); err != nil {
+			return nil, fmt.Errorf("invalid clientcert: %w", err)
+		}
+		creds[i].Name = util.NormalizeCertFingerprint(creds[i].Name)
+	}
+
+	return creds, nil
+}
+
+// ClientNameCredentials returns the common names of the client certificates
+func (c *RPCAuthConfig) ClientNameCredentials() ([]RPCCredential, error) {
+	return parseRPCCredentials("clientname", c.ClientNames, false)
+}
+
+// parseRPCCredentials parses the <role>:<name>[:<secret>] options. The
+// secret is the rest of the option, so that it can hold a reference such
+// as file:///path.
+func parseRPCCredentials(option string, values []string, withSecret bool) ([]RPCCredential, error) {
+	n := 2
+	format := "<role>:<name>"
+	if withSecret {
+		n = 3
+		format = "<role>:<name>:<secret>"
+	}
+
+	creds := make([]RPCCredential, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		parts := strings.SplitN(v, ":", n)
+		if len(parts) != n || parts[1] == "" || (withSecret && parts[2] == "") {
+			return nil, fmt.Errorf("invalid %s, expected %s", option, format)
+		}
+		if !slices.Contains(RPCRoles, parts[0]) {
+			return nil, fmt.Errorf("invalid role %s of %s %s, expected one of %s",
+				parts[0], option, parts[1], strings.Join(RPCRoles, ", "))
+		}
+		if seen[parts[1]] {
+			return nil, fmt.Errorf("duplicate %s %s", option, parts[1])
+		}
+		seen[parts[1]] = true
+
+		cred := RPCCredential{Role: parts[0], Name: parts[1]}
+		if withSecret {
+			cred.Secret = parts[2]
+		}
+		creds = append(creds, cred)
+	}
+
+	return creds, nil
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+)
+
+// ThresholdEOTSManagerConfig defines the eotsd holding the shares of
+// threshold EOTS keys, which fpd signs with instead of EOTSManagerAddress
+type ThresholdEOTSManagerConfig struct {
+	Threshold uint32   `long:"threshold" description:"The number of eotsd needed to sign with a threshold EOTS key; 0 disables threshold signing"`
+	Addresses []string `long:"address" description:"The address of an eotsd holding a share of the threshold EOTS keys; repeat the option for each eotsd"`
+}
+
+// IsEnabled returns whether fpd signs with threshold EOTS keys
+func (c *ThresholdEOTSManagerConfig) IsEnabled() bool {
+	return c != nil && c.Threshold > 0
+}
+
+func (c *ThresholdEOTSManagerConfig) Validate() error {
+	if !c.IsEnabled() {
+		return nil
+	}
+
+	if c.Threshold < 2 {
+		return fmt.Errorf("invalid threshold %d, must be at least 2", c.Threshold)
+	}
+
+	if len(c.Addresses) < int(c.Threshold) {
+		return fmt.Errorf("the threshold %d exceeds the number of eotsd addresses %d", c.Threshold, len(c.Addresses))
+	}
+
+	// two disjoint sets of eotsd could otherwise sign two messages with the
+	// same randomness
+	if 2*int(c.Threshold) <= len(c.Addresses) {
+		return fmt.Errorf("the threshold %d must be more than half of the number of eotsd addresses %d", c.Threshold, len(c.Addresses))
+	}
+
+	seen := make(map[string]bool, len(c.Addresses))
+	for _, addr := range c.Addresses {
+		if addr == "" {
+			return fmt.Errorf("empty eotsd address")
+		}
+		if seen[addr] {
+			return fmt.Errorf("duplicate eotsd address %s", addr)
+		}
+		seen[addr] = true
+	}
+
+	return nil
+}
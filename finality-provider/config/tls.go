@@ -0,0 +1,88 @@
+package config
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// EOTSManagerTLSConfig defines the transport security of the connection to the
+// EOTS manager. TLS is disabled if no CA is set.
+type EOTSManagerTLSConfig struct {
+	CAFile     string `long:"cafile" description:"The path to the CA bundle used to verify the certificate of the EOTS manager; TLS is disabled if empty"`
+	CertFile   string `long:"certfile" description:"The path to the client certificate presented to the EOTS manager, required if it enables mutual TLS"`
+	KeyFile    string `long:"keyfile" description:"The path to the private key of the client certificate"`
+	ServerName string `long:"servername" description:"The name expected in the certificate of the EOTS manager; defaults to the host of the EOTS manager address"`
+}
+
+// IsEnabled returns whether the connection to the EOTS manager uses TLS
+func (c *EOTSManagerTLSConfig) IsEnabled() bool {
+	return c != nil && c.CAFile != ""
+}
+
+func (c *EOTSManagerTLSConfig) Validate() error {
+	if c.CAFile == "" {
+		if c.CertFile != "" || c.KeyFile != "" || c.ServerName != "" {
+			return fmt.Errorf("the CA should be set to enable TLS")
+		}
+
+		return nil
+	}
+
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return fmt.Errorf("both the client certificate and key should be set")
+	}
+
+	return nil
+}
+
+// ClientTLSConfig loads the certificates used to connect to the EOTS manager
+func (c *EOTSManagerTLSConfig) ClientTLSConfig() (*tls.Config, error) {
+	tlsCfg, err := util.LoadClientTLSConfig(c.CAFile, c.CertFile, c.KeyFile, c.ServerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load EOTS manager TLS config: %w", err)
+	}
+
+	return tlsCfg, nil
+}
+
+// RPCTLSConfig defines the transport security of the RPC server. TLS is
+// disabled if no certificate is set, and the clients are authenticated with
+// their certificate if a client CA is set.
+type RPCTLSConfig struct {
+	CertFile     string `long:"certfile" description:"The path to the TLS certificate of the RPC server; TLS is disabled if empty"`
+	KeyFile      string `long:"keyfile" description:"The path to the private key of the TLS certificate of the RPC server"`
+	ClientCAFile string `long:"clientcafile" description:"The path to the CA bundle used to verify the client certificates; if set, the clients must present a certificate signed by one of its CAs"`
+}
+
+// IsEnabled returns whether the RPC server serves TLS
+func (c *RPCTLSConfig) IsEnabled() bool {
+	return c != nil && c.CertFile != ""
+}
+
+func (c *RPCTLSConfig) Validate() error {
+	if c.CertFile == "" {
+		if c.KeyFile != "" || c.ClientCAFile != "" {
+			return fmt.Errorf("the TLS certificate should be set to enable TLS")
+		}
+
+		return nil
+	}
+
+	if c.KeyFile == "" {
+		return fmt.Errorf("the TLS key should be set along with the certificate")
+	}
+
+	return nil
+}
+
+// ServerTLSConfig loads the certificates of the RPC server
+func (c *RPCTLSConfig) ServerTLSConfig() (*tls.Config, error) {
+	tlsCfg, err := util.LoadServerTLSConfig(c.CertFile, c.KeyFile, c.ClientCAFile, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load RPC TLS config: %w", err)
+	}
+
+	return tlsCfg, nil
+}
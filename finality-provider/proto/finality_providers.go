@@ -88,3 +88,24 @@ func (req *CreateFinalityProviderRequest) GetCommissionRates() (btcstktypes.Comm
 
 	return btcstktypes.NewCommissionRates(rate, maxRate, maxChangeRate), nil
 }
+
+func NewKeyRotationInfo(kr *KeyRotation) (*KeyRotationInfo, error) {
+	oldPk, err := bbn.NewBIP340PubKey(kr.OldBtcPk)
+	if err != nil {
+		return nil, fmt.Errorf("invalid old BTC PK: %w", err)
+	}
+
+	newPk, err := bbn.NewBIP340PubKey(kr.NewBtcPk)
+	if err != nil {
+		return nil, fmt.Errorf("invalid new BTC PK: %w", err)
+	}
+
+	return &KeyRotationInfo{
+		OldBtcPkHex:  oldPk.MarshalHex(),
+		NewBtcPkHex:  newPk.MarshalHex(),
+		NewKeyName:   kr.NewKeyName,
+		Phase:        kr.Phase.String(),
+		RetireHeight: kr.RetireHeight,
+		UpdateTime:   kr.UpdateTime,
+	}, nil
+}
@@ -28,6 +28,10 @@ const (
 	FinalityProviders_EditFinalityProvider_FullMethodName      = "/proto.FinalityProviders/EditFinalityProvider"
 	FinalityProviders_UnsafeRemoveMerkleProof_FullMethodName   = "/proto.FinalityProviders/UnsafeRemoveMerkleProof"
 	FinalityProviders_Backup_FullMethodName                    = "/proto.FinalityProviders/Backup"
+	FinalityProviders_StartKeyRotation_FullMethodName          = "/proto.FinalityProviders/StartKeyRotation"
+	FinalityProviders_RetireKey_FullMethodName                 = "/proto.FinalityProviders/RetireKey"
+	FinalityProviders_QueryKeyRotationList_FullMethodName      = "/proto.FinalityProviders/QueryKeyRotationList"
+	FinalityProviders_ReloadConfig_FullMethodName              = "/proto.FinalityProviders/ReloadConfig"
 )
 
 // FinalityProvidersClient is the client API for FinalityProviders service.
@@ -54,6 +58,17 @@ type FinalityProvidersClient interface {
 	UnsafeRemoveMerkleProof(ctx context.Context, in *RemoveMerkleProofRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	// Backup - hot backup finality provider db
 	Backup(ctx context.Context, in *FpdBackupRequest, opts ...grpc.CallOption) (*FpdBackupResponse, error)
+	// StartKeyRotation registers a new finality provider with a fresh EOTS key
+	// and runs it side by side with the finality provider being rotated
+	StartKeyRotation(ctx context.Context, in *StartKeyRotationRequest, opts ...grpc.CallOption) (*KeyRotationResponse, error)
+	// RetireKey stops committing public randomness for the rotated EOTS key,
+	// which keeps voting until its already committed heights are used
+	RetireKey(ctx context.Context, in *RetireKeyRequest, opts ...grpc.CallOption) (*KeyRotationResponse, error)
+	// QueryKeyRotationList queries the EOTS key rotations
+	QueryKeyRotationList(ctx context.Context, in *QueryKeyRotationListRequest, opts ...grpc.CallOption) (*QueryKeyRotationListResponse, error)
+	// ReloadConfig reads fpd.conf again and applies the fields which can be
+	// changed without a restart to the running finality providers
+	ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error)
 }
 
 type finalityProvidersClient struct {
@@ -145,6 +160,42 @@ func (c *finalityProvidersClient) Backup(ctx context.Context, in *FpdBackupReque
 	return out, nil
 }
 
+func (c *finalityProvidersClient) StartKeyRotation(ctx context.Context, in *StartKeyRotationRequest, opts ...grpc.CallOption) (*KeyRotationResponse, error) {
+	out := new(KeyRotationResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_StartKeyRotation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) RetireKey(ctx context.Context, in *RetireKeyRequest, opts ...grpc.CallOption) (*KeyRotationResponse, error) {
+	out := new(KeyRotationResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_RetireKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) QueryKeyRotationList(ctx context.Context, in *QueryKeyRotationListRequest, opts ...grpc.CallOption) (*QueryKeyRotationListResponse, error) {
+	out := new(QueryKeyRotationListResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_QueryKeyRotationList_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error) {
+	out := new(ReloadConfigResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_ReloadConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FinalityProvidersServer is the server API for FinalityProviders service.
 // All implementations must embed UnimplementedFinalityProvidersServer
 // for forward compatibility
@@ -169,6 +220,17 @@ type FinalityProvidersServer interface {
 	UnsafeRemoveMerkleProof(context.Context, *RemoveMerkleProofRequest) (*EmptyResponse, error)
 	// Backup - hot backup finality provider db
 	Backup(context.Context, *FpdBackupRequest) (*FpdBackupResponse, error)
+	// StartKeyRotation registers a new finality provider with a fresh EOTS key
+	// and runs it side by side with the finality provider being rotated
+	StartKeyRotation(context.Context, *StartKeyRotationRequest) (*KeyRotationResponse, error)
+	// RetireKey stops committing public randomness for the rotated EOTS key,
+	// which keeps voting until its already committed heights are used
+	RetireKey(context.Context, *RetireKeyRequest) (*KeyRotationResponse, error)
+	// QueryKeyRotationList queries the EOTS key rotations
+	QueryKeyRotationList(context.Context, *QueryKeyRotationListRequest) (*QueryKeyRotationListResponse, error)
+	// ReloadConfig reads fpd.conf again and applies the fields which can be
+	// changed without a restart to the running finality providers
+	ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error)
 	mustEmbedUnimplementedFinalityProvidersServer()
 }
 
@@ -203,6 +265,18 @@ func (UnimplementedFinalityProvidersServer) UnsafeRemoveMerkleProof(context.Cont
 func (UnimplementedFinalityProvidersServer) Backup(context.Context, *FpdBackupRequest) (*FpdBackupResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Backup not implemented")
 }
+func (UnimplementedFinalityProvidersServer) StartKeyRotation(context.Context, *StartKeyRotationRequest) (*KeyRotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartKeyRotation not implemented")
+}
+func (UnimplementedFinalityProvidersServer) RetireKey(context.Context, *RetireKeyRequest) (*KeyRotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetireKey not implemented")
+}
+func (UnimplementedFinalityProvidersServer) QueryKeyRotationList(context.Context, *QueryKeyRotationListRequest) (*QueryKeyRotationListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryKeyRotationList not implemented")
+}
+func (UnimplementedFinalityProvidersServer) ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
 func (UnimplementedFinalityProvidersServer) mustEmbedUnimplementedFinalityProvidersServer() {}
 
 // UnsafeFinalityProvidersServer may be embedded to opt out of forward compatibility for this service.
@@ -378,6 +452,78 @@ func _FinalityProviders_Backup_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FinalityProviders_StartKeyRotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartKeyRotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).StartKeyRotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_StartKeyRotation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).StartKeyRotation(ctx, req.(*StartKeyRotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_RetireKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetireKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).RetireKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_RetireKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).RetireKey(ctx, req.(*RetireKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_QueryKeyRotationList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryKeyRotationListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).QueryKeyRotationList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_QueryKeyRotationList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).QueryKeyRotationList(ctx, req.(*QueryKeyRotationListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_ReloadConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).ReloadConfig(ctx, req.(*ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FinalityProviders_ServiceDesc is the grpc.ServiceDesc for FinalityProviders service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -421,6 +567,22 @@ var FinalityProviders_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Backup",
 			Handler:    _FinalityProviders_Backup_Handler,
 		},
+		{
+			MethodName: "StartKeyRotation",
+			Handler:    _FinalityProviders_StartKeyRotation_Handler,
+		},
+		{
+			MethodName: "RetireKey",
+			Handler:    _FinalityProviders_RetireKey_Handler,
+		},
+		{
+			MethodName: "QueryKeyRotationList",
+			Handler:    _FinalityProviders_QueryKeyRotationList_Handler,
+		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _FinalityProviders_ReloadConfig_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "finality_providers.proto",
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/finality-provider/alerting"
+	eotsclient "github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// Names of the alerting rules, which are the ones to silence
+const (
+	AlertRuleJailed            = "finality_provider_jailed"
+	AlertRuleSlashed           = "finality_provider_slashed"
+	AlertRuleDegraded          = "finality_provider_degraded"
+	AlertRuleMissedVotes       = "missed_votes"
+	AlertRuleRandomnessRunway  = "randomness_runway"
+	AlertRuleTimeSinceLastVote = "time_since_last_vote"
+	AlertRuleCriticalErrors    = "critical_errors"
+)
+
+// criticalErrorLog keeps the critical errors of each finality provider
+// within the window in which they are counted
+type criticalErrorLog struct {
+	mu      sync.Mutex
+	window  time.Duration
+	times   map[string][]time.Time
+	lastErr map[string]error
+}
+
+func newCriticalErrorLog(window time.Duration) *criticalErrorLog {
+	return &criticalErrorLog{
+		window:  window,
+		times:   make(map[string][]time.Time),
+		lastErr: make(map[string]error),
+	}
+}
+
+// Record records a critical error of the finality provider at the given time
+func (l *criticalErrorLog) Record(fpBtcPkHex string, err error, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.times[fpBtcPkHex] = append(l.prune(fpBtcPkHex, now), now)
+	l.lastErr[fpBtcPkHex] = err
+}
+
+// Counts returns the number of critical errors within the window before
+// the given time and the last of them, by finality provider
+func (l *criticalErrorLog) Counts(now time.Time) (map[string]int, map[string]error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	counts := make(map[string]int, len(l.times))
+	lastErrs := make(map[string]error, len(l.times))
+	for pk := range l.times {
+		if n := len(l.prune(pk, now)); n > 0 {
+			counts[pk] = n
+			lastErrs[pk] = l.lastErr[pk]
+		}
+	}
+
+	return counts, lastErrs
+}
+
+func (l *criticalErrorLog) prune(fpBtcPkHex string, now time.Time) []time.Time {
+	times := l.times[fpBtcPkHex]
+	i := 0
+	for i < len(times) && now.Sub(times[i]) >= l.window {
+		i++
+	}
+	times = times[i:]
+	if len(times) == 0 {
+		delete(l.times, fpBtcPkHex)
+		delete(l.lastErr, fpBtcPkHex)
+
+		return nil
+	}
+	l.times[fpBtcPkHex] = times
+
+	return times
+}
+
+// newAlertEngine creates the engine evaluating the alerting rules of the
+// config, which notifies the webhooks of the config
+func (app *FinalityProviderApp) newAlertEngine() (*alerting.Engine, error) {
+	cfg := app.config.Alerting
+
+	routingKey, err := eotsclient.GetSecretValue(cfg.PagerDutyRoutingKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the pagerduty routing key: %w", err)
+	}
+
+	notifiers := make([]alerting.Notifier, 0, len(cfg.Webhooks))
+	for _, w := range cfg.Webhooks {
+		webhook, err := alerting.ParseWebhook(w)
+		if err != nil {
+			return nil, err
+		}
+		n, err := alerting.NewWebhookNotifier(webhook, routingKey)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, n)
+	}
+
+	silences := make([]alerting.Silence, 0, len(cfg.Silences))
+	for _, s := range cfg.Silences {
+		silence, err := alerting.ParseSilence(s)
+		if err != nil {
+			return nil, err
+		}
+		silences = append(silences, silence)
+	}
+
+	rules := []alerting.Rule{
+		{Name: AlertRuleJailed, Severity: alerting.SeverityCritical, Evaluate: app.storedStatusRule(proto.FinalityProviderStatus_JAILED)},
+		{Name: AlertRuleSlashed, Severity: alerting.SeverityCritical, Evaluate: app.storedStatusRule(proto.FinalityProviderStatus_SLASHED)},
+		{Name: AlertRuleDegraded, Severity: alerting.SeverityWarning, Evaluate: app.evaluateDegraded},
+	}
+	if cfg.MissedVoteStreak > 0 {
+		rules = append(rules, alerting.Rule{Name: AlertRuleMissedVotes, Severity: alerting.SeverityCritical, Evaluate: app.evaluateMissedVotes})
+	}
+	if cfg.MinRandomnessRunway > 0 {
+		rules = append(rules, alerting.Rule{Name: AlertRuleRandomnessRunway, Severity: alerting.SeverityWarning, Evaluate: app.evaluateRandomnessRunway})
+	}
+	if cfg.MaxTimeSinceLastVote > 0 {
+		rules = append(rules, alerting.Rule{Name: AlertRuleTimeSinceLastVote, Severity: alerting.SeverityWarning, Evaluate: app.evaluateTimeSinceLastVote})
+	}
+	if cfg.CriticalErrors > 0 {
+		rules = append(rules, alerting.Rule{Name: AlertRuleCriticalErrors, Severity: alerting.SeverityCritical, Evaluate: app.evaluateCriticalErrors})
+	}
+
+	return alerting.NewEngine(rules, notifiers, cfg.RepeatInterval, silences, app.logger), nil
+}
+
+// storedStatusRule returns the evaluation of the finality providers
+// whose status in the store is the given one
+func (app *FinalityProviderApp) storedStatusRule(status proto.FinalityProviderStatus) func(context.Context) ([]alerting.Firing, error) {
+	return func(_ context.Context) ([]alerting.Firing, error) {
+		fps, err := app.fps.GetAllStoredFinalityProviders()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the stored finality providers: %w", err)
+		}
+
+		var firings []alerting.Firing
+		for _, fp := range fps {
+			if fp.Status != status {
+				continue
+			}
+			firings = append(firings, alerting.Firing{
+				Subject: fp.GetBIP340BTCPK().MarshalHex(),
+				Summary: fmt.Sprintf("the finality provider is %s", status),
+			})
+		}
+
+		return firings, nil
+	}
+}
+
+func (app *FinalityProviderApp) evaluateDegraded(_ context.Context) ([]alerting.Firing, error) {
+	fpi, err := app.runningInstance()
+	if err != nil {
+		return nil, nil
+	}
+
+	degraded := fpi.DegradedComponents()
+	if len(degraded) == 0 {
+		return nil, nil
+	}
+
+	return []alerting.Firing{{
+		Subject: fpi.GetBtcPkHex(),
+		Summary: "the finality provider is degraded: " + degradedSummary(degraded),
+	}}, nil
+}
+
+// activeInstance returns the finality provider instance if it is running
+// and has voting power, and nil otherwise
+func (app *FinalityProviderApp) activeInstance() *FinalityProviderInstance {
+	fpi, err := app.runningInstance()
+	if err != nil || fpi.GetStatus() != proto.FinalityProviderStatus_ACTIVE {
+		return nil
+	}
+
+	return fpi
+}
+
+func (app *FinalityProviderApp) evaluateMissedVotes(ctx context.Context) ([]alerting.Firing, error) {
+	fpi := app.activeInstance()
+	if fpi == nil {
+		return nil, nil
+	}
+
+	tip, err := fpi.consumerCon.QueryLatestBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the latest block: %w", err)
+	}
+
+	lastVotedHeight := fpi.GetLastVotedHeight()
+	if tip.GetHeight() <= lastVotedHeight {
+		return nil, nil
+	}
+	if missed := tip.GetHeight() - lastVotedHeight; missed >= app.config.Alerting.MissedVoteStreak {
+		return []alerting.Firing{{
+			Subject: fpi.GetBtcPkHex(),
+			Summary: fmt.Sprintf("the finality provider has not voted for the %d blocks up to the tip %d since its last vote at height %d",
+				missed, tip.GetHeight(), lastVotedHeight),
+		}}, nil
+	}
+
+	return nil, nil
+}
+
+func (app *FinalityProviderApp) evaluateRandomnessRunway(ctx context.Context) ([]alerting.Firing, error) {
+	fpi, err := app.runningInstance()
+	// a retiring key votes with the randomness it already committed
+	if err != nil || fpi.IsRetiring() {
+		return nil, nil
+	}
+
+	runway, tipHeight, err := randomnessRunway(ctx, fpi)
+	if err != nil {
+		return nil, err
+	}
+	if minRunway := app.config.Alerting.MinRandomnessRunway; runway < minRunway {
+		return []alerting.Firing{{
+			Subject: fpi.GetBtcPkHex(),
+			Summary: fmt.Sprintf("the committed randomness covers %d blocks after the tip %d, less than %d",
+				runway, tipHeight, minRunway),
+		}}, nil
+	}
+
+	return nil, nil
+}
+
+func (app *FinalityProviderApp) evaluateTimeSinceLastVote(_ context.Context) ([]alerting.Firing, error) {
+	fpi := app.activeInstance()
+	if fpi == nil {
+		return nil, nil
+	}
+
+	// the missed votes rule covers a finality provider not voting since the start
+	lastVote, ok := app.metrics.LastVoteTime(fpi.GetBtcPkHex())
+	if !ok {
+		return nil, nil
+	}
+	if elapsed := time.Since(lastVote); elapsed > app.config.Alerting.MaxTimeSinceLastVote {
+		return []alerting.Firing{{
+			Subject: fpi.GetBtcPkHex(),
+			Summary: fmt.Sprintf("the finality provider has not voted for %s", elapsed.Round(time.Second)),
+		}}, nil
+	}
+
+	return nil, nil
+}
+
+func (app *FinalityProviderApp) evaluateCriticalErrors(_ context.Context) ([]alerting.Firing, error) {
+	cfg := app.config.Alerting
+	counts, lastErrs := app.criticalErrs.Counts(time.Now())
+
+	var firings []alerting.Firing
+	for pk, n := range counts {
+		if n < int(cfg.CriticalErrors) {
+			continue
+		}
+		firings = append(firings, alerting.Firing{
+			Subject: pk,
+			Summary: fmt.Sprintf("%d critical errors within %s, the last: %v", n, cfg.CriticalErrorWindow, lastErrs[pk]),
+		})
+	}
+
+	return firings, nil
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/lightningnetwork/lnd/kvdb"
 	"go.uber.org/zap"
 
+	"github.com/babylonlabs-io/finality-provider/alerting"
 	fpcc "github.com/babylonlabs-io/finality-provider/clientcontroller"
 	ccapi "github.com/babylonlabs-io/finality-provider/clientcontroller/api"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
@@ -48,12 +49,30 @@ type FinalityProviderApp struct {
 	heightDeterminer  types.HeightDeterminer
 	finalitySubmitter types.FinalitySignatureSubmitter
 
-	fpInsMu     sync.RWMutex // Protects fpIns
+	fpInsMu     sync.RWMutex // Protects fpIns and rotationFpIns
 	fpIns       *FinalityProviderInstance
 	eotsManager eotsmanager.EOTSManager
 
+	// rotationFpIns is the instance of the new finality provider running
+	// side by side with fpIns while the EOTS key of fpIns is rotated
+	rotationFpIns *FinalityProviderInstance
+	// newRotationComponents creates the components of rotationFpIns,
+	// it is nil if the app is not created from the config
+	newRotationComponents func(keyName string) (*instanceComponents, error)
+
 	metrics *metrics.FpMetrics
 
+	// configLoader reads the config again on a reload,
+	// it is nil if the config cannot be reloaded
+	configLoader func() (*fpcfg.Config, error)
+
+	// balanceMonitor is nil if the balance monitor is disabled
+	balanceMonitor *BalanceMonitor
+
+	// alertEngine is nil if alerting is disabled
+	alertEngine  *alerting.Engine
+	criticalErrs *criticalErrorLog
+
 	createFinalityProviderRequestChan chan *CreateFinalityProviderRequest
 	unjailFinalityProviderRequestChan chan *UnjailFinalityProviderRequest
 	criticalErrChan                   chan *CriticalError
@@ -65,6 +84,62 @@ func NewFinalityProviderAppFromConfig(
 	db kvdb.Backend,
 	logger *zap.Logger,
 ) (*FinalityProviderApp, error) {
+	em, err := NewEOTSManagerFromConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create EOTS manager client: %w", err)
+	}
+
+	if cfg.ThresholdEOTSManager.IsEnabled() {
+		logger.Info("successfully connected to the EOTS managers of threshold EOTS keys",
+			zap.Strings("addresses", cfg.ThresholdEOTSManager.Addresses),
+			zap.Uint32("threshold", cfg.ThresholdEOTSManager.Threshold))
+	} else {
+		logger.Info("successfully connected to a remote EOTS manager", zap.String("address", cfg.EOTSManagerAddress))
+	}
+
+	fpMetrics := metrics.NewFpMetrics()
+
+	pubRandStore, err := store.NewPubRandProofStore(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate public randomness store: %w", err)
+	}
+
+	c, err := newInstanceComponents(cfg, em, pubRandStore, fpMetrics, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	app, err := NewFinalityProviderApp(cfg, c.cc, c.consumerCon, em, c.poller, c.rndCommitter, c.heightDeterminer, c.finalitySubmitter, fpMetrics, db, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	app.newRotationComponents = func(keyName string) (*instanceComponents, error) {
+		return newInstanceComponents(rotationConfig(cfg, keyName), em, pubRandStore, fpMetrics, logger)
+	}
+
+	return app, nil
+}
+
+// instanceComponents are the clients and helpers bound to a single finality
+// provider instance, as the poller follows one stream of blocks and the
+// committer and submitter are initialized with the state of one finality provider
+type instanceComponents struct {
+	cc                ccapi.BabylonController
+	consumerCon       ccapi.ConsumerController
+	poller            types.BlockPoller[types.BlockDescription]
+	rndCommitter      types.RandomnessCommitter
+	heightDeterminer  types.HeightDeterminer
+	finalitySubmitter types.FinalitySignatureSubmitter
+}
+
+func newInstanceComponents(
+	cfg *fpcfg.Config,
+	em eotsmanager.EOTSManager,
+	pubRandStore *store.PubRandProofStore,
+	fpMetrics *metrics.FpMetrics,
+	logger *zap.Logger,
+) (*instanceComponents, error) {
 	cc, err := fpcc.NewBabylonController(cfg.BabylonConfig, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create rpc client for the Babylon chain: %w", err)
@@ -78,23 +153,22 @@ func NewFinalityProviderAppFromConfig(
 		return nil, fmt.Errorf("failed to create rpc client for the consumer chain babylon: %w", err)
 	}
 
-	// if the EOTSManagerAddress is empty, run a local EOTS manager;
-	// otherwise connect a remote one with a gRPC client
-	em, err := InitEOTSManagerClient(cfg.EOTSManagerAddress, cfg.HMACKey, cfg.GRPCMaxContentLength)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create EOTS manager client: %w", err)
-	}
-
-	logger.Info("successfully connected to a remote EOTS manager", zap.String("address", cfg.EOTSManagerAddress))
+	return newInstanceComponentsWithControllers(cfg, cc, consumerCon, em, pubRandStore, fpMetrics, logger), nil
+}
 
-	fpMetrics := metrics.NewFpMetrics()
+// newInstanceComponentsWithControllers creates the helpers of a finality
+// provider instance on top of the given controllers
+func newInstanceComponentsWithControllers(
+	cfg *fpcfg.Config,
+	cc ccapi.BabylonController,
+	consumerCon ccapi.ConsumerController,
+	em eotsmanager.EOTSManager,
+	pubRandStore *store.PubRandProofStore,
+	fpMetrics *metrics.FpMetrics,
+	logger *zap.Logger,
+) *instanceComponents {
 	poller := NewChainPoller(logger, cfg.PollerConfig, consumerCon, fpMetrics)
 
-	pubRandStore, err := store.NewPubRandProofStore(db)
-	if err != nil {
-		return nil, fmt.Errorf("failed to initiate public randomness store: %w", err)
-	}
-
 	rndCommiter := NewDefaultRandomnessCommitter(
 		NewRandomnessCommitterConfig(cfg.NumPubRand, int64(cfg.TimestampingDelayBlocks), cfg.ContextSigningHeight),
 		NewPubRandState(pubRandStore),
@@ -112,7 +186,14 @@ func NewFinalityProviderAppFromConfig(
 	)
 	finalitySubmitter := NewDefaultFinalitySubmitter(consumerCon, em, rndCommiter.GetPubRandProofList, fsCfg, logger, fpMetrics)
 
-	return NewFinalityProviderApp(cfg, cc, consumerCon, em, poller, rndCommiter, heightDeterminer, finalitySubmitter, fpMetrics, db, logger)
+	return &instanceComponents{
+		cc:                cc,
+		consumerCon:       consumerCon,
+		poller:            poller,
+		rndCommitter:      rndCommiter,
+		heightDeterminer:  heightDeterminer,
+		finalitySubmitter: finalitySubmitter,
+	}
 }
 
 func NewFinalityProviderApp(
@@ -146,7 +227,12 @@ func NewFinalityProviderApp(
 		return nil, fmt.Errorf("failed to create keyring: %w", err)
 	}
 
-	return &FinalityProviderApp{
+	var balanceMonitor *BalanceMonitor
+	if config.BalanceMonitor.IsEnabled() {
+		balanceMonitor = NewBalanceMonitor(config.BalanceMonitor.MinBalance)
+	}
+
+	app := &FinalityProviderApp{
 		cc:                                cc,
 		consumerCon:                       consumerCon,
 		fps:                               fpStore,
@@ -160,11 +246,22 @@ func NewFinalityProviderApp(
 		heightDeterminer:                  heightDeterminer,
 		finalitySubmitter:                 finalitySubmitter,
 		metrics:                           metrics,
+		balanceMonitor:                    balanceMonitor,
 		unjailFinalityProviderRequestChan: make(chan *UnjailFinalityProviderRequest),
 		createFinalityProviderRequestChan: make(chan *CreateFinalityProviderRequest),
 		criticalErrChan:                   make(chan *CriticalError),
 		quit:                              make(chan struct{}),
-	}, nil
+	}
+
+	if config.Alerting.IsEnabled() {
+		app.criticalErrs = newCriticalErrorLog(config.Alerting.CriticalErrorWindow)
+		app.alertEngine, err = app.newAlertEngine()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the alert engine: %w", err)
+		}
+	}
+
+	return app, nil
 }
 
 func (app *FinalityProviderApp) GetConfig() *fpcfg.Config {
@@ -194,10 +291,7 @@ func (app *FinalityProviderApp) GetFinalityProviderInfo(fpPk *bbntypes.BIP340Pub
 	}
 
 	fpInfo := storedFp.ToFinalityProviderInfo()
-
-	if app.IsFinalityProviderRunning(fpPk) {
-		fpInfo.IsRunning = true
-	}
+	app.setInstanceInfo(fpInfo, fpPk)
 
 	return fpInfo, nil
 }
@@ -211,10 +305,7 @@ func (app *FinalityProviderApp) ListAllFinalityProvidersInfo() ([]*proto.Finalit
 	fpsInfo := make([]*proto.FinalityProviderInfo, 0, len(storedFps))
 	for _, fp := range storedFps {
 		fpInfo := fp.ToFinalityProviderInfo()
-
-		if app.IsFinalityProviderRunning(fp.GetBIP340BTCPK()) {
-			fpInfo.IsRunning = true
-		}
+		app.setInstanceInfo(fpInfo, fp.GetBIP340BTCPK())
 
 		fpsInfo = append(fpsInfo, fpInfo)
 	}
@@ -222,6 +313,23 @@ func (app *FinalityProviderApp) ListAllFinalityProvidersInfo() ([]*proto.Finalit
 	return fpsInfo, nil
 }
 
+// setInstanceInfo sets whether the finality provider runs in the daemon in
+// its info, and the components backing off after failures if it does
+func (app *FinalityProviderApp) setInstanceInfo(fpInfo *proto.FinalityProviderInfo, fpPk *bbntypes.BIP340PubKey) {
+	fpIns, err := app.getFinalityProviderInstanceByPk(fpPk)
+	if err != nil || !fpIns.IsRunning() {
+		return
+	}
+
+	fpInfo.IsRunning = true
+	for name, reason := range fpIns.DegradedComponents() {
+		if fpInfo.DegradedComponents == nil {
+			fpInfo.DegradedComponents = make(map[string]string)
+		}
+		fpInfo.DegradedComponents[name] = reason.Error()
+	}
+}
+
 // GetFinalityProviderInstance returns the finality-provider instance with the given Babylon public key
 func (app *FinalityProviderApp) GetFinalityProviderInstance() (*FinalityProviderInstance, error) {
 	app.fpInsMu.RLock()
@@ -243,10 +351,27 @@ func (app *FinalityProviderApp) Logger() *zap.Logger {
 func (app *FinalityProviderApp) StartFinalityProvider(ctx context.Context, fpPk *bbntypes.BIP340PubKey) error {
 	app.logger.Info("starting finality provider", zap.String("pk", fpPk.MarshalHex()))
 
+	rotation, err := app.fps.GetKeyRotation(fpPk.MustToBTCPK())
+	if err != nil && !errors.Is(err, store.ErrKeyRotationNotFound) {
+		return err
+	}
+	if rotation != nil && rotation.Phase == proto.KeyRotationPhase_RETIRED {
+		return fmt.Errorf("the EOTS key %s is retired, start the finality provider with the new EOTS key %x",
+			fpPk.MarshalHex(), rotation.NewBtcPk)
+	}
+
 	if err := app.startFinalityProviderInstance(ctx, fpPk); err != nil {
 		return err
 	}
 
+	fpIns, err := app.GetFinalityProviderInstance()
+	if err != nil {
+		return err
+	}
+	if err := app.resumeKeyRotation(ctx, fpIns); err != nil {
+		return fmt.Errorf("failed to resume the key rotation of %s: %w", fpPk.MarshalHex(), err)
+	}
+
 	app.logger.Info("finality provider is started", zap.String("pk", fpPk.MarshalHex()))
 
 	return nil
@@ -341,11 +466,22 @@ func (app *FinalityProviderApp) Start(ctx context.Context) error {
 			return
 		}
 
-		app.wg.Add(4)
+		app.wg.Add(5)
 		go app.metricsUpdateLoop(ctx)
 		go app.monitorCriticalErr(ctx)
 		go app.registrationLoop(ctx)
 		go app.unjailFpLoop(ctx)
+		go app.keyRotationLoop(ctx)
+
+		if app.balanceMonitor != nil {
+			app.wg.Add(1)
+			go app.balanceMonitorLoop(ctx)
+		}
+
+		if app.alertEngine != nil {
+			app.wg.Add(1)
+			go app.alertingLoop(ctx)
+		}
 	})
 
 	return startErr
@@ -358,11 +494,15 @@ func (app *FinalityProviderApp) Stop() error {
 
 		app.wg.Wait()
 
-		if app.fpIns != nil && app.fpIns.IsRunning() {
-			pkHex := app.fpIns.GetBtcPkHex()
+		for _, fpIns := range []*FinalityProviderInstance{app.fpIns, app.rotationFpIns} {
+			if fpIns == nil || !fpIns.IsRunning() {
+				continue
+			}
+
+			pkHex := fpIns.GetBtcPkHex()
 			app.logger.Info("stopping finality provider", zap.String("pk", pkHex))
 
-			if err := app.fpIns.Stop(); err != nil {
+			if err := fpIns.Stop(); err != nil {
 				stopErr = fmt.Errorf("failed to close the fp instance: %w", err)
 
 				return
@@ -567,6 +707,7 @@ func (app *FinalityProviderApp) startFinalityProviderInstance(
 		if err != nil {
 			return fmt.Errorf("failed to create finality provider instance %s: %w", pkHex, err)
 		}
+		fpIns.balanceMonitor = app.balanceMonitor
 
 		app.fpIns = fpIns
 	} else if !pk.Equals(app.fpIns.btcPk) {
@@ -578,26 +719,34 @@ func (app *FinalityProviderApp) startFinalityProviderInstance(
 }
 
 func (app *FinalityProviderApp) IsFinalityProviderRunning(fpPk *bbntypes.BIP340PubKey) bool {
-	app.fpInsMu.RLock()
-	defer app.fpInsMu.RUnlock()
-
-	if app.fpIns == nil {
+	fpIns, err := app.getFinalityProviderInstanceByPk(fpPk)
+	if err != nil {
 		return false
 	}
 
-	if app.fpIns.GetBtcPkHex() != fpPk.MarshalHex() {
-		return false
+	return fpIns.IsRunning()
+}
+
+// getFinalityProviderInstanceByPk returns the running instance with the given
+// EOTS public key, which is either the main instance or the one of a key rotation
+func (app *FinalityProviderApp) getFinalityProviderInstanceByPk(fpPk *bbntypes.BIP340PubKey) (*FinalityProviderInstance, error) {
+	app.fpInsMu.RLock()
+	defer app.fpInsMu.RUnlock()
+
+	for _, fpIns := range []*FinalityProviderInstance{app.fpIns, app.rotationFpIns} {
+		if fpIns != nil && fpIns.GetBtcPkHex() == fpPk.MarshalHex() {
+			return fpIns, nil
+		}
 	}
 
-	return app.fpIns.IsRunning()
+	return nil, fmt.Errorf("finality provider %s does not exist", fpPk.MarshalHex())
 }
 
-func (app *FinalityProviderApp) removeFinalityProviderInstance() error {
+func (app *FinalityProviderApp) removeFinalityProviderInstance(fpi *FinalityProviderInstance) error {
 	app.fpInsMu.Lock()
 	defer app.fpInsMu.Unlock()
 
-	fpi := app.fpIns
-	if fpi == nil {
+	if fpi == nil || (fpi != app.fpIns && fpi != app.rotationFpIns) {
 		return fmt.Errorf("the finality provider instance does not exist")
 	}
 	if fpi.IsRunning() {
@@ -606,14 +755,18 @@ func (app *FinalityProviderApp) removeFinalityProviderInstance() error {
 		}
 	}
 
-	app.fpIns = nil
+	if fpi == app.fpIns {
+		app.fpIns = nil
+	} else {
+		app.rotationFpIns = nil
+	}
 
 	return nil
 }
 
 func (app *FinalityProviderApp) setFinalityProviderSlashed(fpi *FinalityProviderInstance) {
-	fpi.mustSetStatus(proto.FinalityProviderStatus_SLASHED)
-	if err := app.removeFinalityProviderInstance(); err != nil {
+	fpi.setStatus(proto.FinalityProviderStatus_SLASHED)
+	if err := app.removeFinalityProviderInstance(fpi); err != nil {
 		panic(fmt.Errorf("failed to terminate a slashed finality-provider %s: %w", fpi.GetBtcPkHex(), err))
 	}
 }
@@ -317,6 +317,56 @@ func FuzzUnjailFinalityProvider(f *testing.F) {
 	})
 }
 
+func TestFinalityProviderInfoDegradedComponents(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	mockBabylonController := testutil.PrepareMockedBabylonController(t)
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	currentHeight := randomStartingHeight + uint64(r.Int63n(10)+2)
+	mockConsumerController := testutil.PrepareMockedConsumerController(t, r, randomStartingHeight, currentHeight)
+	mockConsumerController.EXPECT().GetFpRandCommitContext().Return("").AnyTimes()
+	mockConsumerController.EXPECT().IsBSN().Return(false).AnyTimes()
+	// the last randomness commit cannot be queried
+	mockConsumerController.EXPECT().QueryLastPubRandCommit(gomock.Any(), gomock.Any()).Return(nil, errors.New("invalid response")).AnyTimes()
+	mockConsumerController.EXPECT().QueryLatestFinalizedBlock(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockConsumerController.EXPECT().QueryBlocks(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockConsumerController.EXPECT().QueryFinalityProviderHasPower(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	mockConsumerController.EXPECT().QueryFinalityProviderStatus(gomock.Any(), gomock.Any()).Return(&api.FinalityProviderStatusResponse{}, nil).AnyTimes()
+	mockConsumerController.EXPECT().QueryFinalityProviderHighestVotedHeight(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	ctx, cancel := context.WithCancel(t.Context())
+	app, fpPk, cleanup := startFPAppWithRegisteredFp(ctx, t, r, fpHomeDir, &fpCfg, mockBabylonController, mockConsumerController)
+	defer func() {
+		cancel()
+		cleanup()
+	}()
+
+	fpInfo, err := app.GetFinalityProviderInfo(fpPk)
+	require.NoError(t, err)
+	require.False(t, fpInfo.IsRunning)
+	require.Empty(t, fpInfo.DegradedComponents)
+
+	// the degraded components of the running finality provider are reported
+	// once the query of the last commit exhausts its retries
+	require.NoError(t, app.StartFinalityProvider(ctx, fpPk))
+	require.Eventually(t, func() bool {
+		fpInfo, err = app.GetFinalityProviderInfo(fpPk)
+		require.NoError(t, err)
+
+		return fpInfo.DegradedComponents[service.ComponentRandomness] != ""
+	}, 20*time.Second, eventuallyPollTime)
+	require.True(t, fpInfo.IsRunning)
+	require.Contains(t, fpInfo.DegradedComponents[service.ComponentRandomness], "invalid response")
+
+	fpsInfo, err := app.ListAllFinalityProvidersInfo()
+	require.NoError(t, err)
+	require.Len(t, fpsInfo, 1)
+	require.Contains(t, fpsInfo[0].DegradedComponents, service.ComponentRandomness)
+}
+
 func FuzzSaveAlreadyRegisteredFinalityProvider(f *testing.F) {
 	testutil.AddRandomSeedsToFuzzer(f, 10)
 	f.Fuzz(func(t *testing.T, seed int64) {
@@ -456,6 +506,68 @@ func FuzzSaveAlreadyRegisteredFinalityProvider(f *testing.F) {
 	})
 }
 
+func TestReloadConfig(t *testing.T) {
+	t.Parallel()
+
+	homePath := t.TempDir()
+	cfg := config.DefaultConfigWithHome(homePath)
+	require.NoError(t, util.MakeDirectory(config.DataDir(homePath)))
+	db, err := cfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+
+	app, err := service.NewFinalityProviderApp(&cfg, testutil.PrepareMockedBabylonController(t), nil,
+		nil, nil, nil, nil, nil, metrics.NewFpMetrics(), db, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	_, err = app.ReloadConfig()
+	require.Error(t, err)
+
+	var newCfg config.Config
+	app.SetConfigLoader(func() (*config.Config, error) {
+		c := newCfg
+
+		return &c, nil
+	})
+
+	// the reloadable fields are applied
+	newCfg = config.DefaultConfigWithHome(homePath)
+	newCfg.BatchSubmissionSize = 10
+	newCfg.PollerConfig.PollInterval = 5 * time.Second
+	newCfg.BabylonConfig.GasPrices = "0.01ubbn"
+	changes, err := app.ReloadConfig()
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	require.Equal(t, "BatchSubmissionSize: 1000 -> 10", changes[0].String())
+	require.Equal(t, uint32(10), cfg.GetBatchSubmissionSize())
+	require.Equal(t, 5*time.Second, cfg.PollerConfig.GetPollInterval())
+	require.Equal(t, "0.01ubbn", cfg.BabylonConfig.GasPrices)
+
+	// while nothing is applied if a field requires a restart
+	newCfg = config.DefaultConfigWithHome(homePath)
+	newCfg.BatchSubmissionSize = 20
+	newCfg.BabylonConfig.ChainID = "other-chain"
+	newCfg.DatabaseConfig.DBPath = t.TempDir()
+	newCfg.HMACKey = "secret"
+	_, err = app.ReloadConfig()
+	var restartErr *config.RestartRequiredError
+	require.ErrorAs(t, err, &restartErr)
+	require.Len(t, restartErr.Changes, 3)
+	require.Contains(t, err.Error(), "babylon.ChainID: chain-test -> other-chain")
+	require.Contains(t, err.Error(), "dbconfig.DBPath")
+	require.Contains(t, err.Error(), "HMACKey: <redacted> -> <redacted>")
+	require.NotContains(t, err.Error(), "secret")
+	require.Equal(t, uint32(10), cfg.GetBatchSubmissionSize())
+
+	// or if the config is invalid
+	newCfg = config.DefaultConfigWithHome(homePath)
+	newCfg.BatchSubmissionSize = 0
+	_, err = app.ReloadConfig()
+	require.ErrorContains(t, err, "invalid batch submission size")
+}
+
 func startFPAppWithRegisteredFp(ctx context.Context, t *testing.T, r *rand.Rand, homePath string, cfg *config.Config, cc api.BabylonController, consumerCon api.ConsumerController) (*service.FinalityProviderApp, *bbntypes.BIP340PubKey, func()) {
 	logger := zaptest.NewLogger(t)
 	// create an EOTS manager
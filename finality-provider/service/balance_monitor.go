@@ -0,0 +1,99 @@
+package service
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// BalanceMonitor keeps track of the balance of the account paying the
+// transaction fees and projects when it runs out based on the observed burn.
+// Only decreases of the balance are counted as burn, so top-ups do not
+// distort the projection.
+type BalanceMonitor struct {
+	mu sync.RWMutex
+
+	minBalance uint64
+
+	balance       uint64
+	lastObserved  time.Time
+	firstObserved time.Time
+	totalBurned   uint64
+}
+
+func NewBalanceMonitor(minBalance uint64) *BalanceMonitor {
+	return &BalanceMonitor{
+		minBalance: minBalance,
+	}
+}
+
+// Observe records the balance at the given time
+func (bm *BalanceMonitor) Observe(balance uint64, now time.Time) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	if bm.firstObserved.IsZero() {
+		bm.firstObserved = now
+	} else if balance < bm.balance {
+		bm.totalBurned += bm.balance - balance
+	}
+
+	bm.balance = balance
+	bm.lastObserved = now
+}
+
+// Balance returns the last observed balance and whether any was observed
+func (bm *BalanceMonitor) Balance() (uint64, bool) {
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+
+	return bm.balance, !bm.firstObserved.IsZero()
+}
+
+// BurnPerDay returns the average amount burned per day since the first observation
+func (bm *BalanceMonitor) BurnPerDay() float64 {
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+
+	return bm.burnPerDay()
+}
+
+func (bm *BalanceMonitor) burnPerDay() float64 {
+	elapsed := bm.lastObserved.Sub(bm.firstObserved)
+	if elapsed <= 0 {
+		return 0
+	}
+
+	return float64(bm.totalBurned) / (elapsed.Hours() / 24)
+}
+
+// DaysLeft returns the projected number of days before the balance runs out.
+// It returns +Inf if no burn has been observed yet.
+func (bm *BalanceMonitor) DaysLeft() float64 {
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+
+	burn := bm.burnPerDay()
+	if burn == 0 {
+		return math.Inf(1)
+	}
+
+	return float64(bm.balance) / burn
+}
+
+// IsBelowFloor returns true if the last observed balance is lower than the floor.
+// It is always false if the floor is disabled or no balance was observed yet.
+func (bm *BalanceMonitor) IsBelowFloor() bool {
+	if bm == nil {
+		return false
+	}
+
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+
+	if bm.minBalance == 0 || bm.firstObserved.IsZero() {
+		return false
+	}
+
+	return bm.balance < bm.minBalance
+}
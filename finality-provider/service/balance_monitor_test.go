@@ -0,0 +1,51 @@
+package service_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+)
+
+func TestBalanceMonitor(t *testing.T) {
+	t.Parallel()
+
+	bm := service.NewBalanceMonitor(1000)
+	require.False(t, bm.IsBelowFloor())
+	require.True(t, math.IsInf(bm.DaysLeft(), 1))
+
+	start := time.Now()
+	bm.Observe(10_000, start)
+	require.False(t, bm.IsBelowFloor())
+	require.True(t, math.IsInf(bm.DaysLeft(), 1))
+
+	// burn 1000 in one day
+	bm.Observe(9_000, start.Add(24*time.Hour))
+	require.InDelta(t, 1000, bm.BurnPerDay(), 0.001)
+	require.InDelta(t, 9, bm.DaysLeft(), 0.001)
+
+	// a top-up is not counted as negative burn
+	bm.Observe(19_000, start.Add(48*time.Hour))
+	require.InDelta(t, 500, bm.BurnPerDay(), 0.001)
+	require.InDelta(t, 38, bm.DaysLeft(), 0.001)
+
+	bm.Observe(999, start.Add(72*time.Hour))
+	require.True(t, bm.IsBelowFloor())
+	balance, ok := bm.Balance()
+	require.True(t, ok)
+	require.Equal(t, uint64(999), balance)
+}
+
+func TestBalanceMonitorFloorDisabled(t *testing.T) {
+	t.Parallel()
+
+	bm := service.NewBalanceMonitor(0)
+	bm.Observe(0, time.Now())
+	require.False(t, bm.IsBelowFloor())
+
+	var nilMonitor *service.BalanceMonitor
+	require.False(t, nilMonitor.IsBelowFloor())
+}
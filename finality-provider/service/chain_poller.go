@@ -10,6 +10,7 @@ import (
 	ccapi "github.com/babylonlabs-io/finality-provider/clientcontroller/api"
 	cfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 	"github.com/babylonlabs-io/finality-provider/types"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
@@ -44,6 +45,10 @@ type ChainPoller struct {
 
 	nextHeight uint64
 
+	// degradedErr is set while the poller backs off after too many
+	// consecutive failed poll cycles
+	degradedErr error
+
 	blockChan     chan types.BlockDescription
 	blockChanSize int
 }
@@ -121,6 +126,7 @@ func (cp *ChainPoller) SetStartHeight(ctx context.Context, height uint64) error
 
 	cp.mu.Lock()
 	cp.nextHeight = height
+	cp.degradedErr = nil
 	cp.quit = make(chan struct{})
 	cp.blockChan = make(chan types.BlockDescription, cp.blockChanSize)
 	cp.mu.Unlock()
@@ -162,7 +168,7 @@ func (cp *ChainPoller) Stop() error {
 // waitForActivation waits until BTC staking is activated, adjusting the start height if necessary.
 func (cp *ChainPoller) waitForActivation(ctx context.Context) error {
 	cp.logger.Info("waiting for BTC staking activation")
-	ticker := time.NewTicker(cp.cfg.PollInterval)
+	ticker := time.NewTicker(cp.cfg.GetPollInterval())
 	defer ticker.Stop()
 
 	for {
@@ -205,7 +211,8 @@ func (cp *ChainPoller) pollChain(ctx context.Context) {
 		return
 	}
 
-	ticker := time.NewTicker(cp.cfg.PollInterval)
+	interval := cp.cfg.GetPollInterval()
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	var failedCycles uint32
@@ -224,28 +231,50 @@ func (cp *ChainPoller) pollChain(ctx context.Context) {
 					zap.Error(err))
 
 				if failedCycles > maxFailedCycles {
-					cp.logger.Fatal("the poller has reached the max failed cycles, exiting")
-
-					return
+					backoff := degradedBackoff(cp.cfg.GetPollInterval(), failedCycles-maxFailedCycles)
+					cp.setDegraded(fmt.Errorf("%d consecutive poll cycles failed, the last: %w", failedCycles, err))
+					cp.logger.Error("the poller has reached the max failed cycles, backing off",
+						zap.Uint32("current_failures", failedCycles),
+						zap.Duration("backoff", backoff),
+						zap.Error(err))
+					ticker.Reset(backoff)
 				}
 			} else {
-				if failedCycles > 0 {
+				if failedCycles > maxFailedCycles {
+					cp.setDegraded(nil)
+					cp.logger.Info("the poller recovered from errors",
+						zap.Uint32("recovered_from_failures", failedCycles))
+					interval = cp.cfg.GetPollInterval()
+					ticker.Reset(interval)
+				} else if failedCycles > 0 {
 					cp.logger.Debug("poll cycle recovered from errors",
 						zap.Uint32("recovered_from_failures", failedCycles))
 				}
 				failedCycles = 0
+				interval = resetOnReload(ticker, interval, cp.cfg.GetPollInterval())
 			}
 		}
 	}
 }
 
-func (cp *ChainPoller) pollCycle(ctx context.Context) error {
+func (cp *ChainPoller) pollCycle(ctx context.Context) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "ChainPoller.pollCycle")
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	latestBlockHeight, err := cp.latestBlockHeightWithRetry(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get latest block height: %w", err)
 	}
 
 	blockToRetrieve := cp.getNextHeight()
+	span.SetAttributes(tracing.HeightRange(blockToRetrieve, latestBlockHeight)...)
+	if latestBlockHeight > blockToRetrieve {
+		cp.metrics.RecordPollerLag(latestBlockHeight - blockToRetrieve)
+	} else {
+		cp.metrics.RecordPollerLag(0)
+	}
 
 	return cp.tryPollChain(ctx, latestBlockHeight, blockToRetrieve)
 }
@@ -316,6 +345,7 @@ func (cp *ChainPoller) tryPollChain(ctx context.Context, latestBlockHeight, bloc
 	lastBlock := blocks[len(blocks)-1]
 	cp.setNextHeight(lastBlock.GetHeight() + 1)
 	cp.metrics.RecordLastPolledHeight(lastBlock.GetHeight())
+	cp.metrics.RecordPollerBatchSize(len(blocks))
 
 	cp.logger.Debug("sent blocks to channel",
 		zap.Uint64("start_height", blockToRetrieve),
@@ -401,3 +431,20 @@ func (cp *ChainPoller) latestBlockHeightWithRetry(ctx context.Context) (uint64,
 func (cp *ChainPoller) NextHeight() uint64 {
 	return cp.getNextHeight()
 }
+
+func (cp *ChainPoller) setDegraded(err error) {
+	cp.mu.Lock()
+	cp.degradedErr = err
+	cp.mu.Unlock()
+
+	cp.metrics.RecordPollerDegraded(err != nil)
+}
+
+// Degraded returns why the poller backs off after failed poll cycles,
+// and nil if it does not
+func (cp *ChainPoller) Degraded() error {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	return cp.degradedErr
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	eotsclient "github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+)
+
+// WithBearerToken returns a dial option sending the bearer token with each
+// request. The token is sent in the clear unless the connection uses TLS.
+func WithBearerToken(token string) grpc.DialOption {
+	return grpc.WithPerRPCCredentials(bearerToken(token))
+}
+
+// WithHMACKey returns the dial options authenticating each request with the
+// HMAC key of the ID, as expected by the RPC server of fpd
+func WithHMACKey(hmacKey, keyID string) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(eotsclient.HMACUnaryClientInterceptor(hmacKey)),
+		eotsclient.WithHMACKeyID(keyID),
+	}
+}
+
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity allows sending the token to a daemon listening
+// on the loopback interface without TLS
+func (t bearerToken) RequireTransportSecurity() bool {
+	return false
+}
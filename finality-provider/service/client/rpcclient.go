@@ -17,8 +17,10 @@ type FinalityProviderServiceGRpcClient struct {
 }
 
 // NewFinalityProviderServiceGRpcClient creates a new GRPC connection with finality provider daemon.
-func NewFinalityProviderServiceGRpcClient(remoteAddr string) (*FinalityProviderServiceGRpcClient, func() error, error) {
-	conn, err := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// The connection is insecure unless grpcOpts set the transport credentials, e.g., to use TLS.
+func NewFinalityProviderServiceGRpcClient(remoteAddr string, grpcOpts ...grpc.DialOption) (*FinalityProviderServiceGRpcClient, func() error, error) {
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, grpcOpts...)
+	conn, err := grpc.NewClient(remoteAddr, dialOpts...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to build gRPC connection to %s: %w", remoteAddr, err)
 	}
@@ -167,3 +169,48 @@ func (c *FinalityProviderServiceGRpcClient) Backup(
 
 	return resp.GetBackupName(), nil
 }
+
+// StartKeyRotation - rpc call to register a new finality provider replacing the EOTS key oldPk
+func (c *FinalityProviderServiceGRpcClient) StartKeyRotation(
+	ctx context.Context, oldPk, newPk, keyName string) (*proto.KeyRotationResponse, error) {
+	res, err := c.client.StartKeyRotation(ctx, &proto.StartKeyRotationRequest{
+		OldEotsPkHex: oldPk,
+		NewEotsPkHex: newPk,
+		KeyName:      keyName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start key rotation: %w", err)
+	}
+
+	return res, nil
+}
+
+// ReloadConfig - rpc call to reload the config of fpd
+func (c *FinalityProviderServiceGRpcClient) ReloadConfig(ctx context.Context) (*proto.ReloadConfigResponse, error) {
+	res, err := c.client.ReloadConfig(ctx, &proto.ReloadConfigRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload config: %w", err)
+	}
+
+	return res, nil
+}
+
+// RetireKey - rpc call to retire the rotated EOTS key oldPk
+func (c *FinalityProviderServiceGRpcClient) RetireKey(ctx context.Context, oldPk string) (*proto.KeyRotationResponse, error) {
+	res, err := c.client.RetireKey(ctx, &proto.RetireKeyRequest{OldEotsPkHex: oldPk})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retire key: %w", err)
+	}
+
+	return res, nil
+}
+
+// QueryKeyRotationList - rpc call to list the key rotations tracked by the daemon
+func (c *FinalityProviderServiceGRpcClient) QueryKeyRotationList(ctx context.Context) (*proto.QueryKeyRotationListResponse, error) {
+	res, err := c.client.QueryKeyRotationList(ctx, &proto.QueryKeyRotationListRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query key rotations: %w", err)
+	}
+
+	return res, nil
+}
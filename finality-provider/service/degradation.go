@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	eotstypes "github.com/babylonlabs-io/finality-provider/eotsmanager/types"
+)
+
+// errorClass is how a failure of an instance is handled
+type errorClass int
+
+const (
+	// errorClassTransient failures are retried at the next cycle, the
+	// instance is only degraded once they repeat
+	errorClassTransient errorClass = iota
+	// errorClassDegraded failures degrade the instance right away
+	errorClassDegraded
+	// errorClassFatal failures are safety violations, which terminate fpd
+	// unless the finality provider is slashed
+	errorClassFatal
+)
+
+const (
+	// transientFailuresBeforeDegraded is the number of consecutive transient
+	// failures of a component after which the instance is degraded
+	transientFailuresBeforeDegraded = 3
+	// maxDegradedBackoff caps the backoff of a degraded component
+	maxDegradedBackoff = 5 * time.Minute
+)
+
+// Components of an instance whose failures degrade it
+const (
+	ComponentVoting     = "voting"
+	ComponentRandomness = "randomness"
+	ComponentStore      = "store"
+	ComponentPoller     = "poller"
+)
+
+// transientErrStrs are the messages of the errors of the chain and EOTS
+// manager clients which are not wrapped as such
+var transientErrStrs = []string{
+	"connection refused",
+	"connection reset",
+	"broken pipe",
+	"i/o timeout",
+	"EOF",
+	"timed out",
+	"no such host",
+}
+
+// classifyError returns how a failure of an instance is handled
+func classifyError(err error) errorClass {
+	switch {
+	// the EOTS manager refuses to sign two different messages at the same height
+	case errors.Is(err, ErrFailedPrecondition),
+		errors.Is(err, ErrFinalityProviderSlashed),
+		errors.Is(err, eotstypes.ErrDoubleSign):
+		return errorClassFatal
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return errorClassTransient
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return errorClassTransient
+	}
+
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+			return errorClassTransient
+		}
+	}
+
+	msg := err.Error()
+	for _, s := range transientErrStrs {
+		if strings.Contains(msg, s) {
+			return errorClassTransient
+		}
+	}
+
+	return errorClassDegraded
+}
+
+// degradedBackoff returns the wait before the next attempt of a component
+// after the given number of consecutive failures, doubling from the
+// interval of its loop up to maxDegradedBackoff
+func degradedBackoff(interval time.Duration, failures uint32) time.Duration {
+	backoff := interval
+	for i := uint32(1); i < failures && backoff < maxDegradedBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxDegradedBackoff)
+}
+
+// componentHealth is the state of a failing component
+type componentHealth struct {
+	failures uint32
+	lastErr  error
+	// degradedSince is zero as long as the failures are transient
+	degradedSince time.Time
+	retryAt       time.Time
+}
+
+// instanceHealth keeps track of the failing components of an instance
+type instanceHealth struct {
+	mu         sync.Mutex
+	components map[string]*componentHealth
+}
+
+func newInstanceHealth() *instanceHealth {
+	return &instanceHealth{components: make(map[string]*componentHealth)}
+}
+
+// fail records a failure of the component and returns whether the
+// instance is degraded by it and the wait before the next attempt
+func (h *instanceHealth) fail(
+	component string, class errorClass, err error, now time.Time, interval time.Duration,
+) (bool, time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	c, ok := h.components[component]
+	if !ok {
+		c = &componentHealth{}
+		h.components[component] = c
+	}
+	c.failures++
+	c.lastErr = err
+
+	if class == errorClassTransient && c.failures < transientFailuresBeforeDegraded {
+		return false, 0
+	}
+
+	if c.degradedSince.IsZero() {
+		c.degradedSince = now
+	}
+	backoff := degradedBackoff(interval, c.failures)
+	c.retryAt = now.Add(backoff)
+
+	return true, backoff
+}
+
+// recover resets the component after a success and returns for how
+// long it was degraded, if it was
+func (h *instanceHealth) recover(component string, now time.Time) (time.Duration, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	c, ok := h.components[component]
+	if !ok {
+		return 0, false
+	}
+	delete(h.components, component)
+
+	if c.degradedSince.IsZero() {
+		return 0, false
+	}
+
+	return now.Sub(c.degradedSince), true
+}
+
+// canAttempt returns whether the backoff of the component has elapsed
+func (h *instanceHealth) canAttempt(component string, now time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	c, ok := h.components[component]
+
+	return !ok || !now.Before(c.retryAt)
+}
+
+// degraded returns the degraded components with the last error of each
+func (h *instanceHealth) degraded() map[string]error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	res := make(map[string]error)
+	for name, c := range h.components {
+		if !c.degradedSince.IsZero() {
+			res[name] = fmt.Errorf("%d consecutive failures since %s, the last: %w",
+				c.failures, c.degradedSince.Format(time.RFC3339), c.lastErr)
+		}
+	}
+
+	return res
+}
+
+// degradable is implemented by the pollers which back off after failures
+type degradable interface {
+	// Degraded returns why the poller backs off, and nil if it does not
+	Degraded() error
+}
+
+// DegradedComponents returns the components of the instance backing off
+// after failures, with the reason of each
+func (fp *FinalityProviderInstance) DegradedComponents() map[string]error {
+	components := fp.health.degraded()
+	if d, ok := fp.poller.(degradable); ok {
+		if err := d.Degraded(); err != nil {
+			components[ComponentPoller] = err
+		}
+	}
+
+	return components
+}
+
+// degradedSummary describes the degraded components in a stable order
+func degradedSummary(components map[string]error) string {
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s: %v", name, components[name]))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// handleFailure handles a failure of a component of the instance by its
+// class: a safety violation is reported as critical, which terminates fpd,
+// while other failures degrade the component, which backs off until it
+// recovers
+func (fp *FinalityProviderInstance) handleFailure(component string, err error, interval time.Duration) {
+	class := classifyError(err)
+	if class == errorClassFatal {
+		fp.reportCriticalErr(err)
+
+		return
+	}
+
+	degraded, backoff := fp.health.fail(component, class, err, time.Now(), interval)
+	if !degraded {
+		fp.logger.Warn("transient failure, retrying at the next cycle",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.String("component", component),
+			zap.Error(err))
+
+		return
+	}
+
+	fp.metrics.RecordFpDegraded(fp.GetBtcPkHex(), component, true)
+	fp.logger.Error("the finality provider is degraded, backing off",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.String("component", component),
+		zap.Duration("backoff", backoff),
+		zap.Error(err))
+}
+
+// recoverComponent records a success of a component of the instance
+func (fp *FinalityProviderInstance) recoverComponent(component string) {
+	degradedFor, wasDegraded := fp.health.recover(component, time.Now())
+	if !wasDegraded {
+		return
+	}
+
+	fp.metrics.RecordFpDegraded(fp.GetBtcPkHex(), component, false)
+	fp.logger.Info("the finality provider recovered",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.String("component", component),
+		zap.Duration("degraded_for", degradedFor))
+}
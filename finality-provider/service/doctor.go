@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	bbntypes "github.com/babylonlabs-io/babylon/v4/types"
+
+	ccapi "github.com/babylonlabs-io/finality-provider/clientcontroller/api"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+const (
+	DoctorSeverityWarning = "warning"
+	DoctorSeverityError   = "error"
+)
+
+// SignStoreQuerier queries the sign store of an eotsd
+type SignStoreQuerier interface {
+	GetLastSignedHeight(uid, chainID []byte) (uint64, bool, error)
+}
+
+// EOTSSignStore is the sign store of the eotsd at Address
+type EOTSSignStore struct {
+	Address string
+	Querier SignStoreQuerier
+}
+
+// DoctorFinding is an inconsistency or an unsafe condition found by the
+// doctor, with the action to recover from it
+type DoctorFinding struct {
+	Severity string `json:"severity"`
+	Check    string `json:"check"`
+	Message  string `json:"message"`
+	Action   string `json:"action,omitempty"`
+}
+
+// SignStoreState is the state of the sign store of an eotsd for the
+// finality provider
+type SignStoreState struct {
+	Address          string `json:"address"`
+	Found            bool   `json:"found"`
+	LastSignedHeight uint64 `json:"last_signed_height,omitempty"`
+	Error            string `json:"error,omitempty"`
+}
+
+// HeightRange is an inclusive range of heights
+type HeightRange struct {
+	StartHeight uint64 `json:"start_height"`
+	EndHeight   uint64 `json:"end_height"`
+}
+
+// DoctorReport is the state of a finality provider in the fpd database, in
+// the sign stores of the eotsd and on the consumer chain, and the findings
+// of their consistency checks
+type DoctorReport struct {
+	FpBtcPkHex              string           `json:"fp_btc_pk_hex"`
+	ChainID                 string           `json:"chain_id"`
+	LocalStatus             string           `json:"local_status"`
+	LocalLastVotedHeight    uint64           `json:"local_last_voted_height"`
+	ChainHighestVotedHeight uint64           `json:"chain_highest_voted_height"`
+	ChainTipHeight          uint64           `json:"chain_tip_height"`
+	Slashed                 bool             `json:"slashed"`
+	Jailed                  bool             `json:"jailed"`
+	SignStores              []SignStoreState `json:"sign_stores"`
+	PubRandCommits          []HeightRange    `json:"pub_rand_commits"`
+	MissingProofs           []HeightRange    `json:"missing_proofs,omitempty"`
+	Findings                []DoctorFinding  `json:"findings"`
+}
+
+// Healthy returns whether no finding of the report is an error
+func (r *DoctorReport) Healthy() bool {
+	for _, f := range r.Findings {
+		if f.Severity == DoctorSeverityError {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *DoctorReport) add(severity, check, action, format string, args ...any) {
+	r.Findings = append(r.Findings, DoctorFinding{
+		Severity: severity,
+		Check:    check,
+		Message:  fmt.Sprintf(format, args...),
+		Action:   action,
+	})
+}
+
+// Doctor checks the consistency of the state of finality providers in the fpd
+// database, in the sign stores of the eotsd and on the consumer chain
+type Doctor struct {
+	consumerCon  ccapi.ConsumerController
+	pubRandStore *store.PubRandProofStore
+	signStores   []EOTSSignStore
+	// threshold is the number of sign stores needed to sign if they hold
+	// the shares of threshold EOTS keys, and 0 otherwise
+	threshold uint32
+}
+
+func NewDoctor(
+	consumerCon ccapi.ConsumerController,
+	pubRandStore *store.PubRandProofStore,
+	signStores []EOTSSignStore,
+	threshold uint32,
+) *Doctor {
+	return &Doctor{
+		consumerCon:  consumerCon,
+		pubRandStore: pubRandStore,
+		signStores:   signStores,
+		threshold:    threshold,
+	}
+}
+
+// Diagnose checks the finality provider stored in the fpd database. The
+// errors returned are the failures to query the consumer chain or the fpd
+// database, while the unreachable eotsd are reported as findings.
+func (d *Doctor) Diagnose(ctx context.Context, fp *store.StoredFinalityProvider) (*DoctorReport, error) {
+	fpPk := bbntypes.NewBIP340PubKeyFromBTCPK(fp.BtcPk)
+	report := &DoctorReport{
+		FpBtcPkHex:           fpPk.MarshalHex(),
+		ChainID:              fp.ChainID,
+		LocalStatus:          fp.Status.String(),
+		LocalLastVotedHeight: fp.LastVotedHeight,
+		SignStores:           []SignStoreState{},
+		PubRandCommits:       []HeightRange{},
+		Findings:             []DoctorFinding{},
+	}
+
+	status, err := d.consumerCon.QueryFinalityProviderStatus(ctx, fp.BtcPk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the finality provider status: %w", err)
+	}
+	report.Slashed, report.Jailed = status.Slashed, status.Jailed
+
+	report.ChainHighestVotedHeight, err = d.consumerCon.QueryFinalityProviderHighestVotedHeight(ctx, fp.BtcPk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the highest voted height: %w", err)
+	}
+
+	tip, err := d.consumerCon.QueryLatestBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the latest block: %w", err)
+	}
+	if tip != nil {
+		report.ChainTipHeight = tip.GetHeight()
+	}
+
+	d.checkStatus(report)
+	d.checkVotedHeights(report)
+	d.checkSignStores(report, fpPk, []byte(fp.ChainID))
+
+	if err := d.checkPubRand(ctx, report, fp, fpPk); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (d *Doctor) checkStatus(r *DoctorReport) {
+	if r.Slashed {
+		r.add(DoctorSeverityError, "status",
+			"do not restart the finality provider; investigate how its EOTS key was used to sign twice at a height",
+			"the finality provider is slashed on the consumer chain")
+
+		return
+	}
+
+	if r.Jailed {
+		r.add(DoctorSeverityWarning, "status",
+			fmt.Sprintf("start fpd and run \"fpd unjail-finality-provider %s\" once the jail period is over", r.FpBtcPkHex),
+			"the finality provider is jailed on the consumer chain")
+	}
+}
+
+func (d *Doctor) checkVotedHeights(r *DoctorReport) {
+	local, chain := r.LocalLastVotedHeight, r.ChainHighestVotedHeight
+
+	switch {
+	case local > chain:
+		r.add(DoctorSeverityWarning, "voted_height",
+			"check the fpd logs for failed vote submissions; fpd resumes voting above the local last voted height",
+			"the local last voted height %d is above the highest voted height %d on the consumer chain", local, chain)
+	case local < chain:
+		r.add(DoctorSeverityWarning, "voted_height",
+			"make sure that no other fpd runs the finality provider, e.g. after restoring the fpd database from a backup",
+			"the local last voted height %d is below the highest voted height %d on the consumer chain", local, chain)
+	}
+}
+
+func (d *Doctor) checkSignStores(r *DoctorReport, fpPk *bbntypes.BIP340PubKey, chainID []byte) {
+	voted := max(r.LocalLastVotedHeight, r.ChainHighestVotedHeight)
+	// the eotsd holding shares sign the heights as long as enough of them
+	// are reachable, so some of them may have missed heights
+	lagSeverity := DoctorSeverityError
+	if d.threshold > 0 {
+		lagSeverity = DoctorSeverityWarning
+	}
+
+	upToDate := 0
+	for _, s := range d.signStores {
+		state := SignStoreState{Address: s.Address}
+
+		height, found, err := s.Querier.GetLastSignedHeight(fpPk.MustMarshal(), chainID)
+		if err != nil {
+			state.Error = err.Error()
+			r.SignStores = append(r.SignStores, state)
+			r.add(DoctorSeverityError, "sign_store",
+				fmt.Sprintf("check that eotsd runs at %s and that the HMAC key of fpd is valid for it", s.Address),
+				"failed to query the sign store of eotsd at %s: %v", s.Address, err)
+
+			continue
+		}
+		state.Found, state.LastSignedHeight = found, height
+		r.SignStores = append(r.SignStores, state)
+
+		switch {
+		case voted > 0 && !found:
+			r.add(lagSeverity, "sign_store",
+				"check that eotsd holds the EOTS key for this chain ID, or restore its database from a backup; it cannot detect double signing for the heights it has no record of",
+				"the sign store of eotsd at %s has no record, but the finality provider voted up to height %d", s.Address, voted)
+		case found && height < voted:
+			r.add(lagSeverity, "sign_store",
+				"restore the eotsd database from a backup or check whether it was rolled back; it cannot detect double signing for the heights it has no record of",
+				"the sign store of eotsd at %s has records up to height %d, but the finality provider voted up to height %d", s.Address, height, voted)
+		default:
+			upToDate++
+		}
+
+		if found && r.ChainTipHeight > 0 && height > r.ChainTipHeight {
+			r.add(DoctorSeverityError, "sign_store",
+				"make sure that no other client uses the EOTS key, and consider enabling the [signingguard] limits of eotsd",
+				"eotsd at %s signed height %d, above the chain tip %d", s.Address, height, r.ChainTipHeight)
+		}
+	}
+
+	if d.threshold > 0 && voted > 0 && upToDate < int(d.threshold) {
+		r.add(DoctorSeverityError, "sign_store",
+			"restore the databases of the lagging eotsd from backups",
+			"only %d eotsd have records up to height %d, fewer than the threshold %d", upToDate, voted, d.threshold)
+	}
+}
+
+func (d *Doctor) checkPubRand(ctx context.Context, r *DoctorReport, fp *store.StoredFinalityProvider, fpPk *bbntypes.BIP340PubKey) error {
+	next := max(r.LocalLastVotedHeight, r.ChainHighestVotedHeight) + 1
+
+	commits, err := d.consumerCon.QueryPubRandCommitList(ctx, fp.BtcPk, next)
+	if err != nil {
+		return fmt.Errorf("failed to query the public randomness commits: %w", err)
+	}
+
+	for i, c := range commits {
+		r.PubRandCommits = append(r.PubRandCommits, HeightRange{StartHeight: c.GetStartHeight(), EndHeight: c.GetEndHeight()})
+
+		if i > 0 && c.GetStartHeight() > commits[i-1].GetEndHeight()+1 {
+			r.add(DoctorSeverityWarning, "pub_rand",
+				"none if the gap was skipped on purpose; otherwise check the fpd logs for failed commits, as the finality provider cannot vote in the gap",
+				"no public randomness is committed from height %d to height %d",
+				commits[i-1].GetEndHeight()+1, c.GetStartHeight()-1)
+		}
+	}
+
+	if len(commits) == 0 || commits[0].GetStartHeight() > next {
+		r.add(DoctorSeverityWarning, "pub_rand",
+			"start fpd to commit public randomness, and check that its account has enough funds for the commits",
+			"no public randomness is committed for the next height %d to vote", next)
+	}
+
+	for _, c := range commits {
+		for h := max(c.GetStartHeight(), next); h <= c.GetEndHeight(); h++ {
+			_, err := d.pubRandStore.GetPubRandProof([]byte(fp.ChainID), fpPk.MustMarshal(), h)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, store.ErrPubRandProofNotFound) {
+				return fmt.Errorf("failed to get the public randomness proof at height %d: %w", h, err)
+			}
+
+			if n := len(r.MissingProofs); n > 0 && r.MissingProofs[n-1].EndHeight+1 == h {
+				r.MissingProofs[n-1].EndHeight = h
+			} else {
+				r.MissingProofs = append(r.MissingProofs, HeightRange{StartHeight: h, EndHeight: h})
+			}
+		}
+	}
+
+	if len(r.MissingProofs) > 0 {
+		first := r.MissingProofs[0].StartHeight
+		r.add(DoctorSeverityError, "pub_rand_proof",
+			fmt.Sprintf("run \"fpd recover-rand-proof %s --chain-id %s --start-height %d\" while fpd is stopped",
+				r.FpBtcPkHex, r.ChainID, first),
+			"the Merkle proofs of the committed public randomness are missing in %d ranges of heights, from height %d",
+			len(r.MissingProofs), first)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	bbntypes "github.com/babylonlabs-io/babylon/v4/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/api"
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/babylon"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/testutil/mocks"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+type fakeSignStore struct {
+	height uint64
+	found  bool
+	err    error
+}
+
+func (s fakeSignStore) GetLastSignedHeight(_, _ []byte) (uint64, bool, error) {
+	return s.height, s.found, s.err
+}
+
+func TestDoctor(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	fp := testutil.GenRandomFinalityProvider(r, t)
+	fp.LastVotedHeight = 100
+	fpPk := fp.BtcPk
+
+	cfg := config.DefaultConfigWithHome(filepath.Join(t.TempDir(), "fp-home"))
+	db, err := cfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	pubRandStore, err := store.NewPubRandProofStore(db)
+	require.NoError(t, err)
+
+	// the proofs of the first commit are stored, but not the ones of the second
+	commits := []types.PubRandCommit{
+		&babylon.BabylonPubRandCommit{StartHeight: 90, NumPubRand: 20},
+		&babylon.BabylonPubRandCommit{StartHeight: 120, NumPubRand: 10},
+	}
+	pubRandList := make([]*btcec.FieldVal, 20)
+	for i := range pubRandList {
+		pubRandList[i] = new(btcec.FieldVal).SetInt(uint16(i + 1))
+	}
+	_, proofList := types.GetPubRandCommitAndProofs(pubRandList)
+	err = pubRandStore.AddPubRandProofList([]byte(fp.ChainID), bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MustMarshal(), 90, 20, proofList)
+	require.NoError(t, err)
+
+	t.Run("consistent state", func(t *testing.T) {
+		t.Parallel()
+		ctl := gomock.NewController(t)
+		cc := mocks.NewMockConsumerController(ctl)
+		cc.EXPECT().QueryFinalityProviderStatus(gomock.Any(), fpPk).Return(&api.FinalityProviderStatusResponse{}, nil)
+		cc.EXPECT().QueryFinalityProviderHighestVotedHeight(gomock.Any(), fpPk).Return(uint64(100), nil)
+		cc.EXPECT().QueryLatestBlock(gomock.Any()).Return(types.NewBlockInfo(105, nil, false), nil)
+		cc.EXPECT().QueryPubRandCommitList(gomock.Any(), fpPk, uint64(101)).Return(commits[:1], nil)
+
+		doctor := service.NewDoctor(cc, pubRandStore, []service.EOTSSignStore{
+			{Address: "eotsd", Querier: fakeSignStore{height: 101, found: true}},
+		}, 0)
+
+		report, err := doctor.Diagnose(context.Background(), fp)
+		require.NoError(t, err)
+		require.Empty(t, report.Findings)
+		require.True(t, report.Healthy())
+		require.Equal(t, []service.HeightRange{{StartHeight: 90, EndHeight: 109}}, report.PubRandCommits)
+	})
+
+	t.Run("inconsistent state", func(t *testing.T) {
+		t.Parallel()
+		ctl := gomock.NewController(t)
+		cc := mocks.NewMockConsumerController(ctl)
+		cc.EXPECT().QueryFinalityProviderStatus(gomock.Any(), fpPk).Return(&api.FinalityProviderStatusResponse{Jailed: true}, nil)
+		cc.EXPECT().QueryFinalityProviderHighestVotedHeight(gomock.Any(), fpPk).Return(uint64(104), nil)
+		cc.EXPECT().QueryLatestBlock(gomock.Any()).Return(types.NewBlockInfo(105, nil, false), nil)
+		cc.EXPECT().QueryPubRandCommitList(gomock.Any(), fpPk, uint64(105)).Return(commits, nil)
+
+		doctor := service.NewDoctor(cc, pubRandStore, []service.EOTSSignStore{
+			{Address: "eotsd-1", Querier: fakeSignStore{height: 102, found: true}},
+			{Address: "eotsd-2", Querier: fakeSignStore{height: 200, found: true}},
+			{Address: "eotsd-3", Querier: fakeSignStore{err: errors.New("connection refused")}},
+		}, 2)
+
+		report, err := doctor.Diagnose(context.Background(), fp)
+		require.NoError(t, err)
+		require.False(t, report.Healthy())
+		require.Equal(t, []service.HeightRange{{StartHeight: 120, EndHeight: 129}}, report.MissingProofs)
+
+		checks := make(map[string]int)
+		for _, f := range report.Findings {
+			checks[f.Check]++
+			require.NotEmpty(t, f.Action)
+		}
+		require.Equal(t, map[string]int{
+			"status":         1, // jailed
+			"voted_height":   1, // local 100 below chain 104
+			"sign_store":     4, // eotsd-1 lags, eotsd-2 beyond the tip, eotsd-3 unreachable, below threshold
+			"pub_rand":       1, // gap from 110 to 119
+			"pub_rand_proof": 1, // 120 to 129
+		}, checks)
+	})
+}
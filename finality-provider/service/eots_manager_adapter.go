@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	bbntypes "github.com/babylonlabs-io/babylon/v4/types"
 	"github.com/btcsuite/btcd/btcec/v2"
@@ -15,18 +16,20 @@ import (
 
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 	"github.com/babylonlabs-io/finality-provider/types"
 )
 
 const failedPreconditionErrStr = "FailedPrecondition"
 
 // InitEOTSManagerClient initializes an EOTS manager client with HMAC authentication
-func InitEOTSManagerClient(address string, hmacKey string, grpcMaxContentLen int) (eotsmanager.EOTSManager, error) {
-	eotsClient, err := client.NewEOTSManagerGRPCClient(address, hmacKey,
-		grpc.WithDefaultCallOptions(
-			grpc.MaxCallRecvMsgSize(grpcMaxContentLen),
-			grpc.MaxCallSendMsgSize(grpcMaxContentLen)),
+func InitEOTSManagerClient(address string, hmacKey string, grpcMaxContentLen int, grpcOpts ...grpc.DialOption) (eotsmanager.EOTSManager, error) {
+	grpcOpts = append(grpcOpts, grpc.WithDefaultCallOptions(
+		grpc.MaxCallRecvMsgSize(grpcMaxContentLen),
+		grpc.MaxCallSendMsgSize(grpcMaxContentLen)),
 	)
+	eotsClient, err := client.NewEOTSManagerGRPCClient(address, hmacKey, grpcOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create EOTS manager client: %w", err)
 	}
@@ -34,6 +37,72 @@ func InitEOTSManagerClient(address string, hmacKey string, grpcMaxContentLen int
 	return eotsClient, nil
 }
 
+// NewEOTSManagerFromConfig connects the EOTS manager of the config: the eotsd
+// at EOTSManagerAddress, or the eotsd holding the shares of the threshold
+// EOTS keys if threshold signing is enabled
+func NewEOTSManagerFromConfig(cfg *fpcfg.Config) (eotsmanager.EOTSManager, error) {
+	dialOpts, err := EOTSManagerDialOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cfg.ThresholdEOTSManager.IsEnabled() {
+		return InitEOTSManagerClient(cfg.EOTSManagerAddress, cfg.HMACKey, cfg.GRPCMaxContentLength, dialOpts...)
+	}
+
+	dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(
+		grpc.MaxCallRecvMsgSize(cfg.GRPCMaxContentLength),
+		grpc.MaxCallSendMsgSize(cfg.GRPCMaxContentLength)),
+	)
+
+	clients := make([]*client.EOTSManagerGRPCClient, 0, len(cfg.ThresholdEOTSManager.Addresses))
+	for _, addr := range cfg.ThresholdEOTSManager.Addresses {
+		c, err := client.NewEOTSManagerGRPCClient(addr, cfg.HMACKey, dialOpts...)
+		if err != nil {
+			for _, c := range clients {
+				_ = c.Close()
+			}
+
+			return nil, fmt.Errorf("failed to create EOTS manager client for %s: %w", addr, err)
+		}
+		clients = append(clients, c)
+	}
+
+	em, err := client.NewThresholdEOTSManager(cfg.ThresholdEOTSManager.Threshold, clients...)
+	if err != nil {
+		for _, c := range clients {
+			_ = c.Close()
+		}
+
+		return nil, fmt.Errorf("failed to create threshold EOTS manager: %w", err)
+	}
+
+	return em, nil
+}
+
+// EOTSManagerDialOptions returns the options to connect to the EOTS manager
+// with TLS if it is enabled in the config, and with the ID of the HMAC key if set
+func EOTSManagerDialOptions(cfg *fpcfg.Config) ([]grpc.DialOption, error) {
+	var opts []grpc.DialOption
+	if cfg.HMACKeyID != "" {
+		opts = append(opts, client.WithHMACKeyID(cfg.HMACKeyID))
+	}
+	if cfg.Tracing.IsEnabled() {
+		opts = append(opts, tracing.ClientDialOption())
+	}
+
+	if !cfg.EOTSManagerTLS.IsEnabled() {
+		return opts, nil
+	}
+
+	tlsCfg, err := cfg.EOTSManagerTLS.ClientTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg))), nil
+}
+
 func (fp *FinalityProviderInstance) GetPubRandList(startHeight uint64, numPubRand uint32) ([]*btcec.FieldVal, error) {
 	pubRandList, err := fp.em.CreateRandomnessPairList(
 		fp.btcPk.MustMarshal(),
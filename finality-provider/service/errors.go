@@ -3,12 +3,17 @@ package service
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	bbntypes "github.com/babylonlabs-io/babylon/v4/types"
 )
 
 const instanceTerminatingMsg = "terminating the finality-provider instance due to critical error"
 
+// criticalErrAlertTimeout bounds the evaluation of the alerting rules
+// before terminating due to a critical error
+const criticalErrAlertTimeout = 15 * time.Second
+
 type CriticalError struct {
 	err     error
 	fpBtcPk *bbntypes.BIP340PubKey
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/babylonlabs-io/finality-provider/clientcontroller/api"
+	"math"
 	"time"
 
 	bbntypes "github.com/babylonlabs-io/babylon/v4/types"
@@ -46,7 +47,8 @@ type UnjailFinalityProviderResponse struct {
 	TxHash string
 }
 
-// event loop for critical errors
+// event loop for critical errors, which are the safety violations reported
+// by the instances, as the other failures degrade them instead
 func (app *FinalityProviderApp) monitorCriticalErr(ctx context.Context) {
 	defer app.wg.Done()
 
@@ -55,7 +57,7 @@ func (app *FinalityProviderApp) monitorCriticalErr(ctx context.Context) {
 	for {
 		select {
 		case criticalErr = <-app.criticalErrChan:
-			fpi, err := app.GetFinalityProviderInstance()
+			fpi, err := app.getFinalityProviderInstanceByPk(criticalErr.fpBtcPk)
 			if err != nil {
 				app.logger.Debug("the finality-provider instance is already shutdown",
 					zap.String("pk", criticalErr.fpBtcPk.MarshalHex()))
@@ -69,6 +71,7 @@ func (app *FinalityProviderApp) monitorCriticalErr(ctx context.Context) {
 
 				continue
 			}
+			app.recordCriticalErr(ctx, criticalErr)
 			app.logger.Fatal(instanceTerminatingMsg,
 				zap.String("pk", criticalErr.fpBtcPk.MarshalHex()), zap.Error(criticalErr.err))
 		case <-ctx.Done():
@@ -229,3 +232,107 @@ func (app *FinalityProviderApp) metricsUpdateLoop(ctx context.Context) {
 		}
 	}
 }
+
+// event loop for evaluating the alerting rules
+func (app *FinalityProviderApp) alertingLoop(ctx context.Context) {
+	defer app.wg.Done()
+
+	interval := app.config.Alerting.EvaluationInterval
+	app.logger.Info("starting alerting loop",
+		zap.Float64("interval seconds", interval.Seconds()))
+
+	evalTicker := time.NewTicker(interval)
+	defer evalTicker.Stop()
+
+	for {
+		select {
+		case <-evalTicker.C:
+			app.alertEngine.Evaluate(ctx, time.Now())
+		case <-ctx.Done():
+			app.logger.Info("exiting alerting loop")
+
+			return
+		}
+	}
+}
+
+// recordCriticalErr records the critical error for the alerting rules and,
+// as fpd terminates on it, evaluates them once more so that it is notified
+func (app *FinalityProviderApp) recordCriticalErr(ctx context.Context, criticalErr *CriticalError) {
+	if app.alertEngine == nil {
+		return
+	}
+
+	app.criticalErrs.Record(criticalErr.fpBtcPk.MarshalHex(), criticalErr.err, time.Now())
+
+	evalCtx, cancel := context.WithTimeout(ctx, criticalErrAlertTimeout)
+	defer cancel()
+	app.alertEngine.Evaluate(evalCtx, time.Now())
+}
+
+// event loop for checking the balance of the account paying the transaction fees
+func (app *FinalityProviderApp) balanceMonitorLoop(ctx context.Context) {
+	defer app.wg.Done()
+
+	interval := app.config.BalanceMonitor.CheckInterval
+	app.logger.Info("starting balance monitor loop",
+		zap.Float64("interval seconds", interval.Seconds()))
+
+	app.checkFeePayerBalance(ctx)
+
+	checkTicker := time.NewTicker(interval)
+	defer checkTicker.Stop()
+
+	for {
+		select {
+		case <-checkTicker.C:
+			app.checkFeePayerBalance(ctx)
+		case <-ctx.Done():
+			app.logger.Info("exiting balance monitor loop")
+
+			return
+		}
+	}
+}
+
+func (app *FinalityProviderApp) checkFeePayerBalance(ctx context.Context) {
+	cfg := app.config.BalanceMonitor
+
+	res, err := app.cc.QueryFeePayerBalance(ctx, cfg.Denom)
+	if err != nil {
+		app.logger.Warn("failed to query the balance of the fee payer account", zap.Error(err))
+
+		return
+	}
+
+	balance := uint64(math.MaxUint64)
+	if res.Amount.IsUint64() {
+		balance = res.Amount.Uint64()
+	}
+
+	app.balanceMonitor.Observe(balance, time.Now())
+	app.metrics.RecordFpAccountBalance(res.Address, cfg.Denom, float64(balance))
+
+	daysLeft := app.balanceMonitor.DaysLeft()
+	if !math.IsInf(daysLeft, 1) {
+		app.metrics.RecordFpAccountBalanceDaysLeft(res.Address, daysLeft)
+	}
+
+	if app.balanceMonitor.IsBelowFloor() {
+		app.logger.Warn("the balance of the fee payer account is below the floor, non-essential transactions are paused",
+			zap.String("address", res.Address),
+			zap.Uint64("balance", balance),
+			zap.Uint64("min_balance", cfg.MinBalance),
+			zap.String("denom", cfg.Denom),
+		)
+	}
+
+	if cfg.WarnDaysLeft > 0 && daysLeft < cfg.WarnDaysLeft {
+		app.logger.Warn("the fee payer account is projected to run out of funds soon",
+			zap.String("address", res.Address),
+			zap.Uint64("balance", balance),
+			zap.Float64("burn_per_day", app.balanceMonitor.BurnPerDay()),
+			zap.Float64("days_left", daysLeft),
+		)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/avast/retry-go/v4"
@@ -15,6 +16,7 @@ import (
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 	"github.com/babylonlabs-io/finality-provider/types"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"go.uber.org/zap"
@@ -22,6 +24,10 @@ import (
 
 var _ types.FinalitySignatureSubmitter = (*DefaultFinalitySubmitter)(nil)
 
+// blockTimesQueryTimeout bounds the query of the block
+// times of the vote latency metrics
+const blockTimesQueryTimeout = 5 * time.Second
+
 type PubRandProofListGetterFunc func(startHeight uint64, numPubRand uint64) ([][]byte, error)
 
 type DefaultFinalitySubmitter struct {
@@ -32,6 +38,10 @@ type DefaultFinalitySubmitter struct {
 	Cfg                 *FinalitySubmitterConfig
 	Logger              *zap.Logger
 	Metrics             *metrics.FpMetrics
+
+	// queryingBlockTimes is set while the block times of the
+	// vote latency metrics are queried in the background
+	queryingBlockTimes atomic.Bool
 }
 
 type FinalitySubmitterConfig struct {
@@ -69,6 +79,38 @@ func NewDefaultFinalitySubmitter(
 	}
 }
 
+// recordBlockToVoteLatency records the time from the production of the blocks to
+// the broadcast of their votes. The block times are queried in the background,
+// one query at a time, so that the metric never delays the votes. The latency
+// is not recorded for the votes broadcast while a query is in flight.
+func (ds *DefaultFinalitySubmitter) recordBlockToVoteLatency(blocks []types.BlockDescription, broadcastAt time.Time) {
+	querier, ok := ds.ConsumerCtrl.(api.BlockTimeQuerier)
+	if !ok || len(blocks) == 0 || !ds.queryingBlockTimes.CompareAndSwap(false, true) {
+		return
+	}
+
+	startHeight, endHeight := blocks[0].GetHeight(), blocks[len(blocks)-1].GetHeight()
+	go func() {
+		defer ds.queryingBlockTimes.Store(false)
+
+		ctx, cancel := context.WithTimeout(context.Background(), blockTimesQueryTimeout)
+		defer cancel()
+
+		times, err := querier.QueryBlockTimes(ctx, startHeight, endHeight)
+		if err != nil {
+			ds.Logger.Debug("failed to query the block times", zap.Error(err))
+
+			return
+		}
+
+		for _, b := range blocks {
+			if t, ok := times[b.GetHeight()]; ok {
+				ds.Metrics.RecordFpBlockToVoteLatency(ds.GetBtcPkHex(), broadcastAt.Sub(t))
+			}
+		}
+	}()
+}
+
 func (ds *DefaultFinalitySubmitter) GetBtcPkHex() string {
 	return ds.GetBtcPkBIP340().MarshalHex()
 }
@@ -81,7 +123,11 @@ func (ds *DefaultFinalitySubmitter) GetBtcPkBIP340() *bbntypes.BIP340PubKey {
 	return ds.State.GetBtcPkBIP340()
 }
 
-func (ds *DefaultFinalitySubmitter) MustSetLastVotedHeight(height uint64) {
+// setLastVotedHeight updates the last voted height of the state. The votes
+// are already on chain if writing it to the store fails, so it is flushed
+// later by the instance, while the height in memory keeps the voted blocks
+// from being voted again.
+func (ds *DefaultFinalitySubmitter) setLastVotedHeight(height uint64) {
 	if err := ds.State.SetLastVotedHeight(height); err != nil {
 		// Handle graceful shutdown case where database may be closed
 		if strings.Contains(err.Error(), "database not open") {
@@ -90,15 +136,17 @@ func (ds *DefaultFinalitySubmitter) MustSetLastVotedHeight(height uint64) {
 
 			return
 		}
-		ds.Logger.Fatal("failed to update state after finality signature submitted",
+		ds.Logger.Error("failed to update state after finality signature submitted",
 			zap.String("pk", ds.GetBtcPkHex()), zap.Uint64("height", height), zap.Error(err))
 	}
 }
 
-func (ds *DefaultFinalitySubmitter) mustSetStatus(s proto.FinalityProviderStatus) {
+// setStatus updates the status of the state, which is flushed later by
+// the instance if writing it to the store fails
+func (ds *DefaultFinalitySubmitter) setStatus(s proto.FinalityProviderStatus) {
 	if err := ds.State.SetStatus(s); err != nil {
-		ds.Logger.Fatal("failed to set finality-provider status",
-			zap.String("pk", ds.GetBtcPkHex()), zap.String("status", s.String()))
+		ds.Logger.Error("failed to set finality-provider status",
+			zap.String("pk", ds.GetBtcPkHex()), zap.String("status", s.String()), zap.Error(err))
 	}
 }
 
@@ -114,11 +162,16 @@ func (ds *DefaultFinalitySubmitter) InitState(state types.FinalityProviderState)
 }
 
 // FilterBlocksForVoting filters blocks based on the finality provider's voting power and height criteria for submission, returning a slice of blocks eligible for voting and an error if any issues are encountered during processing. It also updates the finality provider instance status according to the block's voting power.
-func (ds *DefaultFinalitySubmitter) FilterBlocksForVoting(ctx context.Context, blocks []types.BlockDescription) ([]types.BlockDescription, error) {
+func (ds *DefaultFinalitySubmitter) FilterBlocksForVoting(ctx context.Context, blocks []types.BlockDescription) (_ []types.BlockDescription, err error) {
+	ctx, span := tracing.StartSpan(ctx, "DefaultFinalitySubmitter.FilterBlocksForVoting",
+		tracing.NumBlocksKey.Int(len(blocks)))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	processedBlocks := make([]types.BlockDescription, 0, len(blocks))
 
 	var hasPower bool
-	var err error
 	for _, b := range blocks {
 		blk := b
 		if blk.GetHeight() <= ds.State.GetLastVotedHeight() {
@@ -162,11 +215,11 @@ func (ds *DefaultFinalitySubmitter) FilterBlocksForVoting(ctx context.Context, b
 
 	// update fp status according to the power for the last block
 	if hasPower && ds.State.GetStatus() != proto.FinalityProviderStatus_ACTIVE {
-		ds.mustSetStatus(proto.FinalityProviderStatus_ACTIVE)
+		ds.setStatus(proto.FinalityProviderStatus_ACTIVE)
 	}
 
 	if !hasPower && ds.State.GetStatus() == proto.FinalityProviderStatus_ACTIVE {
-		ds.mustSetStatus(proto.FinalityProviderStatus_INACTIVE)
+		ds.setStatus(proto.FinalityProviderStatus_INACTIVE)
 	}
 
 	return processedBlocks, nil
@@ -261,7 +314,7 @@ func (ds *DefaultFinalitySubmitter) SubmitBatchFinalitySignatures(ctx context.Co
 }
 
 // submitBatchFinalitySignaturesOnce performs a single submission attempt (original SubmitBatchFinalitySignatures logic)
-func (ds *DefaultFinalitySubmitter) submitBatchFinalitySignaturesOnce(ctx context.Context, blocks []types.BlockDescription) (*types.TxResponse, error) {
+func (ds *DefaultFinalitySubmitter) submitBatchFinalitySignaturesOnce(ctx context.Context, blocks []types.BlockDescription) (_ *types.TxResponse, err error) {
 	if len(blocks) == 0 {
 		return nil, fmt.Errorf("should not submit batch finality signature with zero block")
 	}
@@ -270,9 +323,17 @@ func (ds *DefaultFinalitySubmitter) submitBatchFinalitySignaturesOnce(ctx contex
 		return nil, fmt.Errorf("should not submit batch finality signature with too many blocks")
 	}
 
+	ctx, span := tracing.StartSpan(ctx, "DefaultFinalitySubmitter.submitBatchFinalitySignaturesOnce",
+		tracing.HeightRange(blocks[0].GetHeight(), blocks[len(blocks)-1].GetHeight())...)
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	// #nosec G115 -- performed the conversion check above
 	numPubRand := uint32(len(blocks))
+	_, prSpan := tracing.StartSpan(ctx, "DefaultFinalitySubmitter.GetPubRandList")
 	prList, err := ds.GetPubRandList(blocks[0].GetHeight(), numPubRand)
+	tracing.End(prSpan, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get public randomness for height %d: %w", blocks[0].GetHeight(), err)
 	}
@@ -315,6 +376,10 @@ func (ds *DefaultFinalitySubmitter) submitBatchFinalitySignaturesOnce(ctx contex
 		return nil, nil
 	}
 
+	broadcastAt := time.Now()
+	ds.recordBlockToVoteLatency(validBlocks, broadcastAt)
+	ds.Metrics.RecordFpVoteBatchSize(ds.GetBtcPkHex(), len(validBlocks))
+
 	// send finality signature to the consumer chain
 	res, err := ds.ConsumerCtrl.SubmitBatchFinalitySigs(ctx, api.NewSubmitBatchFinalitySigsRequest(
 		ds.GetBtcPk(),
@@ -342,6 +407,9 @@ func (ds *DefaultFinalitySubmitter) submitBatchFinalitySignaturesOnce(ctx contex
 		return res, nil
 	}
 
+	// the submission returns once the transaction is included in a block
+	ds.Metrics.RecordFpVoteTxInclusionDuration(ds.GetBtcPkHex(), time.Since(broadcastAt))
+
 	// update the metrics with voted blocks
 	for _, b := range validBlocks {
 		ds.Metrics.RecordFpVotedHeight(ds.GetBtcPkHex(), b.GetHeight())
@@ -349,7 +417,7 @@ func (ds *DefaultFinalitySubmitter) submitBatchFinalitySignaturesOnce(ctx contex
 
 	// update state with the highest height of this batch
 	highBlock := blocks[len(blocks)-1]
-	ds.MustSetLastVotedHeight(highBlock.GetHeight())
+	ds.setLastVotedHeight(highBlock.GetHeight())
 
 	return res, nil
 }
@@ -377,7 +445,13 @@ func (ds *DefaultFinalitySubmitter) SignFinalitySig(_ context.Context, b types.B
 	return bbntypes.NewSchnorrEOTSSigFromModNScalar(sig), nil
 }
 
-func (ds *DefaultFinalitySubmitter) SignFinalitySigBatch(_ context.Context, blocks []types.BlockDescription) (map[uint64]*bbntypes.SchnorrEOTSSig, error) {
+func (ds *DefaultFinalitySubmitter) SignFinalitySigBatch(ctx context.Context, blocks []types.BlockDescription) (_ map[uint64]*bbntypes.SchnorrEOTSSig, err error) {
+	ctx, span := tracing.StartSpan(ctx, "DefaultFinalitySubmitter.SignFinalitySigBatch",
+		tracing.NumBlocksKey.Int(len(blocks)))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	signDataReq := make([]*eotsmanager.SignDataRequest, 0, len(blocks))
 	for _, b := range blocks {
 		signDataReq = append(signDataReq, &eotsmanager.SignDataRequest{
@@ -386,11 +460,13 @@ func (ds *DefaultFinalitySubmitter) SignFinalitySigBatch(_ context.Context, bloc
 		})
 	}
 
-	resp, err := ds.Em.SignBatchEOTS(&eotsmanager.SignBatchEOTSRequest{
+	signStart := time.Now()
+	resp, err := ds.Em.SignBatchEOTS(ctx, &eotsmanager.SignBatchEOTSRequest{
 		UID:         ds.GetBtcPkBIP340().MustMarshal(),
 		ChainID:     ds.State.GetChainID(),
 		SignRequest: signDataReq,
 	})
+	ds.Metrics.RecordFpEotsSignBatchDuration(ds.GetBtcPkHex(), time.Since(signStart))
 	if err != nil {
 		if strings.Contains(err.Error(), failedPreconditionErrStr) {
 			return nil, ErrFailedPrecondition
@@ -422,7 +498,13 @@ func (ds *DefaultFinalitySubmitter) GetPubRandList(startHeight uint64, numPubRan
 	return pubRandList, nil
 }
 
-func (ds *DefaultFinalitySubmitter) getVotingPowerWithRetry(ctx context.Context, height uint64) (bool, error) {
+func (ds *DefaultFinalitySubmitter) getVotingPowerWithRetry(ctx context.Context, height uint64) (_ bool, rErr error) {
+	ctx, span := tracing.StartSpan(ctx, "DefaultFinalitySubmitter.getVotingPowerWithRetry",
+		tracing.HeightKey.Int64(int64(height))) // #nosec G115 -- block heights fit in int64
+	defer func() {
+		tracing.End(span, rErr)
+	}()
+
 	var (
 		hasPower bool
 		err      error
@@ -439,6 +521,7 @@ func (ds *DefaultFinalitySubmitter) getVotingPowerWithRetry(ctx context.Context,
 
 		return nil
 	}, RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
+		span.AddEvent("retrying the voting power query")
 		ds.Logger.Debug(
 			"failed to query the voting power",
 			zap.Uint("attempt", n+1),
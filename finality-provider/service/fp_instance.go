@@ -15,6 +15,7 @@ import (
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
 	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 	"github.com/babylonlabs-io/finality-provider/types"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"go.uber.org/atomic"
@@ -37,12 +38,24 @@ type FinalityProviderInstance struct {
 	heightDeterminer  types.HeightDeterminer
 	finalitySubmitter types.FinalitySignatureSubmitter
 	metrics           *metrics.FpMetrics
+	balanceMonitor    *BalanceMonitor
 
 	criticalErrChan chan<- *CriticalError
+	health          *instanceHealth
+
+	// pendingBlocks are the blocks of the last batch failed to be voted,
+	// they are retried before the next blocks of the poller
+	pendingBlocks []types.BlockDescription
 
 	isStarted *atomic.Bool
-	wg        sync.WaitGroup
-	quit      chan struct{}
+	// isRetiring is set once the EOTS key is retired by a key rotation,
+	// the instance then only votes with the already committed randomness
+	isRetiring *atomic.Bool
+	// rndCommitMu serializes the randomness commitments with
+	// the retirement of the EOTS key
+	rndCommitMu sync.Mutex
+	wg          sync.WaitGroup
+	quit        chan struct{}
 }
 
 // NewFinalityProviderInstance returns a FinalityProviderInstance instance with the given Babylon public key
@@ -124,7 +137,9 @@ func newFinalityProviderInstanceFromStore(
 		cfg:               cfg,
 		logger:            logger,
 		isStarted:         atomic.NewBool(false),
+		isRetiring:        atomic.NewBool(false),
 		criticalErrChan:   errChan,
+		health:            newInstanceHealth(),
 		em:                em,
 		poller:            poller,
 		rndCommitter:      rndCommitter,
@@ -220,17 +235,47 @@ func (fp *FinalityProviderInstance) IsRunning() bool {
 	return fp.isStarted.Load()
 }
 
+// IsRetiring returns true if the instance stopped committing randomness
+// because its EOTS key is being rotated
+func (fp *FinalityProviderInstance) IsRetiring() bool {
+	return fp.isRetiring.Load()
+}
+
+func (fp *FinalityProviderInstance) setRetiring() {
+	fp.isRetiring.Store(true)
+}
+
+// stopRandomnessCommitment waits for the in-flight commitment, if any, and
+// calls retire while no randomness is committed, so that the last committed
+// height is final. The commitment only stops if retire succeeds.
+func (fp *FinalityProviderInstance) stopRandomnessCommitment(retire func() error) error {
+	fp.rndCommitMu.Lock()
+	defer fp.rndCommitMu.Unlock()
+
+	if err := retire(); err != nil {
+		return err
+	}
+
+	fp.setRetiring()
+
+	return nil
+}
+
 // IsJailed returns true if fp is JAILED
 // NOTE: it retrieves the the status from the db to
 // ensure status is up-to-date
 func (fp *FinalityProviderInstance) IsJailed() bool {
 	storedFp, err := fp.fpState.s.GetFinalityProvider(fp.GetBtcPk())
 	if err != nil {
-		panic(fmt.Errorf("failed to retrieve the finality provider %s from db: %w", fp.GetBtcPkHex(), err))
+		fp.logger.Error("failed to retrieve the finality provider from db, using the status in memory",
+			zap.String("pk", fp.GetBtcPkHex()), zap.Error(err))
+
+		return fp.GetStatus() == proto.FinalityProviderStatus_JAILED
 	}
 
-	if storedFp.Status != fp.GetStatus() {
-		fp.mustSetStatus(storedFp.Status)
+	// the status in memory is ahead of the store until it is flushed
+	if storedFp.Status != fp.GetStatus() && fp.fpState.IsPersisted() {
+		fp.setStatus(storedFp.Status)
 	}
 
 	return fp.GetStatus() == proto.FinalityProviderStatus_JAILED
@@ -242,13 +287,15 @@ func (fp *FinalityProviderInstance) finalitySigSubmissionLoop(ctx context.Contex
 	// Process immediately for the first iteration without waiting
 	fp.processAndSubmitSignatures(ctx)
 
-	ticker := time.NewTicker(fp.cfg.SignatureSubmissionInterval)
+	interval := fp.cfg.GetSignatureSubmissionInterval()
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
 			fp.processAndSubmitSignatures(ctx)
+			interval = resetOnReload(ticker, interval, fp.cfg.GetSignatureSubmissionInterval())
 		case <-fp.quit:
 			fp.logger.Info(
 				"the finality signature submission loop is closing",
@@ -274,6 +321,12 @@ func (fp *FinalityProviderInstance) processAndSubmitSignatures(ctx context.Conte
 	default:
 	}
 
+	fp.flushState()
+
+	if !fp.health.canAttempt(ComponentVoting, time.Now()) {
+		return
+	}
+
 	pollerBlocks := fp.getBatchBlocksFromPoller()
 	if len(pollerBlocks) == 0 {
 		return
@@ -294,12 +347,20 @@ func (fp *FinalityProviderInstance) processAndSubmitSignatures(ctx context.Conte
 		zap.Uint64("end_height", targetHeight),
 	)
 
+	ctx, span := tracing.StartSpan(ctx, "FinalityProviderInstance.processAndSubmitSignatures",
+		append(tracing.HeightRange(pollerBlocks[0].GetHeight(), targetHeight),
+			tracing.FpPkKey.String(fp.GetBtcPkHex()),
+			tracing.ChainIDKey.String(string(fp.GetChainID())),
+			tracing.NumBlocksKey.Int(len(pollerBlocks)),
+		)...,
+	)
 	res, err := fp.finalitySubmitter.SubmitBatchFinalitySignatures(ctx, pollerBlocks)
+	tracing.End(span, err)
 	if err != nil {
 		fp.metrics.IncrementFpTotalFailedVotes(fp.GetBtcPkHex())
 
 		if errors.Is(err, ErrFinalityProviderJailed) {
-			fp.mustSetStatus(proto.FinalityProviderStatus_JAILED)
+			fp.setStatus(proto.FinalityProviderStatus_JAILED)
 			fp.logger.Debug("the finality-provider has been jailed",
 				zap.String("pk", fp.GetBtcPkHex()))
 
@@ -307,11 +368,14 @@ func (fp *FinalityProviderInstance) processAndSubmitSignatures(ctx context.Conte
 		}
 
 		if !errors.Is(err, ErrFinalityProviderShutDown) {
-			fp.reportCriticalErr(err)
+			// the blocks already voted are filtered out when retried
+			fp.pendingBlocks = pollerBlocks
+			fp.handleFailure(ComponentVoting, err, fp.cfg.GetSignatureSubmissionInterval())
 		}
 
 		return
 	}
+	fp.recoverComponent(ComponentVoting)
 
 	if res == nil {
 		// this can happen when a finality signature is not needed
@@ -331,10 +395,13 @@ func (fp *FinalityProviderInstance) processAndSubmitSignatures(ctx context.Conte
 }
 
 // getBatchBlocksFromPoller retrieves a batch of blocks from the poller, limited by the configured batch size.
+// The blocks of the last batch failed to be voted come first.
 func (fp *FinalityProviderInstance) getBatchBlocksFromPoller() []types.BlockDescription {
-	var pollerBlocks []types.BlockDescription
+	pollerBlocks := fp.pendingBlocks
+	fp.pendingBlocks = nil
 
-	for {
+	batchSize := int(fp.cfg.GetBatchSubmissionSize())
+	for len(pollerBlocks) < batchSize {
 		block, hasBlock := fp.poller.TryNextBlock()
 		if !hasBlock {
 			// No more blocks immediately available, return what we have
@@ -342,10 +409,24 @@ func (fp *FinalityProviderInstance) getBatchBlocksFromPoller() []types.BlockDesc
 		}
 
 		pollerBlocks = append(pollerBlocks, block)
-		if len(pollerBlocks) == int(fp.cfg.BatchSubmissionSize) {
-			return pollerBlocks
-		}
 	}
+
+	return pollerBlocks
+}
+
+// flushState writes the state of the finality provider to the store if
+// writing it failed earlier, which degrades the store until it succeeds
+func (fp *FinalityProviderInstance) flushState() {
+	if fp.fpState.IsPersisted() || !fp.health.canAttempt(ComponentStore, time.Now()) {
+		return
+	}
+
+	if err := fp.fpState.Flush(); err != nil {
+		fp.handleFailure(ComponentStore, err, fp.cfg.GetSignatureSubmissionInterval())
+
+		return
+	}
+	fp.recoverComponent(ComponentStore)
 }
 
 func (fp *FinalityProviderInstance) randomnessCommitmentLoop(ctx context.Context) {
@@ -354,13 +435,15 @@ func (fp *FinalityProviderInstance) randomnessCommitmentLoop(ctx context.Context
 	// Process immediately for the first iteration without waiting
 	fp.processRandomnessCommitment(ctx)
 
-	ticker := time.NewTicker(fp.cfg.RandomnessCommitInterval)
+	interval := fp.cfg.GetRandomnessCommitInterval()
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
 			fp.processRandomnessCommitment(ctx)
+			interval = resetOnReload(ticker, interval, fp.cfg.GetRandomnessCommitInterval())
 		case <-fp.quit:
 			fp.logger.Info(
 				"the randomness commitment loop is closing",
@@ -375,24 +458,56 @@ func (fp *FinalityProviderInstance) randomnessCommitmentLoop(ctx context.Context
 // processRandomnessCommitment handles the logic of checking if randomness should be committed
 // and submitting the commitment if needed
 func (fp *FinalityProviderInstance) processRandomnessCommitment(ctx context.Context) {
+	fp.rndCommitMu.Lock()
+	defer fp.rndCommitMu.Unlock()
+
+	if fp.IsRetiring() {
+		fp.logger.Debug("skip committing randomness as the EOTS key is retiring",
+			zap.String("pk", fp.GetBtcPkHex()),
+		)
+
+		return
+	}
+
+	if !fp.health.canAttempt(ComponentRandomness, time.Now()) {
+		return
+	}
+
 	should, startHeight, err := fp.rndCommitter.ShouldCommit(ctx)
 	if err != nil {
-		fp.reportCriticalErr(err)
+		fp.handleFailure(ComponentRandomness, err, fp.cfg.GetRandomnessCommitInterval())
 
 		return
 	}
 
 	if !should {
+		fp.recoverComponent(ComponentRandomness)
+
 		return
 	}
 
+	if fp.balanceMonitor.IsBelowFloor() {
+		early, err := fp.isEarlyRandomnessCommit(ctx)
+		if err != nil {
+			fp.logger.Warn("failed to check whether the randomness commit is early", zap.Error(err))
+		} else if early {
+			fp.logger.Warn("skip committing randomness ahead of time as the balance of the fee payer account is below the floor",
+				zap.String("pk", fp.GetBtcPkHex()),
+				zap.Uint64("start_height", startHeight),
+			)
+
+			return
+		}
+	}
+
 	txRes, err := fp.rndCommitter.Commit(ctx, startHeight)
 	if err != nil {
 		fp.metrics.IncrementFpTotalFailedRandomness(fp.GetBtcPkHex())
-		fp.reportCriticalErr(err)
+		fp.handleFailure(ComponentRandomness, err, fp.cfg.GetRandomnessCommitInterval())
 
 		return
 	}
+	fp.recoverComponent(ComponentRandomness)
 
 	// txRes could be nil if no need to commit more randomness
 	if txRes != nil {
@@ -405,6 +520,23 @@ func (fp *FinalityProviderInstance) processRandomnessCommitment(ctx context.Cont
 	}
 }
 
+// isEarlyRandomnessCommit returns true if the committed randomness still covers
+// the heights until the estimated timestamping delay, so a new commit is not
+// needed yet to keep voting
+func (fp *FinalityProviderInstance) isEarlyRandomnessCommit(ctx context.Context) (bool, error) {
+	lastCommittedHeight, err := fp.rndCommitter.GetLastCommittedHeight(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get last committed height: %w", err)
+	}
+
+	tipBlock, err := fp.consumerCon.QueryLatestBlock(ctx)
+	if tipBlock == nil || err != nil {
+		return false, fmt.Errorf("failed to get the last block: %w", err)
+	}
+
+	return lastCommittedHeight >= tipBlock.GetHeight()+uint64(fp.cfg.TimestampingDelayBlocks), nil
+}
+
 // reportCriticalErr reports a critical error by sending it to the criticalErrChan for further handling.
 func (fp *FinalityProviderInstance) reportCriticalErr(err error) {
 	select {
@@ -528,10 +660,12 @@ func (fp *FinalityProviderInstance) GetChainID() []byte {
 	return fp.fpState.GetChainID()
 }
 
-func (fp *FinalityProviderInstance) mustSetStatus(s proto.FinalityProviderStatus) {
+// setStatus sets the status of the finality provider, which is flushed
+// later if writing it to the store fails
+func (fp *FinalityProviderInstance) setStatus(s proto.FinalityProviderStatus) {
 	if err := fp.fpState.SetStatus(s); err != nil {
-		fp.logger.Fatal("failed to set finality-provider status",
-			zap.String("pk", fp.GetBtcPkHex()), zap.String("status", s.String()))
+		fp.logger.Error("failed to set finality-provider status",
+			zap.String("pk", fp.GetBtcPkHex()), zap.String("status", s.String()), zap.Error(err))
 	}
 }
 
@@ -2,11 +2,13 @@ package service_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/babylonlabs-io/babylon/v4/testutil/datagen"
 	bbntypes "github.com/babylonlabs-io/babylon/v4/types"
@@ -144,6 +146,50 @@ func FuzzDetermineStartHeight(f *testing.F) {
 	})
 }
 
+func TestRandomnessCommitmentDegradation(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	mockBabylonController := testutil.PrepareMockedBabylonController(t)
+	ctl := gomock.NewController(t)
+	mockConsumerController := mocks.NewMockConsumerController(ctl)
+	mockConsumerController.EXPECT().QueryLastPubRandCommit(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockConsumerController.EXPECT().QueryLatestBlock(gomock.Any()).
+		Return(types.NewBlockInfo(10, testutil.GenRandomByteArray(r, 32), false), nil).AnyTimes()
+	mockConsumerController.EXPECT().QueryFinalityActivationBlockHeight(gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockConsumerController.EXPECT().GetFpRandCommitContext().Return("").AnyTimes()
+	gomock.InOrder(
+		mockConsumerController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("dial tcp 127.0.0.1:26657: connection refused")).Times(1),
+		mockConsumerController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("invalid response")).Times(1),
+		mockConsumerController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any()).
+			Return(&types.TxResponse{TxHash: testutil.GenRandomHexStr(r, 32)}, nil).AnyTimes(),
+	)
+
+	_, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockBabylonController, mockConsumerController, true, 1, testutil.TestPubRandNum)
+	defer cleanUp()
+	fpIns.GetConfig().RandomnessCommitInterval = 50 * time.Millisecond
+	th := fpIns.NewTestHelper()
+
+	// a transient failure is retried at the next cycle
+	th.ProcessRandomnessCommitment(t.Context())
+	require.Empty(t, fpIns.DegradedComponents())
+
+	// while another failure degrades the instance, which backs off
+	th.ProcessRandomnessCommitment(t.Context())
+	require.Contains(t, fpIns.DegradedComponents(), service.ComponentRandomness)
+	th.ProcessRandomnessCommitment(t.Context())
+	require.NotEmpty(t, fpIns.DegradedComponents())
+
+	// until it recovers once the backoff elapsed
+	require.Eventually(t, func() bool {
+		th.ProcessRandomnessCommitment(t.Context())
+
+		return len(fpIns.DegradedComponents()) == 0
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
 func startFinalityProviderAppWithRegisteredFp(
 	t *testing.T,
 	r *rand.Rand,
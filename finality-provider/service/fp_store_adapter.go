@@ -22,6 +22,9 @@ type FpState struct {
 	s       *store.FinalityProviderStore
 	metrics *metrics.FpMetrics
 	logger  *zap.Logger
+	// unpersisted is set once writing the state to the store fails, the
+	// state in memory is then ahead of the store until it is flushed
+	unpersisted bool
 }
 
 func NewFpState(
@@ -100,6 +103,8 @@ func (fps *FpState) SetStatus(s proto.FinalityProviderStatus) error {
 	fps.mu.Unlock()
 
 	if err := fps.s.SetFpStatus(fps.sfp.BtcPk, s); err != nil {
+		fps.setUnpersisted()
+
 		return fmt.Errorf("failed to set finality provider status: %w", err)
 	}
 
@@ -116,6 +121,8 @@ func (fps *FpState) SetLastVotedHeight(height uint64) error {
 	fps.mu.Unlock()
 
 	if err := fps.s.SetFpLastVotedHeight(fps.sfp.BtcPk, height); err != nil {
+		fps.setUnpersisted()
+
 		return fmt.Errorf("failed to set finality provider last voted height: %w", err)
 	}
 
@@ -131,3 +138,42 @@ func (fps *FpState) SetLastVotedHeight(height uint64) error {
 
 	return nil
 }
+
+func (fps *FpState) setUnpersisted() {
+	fps.withLock(func() {
+		fps.unpersisted = true
+	})
+}
+
+// IsPersisted returns whether the state in memory is written to the store
+func (fps *FpState) IsPersisted() bool {
+	var unpersisted bool
+	fps.withLock(func() {
+		unpersisted = fps.unpersisted
+	})
+
+	return !unpersisted
+}
+
+// Flush writes the status and last voted height in memory to the store
+// after writing them failed
+func (fps *FpState) Flush() error {
+	var (
+		btcPk           = fps.GetBtcPk()
+		status          = fps.GetStatus()
+		lastVotedHeight = fps.GetLastVotedHeight()
+	)
+
+	if err := fps.s.SetFpStatus(btcPk, status); err != nil {
+		return fmt.Errorf("failed to flush finality provider status: %w", err)
+	}
+	if err := fps.s.SetFpLastVotedHeight(btcPk, lastVotedHeight); err != nil {
+		return fmt.Errorf("failed to flush finality provider last voted height: %w", err)
+	}
+
+	fps.withLock(func() {
+		fps.unpersisted = false
+	})
+
+	return nil
+}
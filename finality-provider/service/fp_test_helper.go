@@ -183,6 +183,11 @@ func (th *FinalityProviderTestHelper) GetFinalityProviderInstance() *FinalityPro
 	return th.fp
 }
 
+// ProcessRandomnessCommitment runs one cycle of the randomness commitment loop
+func (th *FinalityProviderTestHelper) ProcessRandomnessCommitment(ctx context.Context) {
+	th.fp.processRandomnessCommitment(ctx)
+}
+
 func (th *FinalityProviderTestHelper) SubmitBatchFinalitySignatures(t *testing.T, blocks []types.BlockDescription) (*types.TxResponse, error) {
 	t.Helper()
 
@@ -296,3 +301,39 @@ func (th *FinalityProviderTestHelper) SubmitBatchFinalitySignaturesAndExtractPri
 
 	return res, privKey, nil
 }
+
+// FinalityProviderAppTestHelper provides testing utilities for FinalityProviderApp
+// This struct is designed to be used in testing scenarios and should not be used in production
+type FinalityProviderAppTestHelper struct {
+	app *FinalityProviderApp
+}
+
+func (app *FinalityProviderApp) NewTestHelper() *FinalityProviderAppTestHelper {
+	return &FinalityProviderAppTestHelper{app: app}
+}
+
+// SetRotationControllers makes the app run the new finality provider
+// of a key rotation with the given controllers
+func (th *FinalityProviderAppTestHelper) SetRotationControllers(cc ccapi.BabylonController, consumerCon ccapi.ConsumerController) {
+	app := th.app
+	app.newRotationComponents = func(keyName string) (*instanceComponents, error) {
+		return newInstanceComponentsWithControllers(
+			rotationConfig(app.config, keyName), cc, consumerCon,
+			app.eotsManager, app.pubRandStore, app.metrics, app.logger,
+		), nil
+	}
+}
+
+// ProcessKeyRotation runs one cycle of the key rotation loop
+func (th *FinalityProviderAppTestHelper) ProcessKeyRotation(ctx context.Context) error {
+	return th.app.processKeyRotation(ctx)
+}
+
+// GetRotationInstance returns the instance of the new finality provider
+// of the ongoing key rotation, if any
+func (th *FinalityProviderAppTestHelper) GetRotationInstance() *FinalityProviderInstance {
+	th.app.fpInsMu.RLock()
+	defer th.app.fpInsMu.RUnlock()
+
+	return th.app.rotationFpIns
+}
@@ -0,0 +1,417 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/v4/types"
+	bstypes "github.com/babylonlabs-io/babylon/v4/x/btcstaking/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/zap"
+
+	ccapi "github.com/babylonlabs-io/finality-provider/clientcontroller/api"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	fpkr "github.com/babylonlabs-io/finality-provider/keyring"
+)
+
+// rotationConfig returns a copy of the config where the new finality provider
+// signs with its own key. The authz and feegrant grants given to the operation
+// key of the rotated finality provider do not apply to the new one.
+func rotationConfig(cfg *fpcfg.Config, keyName string) *fpcfg.Config {
+	bbnCfg := *cfg.BabylonConfig
+	bbnCfg.Key = keyName
+	bbnCfg.Granter = ""
+	bbnCfg.FeeGranter = ""
+
+	rotationCfg := *cfg
+	rotationCfg.BabylonConfig = &bbnCfg
+
+	return &rotationCfg
+}
+
+// StartKeyRotation registers a new finality provider with the fresh EOTS key newPk
+// and the Babylon key keyName, and runs it side by side with the running finality
+// provider of oldPk until the delegators migrate. It returns the hash of the
+// registration tx, which is empty if the new finality provider was already registered.
+func (app *FinalityProviderApp) StartKeyRotation(
+	ctx context.Context,
+	oldPk, newPk *bbntypes.BIP340PubKey,
+	keyName string,
+) (*proto.KeyRotation, string, error) {
+	if app.newRotationComponents == nil {
+		return nil, "", fmt.Errorf("key rotation is not supported by this finality provider app")
+	}
+
+	if oldPk.Equals(newPk) {
+		return nil, "", fmt.Errorf("the new EOTS key must differ from the rotated one")
+	}
+
+	if _, err := app.fps.GetKeyRotation(oldPk.MustToBTCPK()); err == nil {
+		return nil, "", fmt.Errorf("the EOTS key %s: %w", oldPk.MarshalHex(), store.ErrDuplicateKeyRotation)
+	} else if !errors.Is(err, store.ErrKeyRotationNotFound) {
+		return nil, "", err
+	}
+
+	app.fpInsMu.RLock()
+	oldFpIns, rotationFpIns := app.fpIns, app.rotationFpIns
+	app.fpInsMu.RUnlock()
+
+	if oldFpIns == nil || !oldFpIns.IsRunning() || !oldPk.Equals(oldFpIns.GetBtcPkBIP340()) {
+		return nil, "", fmt.Errorf("the rotated finality provider %s is not running in the daemon", oldPk.MarshalHex())
+	}
+	if rotationFpIns != nil {
+		return nil, "", fmt.Errorf("the daemon is already running the rotated finality provider %s", rotationFpIns.GetBtcPkHex())
+	}
+
+	oldFp := oldFpIns.GetStoreFinalityProvider()
+
+	kr, err := fpkr.NewChainKeyringControllerWithKeyring(app.kr, keyName)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create keyring controller: %w", err)
+	}
+	newFpAddr, err := kr.Address()
+	if err != nil {
+		return nil, "", fmt.Errorf("the keyname %s does not exist, add the key first: %w", keyName, err)
+	}
+	// babylon allows a single finality provider per account
+	if newFpAddr.String() == oldFp.FPAddr {
+		return nil, "", fmt.Errorf("the new finality provider needs a different Babylon account than %s", oldFp.FPAddr)
+	}
+
+	components, err := app.newRotationComponents(keyName)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create the components of the new finality provider: %w", err)
+	}
+
+	txHash, err := app.registerRotatedFinalityProvider(ctx, components.cc, oldFp, newFpAddr, newPk)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := app.fps.CreateKeyRotation(oldPk.MustToBTCPK(), newPk.MustToBTCPK(), keyName); err != nil {
+		return nil, "", fmt.Errorf("failed to save key rotation: %w", err)
+	}
+
+	if err := app.startRotationInstance(ctx, newPk, components); err != nil {
+		return nil, "", err
+	}
+
+	app.logger.Info("started EOTS key rotation",
+		zap.String("old_eots_pk", oldPk.MarshalHex()),
+		zap.String("new_eots_pk", newPk.MarshalHex()),
+		zap.String("new_addr", newFpAddr.String()),
+	)
+
+	rotation, err := app.fps.GetKeyRotation(oldPk.MustToBTCPK())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get key rotation: %w", err)
+	}
+
+	return rotation, txHash, nil
+}
+
+// registerRotatedFinalityProvider registers the new finality provider with the
+// description and commission of the rotated one, and saves it in the local store.
+func (app *FinalityProviderApp) registerRotatedFinalityProvider(
+	ctx context.Context,
+	cc ccapi.BabylonController,
+	oldFp *store.StoredFinalityProvider,
+	newFpAddr sdk.AccAddress,
+	newPk *bbntypes.BIP340PubKey,
+) (string, error) {
+	resp, err := cc.QueryFinalityProvider(ctx, newPk.MustToBTCPK())
+	if err != nil && !strings.Contains(err.Error(), "the finality provider is not found") {
+		return "", fmt.Errorf("err getting finality provider: %w", err)
+	}
+	if resp != nil {
+		if resp.FinalityProvider.Addr != newFpAddr.String() {
+			return "", fmt.Errorf("the EOTS key %s is already registered by %s", newPk.MarshalHex(), resp.FinalityProvider.Addr)
+		}
+
+		app.logger.Info("the new finality-provider is already registered on the consumer chain",
+			zap.String("eots_pk", newPk.MarshalHex()),
+			zap.String("addr", newFpAddr.String()),
+		)
+
+		return "", app.putFpFromResponse(ctx, resp.FinalityProvider, oldFp.ChainID)
+	}
+
+	// the commission rates are taken from the chain as the
+	// local copy only keeps the max rates as strings
+	oldResp, err := cc.QueryFinalityProvider(ctx, oldFp.BtcPk)
+	if err != nil {
+		return "", fmt.Errorf("failed to query the rotated finality provider: %w", err)
+	}
+	old := oldResp.FinalityProvider
+	if old.Commission == nil || old.CommissionInfo == nil {
+		return "", errors.New("nil Commission in FinalityProviderResponse")
+	}
+	commission := bstypes.NewCommissionRates(*old.Commission, old.CommissionInfo.MaxRate, old.CommissionInfo.MaxChangeRate)
+
+	pop, err := app.CreatePop(ctx, newFpAddr, newPk)
+	if err != nil {
+		return "", fmt.Errorf("failed to create proof-of-possession of the new finality-provider: %w", err)
+	}
+	popBytes, err := pop.Marshal()
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal proof-of-possession: %w", err)
+	}
+
+	desBytes, err := old.Description.Marshal()
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal description: %w", err)
+	}
+
+	res, err := cc.RegisterFinalityProvider(ctx, &ccapi.RegisterFinalityProviderRequest{
+		ChainID:     oldFp.ChainID,
+		FpPk:        newPk.MustToBTCPK(),
+		Pop:         popBytes,
+		Commission:  commission,
+		Description: desBytes,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to register the new finality provider: %w", err)
+	}
+
+	app.logger.Info(
+		"successfully registered the new finality-provider on babylon",
+		zap.String("btc_pk", newPk.MarshalHex()),
+		zap.String("fp_addr", newFpAddr.String()),
+		zap.String("txHash", res.TxHash),
+	)
+
+	if err := app.fps.CreateFinalityProvider(newFpAddr, newPk.MustToBTCPK(), old.Description, commission, oldFp.ChainID); err != nil {
+		return "", fmt.Errorf("failed to save the new finality-provider: %w", err)
+	}
+
+	app.metrics.RecordFpStatus(newPk.MarshalHex(), proto.FinalityProviderStatus_REGISTERED)
+
+	return res.TxHash, nil
+}
+
+// startRotationInstance starts the instance of the new finality provider
+// with its own set of components
+func (app *FinalityProviderApp) startRotationInstance(
+	ctx context.Context,
+	newPk *bbntypes.BIP340PubKey,
+	c *instanceComponents,
+) error {
+	app.fpInsMu.Lock()
+	defer app.fpInsMu.Unlock()
+
+	if app.rotationFpIns != nil {
+		return fmt.Errorf("the daemon is already running the rotated finality provider %s", app.rotationFpIns.GetBtcPkHex())
+	}
+
+	fpIns, err := NewFinalityProviderInstance(
+		newPk, app.config, app.fps, app.pubRandStore, c.cc, c.consumerCon,
+		app.eotsManager, c.poller, c.rndCommitter, c.heightDeterminer, c.finalitySubmitter,
+		app.metrics, app.criticalErrChan, app.logger,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create finality provider instance %s: %w", newPk.MarshalHex(), err)
+	}
+	fpIns.balanceMonitor = app.balanceMonitor
+
+	if err := fpIns.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start finality provider instance %s: %w", newPk.MarshalHex(), err)
+	}
+
+	app.rotationFpIns = fpIns
+
+	return nil
+}
+
+// resumeKeyRotation restarts the new finality provider of an ongoing rotation
+// of the EOTS key of fpIns after the daemon is restarted
+func (app *FinalityProviderApp) resumeKeyRotation(ctx context.Context, fpIns *FinalityProviderInstance) error {
+	rotation, err := app.fps.GetKeyRotation(fpIns.GetBtcPk())
+	if errors.Is(err, store.ErrKeyRotationNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if rotation.Phase == proto.KeyRotationPhase_RETIRED {
+		return nil
+	}
+
+	if rotation.Phase == proto.KeyRotationPhase_RETIRING {
+		fpIns.setRetiring()
+	}
+
+	newPk, err := bbntypes.NewBIP340PubKey(rotation.NewBtcPk)
+	if err != nil {
+		return fmt.Errorf("invalid new EOTS key of the rotation: %w", err)
+	}
+
+	if app.newRotationComponents == nil {
+		return fmt.Errorf("key rotation is not supported by this finality provider app")
+	}
+
+	components, err := app.newRotationComponents(rotation.NewKeyName)
+	if err != nil {
+		return fmt.Errorf("failed to create the components of the new finality provider: %w", err)
+	}
+
+	app.logger.Info("resuming EOTS key rotation",
+		zap.String("old_eots_pk", fpIns.GetBtcPkHex()),
+		zap.String("new_eots_pk", newPk.MarshalHex()),
+		zap.String("phase", rotation.Phase.String()),
+	)
+
+	return app.startRotationInstance(ctx, newPk, components)
+}
+
+// RetireKey stops committing randomness for the rotated EOTS key oldPk. The
+// finality provider keeps voting until the last height with committed randomness,
+// after which the new finality provider replaces it in the daemon.
+func (app *FinalityProviderApp) RetireKey(ctx context.Context, oldPk *bbntypes.BIP340PubKey) (*proto.KeyRotation, error) {
+	rotation, err := app.fps.GetKeyRotation(oldPk.MustToBTCPK())
+	if err != nil {
+		return nil, err
+	}
+	if rotation.Phase != proto.KeyRotationPhase_PARALLEL {
+		return nil, fmt.Errorf("the EOTS key %s is already %s", oldPk.MarshalHex(), rotation.Phase)
+	}
+
+	app.fpInsMu.RLock()
+	fpIns := app.fpIns
+	app.fpInsMu.RUnlock()
+
+	if fpIns == nil || !fpIns.IsRunning() || !oldPk.Equals(fpIns.GetBtcPkBIP340()) {
+		return nil, fmt.Errorf("the rotated finality provider %s is not running in the daemon", oldPk.MarshalHex())
+	}
+
+	// no randomness is committed while the retire height is read and stored,
+	// so that it is final, and the commitment keeps going if either fails
+	var retireHeight uint64
+	if err := fpIns.stopRandomnessCommitment(func() error {
+		retireHeight, err = fpIns.GetLastCommittedHeight(ctx)
+		if err != nil {
+			return err
+		}
+
+		return app.fps.SetKeyRotationPhase(oldPk.MustToBTCPK(), proto.KeyRotationPhase_RETIRING, retireHeight)
+	}); err != nil {
+		return nil, err
+	}
+
+	app.logger.Info("retiring EOTS key",
+		zap.String("eots_pk", oldPk.MarshalHex()),
+		zap.Uint64("retire_height", retireHeight),
+	)
+
+	return app.fps.GetKeyRotation(oldPk.MustToBTCPK())
+}
+
+// ListKeyRotations returns all the key rotations tracked in the local store
+func (app *FinalityProviderApp) ListKeyRotations() ([]*proto.KeyRotationInfo, error) {
+	rotations, err := app.fps.GetAllKeyRotations()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]*proto.KeyRotationInfo, 0, len(rotations))
+	for _, r := range rotations {
+		info, err := proto.NewKeyRotationInfo(r)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+// keyRotationLoop completes the retirement of a rotated EOTS key once the
+// finality provider voted for all the heights it committed randomness for
+func (app *FinalityProviderApp) keyRotationLoop(ctx context.Context) {
+	defer app.wg.Done()
+
+	interval := app.config.GetRandomnessCommitInterval()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := app.processKeyRotation(ctx); err != nil {
+				app.logger.Warn("failed to process key rotation", zap.Error(err))
+			}
+			interval = resetOnReload(ticker, interval, app.config.GetRandomnessCommitInterval())
+		case <-ctx.Done():
+			app.logger.Info("exiting key rotation loop")
+
+			return
+		}
+	}
+}
+
+func (app *FinalityProviderApp) processKeyRotation(ctx context.Context) error {
+	app.fpInsMu.RLock()
+	fpIns, rotationFpIns := app.fpIns, app.rotationFpIns
+	app.fpInsMu.RUnlock()
+
+	if fpIns == nil || rotationFpIns == nil || !fpIns.IsRetiring() {
+		return nil
+	}
+
+	rotation, err := app.fps.GetKeyRotation(fpIns.GetBtcPk())
+	if err != nil {
+		return err
+	}
+	if rotation.Phase != proto.KeyRotationPhase_RETIRING {
+		return nil
+	}
+
+	tip, err := app.consumerCon.QueryLatestBlock(ctx)
+	if err != nil || tip == nil {
+		return fmt.Errorf("failed to query latest block: %w", err)
+	}
+	if tip.GetHeight() <= rotation.RetireHeight {
+		return nil
+	}
+
+	// the key is retired once the finality provider voted up to the retire
+	// height, or if it has no voting power left there to vote with
+	if fpIns.GetLastVotedHeight() < rotation.RetireHeight {
+		hasPower, err := fpIns.GetVotingPowerWithRetry(rotation.RetireHeight)
+		if err != nil {
+			return err
+		}
+		if hasPower {
+			return nil
+		}
+	}
+
+	if err := app.fps.SetKeyRotationPhase(fpIns.GetBtcPk(), proto.KeyRotationPhase_RETIRED, 0); err != nil {
+		return err
+	}
+
+	app.fpInsMu.Lock()
+	defer app.fpInsMu.Unlock()
+
+	if fpIns.IsRunning() {
+		if err := fpIns.Stop(); err != nil {
+			return fmt.Errorf("failed to stop the retired finality provider %s: %w", fpIns.GetBtcPkHex(), err)
+		}
+	}
+
+	app.fpIns = rotationFpIns
+	app.rotationFpIns = nil
+
+	app.logger.Info("EOTS key is retired",
+		zap.String("old_eots_pk", fpIns.GetBtcPkHex()),
+		zap.String("new_eots_pk", rotationFpIns.GetBtcPkHex()),
+		zap.Uint64("retire_height", rotation.RetireHeight),
+	)
+
+	return nil
+}
@@ -0,0 +1,317 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	bbntypes "github.com/babylonlabs-io/babylon/v4/types"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/v4/x/btcstaking/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/api"
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/babylon"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	eotscfg "github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/keyring"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/testutil/mocks"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+func TestKeyRotation(t *testing.T) {
+	t.Parallel()
+	logger := zaptest.NewLogger(t)
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	var tip atomic.Uint64
+	tip.Store(100)
+
+	// the EOTS manager holds both the rotated and the new EOTS key
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	eotsdb, err := eotsCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, eotsdb.Close())
+	}()
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, eotsdb, logger)
+	require.NoError(t, err)
+	oldPkBz, err := em.CreateKey("old-eots-key", "")
+	require.NoError(t, err)
+	oldPk, err := bbntypes.NewBIP340PubKey(oldPkBz)
+	require.NoError(t, err)
+	newPkBz, err := em.CreateKey("new-eots-key", "")
+	require.NoError(t, err)
+	newPk, err := bbntypes.NewBIP340PubKey(newPkBz)
+	require.NoError(t, err)
+
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpCfg.NumPubRand = testutil.TestPubRandNum
+	// the loops only run their first iteration, the
+	// key rotation is processed by the test itself
+	fpCfg.RandomnessCommitInterval = time.Hour
+	fpCfg.SignatureSubmissionInterval = time.Hour
+	db, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+
+	// the first randomness commitment of the rotated finality
+	// provider is in flight until it is released
+	var lastCommit atomic.Pointer[babylon.BabylonPubRandCommit]
+	committing, release := make(chan struct{}), make(chan struct{})
+	releaseCommit := sync.OnceFunc(func() { close(release) })
+	var hasPower atomic.Bool
+	hasPower.Store(true)
+	consumerCon := mockKeyRotationConsumerController(t, &tip)
+	consumerCon.EXPECT().QueryLastPubRandCommit(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ *btcec.PublicKey) (types.PubRandCommit, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if c := lastCommit.Load(); c != nil {
+				return c, nil
+			}
+
+			return nil, nil
+		}).AnyTimes()
+	consumerCon.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *api.CommitPubRandListRequest) (*types.TxResponse, error) {
+			close(committing)
+			<-release
+			lastCommit.Store(&babylon.BabylonPubRandCommit{
+				StartHeight: req.StartHeight,
+				NumPubRand:  req.NumPubRand,
+				Commitment:  req.Commitment,
+			})
+
+			return &types.TxResponse{TxHash: "commit"}, nil
+		}).Times(1)
+	consumerCon.EXPECT().QueryFinalityProviderHasPower(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, *api.QueryFinalityProviderHasPowerRequest) (bool, error) {
+			return hasPower.Load(), nil
+		}).AnyTimes()
+
+	fpMetrics := metrics.NewFpMetrics()
+	pubRandStore, err := fpstore.NewPubRandProofStore(db)
+	require.NoError(t, err)
+	poller := service.NewChainPoller(logger, fpCfg.PollerConfig, consumerCon, fpMetrics)
+	rndCommitter := service.NewDefaultRandomnessCommitter(
+		service.NewRandomnessCommitterConfig(fpCfg.NumPubRand, int64(fpCfg.TimestampingDelayBlocks), fpCfg.ContextSigningHeight),
+		service.NewPubRandState(pubRandStore), consumerCon, em, logger, fpMetrics)
+	heightDeterminer := service.NewStartHeightDeterminer(consumerCon, fpCfg.PollerConfig, logger)
+	fsCfg := service.NewDefaultFinalitySubmitterConfig(
+		fpCfg.MaxSubmissionRetries,
+		fpCfg.ContextSigningHeight,
+		fpCfg.SubmissionRetryInterval,
+	)
+	finalitySubmitter := service.NewDefaultFinalitySubmitter(consumerCon, em, rndCommitter.GetPubRandProofList, fsCfg, logger, fpMetrics)
+
+	app, err := service.NewFinalityProviderApp(&fpCfg, testutil.PrepareMockedBabylonController(t), consumerCon, em,
+		poller, rndCommitter, heightDeterminer, finalitySubmitter, fpMetrics, db, logger)
+	require.NoError(t, err)
+
+	// the rotated and the new finality provider use different Babylon accounts
+	kr, err := keyring.CreateKeyring(fpCfg.BabylonConfig.KeyDirectory, fpCfg.BabylonConfig.ChainID, fpCfg.BabylonConfig.KeyringBackend)
+	require.NoError(t, err)
+	oldKc, err := keyring.NewChainKeyringControllerWithKeyring(kr, "old-key")
+	require.NoError(t, err)
+	oldKey, err := oldKc.CreateChainKey(passphrase, hdPath, "")
+	require.NoError(t, err)
+	newKc, err := keyring.NewChainKeyringControllerWithKeyring(kr, "new-key")
+	require.NoError(t, err)
+	newKey, err := newKc.CreateChainKey(passphrase, hdPath, "")
+	require.NoError(t, err)
+
+	description := testutil.RandomDescription(rand.New(rand.NewSource(time.Now().UnixNano())))
+	commission := testutil.ZeroCommissionRate()
+	require.NoError(t, app.GetFinalityProviderStore().CreateFinalityProvider(
+		oldKey.AccAddress, oldPk.MustToBTCPK(), description, commission, fpCfg.BabylonConfig.ChainID))
+
+	require.NoError(t, app.Start(ctx))
+	defer func() {
+		cancel()
+		require.NoError(t, app.Stop())
+	}()
+	// the instances cannot stop during the commitment
+	defer releaseCommit()
+	require.NoError(t, app.StartFinalityProvider(ctx, oldPk))
+	oldFpIns, err := app.GetFinalityProviderInstance()
+	require.NoError(t, err)
+
+	select {
+	case <-committing:
+	case <-time.After(eventuallyWaitTimeOut):
+		t.Fatal("the randomness commitment did not start")
+	}
+
+	// the new finality provider is registered with the
+	// description and commission of the rotated one
+	rotationCC := testutil.PrepareMockedBabylonController(t)
+	rotationCC.EXPECT().QueryFinalityProvider(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, pk *btcec.PublicKey) (*btcstakingtypes.QueryFinalityProviderResponse, error) {
+			if !bbntypes.NewBIP340PubKeyFromBTCPK(pk).Equals(oldPk) {
+				return nil, errors.New("the finality provider is not found")
+			}
+
+			return &btcstakingtypes.QueryFinalityProviderResponse{FinalityProvider: &btcstakingtypes.FinalityProviderResponse{
+				Addr:        oldKey.AccAddress.String(),
+				BtcPk:       oldPk,
+				Description: description,
+				Commission:  &commission.Rate,
+				CommissionInfo: &btcstakingtypes.CommissionInfo{
+					MaxRate:       commission.MaxRate,
+					MaxChangeRate: commission.MaxChangeRate,
+				},
+			}}, nil
+		}).AnyTimes()
+	rotationCC.EXPECT().RegisterFinalityProvider(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *api.RegisterFinalityProviderRequest) (*types.TxResponse, error) {
+			require.True(t, bbntypes.NewBIP340PubKeyFromBTCPK(req.FpPk).Equals(newPk))
+			require.True(t, req.Commission.Rate.Equal(sdkmath.LegacyZeroDec()))
+
+			return &types.TxResponse{TxHash: "register"}, nil
+		}).Times(1)
+	rotationCon := mockKeyRotationConsumerController(t, &tip)
+	rotationCon.EXPECT().QueryLastPubRandCommit(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	rotationCon.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any()).Return(&types.TxResponse{TxHash: "commit"}, nil).AnyTimes()
+	rotationCon.EXPECT().QueryFinalityProviderHasPower(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+
+	th := app.NewTestHelper()
+	th.SetRotationControllers(rotationCC, rotationCon)
+
+	// StartKeyRotation
+	_, _, err = app.StartKeyRotation(ctx, oldPk, oldPk, "new-key")
+	require.ErrorContains(t, err, "must differ")
+	_, _, err = app.StartKeyRotation(ctx, oldPk, newPk, "old-key")
+	require.ErrorContains(t, err, "different Babylon account")
+
+	rotation, txHash, err := app.StartKeyRotation(ctx, oldPk, newPk, "new-key")
+	require.NoError(t, err)
+	require.Equal(t, "register", txHash)
+	require.Equal(t, proto.KeyRotationPhase_PARALLEL, rotation.Phase)
+	require.Equal(t, newPkBz, rotation.NewBtcPk)
+	newFp, err := app.GetFinalityProviderStore().GetFinalityProvider(newPk.MustToBTCPK())
+	require.NoError(t, err)
+	require.Equal(t, newKey.AccAddress.String(), newFp.FPAddr)
+	newFpIns := th.GetRotationInstance()
+	require.NotNil(t, newFpIns)
+	require.True(t, newFpIns.IsRunning())
+	require.True(t, newPk.Equals(newFpIns.GetBtcPkBIP340()))
+
+	_, _, err = app.StartKeyRotation(ctx, oldPk, newPk, "new-key")
+	require.ErrorIs(t, err, fpstore.ErrDuplicateKeyRotation)
+
+	// the key is not retired before RetireKey
+	require.NoError(t, th.ProcessKeyRotation(ctx))
+	fpIns, err := app.GetFinalityProviderInstance()
+	require.NoError(t, err)
+	require.Equal(t, oldFpIns, fpIns)
+
+	// RetireKey waits for the in-flight randomness commitment, so that
+	// the retire height covers the committed randomness. The query of
+	// the retire height fails with a canceled context.
+	failedCtx, cancelFailed := context.WithCancel(ctx)
+	cancelFailed()
+	retired := make(chan error, 1)
+	go func() {
+		_, err := app.RetireKey(failedCtx, oldPk)
+		retired <- err
+	}()
+	select {
+	case <-retired:
+		t.Fatal("the key was retired during the randomness commitment")
+	case <-time.After(100 * time.Millisecond):
+	}
+	releaseCommit()
+
+	select {
+	case err = <-retired:
+	case <-time.After(eventuallyWaitTimeOut):
+		t.Fatal("the key was not retired")
+	}
+	require.ErrorIs(t, err, context.Canceled)
+
+	// the randomness commitment goes on if the key cannot be retired
+	require.False(t, oldFpIns.IsRetiring())
+	rotation, err = app.GetFinalityProviderStore().GetKeyRotation(oldPk.MustToBTCPK())
+	require.NoError(t, err)
+	require.Equal(t, proto.KeyRotationPhase_PARALLEL, rotation.Phase)
+
+	rotation, err = app.RetireKey(ctx, oldPk)
+	require.NoError(t, err)
+	require.Equal(t, proto.KeyRotationPhase_RETIRING, rotation.Phase)
+	require.NotNil(t, lastCommit.Load())
+	retireHeight := lastCommit.Load().GetEndHeight()
+	require.Equal(t, retireHeight, rotation.RetireHeight)
+	require.True(t, oldFpIns.IsRetiring())
+
+	_, err = app.RetireKey(ctx, oldPk)
+	require.ErrorContains(t, err, "already RETIRING")
+
+	// processKeyRotation waits for the chain to pass the retire height
+	tip.Store(retireHeight)
+	require.NoError(t, th.ProcessKeyRotation(ctx))
+	require.Equal(t, newFpIns, th.GetRotationInstance())
+
+	// and for the rotated finality provider to vote up to it
+	// or to lose its voting power
+	tip.Store(retireHeight + 1)
+	require.NoError(t, th.ProcessKeyRotation(ctx))
+	require.Equal(t, newFpIns, th.GetRotationInstance())
+
+	hasPower.Store(false)
+	require.NoError(t, th.ProcessKeyRotation(ctx))
+	require.Nil(t, th.GetRotationInstance())
+	fpIns, err = app.GetFinalityProviderInstance()
+	require.NoError(t, err)
+	require.Equal(t, newFpIns, fpIns)
+	require.False(t, oldFpIns.IsRunning())
+
+	rotation, err = app.GetFinalityProviderStore().GetKeyRotation(oldPk.MustToBTCPK())
+	require.NoError(t, err)
+	require.Equal(t, proto.KeyRotationPhase_RETIRED, rotation.Phase)
+}
+
+// mockKeyRotationConsumerController mocks a consumer chain without blocks
+// to vote for, whose tip is at the given height
+func mockKeyRotationConsumerController(t *testing.T, tip *atomic.Uint64) *mocks.MockConsumerController {
+	t.Helper()
+
+	consumerCon := mocks.NewMockConsumerController(gomock.NewController(t))
+	consumerCon.EXPECT().QueryLatestBlock(gomock.Any()).DoAndReturn(
+		func(context.Context) (types.BlockDescription, error) {
+			return types.NewBlockInfo(tip.Load(), make([]byte, 32), false), nil
+		}).AnyTimes()
+	consumerCon.EXPECT().QueryBlock(gomock.Any(), gomock.Any()).Return(nil, errors.New("chain not online")).AnyTimes()
+	consumerCon.EXPECT().QueryBlocks(gomock.Any(), gomock.Any()).Return(nil, errors.New("chain not online")).AnyTimes()
+	consumerCon.EXPECT().QueryLatestFinalizedBlock(gomock.Any()).Return(nil, nil).AnyTimes()
+	consumerCon.EXPECT().QueryFinalityActivationBlockHeight(gomock.Any()).Return(uint64(1), nil).AnyTimes()
+	consumerCon.EXPECT().QueryFinalityProviderHighestVotedHeight(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	consumerCon.EXPECT().QueryFinalityProviderStatus(gomock.Any(), gomock.Any()).
+		Return(api.NewFinalityProviderStatusResponse(false, false), nil).AnyTimes()
+	consumerCon.EXPECT().GetFpRandCommitContext().Return("").AnyTimes()
+	consumerCon.EXPECT().GetFpFinVoteContext().Return("").AnyTimes()
+	consumerCon.EXPECT().IsBSN().Return(false).AnyTimes()
+	consumerCon.EXPECT().Close().Return(nil).AnyTimes()
+
+	return consumerCon
+}
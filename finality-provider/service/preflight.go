@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	bbnclient "github.com/babylonlabs-io/babylon/v4/client/client"
+	bbntypes "github.com/babylonlabs-io/babylon/v4/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	eotsclient "github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	fpkr "github.com/babylonlabs-io/finality-provider/keyring"
+	"github.com/babylonlabs-io/finality-provider/preflight"
+)
+
+// preflightDBTimeout bounds the time to open the database, which is locked
+// while fpd runs
+const preflightDBTimeout = 2 * time.Second
+
+// fpdPreflight holds what the preflight checks of fpd set up for the checks
+// depending on them
+type fpdPreflight struct {
+	homePath  string
+	cfg       *fpcfg.Config
+	fps       []*store.StoredFinalityProvider
+	bbnClient *bbnclient.Client
+}
+
+// PreflightChecks returns the checks of whether fpd can start with the
+// config of the home, which run without starting fpd. fpd must be stopped,
+// as its database is opened, while eotsd and the Babylon node must be running.
+func PreflightChecks(homePath string) []preflight.Check {
+	p := &fpdPreflight{homePath: homePath}
+
+	return []preflight.Check{
+		{Name: "config", Run: p.checkConfig},
+		{Name: "permissions", DependsOn: []string{"config"}, Run: p.checkPermissions},
+		{Name: "listeners", DependsOn: []string{"config"}, Run: p.checkListeners},
+		{Name: "database", DependsOn: []string{"config"}, Run: p.checkDatabase},
+		{Name: "finality_providers", DependsOn: []string{"database"}, Run: p.checkFinalityProviders},
+		{Name: "keyring", DependsOn: []string{"config"}, Run: p.checkKeyring},
+		{Name: "babylon_node", DependsOn: []string{"config"}, Run: p.checkBabylonNode},
+		{Name: "finality_params", DependsOn: []string{"babylon_node"}, Run: p.checkFinalityParams},
+		{Name: "eotsd", DependsOn: []string{"config"}, Run: p.checkEOTSManager},
+	}
+}
+
+func (p *fpdPreflight) checkConfig(_ context.Context) error {
+	cfg, err := fpcfg.LoadConfig(p.homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	p.cfg = cfg
+
+	return nil
+}
+
+func (p *fpdPreflight) checkPermissions(_ context.Context) error {
+	for _, dir := range []string{p.homePath, p.cfg.DatabaseConfig.DBPath, fpcfg.LogDir(p.homePath)} {
+		if err := preflight.WritableDir(dir); err != nil {
+			return err
+		}
+	}
+
+	bbnCfg := p.cfg.BabylonConfig
+
+	return preflight.PrivateDir(filepath.Join(bbnCfg.KeyDirectory, "keyring-"+bbnCfg.KeyringBackend))
+}
+
+func (p *fpdPreflight) checkListeners(_ context.Context) error {
+	metricsAddr, err := p.cfg.Metrics.Address()
+	if err != nil {
+		return fmt.Errorf("failed to get metrics address: %w", err)
+	}
+
+	for _, addr := range []string{p.cfg.RPCListener, metricsAddr} {
+		if err := preflight.Bindable(addr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *fpdPreflight) checkDatabase(_ context.Context) error {
+	dbCfg := *p.cfg.DatabaseConfig
+	dbCfg.DBTimeout = preflightDBTimeout
+	db, err := dbCfg.GetDBBackend()
+	if err != nil {
+		return fmt.Errorf("failed to open the database, which is locked while fpd runs: %w", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	fpStore, err := store.NewFinalityProviderStore(db)
+	if err != nil {
+		return fmt.Errorf("failed to initiate finality provider store: %w", err)
+	}
+	if _, err := store.NewPubRandProofStore(db); err != nil {
+		return fmt.Errorf("failed to initiate public randomness store: %w", err)
+	}
+
+	fps, err := fpStore.GetAllStoredFinalityProviders()
+	if err != nil {
+		return fmt.Errorf("failed to get the stored finality providers: %w", err)
+	}
+	p.fps = fps
+
+	return nil
+}
+
+func (p *fpdPreflight) checkFinalityProviders(_ context.Context) error {
+	chainID := p.cfg.BabylonConfig.ChainID
+
+	var mismatched []string
+	for _, fp := range p.fps {
+		if fp.ChainID != chainID {
+			mismatched = append(mismatched, fmt.Sprintf("%s (%s)",
+				bbntypes.NewBIP340PubKeyFromBTCPK(fp.BtcPk).MarshalHex(), fp.ChainID))
+		}
+	}
+	if len(mismatched) > 0 {
+		return fmt.Errorf("the finality providers %s are not registered on the chain %s of the config",
+			strings.Join(mismatched, ", "), chainID)
+	}
+
+	return nil
+}
+
+func (p *fpdPreflight) checkKeyring(_ context.Context) error {
+	bbnCfg := p.cfg.BabylonConfig
+	if bbnCfg.KeyringBackend != "test" {
+		return fmt.Errorf("the keyring backend in config must be `test` for automatic signing, got %s", bbnCfg.KeyringBackend)
+	}
+
+	kr, err := fpkr.CreateKeyring(bbnCfg.KeyDirectory, bbnCfg.ChainID, bbnCfg.KeyringBackend)
+	if err != nil {
+		return err
+	}
+
+	record, err := kr.Key(bbnCfg.Key)
+	if err != nil {
+		return fmt.Errorf("failed to get the key %s from the keyring at %s: %w", bbnCfg.Key, bbnCfg.KeyDirectory, err)
+	}
+	if _, err := record.GetAddress(); err != nil {
+		return fmt.Errorf("failed to get the address of the key %s: %w", bbnCfg.Key, err)
+	}
+
+	return nil
+}
+
+func (p *fpdPreflight) checkBabylonNode(_ context.Context) error {
+	bbnCfg := p.cfg.BabylonConfig.ToBabylonConfig()
+	c, err := bbnclient.New(&bbnCfg, zap.NewNop())
+	if err != nil {
+		return fmt.Errorf("failed to create Babylon rpc client: %w", err)
+	}
+
+	res, err := c.GetStatus()
+	if err != nil {
+		return fmt.Errorf("failed to query the status of the node at %s: %w", bbnCfg.RPCAddr, err)
+	}
+	if network := res.NodeInfo.Network; network != bbnCfg.ChainID {
+		return fmt.Errorf("the node at %s is on the chain %s, while the config expects %s", bbnCfg.RPCAddr, network, bbnCfg.ChainID)
+	}
+	if !res.TxIndexEnabled() {
+		return fmt.Errorf("tx indexing in the babylon node must be enabled")
+	}
+	p.bbnClient = c
+
+	return nil
+}
+
+func (p *fpdPreflight) checkFinalityParams(_ context.Context) error {
+	res, err := p.bbnClient.FinalityParams()
+	if err != nil {
+		return fmt.Errorf("failed to query finality params: %w", err)
+	}
+
+	if minPubRand := res.Params.MinPubRand; uint64(p.cfg.NumPubRand) < minPubRand {
+		return fmt.Errorf("the numPubRand %d of the config is less than the minimum %d of the chain", p.cfg.NumPubRand, minPubRand)
+	}
+
+	return nil
+}
+
+// checkEOTSManager connects to each eotsd of the config and sends it an
+// authenticated request, as pings are not authenticated
+func (p *fpdPreflight) checkEOTSManager(_ context.Context) error {
+	dialOpts, err := EOTSManagerDialOptions(p.cfg)
+	if err != nil {
+		return err
+	}
+	dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(
+		grpc.MaxCallRecvMsgSize(p.cfg.GRPCMaxContentLength),
+		grpc.MaxCallSendMsgSize(p.cfg.GRPCMaxContentLength)),
+	)
+
+	addrs := []string{p.cfg.EOTSManagerAddress}
+	if p.cfg.ThresholdEOTSManager.IsEnabled() {
+		addrs = p.cfg.ThresholdEOTSManager.Addresses
+	}
+
+	for _, addr := range addrs {
+		if err := checkEOTSManagerAt(addr, p.cfg.HMACKey, dialOpts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkEOTSManagerAt(addr, hmacKey string, dialOpts []grpc.DialOption) error {
+	c, err := eotsclient.NewEOTSManagerGRPCClient(addr, hmacKey, dialOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to eotsd at %s: %w", addr, err)
+	}
+	defer func() {
+		_ = c.Close()
+	}()
+
+	_, err = c.ListUnlockedKeys()
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	// the request was authenticated but is not allowed by the policy of eotsd
+	case codes.PermissionDenied:
+		return nil
+	case codes.Unauthenticated:
+		return fmt.Errorf("eotsd at %s does not accept the HMAC key of the config: %w", addr, err)
+	default:
+		return fmt.Errorf("failed to send an authenticated request to eotsd at %s: %w", addr, err)
+	}
+}
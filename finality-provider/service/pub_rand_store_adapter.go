@@ -2,7 +2,7 @@ package service
 
 import (
 	"fmt"
-	eotsclient "github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	"github.com/babylonlabs-io/finality-provider/types"
 	"github.com/btcsuite/btcd/btcec/v2"
 
@@ -11,7 +11,7 @@ import (
 )
 
 type AddProofListFunc func(chainID []byte, pk []byte, commit types.PubRandCommit, proofList []*merkle.Proof) error
-type CreateRandomnessFunc func(em *eotsclient.EOTSManagerGRPCClient, fpPk []byte, chainID []byte, commit types.PubRandCommit) ([]*btcec.FieldVal, error)
+type CreateRandomnessFunc func(em eotsmanager.EOTSManager, fpPk []byte, chainID []byte, commit types.PubRandCommit) ([]*btcec.FieldVal, error)
 
 type PubRandState struct {
 	s *store.PubRandProofStore
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+)
+
+// pinger is implemented by the clients of remote EOTS managers
+type pinger interface {
+	Ping() error
+}
+
+// ReadinessChecks returns the checks of whether fpd can vote, which are
+// served at /readyz of the metrics server
+func (app *FinalityProviderApp) ReadinessChecks() []metrics.ReadinessCheck {
+	return []metrics.ReadinessCheck{
+		{Name: "babylon", Check: app.checkBabylonReachable},
+		{Name: "eotsd", Check: app.checkEOTSManagerReachable},
+		{Name: "finality_provider", Check: app.checkInstanceStatus},
+		{Name: "poller_lag", Check: app.checkPollerLag},
+		{Name: "randomness_runway", Check: app.checkRandomnessRunway},
+	}
+}
+
+// readinessConfig returns the thresholds of the readiness checks, which are
+// the defaults if the config was written before they were introduced
+func (app *FinalityProviderApp) readinessConfig() fpcfg.ReadinessConfig {
+	if app.config.Readiness == nil {
+		return fpcfg.DefaultReadinessConfig()
+	}
+
+	return *app.config.Readiness
+}
+
+func (app *FinalityProviderApp) checkBabylonReachable(ctx context.Context) error {
+	if _, err := app.consumerCon.QueryLatestBlock(ctx); err != nil {
+		return fmt.Errorf("failed to query the latest block: %w", err)
+	}
+
+	return nil
+}
+
+func (app *FinalityProviderApp) checkEOTSManagerReachable(_ context.Context) error {
+	p, ok := app.eotsManager.(pinger)
+	if !ok {
+		return nil
+	}
+	if err := p.Ping(); err != nil {
+		return fmt.Errorf("failed to ping the EOTS manager: %w", err)
+	}
+
+	return nil
+}
+
+// runningInstance returns the finality provider instance if it is running
+func (app *FinalityProviderApp) runningInstance() (*FinalityProviderInstance, error) {
+	fpi, err := app.GetFinalityProviderInstance()
+	if err != nil {
+		return nil, fmt.Errorf("no finality provider is started: %w", err)
+	}
+	if !fpi.IsRunning() {
+		return nil, fmt.Errorf("the finality provider %s is not running", fpi.GetBtcPkHex())
+	}
+
+	return fpi, nil
+}
+
+func (app *FinalityProviderApp) checkInstanceStatus(_ context.Context) error {
+	fpi, err := app.runningInstance()
+	if err != nil {
+		return err
+	}
+
+	switch status := fpi.GetStatus(); status {
+	case proto.FinalityProviderStatus_SLASHED, proto.FinalityProviderStatus_JAILED:
+		return fmt.Errorf("the finality provider %s is %s", fpi.GetBtcPkHex(), status)
+	}
+
+	if degraded := fpi.DegradedComponents(); len(degraded) > 0 {
+		return fmt.Errorf("the finality provider %s is degraded: %s", fpi.GetBtcPkHex(), degradedSummary(degraded))
+	}
+
+	return nil
+}
+
+func (app *FinalityProviderApp) checkPollerLag(ctx context.Context) error {
+	fpi, err := app.runningInstance()
+	if err != nil {
+		return err
+	}
+
+	tip, err := fpi.consumerCon.QueryLatestBlock(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query the latest block: %w", err)
+	}
+
+	// the poller is up to date once the next height is past the tip
+	var lag uint64
+	if next := fpi.poller.NextHeight(); tip.GetHeight() >= next {
+		lag = tip.GetHeight() - next + 1
+	}
+	if maxLag := app.readinessConfig().MaxPollerLag; lag > maxLag {
+		return fmt.Errorf("the poller is %d blocks behind the tip %d, more than %d", lag, tip.GetHeight(), maxLag)
+	}
+
+	return nil
+}
+
+func (app *FinalityProviderApp) checkRandomnessRunway(ctx context.Context) error {
+	fpi, err := app.runningInstance()
+	if err != nil {
+		return err
+	}
+	// a retiring key votes with the randomness it already committed,
+	// while the new key of the rotation commits its own
+	if fpi.IsRetiring() {
+		return nil
+	}
+
+	runway, tipHeight, err := randomnessRunway(ctx, fpi)
+	if err != nil {
+		return err
+	}
+	if minRunway := app.readinessConfig().MinRandomnessRunway; runway < minRunway {
+		return fmt.Errorf("the committed randomness covers %d blocks after the tip %d, less than %d",
+			runway, tipHeight, minRunway)
+	}
+
+	return nil
+}
+
+// randomnessRunway returns the number of blocks after the tip of the chain
+// covered by the committed randomness of the instance, and the tip height
+func randomnessRunway(ctx context.Context, fpi *FinalityProviderInstance) (uint64, uint64, error) {
+	lastCommittedHeight, err := fpi.GetLastCommittedHeight(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	tip, err := fpi.consumerCon.QueryLatestBlock(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to query the latest block: %w", err)
+	}
+
+	if lastCommittedHeight <= tip.GetHeight() {
+		return 0, tip.GetHeight(), nil
+	}
+
+	return lastCommittedHeight - tip.GetHeight(), tip.GetHeight(), nil
+}
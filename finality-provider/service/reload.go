@@ -0,0 +1,81 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+// gasPricesSetter is implemented by the chain clients whose gas prices can
+// be changed while they run
+type gasPricesSetter interface {
+	SetGasPrices(gasPrices string)
+}
+
+// resetOnReload resets the ticker of a loop once its interval was changed
+// by a reload of the config, and returns the interval in use
+func resetOnReload(ticker *time.Ticker, interval, reloaded time.Duration) time.Duration {
+	if reloaded != interval {
+		ticker.Reset(reloaded)
+	}
+
+	return reloaded
+}
+
+// SetConfigLoader sets how the config is read again on a reload, which
+// applies the same overrides as the start of fpd
+func (app *FinalityProviderApp) SetConfigLoader(load func() (*fpcfg.Config, error)) {
+	app.configLoader = load
+}
+
+// ReloadConfig reads the config again and applies the fields which can be
+// changed without a restart to the running finality providers. It fails,
+// applying nothing, if the config is invalid or changes other fields.
+func (app *FinalityProviderApp) ReloadConfig() ([]fpcfg.ConfigChange, error) {
+	if app.configLoader == nil {
+		return nil, fmt.Errorf("the config of this finality provider app cannot be reloaded")
+	}
+
+	newCfg, err := app.configLoader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the config: %w", err)
+	}
+
+	changes, err := app.config.Reload(newCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload the config: %w", err)
+	}
+
+	for _, c := range changes {
+		if c.Field == "babylon.GasPrices" {
+			app.setGasPrices(newCfg.BabylonConfig.GasPrices)
+		}
+		app.logger.Info("applied a change of the config",
+			zap.String("field", c.Field),
+			zap.String("old", c.Old),
+			zap.String("new", c.New))
+	}
+
+	return changes, nil
+}
+
+// setGasPrices sets the gas prices of the chain clients of the app and of
+// the finality provider running side by side during a key rotation
+func (app *FinalityProviderApp) setGasPrices(gasPrices string) {
+	clients := []any{app.cc, app.consumerCon}
+
+	app.fpInsMu.RLock()
+	if app.rotationFpIns != nil {
+		clients = append(clients, app.rotationFpIns.cc, app.rotationFpIns.consumerCon)
+	}
+	app.fpInsMu.RUnlock()
+
+	for _, c := range clients {
+		if s, ok := c.(gasPricesSetter); ok {
+			s.SetGasPrices(gasPrices)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	eotsclient "github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	eotsservice "github.com/babylonlabs-io/finality-provider/eotsmanager/service"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+const (
+	authorizationHeaderKey = "authorization"
+	bearerPrefix           = "Bearer "
+)
+
+// rpcMethodRoles are the roles required to call the RPC methods. The methods
+// which are not listed, such as the ones added later, require the
+// unsafe-admin role.
+var rpcMethodRoles = map[string]string{
+	proto.FinalityProviders_GetInfo_FullMethodName:                   fpcfg.RPCRoleReadOnly,
+	proto.FinalityProviders_QueryFinalityProvider_FullMethodName:     fpcfg.RPCRoleReadOnly,
+	proto.FinalityProviders_QueryFinalityProviderList_FullMethodName: fpcfg.RPCRoleReadOnly,
+	proto.FinalityProviders_QueryKeyRotationList_FullMethodName:      fpcfg.RPCRoleReadOnly,
+	proto.FinalityProviders_CreateFinalityProvider_FullMethodName:    fpcfg.RPCRoleOperator,
+	proto.FinalityProviders_UnjailFinalityProvider_FullMethodName:    fpcfg.RPCRoleOperator,
+	proto.FinalityProviders_EditFinalityProvider_FullMethodName:      fpcfg.RPCRoleOperator,
+	proto.FinalityProviders_Backup_FullMethodName:                    fpcfg.RPCRoleOperator,
+	proto.FinalityProviders_StartKeyRotation_FullMethodName:          fpcfg.RPCRoleOperator,
+	proto.FinalityProviders_RetireKey_FullMethodName:                 fpcfg.RPCRoleOperator,
+	proto.FinalityProviders_ReloadConfig_FullMethodName:              fpcfg.RPCRoleOperator,
+	// a manual vote can sign a fork and returns the extracted key if it does
+	proto.FinalityProviders_AddFinalitySignature_FullMethodName:    fpcfg.RPCRoleUnsafeAdmin,
+	proto.FinalityProviders_UnsafeRemoveMerkleProof_FullMethodName: fpcfg.RPCRoleUnsafeAdmin,
+}
+
+// requiredRPCRole returns the role required to call the method
+func requiredRPCRole(fullMethod string) string {
+	if role, ok := rpcMethodRoles[fullMethod]; ok {
+		return role
+	}
+
+	return fpcfg.RPCRoleUnsafeAdmin
+}
+
+// roleRank orders the roles, a role granting the methods of the lower ones.
+// The empty role has no rank.
+func roleRank(role string) int {
+	return slices.Index(fpcfg.RPCRoles, role)
+}
+
+type bearerToken struct {
+	name string
+	role string
+	hash [sha256.Size]byte
+}
+
+// rpcAuth authenticates the clients of the RPC server and checks that their
+// role allows the methods they call
+type rpcAuth struct {
+	logger *zap.Logger
+
+	// hmac verifies the HMAC of the requests, and is nil if no HMAC key is set
+	hmac      grpc.UnaryServerInterceptor
+	hmacRoles map[string]string
+	tokens    []bearerToken
+	certRoles map[string]string
+	nameRoles map[string]string
+
+	anonymousRole string
+}
+
+// NewRPCAuthUnaryServerInterceptor creates a gRPC server interceptor which
+// authenticates the clients with the credentials of the config, and denies
+// the methods their role does not allow. A client presenting several
+// credentials gets the highest of their roles, and any invalid credential
+// rejects the request.
+func NewRPCAuthUnaryServerInterceptor(cfg *fpcfg.RPCAuthConfig, logger *zap.Logger) (grpc.UnaryServerInterceptor, error) {
+	a := &rpcAuth{
+		logger:        logger,
+		hmacRoles:     make(map[string]string),
+		certRoles:     make(map[string]string),
+		nameRoles:     make(map[string]string),
+		anonymousRole: cfg.AnonymousRole,
+	}
+
+	hmacCreds, err := cfg.HMACKeyCredentials()
+	if err != nil {
+		return nil, err
+	}
+	if len(hmacCreds) > 0 {
+		keys := make([]*eotsservice.HMACKey, 0, len(hmacCreds))
+		for _, c := range hmacCreds {
+			secret, err := eotsclient.ProcessHMACKey(c.Secret)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the HMAC key %s: %w", c.Name, err)
+			}
+			keys = append(keys, &eotsservice.HMACKey{ID: c.Name, Secret: secret})
+			a.hmacRoles[c.Name] = c.Role
+		}
+		a.hmac = eotsservice.HMACKeyRingUnaryServerInterceptor(eotsservice.NewHMACKeyRing(keys...))
+	}
+
+	tokenCreds, err := cfg.TokenCredentials()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range tokenCreds {
+		token, err := eotsclient.GetSecretValue(c.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the token %s: %w", c.Name, err)
+		}
+		a.tokens = append(a.tokens, bearerToken{name: c.Name, role: c.Role, hash: sha256.Sum256([]byte(token))})
+	}
+
+	certCreds, err := cfg.ClientCertCredentials()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range certCreds {
+		a.certRoles[c.Name] = c.Role
+	}
+
+	nameCreds, err := cfg.ClientNameCredentials()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range nameCreds {
+		a.nameRoles[c.Name] = c.Role
+	}
+
+	return a.intercept, nil
+}
+
+// caller is the identity of a client and the highest role of its credentials
+type caller struct {
+	ids  []string
+	role string
+}
+
+func (c *caller) add(id, role string) {
+	c.ids = append(c.ids, id)
+	if roleRank(role) > roleRank(c.role) {
+		c.role = role
+	}
+}
+
+func (c *caller) String() string {
+	if len(c.ids) == 0 {
+		return "anonymous"
+	}
+
+	return strings.Join(c.ids, ", ")
+}
+
+func (a *rpcAuth) intercept(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	var c caller
+
+	if cert := verifiedPeerCert(ctx); cert != nil {
+		fingerprint := util.CertFingerprint(cert.Raw)
+		if role, ok := a.certRoles[fingerprint]; ok {
+			c.add(fmt.Sprintf("cert sha256:%s", fingerprint), role)
+		}
+		if role, ok := a.nameRoles[cert.Subject.CommonName]; ok {
+			c.add(fmt.Sprintf("cert %s", cert.Subject.CommonName), role)
+		}
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(authorizationHeaderKey); len(values) > 0 {
+		token, ok := a.matchBearerToken(values[0])
+		if !ok {
+			return nil, a.deny(codes.Unauthenticated, info.FullMethod, &c, "invalid bearer token")
+		}
+		c.add(fmt.Sprintf("token %s", token.name), token.role)
+	}
+
+	if len(md.Get(eotsclient.HMACHeaderKey)) == 0 {
+		return a.authorize(ctx, req, info, handler, &c)
+	}
+	if a.hmac == nil {
+		return nil, a.deny(codes.Unauthenticated, info.FullMethod, &c, "HMAC authentication is not enabled")
+	}
+
+	return a.hmac(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		keyID := eotsservice.HMACKeyIDFromContext(ctx)
+		c.add(fmt.Sprintf("hmac key %s", keyID), a.hmacRoles[keyID])
+
+		return a.authorize(ctx, req, info, handler, &c)
+	})
+}
+
+// matchBearerToken returns the token of the authorization header, comparing it
+// with all the tokens in constant time
+func (a *rpcAuth) matchBearerToken(header string) (*bearerToken, bool) {
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return nil, false
+	}
+	hash := sha256.Sum256([]byte(strings.TrimPrefix(header, bearerPrefix)))
+
+	var found *bearerToken
+	for i := range a.tokens {
+		if subtle.ConstantTimeCompare(hash[:], a.tokens[i].hash[:]) == 1 {
+			found = &a.tokens[i]
+		}
+	}
+
+	return found, found != nil
+}
+
+func (a *rpcAuth) authorize(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+	c *caller,
+) (interface{}, error) {
+	role := c.role
+	if role == "" {
+		role = a.anonymousRole
+	}
+	if role == "" {
+		return nil, a.deny(codes.Unauthenticated, info.FullMethod, c, "credentials are required")
+	}
+
+	required := requiredRPCRole(info.FullMethod)
+	if roleRank(role) < roleRank(required) {
+		return nil, a.deny(codes.PermissionDenied, info.FullMethod, c,
+			fmt.Sprintf("%s requires the %s role, the caller has the %s role", info.FullMethod, required, role))
+	}
+
+	return handler(withRPCCaller(ctx, c), req)
+}
+
+func (a *rpcAuth) deny(code codes.Code, method string, c *caller, msg string) error {
+	a.logger.Warn("RPC request denied",
+		zap.String("method", method),
+		zap.String("caller", c.String()),
+		zap.String("reason", msg),
+	)
+
+	return status.Error(code, msg)
+}
+
+// verifiedPeerCert returns the client certificate verified by the TLS
+// handshake, if any
+func verifiedPeerCert(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	return tlsInfo.State.VerifiedChains[0][0]
+}
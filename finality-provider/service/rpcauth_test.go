@@ -0,0 +1,159 @@
+package service_test
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"testing"
+
+	"github.com/babylonlabs-io/babylon/v4/testutil/datagen"
+	bbntypes "github.com/babylonlabs-io/babylon/v4/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+)
+
+type stubFinalityProvidersServer struct {
+	proto.UnimplementedFinalityProvidersServer
+}
+
+func (stubFinalityProvidersServer) GetInfo(context.Context, *proto.GetInfoRequest) (*proto.GetInfoResponse, error) {
+	return &proto.GetInfoResponse{Version: "test"}, nil
+}
+
+func (stubFinalityProvidersServer) Backup(context.Context, *proto.FpdBackupRequest) (*proto.FpdBackupResponse, error) {
+	return &proto.FpdBackupResponse{BackupName: "backup"}, nil
+}
+
+func (stubFinalityProvidersServer) UnsafeRemoveMerkleProof(context.Context, *proto.RemoveMerkleProofRequest) (*proto.EmptyResponse, error) {
+	return &proto.EmptyResponse{}, nil
+}
+
+// startAuthServer starts an RPC server authenticating the clients with cfg
+// and returns its address
+func startAuthServer(t *testing.T, cfg *fpcfg.RPCAuthConfig) string {
+	t.Helper()
+
+	auth, err := service.NewRPCAuthUnaryServerInterceptor(cfg, zap.NewNop())
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(auth))
+	proto.RegisterFinalityProvidersServer(grpcServer, stubFinalityProvidersServer{})
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	return lis.Addr().String()
+}
+
+func newAuthClient(t *testing.T, addr string, opts ...grpc.DialOption) *dc.FinalityProviderServiceGRpcClient {
+	t.Helper()
+
+	c, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(addr, opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = cleanUp()
+	})
+
+	return c
+}
+
+func TestRPCAuth(t *testing.T) {
+	t.Parallel()
+
+	addr := startAuthServer(t, &fpcfg.RPCAuthConfig{
+		HMACKeys: []string{"operator:ops:ops-hmac-key"},
+		Tokens:   []string{"read-only:monitoring:monitoring-token", "unsafe-admin:admin:admin-token"},
+	})
+	ctx := t.Context()
+	_, btcPk, err := datagen.GenRandomBTCKeyPair(rand.New(rand.NewSource(1)))
+	require.NoError(t, err)
+	fpPk := bbntypes.NewBIP340PubKeyFromBTCPK(btcPk)
+
+	requireCode := func(t *testing.T, code codes.Code, err error) {
+		t.Helper()
+		require.Error(t, err)
+		require.Equal(t, code, status.Code(err), err.Error())
+	}
+
+	t.Run("anonymous", func(t *testing.T) {
+		t.Parallel()
+		c := newAuthClient(t, addr)
+
+		_, err := c.GetInfo(ctx)
+		requireCode(t, codes.Unauthenticated, err)
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newAuthClient(t, addr, dc.WithBearerToken("wrong-token")).GetInfo(ctx)
+		requireCode(t, codes.Unauthenticated, err)
+
+		_, err = newAuthClient(t, addr, dc.WithHMACKey("wrong-key", "ops")...).GetInfo(ctx)
+		requireCode(t, codes.Unauthenticated, err)
+
+		_, err = newAuthClient(t, addr, dc.WithHMACKey("ops-hmac-key", "unknown")...).GetInfo(ctx)
+		requireCode(t, codes.Unauthenticated, err)
+	})
+
+	t.Run("read-only", func(t *testing.T) {
+		t.Parallel()
+		c := newAuthClient(t, addr, dc.WithBearerToken("monitoring-token"))
+
+		_, err := c.GetInfo(ctx)
+		require.NoError(t, err)
+
+		_, err = c.Backup(ctx, "db", "backups")
+		requireCode(t, codes.PermissionDenied, err)
+	})
+
+	t.Run("operator", func(t *testing.T) {
+		t.Parallel()
+		c := newAuthClient(t, addr, dc.WithHMACKey("ops-hmac-key", "ops")...)
+
+		_, err := c.GetInfo(ctx)
+		require.NoError(t, err)
+
+		_, err = c.Backup(ctx, "db", "backups")
+		require.NoError(t, err)
+
+		err = c.UnsafeRemoveMerkleProof(ctx, fpPk, "chain", 100)
+		requireCode(t, codes.PermissionDenied, err)
+	})
+
+	t.Run("highest role of the credentials", func(t *testing.T) {
+		t.Parallel()
+		opts := append(dc.WithHMACKey("ops-hmac-key", "ops"), dc.WithBearerToken("admin-token"))
+		c := newAuthClient(t, addr, opts...)
+
+		err := c.UnsafeRemoveMerkleProof(ctx, fpPk, "chain", 100)
+		require.NoError(t, err)
+	})
+
+	t.Run("anonymous role", func(t *testing.T) {
+		t.Parallel()
+		addr := startAuthServer(t, &fpcfg.RPCAuthConfig{
+			Tokens:        []string{"operator:ops:ops-token"},
+			AnonymousRole: fpcfg.RPCRoleReadOnly,
+		})
+		c := newAuthClient(t, addr)
+
+		_, err := c.GetInfo(ctx)
+		require.NoError(t, err)
+
+		_, err = c.Backup(ctx, "db", "backups")
+		requireCode(t, codes.PermissionDenied, err)
+	})
+}
@@ -280,6 +280,82 @@ func (r *rpcServer) Backup(_ context.Context, req *proto.FpdBackupRequest) (*pro
 	}, nil
 }
 
+// StartKeyRotation - registers a new finality provider with a fresh EOTS key
+// and runs it side by side with the rotated one
+func (r *rpcServer) StartKeyRotation(ctx context.Context, req *proto.StartKeyRotationRequest) (*proto.KeyRotationResponse, error) {
+	oldPk, err := parseEotsPk(req.OldEotsPkHex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the rotated EOTS public key: %w", err)
+	}
+
+	newPk, err := parseEotsPk(req.NewEotsPkHex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the new EOTS public key: %w", err)
+	}
+
+	if req.KeyName == "" {
+		return nil, fmt.Errorf("key name cannot be empty")
+	}
+
+	rotation, txHash, err := r.app.StartKeyRotation(ctx, oldPk, newPk, req.KeyName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start key rotation: %w", err)
+	}
+
+	return newKeyRotationResponse(rotation, txHash)
+}
+
+// RetireKey - stops committing randomness for the rotated EOTS key
+func (r *rpcServer) RetireKey(ctx context.Context, req *proto.RetireKeyRequest) (*proto.KeyRotationResponse, error) {
+	oldPk, err := parseEotsPk(req.OldEotsPkHex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the rotated EOTS public key: %w", err)
+	}
+
+	rotation, err := r.app.RetireKey(ctx, oldPk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retire key: %w", err)
+	}
+
+	return newKeyRotationResponse(rotation, "")
+}
+
+// QueryKeyRotationList - returns the key rotations tracked by the daemon
+func (r *rpcServer) QueryKeyRotationList(_ context.Context, _ *proto.QueryKeyRotationListRequest) (
+	*proto.QueryKeyRotationListResponse, error) {
+	rotations, err := r.app.ListKeyRotations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list key rotations: %w", err)
+	}
+
+	return &proto.QueryKeyRotationListResponse{KeyRotations: rotations}, nil
+}
+
+// ReloadConfig - reads the config again and applies the fields which can
+// be changed without a restart
+func (r *rpcServer) ReloadConfig(_ context.Context, _ *proto.ReloadConfigRequest) (*proto.ReloadConfigResponse, error) {
+	changes, err := r.app.ReloadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &proto.ReloadConfigResponse{Changes: make([]string, 0, len(changes))}
+	for _, c := range changes {
+		res.Changes = append(res.Changes, c.String())
+	}
+
+	return res, nil
+}
+
+func newKeyRotationResponse(rotation *proto.KeyRotation, txHash string) (*proto.KeyRotationResponse, error) {
+	info, err := proto.NewKeyRotationInfo(rotation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert key rotation: %w", err)
+	}
+
+	return &proto.KeyRotationResponse{KeyRotation: info, TxHash: txHash}, nil
+}
+
 func parseEotsPk(eotsPkHex string) (*bbntypes.BIP340PubKey, error) {
 	if eotsPkHex == "" {
 		return nil, fmt.Errorf("eots-pk cannot be empty")
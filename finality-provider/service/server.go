@@ -10,9 +10,11 @@ import (
 	"github.com/lightningnetwork/lnd/kvdb"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 )
 
 // Server is the main daemon construct for the Finality Provider server. It handles
@@ -53,7 +55,7 @@ func (s *Server) RunUntilShutdown(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to get prometheus address: %w", err)
 	}
-	metricsServer := metrics.Start(promAddr, s.logger)
+	metricsServer := metrics.Start(promAddr, s.logger, s.rpcServer.app.ReadinessChecks()...)
 
 	defer func() {
 		s.logger.Info("Shutdown complete")
@@ -81,7 +83,30 @@ func (s *Server) RunUntilShutdown(ctx context.Context) error {
 		_ = lis.Close()
 	}()
 
-	grpcServer := grpc.NewServer()
+	var opts []grpc.ServerOption
+	if s.cfg.RPCTLS.IsEnabled() {
+		tlsCfg, err := s.cfg.RPCTLS.ServerTLSConfig()
+		if err != nil {
+			return err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+		s.logger.Info("TLS enabled for gRPC server", zap.Bool("client_auth", s.cfg.RPCTLS.ClientCAFile != ""))
+	}
+
+	if s.cfg.RPCAuth.IsEnabled() {
+		auth, err := NewRPCAuthUnaryServerInterceptor(s.cfg.RPCAuth, s.logger)
+		if err != nil {
+			return fmt.Errorf("failed to set up RPC authentication: %w", err)
+		}
+		opts = append(opts, grpc.ChainUnaryInterceptor(auth))
+		s.logger.Info("authentication enabled for gRPC server", zap.String("anonymous_role", s.cfg.RPCAuth.AnonymousRole))
+	}
+	opts = append(opts, grpc.ChainUnaryInterceptor(NewUnsafeEndpointsUnaryServerInterceptor(s.cfg, s.logger)))
+	if s.cfg.Tracing.IsEnabled() {
+		opts = append(opts, tracing.ServerOption())
+	}
+
+	grpcServer := grpc.NewServer(opts...)
 	defer grpcServer.Stop()
 
 	if err := s.rpcServer.RegisterWithGrpcServer(grpcServer); err != nil {
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// unsafeRPCMethods are the RPC methods which bypass the safety checks of
+// the finality provider: a manual vote can sign a fork at a mistyped height
+// and expose the EOTS key, and removing Merkle proofs can prevent voting
+var unsafeRPCMethods = map[string]bool{
+	proto.FinalityProviders_AddFinalitySignature_FullMethodName:    true,
+	proto.FinalityProviders_UnsafeRemoveMerkleProof_FullMethodName: true,
+}
+
+// unsafeEndpointsGuard refuses the unsafe RPC methods, unless they are
+// enabled in the config and their TTL has not expired, and records every
+// use of them in the log
+type unsafeEndpointsGuard struct {
+	logger *zap.Logger
+	// expiry is the time after which the unsafe methods are refused, it
+	// is zero if they are disabled
+	expiry time.Time
+}
+
+// NewUnsafeEndpointsUnaryServerInterceptor creates a gRPC server interceptor
+// which refuses the unsafe RPC methods unless the config enables them. They
+// are then served until the TTL of the config elapses from now.
+func NewUnsafeEndpointsUnaryServerInterceptor(cfg *fpcfg.Config, logger *zap.Logger) grpc.UnaryServerInterceptor {
+	g := &unsafeEndpointsGuard{logger: logger}
+	if !cfg.IsUnsafeEndpointsDisabled() {
+		g.expiry = time.Now().Add(cfg.UnsafeEndpointsTTL)
+		logger.Warn("UNSAFE RPC endpoints are enabled, they can sign arbitrary heights and expose the EOTS key",
+			zap.Time("expiry", g.expiry))
+	}
+
+	return g.intercept
+}
+
+func (g *unsafeEndpointsGuard) intercept(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if !unsafeRPCMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	fields := append([]zap.Field{
+		zap.String("method", info.FullMethod),
+		zap.String("caller", rpcCallerFromContext(ctx)),
+	}, unsafeRequestFields(req)...)
+
+	if g.expiry.IsZero() {
+		g.logger.Warn("AUDIT: refused unsafe RPC endpoint, it is disabled in the config", fields...)
+
+		return nil, status.Error(codes.PermissionDenied, //nolint:wrapcheck
+			fmt.Sprintf("%s is disabled in the configuration for security reasons", info.FullMethod))
+	}
+	if !time.Now().Before(g.expiry) {
+		g.logger.Warn("AUDIT: refused unsafe RPC endpoint, it expired",
+			append(fields, zap.Time("expiry", g.expiry))...)
+
+		return nil, status.Error(codes.PermissionDenied, //nolint:wrapcheck
+			fmt.Sprintf("%s was enabled until %s, restart fpd to enable it again", info.FullMethod, g.expiry.Format(time.RFC3339)))
+	}
+
+	g.logger.Error("AUDIT: UNSAFE RPC endpoint called, it bypasses the safety checks of the finality provider",
+		append(fields, zap.Time("expiry", g.expiry))...)
+
+	res, err := handler(ctx, req)
+	if err != nil {
+		g.logger.Warn("AUDIT: UNSAFE RPC endpoint failed", append(fields, zap.Error(err))...)
+
+		return nil, err
+	}
+	g.logger.Warn("AUDIT: UNSAFE RPC endpoint succeeded", fields...)
+
+	return res, nil
+}
+
+// unsafeRequestFields returns the log fields of the target of an unsafe request
+func unsafeRequestFields(req interface{}) []zap.Field {
+	switch r := req.(type) {
+	case *proto.AddFinalitySignatureRequest:
+		return []zap.Field{
+			zap.String("fp_btc_pk", r.BtcPk),
+			zap.Uint64("height", r.Height),
+			zap.Bool("check_double_sign", r.CheckDoubleSign),
+		}
+	case *proto.RemoveMerkleProofRequest:
+		return []zap.Field{
+			zap.String("fp_btc_pk", r.BtcPkHex),
+			zap.String("chain_id", r.ChainId),
+			zap.Uint64("target_height", r.TargetHeight),
+		}
+	default:
+		return nil
+	}
+}
+
+type rpcCallerKey struct{}
+
+// withRPCCaller records the authenticated caller of a request in its context
+func withRPCCaller(ctx context.Context, c *caller) context.Context {
+	return context.WithValue(ctx, rpcCallerKey{}, c.String())
+}
+
+// rpcCallerFromContext returns the authenticated caller of a request, or
+// its peer address if the RPC server does not authenticate the clients
+func rpcCallerFromContext(ctx context.Context) string {
+	var name string
+	if c, ok := ctx.Value(rpcCallerKey{}).(string); ok {
+		name = c
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return name
+	}
+	if name == "" {
+		return p.Addr.String()
+	}
+
+	return fmt.Sprintf("%s (%s)", name, p.Addr.String())
+}
@@ -0,0 +1,91 @@
+package service_test
+
+import (
+	"math/rand"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/babylon/v4/testutil/datagen"
+	bbntypes "github.com/babylonlabs-io/babylon/v4/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+)
+
+// startGuardedServer starts an RPC server guarding the unsafe endpoints
+// with cfg and returns its address
+func startGuardedServer(t *testing.T, cfg *fpcfg.Config) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		service.NewUnsafeEndpointsUnaryServerInterceptor(cfg, zap.NewNop())))
+	proto.RegisterFinalityProvidersServer(grpcServer, stubFinalityProvidersServer{})
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	return lis.Addr().String()
+}
+
+func TestUnsafeEndpoints(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	_, btcPk, err := datagen.GenRandomBTCKeyPair(rand.New(rand.NewSource(1)))
+	require.NoError(t, err)
+	fpPk := bbntypes.NewBIP340PubKeyFromBTCPK(btcPk)
+
+	t.Run("disabled by default", func(t *testing.T) {
+		t.Parallel()
+		cfg := fpcfg.DefaultConfigWithHome(t.TempDir())
+		c := newAuthClient(t, startGuardedServer(t, &cfg))
+
+		_, err := c.GetInfo(ctx)
+		require.NoError(t, err)
+
+		err = c.UnsafeRemoveMerkleProof(ctx, fpPk, "chain", 100)
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("enabled until the expiry", func(t *testing.T) {
+		t.Parallel()
+		cfg := fpcfg.DefaultConfigWithHome(t.TempDir())
+		cfg.EnableUnsafeEndpoints(time.Second)
+		require.NoError(t, cfg.Validate())
+		c := newAuthClient(t, startGuardedServer(t, &cfg))
+
+		err := c.UnsafeRemoveMerkleProof(ctx, fpPk, "chain", 100)
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			err := c.UnsafeRemoveMerkleProof(ctx, fpPk, "chain", 100)
+
+			return status.Code(err) == codes.PermissionDenied
+		}, 5*time.Second, 100*time.Millisecond)
+
+		_, err = c.GetInfo(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("bounded TTL", func(t *testing.T) {
+		t.Parallel()
+		cfg := fpcfg.DefaultConfigWithHome(t.TempDir())
+
+		cfg.EnableUnsafeEndpoints(0)
+		require.Error(t, cfg.Validate())
+
+		cfg.EnableUnsafeEndpoints(fpcfg.MaxUnsafeEndpointsTTL + time.Second)
+		require.Error(t, cfg.Validate())
+	})
+}
@@ -17,4 +17,13 @@ var (
 
 	// ErrPubRandProofNotFound The finality provider we try update is not found in db
 	ErrPubRandProofNotFound = errors.New("public randomness proof not found")
+
+	// ErrKeyRotationNotFound The key rotation we try to update is not found in db
+	ErrKeyRotationNotFound = errors.New("key rotation not found")
+
+	// ErrDuplicateKeyRotation The EOTS key we try to rotate is already being rotated
+	ErrDuplicateKeyRotation = errors.New("key rotation already exists")
+
+	// ErrInvalidKeyRotationPhase The key rotation cannot move to the requested phase
+	ErrInvalidKeyRotationPhase = errors.New("invalid key rotation phase transition")
 )
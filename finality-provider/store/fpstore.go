@@ -21,6 +21,9 @@ import (
 var (
 	// mapping pk -> proto.FinalityProvider
 	finalityProviderBucketName = []byte("finalityProviders")
+
+	// mapping old pk -> proto.KeyRotation
+	keyRotationBucketName = []byte("keyRotations")
 )
 
 type FinalityProviderStore struct {
@@ -44,6 +47,11 @@ func (s *FinalityProviderStore) initBuckets() error {
 			return fmt.Errorf("failed to create finality provider bucket: %w", err)
 		}
 
+		_, err = tx.CreateTopLevelBucket(keyRotationBucketName)
+		if err != nil {
+			return fmt.Errorf("failed to create key rotation bucket: %w", err)
+		}
+
 		return nil
 	}); err != nil {
 		return fmt.Errorf("failed to initialize finality provider bucket: %w", err)
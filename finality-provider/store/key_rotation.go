@@ -0,0 +1,162 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/lightningnetwork/lnd/kvdb"
+	pm "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// CreateKeyRotation records the start of the rotation of the EOTS key oldPk
+// to newPk in the PARALLEL phase
+func (s *FinalityProviderStore) CreateKeyRotation(
+	oldPk, newPk *btcec.PublicKey,
+	newKeyName string,
+) error {
+	kr := &proto.KeyRotation{
+		OldBtcPk:   schnorr.SerializePubKey(oldPk),
+		NewBtcPk:   schnorr.SerializePubKey(newPk),
+		NewKeyName: newKeyName,
+		Phase:      proto.KeyRotationPhase_PARALLEL,
+		UpdateTime: timestamppb.New(time.Now().UTC()),
+	}
+
+	if err := kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		krBucket := tx.ReadWriteBucket(keyRotationBucketName)
+		if krBucket == nil {
+			return ErrCorruptedFinalityProviderDB
+		}
+
+		if krBucket.Get(kr.OldBtcPk) != nil {
+			return ErrDuplicateKeyRotation
+		}
+
+		return saveKeyRotation(krBucket, kr)
+	}); err != nil {
+		return fmt.Errorf("failed to create key rotation: %w", err)
+	}
+
+	return nil
+}
+
+func saveKeyRotation(
+	krBucket walletdb.ReadWriteBucket,
+	kr *proto.KeyRotation,
+) error {
+	marshalled, err := pm.Marshal(kr)
+	if err != nil {
+		return fmt.Errorf("failed to marshal key rotation: %w", err)
+	}
+
+	if err := krBucket.Put(kr.OldBtcPk, marshalled); err != nil {
+		return fmt.Errorf("failed to store key rotation: %w", err)
+	}
+
+	return nil
+}
+
+// SetKeyRotationPhase moves the rotation of the EOTS key oldPk to the next phase.
+// The retire height is only updated when moving to RETIRING.
+func (s *FinalityProviderStore) SetKeyRotationPhase(
+	oldPk *btcec.PublicKey,
+	phase proto.KeyRotationPhase,
+	retireHeight uint64,
+) error {
+	pkBytes := schnorr.SerializePubKey(oldPk)
+
+	if err := kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		krBucket := tx.ReadWriteBucket(keyRotationBucketName)
+		if krBucket == nil {
+			return ErrCorruptedFinalityProviderDB
+		}
+
+		krBytes := krBucket.Get(pkBytes)
+		if krBytes == nil {
+			return ErrKeyRotationNotFound
+		}
+
+		var kr proto.KeyRotation
+		if err := pm.Unmarshal(krBytes, &kr); err != nil {
+			return ErrCorruptedFinalityProviderDB
+		}
+
+		if phase != kr.Phase+1 {
+			return fmt.Errorf("%w: from %s to %s", ErrInvalidKeyRotationPhase, kr.Phase, phase)
+		}
+
+		kr.Phase = phase
+		if phase == proto.KeyRotationPhase_RETIRING {
+			kr.RetireHeight = retireHeight
+		}
+		kr.UpdateTime = timestamppb.New(time.Now().UTC())
+
+		return saveKeyRotation(krBucket, &kr)
+	}); err != nil {
+		return fmt.Errorf("failed to set key rotation phase: %w", err)
+	}
+
+	return nil
+}
+
+// GetKeyRotation returns the rotation of the EOTS key oldPk
+func (s *FinalityProviderStore) GetKeyRotation(oldPk *btcec.PublicKey) (*proto.KeyRotation, error) {
+	var kr *proto.KeyRotation
+	pkBytes := schnorr.SerializePubKey(oldPk)
+
+	if err := s.db.View(func(tx kvdb.RTx) error {
+		krBucket := tx.ReadBucket(keyRotationBucketName)
+		if krBucket == nil {
+			return ErrCorruptedFinalityProviderDB
+		}
+
+		krBytes := krBucket.Get(pkBytes)
+		if krBytes == nil {
+			return ErrKeyRotationNotFound
+		}
+
+		var krProto proto.KeyRotation
+		if err := pm.Unmarshal(krBytes, &krProto); err != nil {
+			return ErrCorruptedFinalityProviderDB
+		}
+		kr = &krProto
+
+		return nil
+	}, func() {}); err != nil {
+		return nil, fmt.Errorf("failed to get key rotation: %w", err)
+	}
+
+	return kr, nil
+}
+
+// GetAllKeyRotations fetches all the stored key rotations from db
+func (s *FinalityProviderStore) GetAllKeyRotations() ([]*proto.KeyRotation, error) {
+	var krs []*proto.KeyRotation
+
+	if err := s.db.View(func(tx kvdb.RTx) error {
+		krBucket := tx.ReadBucket(keyRotationBucketName)
+		if krBucket == nil {
+			return ErrCorruptedFinalityProviderDB
+		}
+
+		return krBucket.ForEach(func(_, v []byte) error {
+			var krProto proto.KeyRotation
+			if err := pm.Unmarshal(v, &krProto); err != nil {
+				return ErrCorruptedFinalityProviderDB
+			}
+			krs = append(krs, &krProto)
+
+			return nil
+		})
+	}, func() {}); err != nil {
+		return nil, fmt.Errorf("failed to get all key rotations: %w", err)
+	}
+
+	return krs, nil
+}
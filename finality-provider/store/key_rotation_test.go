@@ -0,0 +1,78 @@
+package store_test
+
+import (
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/babylonlabs-io/babylon/v4/testutil/datagen"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// FuzzKeyRotationStore tests the key rotation moves through its phases in order
+func FuzzKeyRotationStore(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		r := rand.New(rand.NewSource(seed))
+
+		homePath := t.TempDir()
+		cfg := config.DefaultDBConfigWithHomePath(homePath)
+
+		fpdb, err := cfg.GetDBBackend()
+		require.NoError(t, err)
+		vs, err := fpstore.NewFinalityProviderStore(fpdb)
+		require.NoError(t, err)
+
+		defer func() {
+			err := fpdb.Close()
+			require.NoError(t, err)
+			err = os.RemoveAll(homePath)
+			require.NoError(t, err)
+		}()
+
+		_, oldPk, err := datagen.GenRandomBTCKeyPair(r)
+		require.NoError(t, err)
+		_, newPk, err := datagen.GenRandomBTCKeyPair(r)
+		require.NoError(t, err)
+
+		_, err = vs.GetKeyRotation(oldPk)
+		require.ErrorIs(t, err, fpstore.ErrKeyRotationNotFound)
+
+		keyName := testutil.GenRandomHexStr(r, 4)
+		err = vs.CreateKeyRotation(oldPk, newPk, keyName)
+		require.NoError(t, err)
+
+		err = vs.CreateKeyRotation(oldPk, newPk, keyName)
+		require.ErrorIs(t, err, fpstore.ErrDuplicateKeyRotation)
+
+		kr, err := vs.GetKeyRotation(oldPk)
+		require.NoError(t, err)
+		require.Equal(t, schnorr.SerializePubKey(newPk), kr.NewBtcPk)
+		require.Equal(t, keyName, kr.NewKeyName)
+		require.Equal(t, proto.KeyRotationPhase_PARALLEL, kr.Phase)
+
+		// phases cannot be skipped
+		err = vs.SetKeyRotationPhase(oldPk, proto.KeyRotationPhase_RETIRED, 0)
+		require.ErrorIs(t, err, fpstore.ErrInvalidKeyRotationPhase)
+
+		retireHeight := uint64(r.Int63n(1000) + 1)
+		err = vs.SetKeyRotationPhase(oldPk, proto.KeyRotationPhase_RETIRING, retireHeight)
+		require.NoError(t, err)
+
+		err = vs.SetKeyRotationPhase(oldPk, proto.KeyRotationPhase_RETIRED, 0)
+		require.NoError(t, err)
+
+		krs, err := vs.GetAllKeyRotations()
+		require.NoError(t, err)
+		require.Len(t, krs, 1)
+		require.Equal(t, proto.KeyRotationPhase_RETIRED, krs[0].Phase)
+		require.Equal(t, retireHeight, krs[0].RetireHeight)
+	})
+}
@@ -262,6 +262,8 @@ func (tm *TestManager) AddFinalityProvider(t *testing.T, ctx context.Context, hm
 
 	cfg.RPCListener = fmt.Sprintf("127.0.0.1:%d", testutil.AllocateUniquePort(t))
 	cfg.Metrics.Port = testutil.AllocateUniquePort(t)
+	// the e2e tests of the cmds call the unsafe endpoints of fpd
+	cfg.EnableUnsafeEndpoints(time.Hour)
 
 	err = fpApp.StartFinalityProvider(ctx, eotsPk)
 	require.NoError(t, err)
@@ -13,6 +13,9 @@ type EotsMetrics struct {
 	EotsFpTotalEotsSignCounter            *prometheus.CounterVec
 	EotsFpLastEotsSignHeight              *prometheus.GaugeVec
 	EotsFpTotalSchnorrSignCounter         *prometheus.CounterVec
+	EotsPolicyDeniedCounter               *prometheus.CounterVec
+	EotsSigningGuardRejectedCounter       *prometheus.CounterVec
+	EotsNewChainIDCounter                 *prometheus.CounterVec
 }
 
 var eotsMetricsRegisterOnce sync.Once
@@ -61,6 +64,27 @@ func NewEotsMetrics() *EotsMetrics {
 				},
 				[]string{"fp_btc_pk_hex"},
 			),
+			EotsPolicyDeniedCounter: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "eots_policy_denied_counter",
+					Help: "Total number of requests denied by the authorization policy",
+				},
+				[]string{"method", "reason"},
+			),
+			EotsSigningGuardRejectedCounter: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "eots_signing_guard_rejected_counter",
+					Help: "Total number of signing requests rejected by the signing guards",
+				},
+				[]string{"fp_btc_pk_hex", "reason"},
+			),
+			EotsNewChainIDCounter: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "eots_new_chain_id_counter",
+					Help: "Total number of chain IDs requested for the first time with an EOTS key",
+				},
+				[]string{"fp_btc_pk_hex", "chain_id"},
+			),
 		}
 
 		// Register the EOTS metrics with Prometheus
@@ -70,6 +94,9 @@ func NewEotsMetrics() *EotsMetrics {
 		prometheus.MustRegister(eotsMetricsInstance.EotsFpTotalEotsSignCounter)
 		prometheus.MustRegister(eotsMetricsInstance.EotsFpLastEotsSignHeight)
 		prometheus.MustRegister(eotsMetricsInstance.EotsFpTotalSchnorrSignCounter)
+		prometheus.MustRegister(eotsMetricsInstance.EotsPolicyDeniedCounter)
+		prometheus.MustRegister(eotsMetricsInstance.EotsSigningGuardRejectedCounter)
+		prometheus.MustRegister(eotsMetricsInstance.EotsNewChainIDCounter)
 	})
 
 	return eotsMetricsInstance
@@ -104,3 +131,18 @@ func (em *EotsMetrics) SetEotsFpLastEotsSignHeight(fpBtcPkHex string, height flo
 func (em *EotsMetrics) IncrementEotsFpTotalSchnorrSignCounter(fpBtcPkHex string) {
 	em.EotsFpTotalSchnorrSignCounter.WithLabelValues(fpBtcPkHex).Inc()
 }
+
+// IncrementEotsPolicyDeniedCounter increments the counter of the requests denied by the policy
+func (em *EotsMetrics) IncrementEotsPolicyDeniedCounter(method, reason string) {
+	em.EotsPolicyDeniedCounter.WithLabelValues(method, reason).Inc()
+}
+
+// IncrementEotsSigningGuardRejectedCounter increments the counter of the signing requests rejected by the signing guards
+func (em *EotsMetrics) IncrementEotsSigningGuardRejectedCounter(fpBtcPkHex, reason string) {
+	em.EotsSigningGuardRejectedCounter.WithLabelValues(fpBtcPkHex, reason).Inc()
+}
+
+// IncrementEotsNewChainIDCounter increments the counter of the chain IDs requested for the first time with an EOTS key
+func (em *EotsMetrics) IncrementEotsNewChainIDCounter(fpBtcPkHex, chainID string) {
+	em.EotsNewChainIDCounter.WithLabelValues(fpBtcPkHex, chainID).Inc()
+}
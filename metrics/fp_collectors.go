@@ -19,6 +19,9 @@ type FpMetrics struct {
 	babylonTipHeight     prometheus.Gauge
 	lastPolledHeight     prometheus.Gauge
 	pollerStartingHeight prometheus.Gauge
+	pollerLag            prometheus.Histogram
+	pollerBatchSize      prometheus.Summary
+	pollerDegraded       prometheus.Gauge
 	// single finality provider metrics
 	fpStatus                        *prometheus.GaugeVec
 	fpSecondsSinceLastVote          *prometheus.GaugeVec
@@ -32,12 +35,24 @@ type FpMetrics struct {
 	fpTotalCommittedRandomness      *prometheus.CounterVec
 	fpTotalFailedVotes              *prometheus.CounterVec
 	fpTotalFailedRandomness         *prometheus.CounterVec
+	fpDegraded                      *prometheus.GaugeVec
+	// vote latency distributions
+	fpBlockToVoteSeconds     *prometheus.HistogramVec
+	fpEotsSignBatchSeconds   *prometheus.HistogramVec
+	fpVoteTxInclusionSeconds *prometheus.HistogramVec
+	fpVoteBatchSize          *prometheus.SummaryVec
+	// fee payer account metrics
+	fpAccountBalance         *prometheus.GaugeVec
+	fpAccountBalanceDaysLeft *prometheus.GaugeVec
 	// time keeper
 	mu                     sync.Mutex
 	previousVoteByFp       map[string]*time.Time
 	previousRandomnessByFp map[string]*time.Time
 }
 
+// batchSizeObjectives are the quantiles of the batch size summaries
+var batchSizeObjectives = map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001}
+
 // Declare a package-level variable for sync.Once to ensure metrics are registered only once
 var fpMetricsRegisterOnce sync.Once
 
@@ -68,6 +83,16 @@ func NewFpMetrics() *FpMetrics {
 				Name: "poller_starting_height",
 				Help: "The initial block height when the poller started operation",
 			}),
+			pollerLag: prometheus.NewHistogram(prometheus.HistogramOpts{
+				Name:    "poller_lag_blocks",
+				Help:    "The number of blocks between the tip of the Babylon network and the next height to poll",
+				Buckets: []float64{0, 1, 2, 5, 10, 20, 50, 100, 500, 1000},
+			}),
+			pollerBatchSize: prometheus.NewSummary(prometheus.SummaryOpts{
+				Name:       "poller_batch_size",
+				Help:       "The number of blocks fetched by each poll of the Babylon network",
+				Objectives: batchSizeObjectives,
+			}),
 			fpSecondsSinceLastVote: prometheus.NewGaugeVec(
 				prometheus.GaugeOpts{
 					Name: "fp_seconds_since_last_vote",
@@ -145,6 +170,63 @@ func NewFpMetrics() *FpMetrics {
 				},
 				[]string{"fp_btc_pk_hex"},
 			),
+			fpBlockToVoteSeconds: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Name:    "fp_block_to_vote_seconds",
+					Help:    "The seconds from the production of a block to the broadcast of the vote for it by a finality provider.",
+					Buckets: []float64{1, 2, 5, 10, 15, 20, 30, 60, 120, 300, 600},
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpEotsSignBatchSeconds: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Name:    "fp_eots_sign_batch_seconds",
+					Help:    "The seconds taken by the EOTS manager to sign a batch of votes of a finality provider.",
+					Buckets: prometheus.ExponentialBuckets(0.005, 2, 12),
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpVoteTxInclusionSeconds: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Name:    "fp_vote_tx_inclusion_seconds",
+					Help:    "The seconds from the broadcast of a vote transaction of a finality provider to its inclusion in a block.",
+					Buckets: []float64{1, 2, 5, 10, 15, 20, 30, 60, 120},
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpVoteBatchSize: prometheus.NewSummaryVec(
+				prometheus.SummaryOpts{
+					Name:       "fp_vote_batch_size",
+					Help:       "The number of blocks voted in each vote transaction of a finality provider.",
+					Objectives: batchSizeObjectives,
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			pollerDegraded: prometheus.NewGauge(prometheus.GaugeOpts{
+				Name: "poller_degraded",
+				Help: "Whether the poller backs off after too many consecutive failed poll cycles (1) or not (0)",
+			}),
+			fpDegraded: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_degraded",
+					Help: "Whether a component of a finality provider backs off after failures (1) or not (0)",
+				},
+				[]string{"fp_btc_pk_hex", "component"},
+			),
+			fpAccountBalance: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_account_balance",
+					Help: "The balance of the account paying the transaction fees of the finality provider.",
+				},
+				[]string{"address", "denom"},
+			),
+			fpAccountBalanceDaysLeft: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_account_balance_days_left",
+					Help: "The projected number of days before the fee payer account runs out of funds, based on the observed fee burn.",
+				},
+				[]string{"address"},
+			),
 			mu: sync.Mutex{},
 		}
 
@@ -154,6 +236,8 @@ func NewFpMetrics() *FpMetrics {
 		prometheus.MustRegister(fpMetricsInstance.babylonTipHeight)
 		prometheus.MustRegister(fpMetricsInstance.lastPolledHeight)
 		prometheus.MustRegister(fpMetricsInstance.pollerStartingHeight)
+		prometheus.MustRegister(fpMetricsInstance.pollerLag)
+		prometheus.MustRegister(fpMetricsInstance.pollerBatchSize)
 		prometheus.MustRegister(fpMetricsInstance.fpSecondsSinceLastVote)
 		prometheus.MustRegister(fpMetricsInstance.fpSecondsSinceLastRandomness)
 		prometheus.MustRegister(fpMetricsInstance.fpLastVotedHeight)
@@ -164,6 +248,14 @@ func NewFpMetrics() *FpMetrics {
 		prometheus.MustRegister(fpMetricsInstance.fpLastCommittedRandomnessHeight)
 		prometheus.MustRegister(fpMetricsInstance.fpTotalFailedVotes)
 		prometheus.MustRegister(fpMetricsInstance.fpTotalFailedRandomness)
+		prometheus.MustRegister(fpMetricsInstance.fpBlockToVoteSeconds)
+		prometheus.MustRegister(fpMetricsInstance.fpEotsSignBatchSeconds)
+		prometheus.MustRegister(fpMetricsInstance.fpVoteTxInclusionSeconds)
+		prometheus.MustRegister(fpMetricsInstance.fpVoteBatchSize)
+		prometheus.MustRegister(fpMetricsInstance.pollerDegraded)
+		prometheus.MustRegister(fpMetricsInstance.fpDegraded)
+		prometheus.MustRegister(fpMetricsInstance.fpAccountBalance)
+		prometheus.MustRegister(fpMetricsInstance.fpAccountBalanceDaysLeft)
 
 		// Set the version info metric (set once at startup)
 		commit, timestamp := version.CommitInfo()
@@ -203,6 +295,36 @@ func (fm *FpMetrics) RecordPollerStartingHeight(height uint64) {
 	fm.pollerStartingHeight.Set(float64(height))
 }
 
+// RecordPollerLag records the number of blocks between the tip and the next height to poll
+func (fm *FpMetrics) RecordPollerLag(blocks uint64) {
+	fm.pollerLag.Observe(float64(blocks))
+}
+
+// RecordPollerBatchSize records the number of blocks fetched by a poll
+func (fm *FpMetrics) RecordPollerBatchSize(size int) {
+	fm.pollerBatchSize.Observe(float64(size))
+}
+
+// RecordFpBlockToVoteLatency records the time from the production of a block to the broadcast of its vote
+func (fm *FpMetrics) RecordFpBlockToVoteLatency(fpBtcPkHex string, latency time.Duration) {
+	fm.fpBlockToVoteSeconds.WithLabelValues(fpBtcPkHex).Observe(latency.Seconds())
+}
+
+// RecordFpEotsSignBatchDuration records the time taken to sign a batch of votes by the EOTS manager
+func (fm *FpMetrics) RecordFpEotsSignBatchDuration(fpBtcPkHex string, d time.Duration) {
+	fm.fpEotsSignBatchSeconds.WithLabelValues(fpBtcPkHex).Observe(d.Seconds())
+}
+
+// RecordFpVoteTxInclusionDuration records the time from the broadcast of a vote transaction to its inclusion
+func (fm *FpMetrics) RecordFpVoteTxInclusionDuration(fpBtcPkHex string, d time.Duration) {
+	fm.fpVoteTxInclusionSeconds.WithLabelValues(fpBtcPkHex).Observe(d.Seconds())
+}
+
+// RecordFpVoteBatchSize records the number of blocks voted in a vote transaction
+func (fm *FpMetrics) RecordFpVoteBatchSize(fpBtcPkHex string, size int) {
+	fm.fpVoteBatchSize.WithLabelValues(fpBtcPkHex).Observe(float64(size))
+}
+
 // RecordFpSecondsSinceLastVote records the seconds since the last finality sig vote by a finality provider
 func (fm *FpMetrics) RecordFpSecondsSinceLastVote(fpBtcPkHex string, seconds float64) {
 	fm.fpSecondsSinceLastVote.WithLabelValues(fpBtcPkHex).Set(seconds)
@@ -263,6 +385,34 @@ func (fm *FpMetrics) IncrementFpTotalFailedRandomness(fpBtcPkHex string) {
 	fm.fpTotalFailedRandomness.WithLabelValues(fpBtcPkHex).Inc()
 }
 
+// RecordFpAccountBalance records the balance of the account paying the transaction fees
+func (fm *FpMetrics) RecordFpAccountBalance(address, denom string, balance float64) {
+	fm.fpAccountBalance.WithLabelValues(address, denom).Set(balance)
+}
+
+// RecordFpAccountBalanceDaysLeft records the projected days before the fee payer account runs out of funds
+func (fm *FpMetrics) RecordFpAccountBalanceDaysLeft(address string, days float64) {
+	fm.fpAccountBalanceDaysLeft.WithLabelValues(address).Set(days)
+}
+
+// RecordPollerDegraded records whether the poller backs off after failures
+func (fm *FpMetrics) RecordPollerDegraded(degraded bool) {
+	fm.pollerDegraded.Set(boolToFloat(degraded))
+}
+
+// RecordFpDegraded records whether a component of a finality provider backs off after failures
+func (fm *FpMetrics) RecordFpDegraded(fpBtcPkHex, component string, degraded bool) {
+	fm.fpDegraded.WithLabelValues(fpBtcPkHex, component).Set(boolToFloat(degraded))
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
 // RecordFpVoteTime records the time of a finality sig vote by a finality provider
 func (fm *FpMetrics) RecordFpVoteTime(fpBtcPkHex string) {
 	fm.mu.Lock()
@@ -276,6 +426,20 @@ func (fm *FpMetrics) RecordFpVoteTime(fpBtcPkHex string) {
 	fm.previousVoteByFp[fpBtcPkHex] = &now
 }
 
+// LastVoteTime returns the time of the last finality sig vote by a finality
+// provider and whether it voted since the start
+func (fm *FpMetrics) LastVoteTime(fpBtcPkHex string) (time.Time, bool) {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	t, ok := fm.previousVoteByFp[fpBtcPkHex]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return *t, true
+}
+
 // RecordFpRandomnessTime records the time of a public randomness commitment by a finality provider
 func (fm *FpMetrics) RecordFpRandomnessTime(fpBtcPkHex string) {
 	fm.mu.Lock()
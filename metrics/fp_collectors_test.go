@@ -0,0 +1,54 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/metrics"
+)
+
+func TestFpLatencyMetrics(t *testing.T) {
+	t.Parallel()
+
+	// the pk is only used by this test, as the metrics are global
+	const fpPk = "latency-test-fp"
+	m := metrics.NewFpMetrics()
+
+	m.RecordFpBlockToVoteLatency(fpPk, 3*time.Second)
+	m.RecordFpBlockToVoteLatency(fpPk, 12*time.Second)
+	m.RecordFpEotsSignBatchDuration(fpPk, 20*time.Millisecond)
+	m.RecordFpVoteTxInclusionDuration(fpPk, 6*time.Second)
+	m.RecordFpVoteBatchSize(fpPk, 2)
+
+	expected := `
+# HELP fp_block_to_vote_seconds The seconds from the production of a block to the broadcast of the vote for it by a finality provider.
+# TYPE fp_block_to_vote_seconds histogram
+fp_block_to_vote_seconds_bucket{fp_btc_pk_hex="latency-test-fp",le="1"} 0
+fp_block_to_vote_seconds_bucket{fp_btc_pk_hex="latency-test-fp",le="2"} 0
+fp_block_to_vote_seconds_bucket{fp_btc_pk_hex="latency-test-fp",le="5"} 1
+fp_block_to_vote_seconds_bucket{fp_btc_pk_hex="latency-test-fp",le="10"} 1
+fp_block_to_vote_seconds_bucket{fp_btc_pk_hex="latency-test-fp",le="15"} 2
+fp_block_to_vote_seconds_bucket{fp_btc_pk_hex="latency-test-fp",le="20"} 2
+fp_block_to_vote_seconds_bucket{fp_btc_pk_hex="latency-test-fp",le="30"} 2
+fp_block_to_vote_seconds_bucket{fp_btc_pk_hex="latency-test-fp",le="60"} 2
+fp_block_to_vote_seconds_bucket{fp_btc_pk_hex="latency-test-fp",le="120"} 2
+fp_block_to_vote_seconds_bucket{fp_btc_pk_hex="latency-test-fp",le="300"} 2
+fp_block_to_vote_seconds_bucket{fp_btc_pk_hex="latency-test-fp",le="600"} 2
+fp_block_to_vote_seconds_bucket{fp_btc_pk_hex="latency-test-fp",le="+Inf"} 2
+fp_block_to_vote_seconds_sum{fp_btc_pk_hex="latency-test-fp"} 15
+fp_block_to_vote_seconds_count{fp_btc_pk_hex="latency-test-fp"} 2
+`
+	require.NoError(t, testutil.GatherAndCompare(
+		prometheus.DefaultGatherer, strings.NewReader(expected), "fp_block_to_vote_seconds"))
+
+	for _, name := range []string{"fp_eots_sign_batch_seconds", "fp_vote_tx_inclusion_seconds", "fp_vote_batch_size"} {
+		count, err := testutil.GatherAndCount(prometheus.DefaultGatherer, name)
+		require.NoError(t, err)
+		require.Positive(t, count, name)
+	}
+}
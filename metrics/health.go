@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// readinessCheckTimeout bounds the time of each readiness check, so that an
+// unreachable dependency fails the check instead of the probe
+const readinessCheckTimeout = 5 * time.Second
+
+// ReadinessCheck is a condition the daemon needs to do its work, e.g.,
+// reaching a dependency. Check returns the reason the daemon is not ready.
+type ReadinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// CheckResult is the result of a readiness check
+type CheckResult struct {
+	Name  string `json:"name"`
+	Ready bool   `json:"ready"`
+	Error string `json:"error,omitempty"`
+}
+
+// ReadinessResponse is the body of the /readyz response
+type ReadinessResponse struct {
+	Ready  bool          `json:"ready"`
+	Checks []CheckResult `json:"checks"`
+}
+
+// RunReadinessChecks runs the checks concurrently, each within the timeout
+func RunReadinessChecks(ctx context.Context, checks []ReadinessCheck, timeout time.Duration) *ReadinessResponse {
+	results := make([]CheckResult, len(checks))
+	done := make(chan struct{}, len(checks))
+	for i, c := range checks {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			results[i] = runReadinessCheck(ctx, c, timeout)
+		}()
+	}
+	for range checks {
+		<-done
+	}
+
+	res := &ReadinessResponse{Ready: true, Checks: results}
+	for _, r := range results {
+		if !r.Ready {
+			res.Ready = false
+		}
+	}
+
+	return res
+}
+
+// runReadinessCheck returns once the check returns or times out, as some
+// clients of the dependencies do not take a context
+func runReadinessCheck(ctx context.Context, c ReadinessCheck, timeout time.Duration) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- c.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+
+	res := CheckResult{Name: c.Name, Ready: err == nil}
+	if err != nil {
+		res.Error = err.Error()
+	}
+
+	return res
+}
+
+// healthzHandler reports that the daemon is alive, as it serves requests
+func healthzHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+// readyzHandler reports whether all the checks pass with 200, or 503 otherwise,
+// along with the result of each check
+func readyzHandler(checks []ReadinessCheck, logger *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res := RunReadinessChecks(r.Context(), checks, readinessCheckTimeout)
+
+		status := http.StatusOK
+		if !res.Ready {
+			status = http.StatusServiceUnavailable
+			for _, c := range res.Checks {
+				if !c.Ready {
+					logger.Debug("readiness check failed", zap.String("check", c.Name), zap.String("error", c.Error))
+				}
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(res); err != nil {
+			logger.Debug("failed to write the readiness response", zap.Error(err))
+		}
+	}
+}
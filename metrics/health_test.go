@@ -0,0 +1,82 @@
+package metrics_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/metrics"
+)
+
+func TestReadinessChecks(t *testing.T) {
+	t.Parallel()
+
+	ready := metrics.ReadinessCheck{Name: "ready", Check: func(_ context.Context) error { return nil }}
+	failing := metrics.ReadinessCheck{Name: "failing", Check: func(_ context.Context) error { return errors.New("unreachable") }}
+	// the check ignores its context, as a client without context would
+	stuck := metrics.ReadinessCheck{Name: "stuck", Check: func(_ context.Context) error {
+		time.Sleep(time.Minute)
+
+		return nil
+	}}
+
+	res := metrics.RunReadinessChecks(t.Context(), []metrics.ReadinessCheck{ready, failing, stuck}, 100*time.Millisecond)
+	require.False(t, res.Ready)
+	require.Equal(t, []metrics.CheckResult{
+		{Name: "ready", Ready: true},
+		{Name: "failing", Error: "unreachable"},
+		{Name: "stuck", Error: "timed out after 100ms"},
+	}, res.Checks)
+
+	require.True(t, metrics.RunReadinessChecks(t.Context(), []metrics.ReadinessCheck{ready}, time.Second).Ready)
+	require.True(t, metrics.RunReadinessChecks(t.Context(), nil, time.Second).Ready)
+
+	t.Run("http endpoints", func(t *testing.T) {
+		t.Parallel()
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := lis.Addr().String()
+		require.NoError(t, lis.Close())
+
+		s := metrics.Start(addr, zap.NewNop(), ready, failing)
+		defer s.Stop(t.Context())
+
+		get := func(path string) *http.Response {
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, fmt.Sprintf("http://%s%s", addr, path), nil)
+			require.NoError(t, err)
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return nil
+			}
+
+			return res
+		}
+
+		var res *http.Response
+		require.Eventually(t, func() bool {
+			res = get("/healthz")
+
+			return res != nil
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+
+		res = get("/readyz")
+		require.NotNil(t, res)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+		var body metrics.ReadinessResponse
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+		require.False(t, body.Ready)
+		require.Len(t, body.Checks, 2)
+	})
+}
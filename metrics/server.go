@@ -15,9 +15,13 @@ type Server struct {
 	logger     *zap.Logger
 }
 
-func Start(addr string, logger *zap.Logger) *Server {
+// Start starts the server of the metrics at /metrics, of the liveness of the
+// daemon at /healthz and of its readiness according to the checks at /readyz
+func Start(addr string, logger *zap.Logger, checks ...ReadinessCheck) *Server {
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", healthzHandler)
+	mux.Handle("/readyz", readyzHandler(checks, logger))
 
 	// Create the HTTP server with the custom ServeMux as the handler
 	server := &http.Server{
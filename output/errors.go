@@ -0,0 +1,226 @@
+package output
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The codes of the errors of the commands, which are stable so that the
+// errors can be handled by scripts
+const (
+	CodeInvalidArgument    = "invalid_argument"
+	CodeNotFound           = "not_found"
+	CodeAlreadyExists      = "already_exists"
+	CodePermissionDenied   = "permission_denied"
+	CodeUnauthenticated    = "unauthenticated"
+	CodeUnavailable        = "unavailable"
+	CodeFailedPrecondition = "failed_precondition"
+	CodeDeadlineExceeded   = "deadline_exceeded"
+	CodeCanceled           = "canceled"
+	CodeResourceExhausted  = "resource_exhausted"
+	CodeOutOfRange         = "out_of_range"
+	CodeAborted            = "aborted"
+	CodeUnimplemented      = "unimplemented"
+	CodeInternal           = "internal"
+	CodeUnknown            = "unknown"
+)
+
+// grpcCodes are the codes of the errors returned by the daemons
+var grpcCodes = map[codes.Code]string{
+	codes.InvalidArgument:    CodeInvalidArgument,
+	codes.NotFound:           CodeNotFound,
+	codes.AlreadyExists:      CodeAlreadyExists,
+	codes.PermissionDenied:   CodePermissionDenied,
+	codes.Unauthenticated:    CodeUnauthenticated,
+	codes.Unavailable:        CodeUnavailable,
+	codes.FailedPrecondition: CodeFailedPrecondition,
+	codes.DeadlineExceeded:   CodeDeadlineExceeded,
+	codes.Canceled:           CodeCanceled,
+	codes.ResourceExhausted:  CodeResourceExhausted,
+	codes.OutOfRange:         CodeOutOfRange,
+	codes.Aborted:            CodeAborted,
+	codes.Unimplemented:      CodeUnimplemented,
+	codes.Internal:           CodeInternal,
+	codes.DataLoss:           CodeInternal,
+}
+
+// codedError is an error with the code reported for it
+type codedError struct {
+	code string
+	err  error
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) Unwrap() error {
+	return e.err
+}
+
+// WithCode returns the error with the code reported for it
+func WithCode(code string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &codedError{code: code, err: err}
+}
+
+// ErrorCode returns the code of the error, which is the code it was given,
+// the code of the gRPC status it wraps or unknown
+func ErrorCode(err error) string {
+	var coded *codedError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return CodeDeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return CodeCanceled
+	}
+
+	if code, ok := grpcCodes[status.Code(err)]; ok {
+		return code
+	}
+
+	// cobra does not type the errors of the unknown commands
+	if strings.HasPrefix(err.Error(), "unknown command") {
+		return CodeInvalidArgument
+	}
+
+	return CodeUnknown
+}
+
+// ErrorObject is the error of a command in the json and yaml formats
+type ErrorObject struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorOutput is what a command which failed prints to the standard error
+// in the json and yaml formats
+type ErrorOutput struct {
+	Error ErrorObject `json:"error"`
+}
+
+// Execute executes the root command and prints its error, if any, to the
+// standard error in the format of the output flag. In the text format, the
+// usage of the command follows the errors of its invocation.
+func Execute(ctx context.Context, root *cobra.Command) error {
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+	root.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return WithCode(CodeInvalidArgument, err)
+	})
+	codeUsageErrors(root)
+
+	cmd, err := root.ExecuteContextC(ctx)
+	if err == nil {
+		return nil
+	}
+
+	code := ErrorCode(err)
+
+	format := errorFormat(cmd)
+	if format == FormatText {
+		cmd.PrintErrln("Error:", err.Error())
+		if code == CodeInvalidArgument {
+			cmd.PrintErrf("%s", cmd.UsageString())
+		}
+
+		return err
+	}
+
+	bz, merr := Marshal(ErrorOutput{Error: ErrorObject{Code: code, Message: err.Error()}}, format)
+	if merr != nil {
+		cmd.PrintErrln("Error:", err.Error())
+
+		return err
+	}
+	cmd.PrintErrln(strings.TrimSuffix(string(bz), "\n"))
+
+	return err
+}
+
+// errorFormat returns the format of the error of the command. The flags
+// following an invalid one are not parsed, so the output flag is looked for
+// in the arguments of the process as well.
+func errorFormat(cmd *cobra.Command) string {
+	if cmd.Flags().Changed(Flag) {
+		if f, err := Format(cmd); err == nil {
+			return f
+		}
+
+		return FormatText
+	}
+
+	args := os.Args[1:]
+	for i, arg := range args {
+		var format string
+		switch {
+		case arg == "--"+Flag && i+1 < len(args):
+			format = args[i+1]
+		case strings.HasPrefix(arg, "--"+Flag+"="):
+			format = strings.TrimPrefix(arg, "--"+Flag+"=")
+		default:
+			continue
+		}
+
+		if slices.Contains(Formats, format) {
+			return format
+		}
+	}
+
+	return FormatText
+}
+
+// codeUsageErrors gives the invalid argument code to the errors of the
+// arguments, the output format and the flags of the commands, which cobra
+// returns untyped
+func codeUsageErrors(cmd *cobra.Command) {
+	if args := cmd.Args; args != nil {
+		cmd.Args = func(c *cobra.Command, a []string) error {
+			return WithCode(CodeInvalidArgument, args(c, a))
+		}
+	}
+
+	if cmd.Runnable() {
+		preRunE, preRun := cmd.PreRunE, cmd.PreRun
+		cmd.PreRun = nil
+		cmd.PreRunE = func(c *cobra.Command, a []string) error {
+			if _, err := Format(c); err != nil {
+				return err
+			}
+			// cobra validates these after the pre run
+			if err := c.ValidateRequiredFlags(); err != nil {
+				return WithCode(CodeInvalidArgument, err)
+			}
+			if err := c.ValidateFlagGroups(); err != nil {
+				return WithCode(CodeInvalidArgument, err)
+			}
+
+			if preRunE != nil {
+				return preRunE(c, a)
+			}
+			if preRun != nil {
+				preRun(c, a)
+			}
+
+			return nil
+		}
+	}
+
+	for _, sub := range cmd.Commands() {
+		codeUsageErrors(sub)
+	}
+}
@@ -0,0 +1,127 @@
+// Package output prints the results and the errors of the fpd and eotsd
+// commands in the format chosen with the --output flag, so that they can be
+// scripted without parsing human text.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+
+	// Flag is the flag choosing the format of the output
+	Flag = "output"
+)
+
+// Formats are the formats of the output
+var Formats = []string{FormatText, FormatJSON, FormatYAML}
+
+// Texter is a result with a human readable form, printed in the text format
+// instead of its JSON
+type Texter interface {
+	Text() string
+}
+
+// AddFlag adds the output flag to the root command for all its commands.
+// It has no shorthand, as the commands derived from the Cosmos SDK define
+// their own output flag with one.
+func AddFlag(root *cobra.Command) {
+	root.PersistentFlags().String(Flag, FormatText,
+		fmt.Sprintf("The format of the output, one of %s", strings.Join(Formats, ", ")))
+}
+
+// Format returns the format of the output of the command, which is text if
+// the command has no output flag
+func Format(cmd *cobra.Command) (string, error) {
+	f := cmd.Flags().Lookup(Flag)
+	if f == nil {
+		return FormatText, nil
+	}
+
+	format := strings.ToLower(f.Value.String())
+	for _, valid := range Formats {
+		if format == valid {
+			return format, nil
+		}
+	}
+
+	return "", WithCode(CodeInvalidArgument,
+		fmt.Errorf("invalid output format %s, expected one of %s", format, strings.Join(Formats, ", ")))
+}
+
+// Print prints the result of the command in its format. The text format is
+// the human readable form of the result if it has one, and its JSON
+// otherwise. Nothing is printed in the text format if its human readable
+// form is empty.
+func Print(cmd *cobra.Command, v any) error {
+	format, err := Format(cmd)
+	if err != nil {
+		return err
+	}
+
+	if t, ok := v.(Texter); ok && format == FormatText {
+		if text := t.Text(); text != "" {
+			return printLine(cmd, text)
+		}
+
+		return nil
+	}
+
+	bz, err := Marshal(v, format)
+	if err != nil {
+		return err
+	}
+
+	return printLine(cmd, string(bz))
+}
+
+// printLine prints the line to the standard output of the command, as the
+// print functions of cobra default to the standard error
+func printLine(cmd *cobra.Command, s string) error {
+	if _, err := fmt.Fprintln(cmd.OutOrStdout(), strings.TrimSuffix(s, "\n")); err != nil {
+		return fmt.Errorf("failed to print output: %w", err)
+	}
+
+	return nil
+}
+
+// Marshal marshals the value in the format, the text format being the JSON
+// of the value. The YAML is converted from the JSON, so that both have the
+// same field names.
+func Marshal(v any, format string) ([]byte, error) {
+	bz, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal output: %w", err)
+	}
+
+	if format != FormatYAML {
+		return bz, nil
+	}
+
+	bz, err = yaml.JSONToYAML(bz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert output to yaml: %w", err)
+	}
+
+	return bz, nil
+}
+
+// Notef prints a hint for the user, to the standard output in the text
+// format and to the standard error otherwise, to keep the output parseable
+func Notef(cmd *cobra.Command, format string, args ...any) {
+	if f, err := Format(cmd); err == nil && f == FormatText {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), format+"\n", args...)
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(cmd.ErrOrStderr(), format+"\n", args...)
+}
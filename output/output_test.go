@@ -0,0 +1,168 @@
+package output_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/output"
+)
+
+type result struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func (r result) Text() string {
+	return fmt.Sprintf("%s has %d", r.Name, r.Count)
+}
+
+// newRoot returns a root command whose run command prints the result or
+// returns the error
+func newRoot(res any, runErr error) (*cobra.Command, *bytes.Buffer, *bytes.Buffer) {
+	root := &cobra.Command{Use: "root"}
+	output.AddFlag(root)
+
+	run := &cobra.Command{
+		Use:  "run",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if runErr != nil {
+				return runErr
+			}
+
+			return output.Print(cmd, res)
+		},
+	}
+	run.Flags().String("required", "", "A required flag")
+	root.AddCommand(run)
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	root.SetOut(stdout)
+	root.SetErr(stderr)
+
+	return root, stdout, stderr
+}
+
+func TestPrint(t *testing.T) {
+	t.Parallel()
+
+	res := result{Name: "fp", Count: 2}
+	tests := []struct {
+		format   string
+		res      any
+		expected string
+	}{
+		{output.FormatText, res, "fp has 2\n"},
+		{output.FormatJSON, res, "{\n  \"name\": \"fp\",\n  \"count\": 2\n}\n"},
+		{output.FormatYAML, res, "count: 2\nname: fp\n"},
+		// the text of a result without a human readable form is its JSON
+		{output.FormatText, map[string]int{"count": 2}, "{\n  \"count\": 2\n}\n"},
+	}
+
+	for _, tc := range tests {
+		root, stdout, stderr := newRoot(tc.res, nil)
+		root.SetArgs([]string{"run", "--output", tc.format})
+		require.NoError(t, output.Execute(context.Background(), root))
+		require.Equal(t, tc.expected, stdout.String(), tc.format)
+		require.Empty(t, stderr.String())
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	t.Parallel()
+
+	grpcErr := status.Error(codes.Unauthenticated, "invalid HMAC signature")
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{fmt.Errorf("failed to unlock: %w", grpcErr), output.CodeUnauthenticated},
+		{output.WithCode(output.CodeNotFound, grpcErr), output.CodeNotFound},
+		{fmt.Errorf("failed to query: %w", context.DeadlineExceeded), output.CodeDeadlineExceeded},
+		{errors.New(`unknown command "foo" for "root"`), output.CodeInvalidArgument},
+		{errors.New("failed to load config"), output.CodeUnknown},
+	}
+
+	for _, tc := range tests {
+		require.Equal(t, tc.expected, output.ErrorCode(tc.err), tc.err.Error())
+	}
+}
+
+func TestExecuteError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		runErr   error
+		args     []string
+		code     string
+		errorMsg string
+	}{
+		{
+			name:     "error of the command",
+			runErr:   fmt.Errorf("failed to get daemon info: %w", status.Error(codes.Unavailable, "connection refused")),
+			args:     []string{"run"},
+			code:     output.CodeUnavailable,
+			errorMsg: "failed to get daemon info: rpc error: code = Unavailable desc = connection refused",
+		},
+		{
+			name:     "unexpected argument",
+			args:     []string{"run", "foo"},
+			code:     output.CodeInvalidArgument,
+			errorMsg: `unknown command "foo" for "root run"`,
+		},
+		{
+			name:     "unknown flag",
+			args:     []string{"run", "--foo"},
+			code:     output.CodeInvalidArgument,
+			errorMsg: "unknown flag: --foo",
+		},
+	}
+
+	for _, tc := range tests {
+		root, stdout, stderr := newRoot(result{}, tc.runErr)
+		// the flags after an unknown one are not parsed
+		root.SetArgs(append([]string{"--output", output.FormatJSON}, tc.args...))
+		require.Error(t, output.Execute(context.Background(), root), tc.name)
+		require.Empty(t, stdout.String(), tc.name)
+
+		var out output.ErrorOutput
+		require.NoError(t, json.Unmarshal(stderr.Bytes(), &out), stderr.String())
+		require.Equal(t, tc.code, out.Error.Code, tc.name)
+		require.Equal(t, tc.errorMsg, out.Error.Message, tc.name)
+	}
+}
+
+func TestExecuteInvalidFormat(t *testing.T) {
+	t.Parallel()
+
+	root, stdout, stderr := newRoot(result{}, nil)
+	root.SetArgs([]string{"run", "--output", "xml"})
+	require.Error(t, output.Execute(context.Background(), root))
+	require.Empty(t, stdout.String())
+	// the error is printed as text, followed by the usage of the command
+	require.Contains(t, stderr.String(), "Error: invalid output format xml, expected one of text, json, yaml\n")
+	require.Contains(t, stderr.String(), "Usage:")
+}
+
+func TestExecuteRequiredFlag(t *testing.T) {
+	t.Parallel()
+
+	root, _, stderr := newRoot(result{}, nil)
+	run, _, err := root.Find([]string{"run"})
+	require.NoError(t, err)
+	require.NoError(t, run.MarkFlagRequired("required"))
+
+	root.SetArgs([]string{"run", "--output", output.FormatYAML})
+	require.Error(t, output.Execute(context.Background(), root))
+	require.Equal(t, "error:\n  code: invalid_argument\n  message: required flag(s) \"required\" not set\n", stderr.String())
+}
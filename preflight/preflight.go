@@ -0,0 +1,225 @@
+// Package preflight runs the checks of whether a daemon can start with its
+// config, without starting it, and reports their results.
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/output"
+)
+
+const (
+	StatusPass = "pass"
+	StatusFail = "fail"
+	StatusSkip = "skip"
+
+	// TimeoutFlag is the flag bounding the time of each check
+	TimeoutFlag = "timeout"
+
+	DefaultTimeout = 10 * time.Second
+)
+
+// Check is a condition the daemon needs to start, e.g., reaching a
+// dependency. Run returns the reason the daemon cannot start. The check is
+// skipped unless all the checks it depends on pass, so that it can use what
+// they set up.
+type Check struct {
+	Name      string
+	DependsOn []string
+	Run       func(ctx context.Context) error
+}
+
+// Result is the result of a check
+type Result struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report is the result of all the checks
+type Report struct {
+	Passed bool     `json:"passed"`
+	Checks []Result `json:"checks"`
+}
+
+// Failed returns the number of the checks which failed
+func (r *Report) Failed() int {
+	failed := 0
+	for _, c := range r.Checks {
+		if c.Status == StatusFail {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+// Text formats the report with a line for each check
+func (r *Report) Text() string {
+	var sb strings.Builder
+	for _, c := range r.Checks {
+		sb.WriteString(fmt.Sprintf("[%s] %s", strings.ToUpper(c.Status), c.Name))
+		if c.Error != "" {
+			sb.WriteString(": " + c.Error)
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// Run runs the checks one after the other, in order, each within the timeout
+func Run(ctx context.Context, checks []Check, timeout time.Duration) *Report {
+	status := make(map[string]string, len(checks))
+	report := &Report{Passed: true, Checks: make([]Result, 0, len(checks))}
+	for _, c := range checks {
+		res := Result{Name: c.Name, Status: StatusPass}
+		for _, dep := range c.DependsOn {
+			if status[dep] != StatusPass {
+				res.Status = StatusSkip
+				res.Error = fmt.Sprintf("%s did not pass", dep)
+
+				break
+			}
+		}
+
+		if res.Status != StatusSkip {
+			if err := runCheck(ctx, c, timeout); err != nil {
+				res.Status = StatusFail
+				res.Error = err.Error()
+				report.Passed = false
+			}
+		}
+
+		status[c.Name] = res.Status
+		report.Checks = append(report.Checks, res)
+	}
+
+	return report
+}
+
+// runCheck returns once the check returns or times out, as some clients of
+// the dependencies do not take a context
+func runCheck(ctx context.Context, c Check, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- c.Run(ctx)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+}
+
+// AddFlags adds the flags of the preflight commands
+func AddFlags(cmd *cobra.Command) {
+	cmd.Flags().Duration(TimeoutFlag, DefaultTimeout, "The maximum time of each check")
+}
+
+// RunCommand runs the checks with the flags of the command, prints the
+// report in the output format of the command and fails if any check failed
+func RunCommand(cmd *cobra.Command, checks []Check) error {
+	timeout, err := cmd.Flags().GetDuration(TimeoutFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", TimeoutFlag, err)
+	}
+	if timeout <= 0 {
+		return output.WithCode(output.CodeInvalidArgument, fmt.Errorf("invalid timeout %s", timeout))
+	}
+
+	// the failed checks are reported rather than a misuse of the command
+	cmd.SilenceUsage = true
+
+	report := Run(cmd.Context(), checks, timeout)
+	if err := output.Print(cmd, report); err != nil {
+		return err
+	}
+
+	if !report.Passed {
+		return output.WithCode(output.CodeFailedPrecondition,
+			fmt.Errorf("%d of %d preflight checks failed", report.Failed(), len(report.Checks)))
+	}
+
+	return nil
+}
+
+// WritableDir returns an error unless files can be created in the
+// directory. A missing directory is created by the daemon, so its closest
+// existing parent is checked instead.
+func WritableDir(dir string) error {
+	info, err := os.Stat(dir)
+	for os.IsNotExist(err) && filepath.Dir(dir) != dir {
+		dir = filepath.Dir(dir)
+		info, err = os.Stat(dir)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+
+	f, err := os.CreateTemp(dir, ".preflight-*")
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %w", dir, err)
+	}
+	_ = f.Close()
+
+	if err := os.Remove(f.Name()); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", f.Name(), err)
+	}
+
+	return nil
+}
+
+// PrivateDir returns an error if a file in the directory can be accessed by
+// the users other than its owner and group, as the keys it holds would leak.
+// A missing directory is left to the checks of the keys.
+func PrivateDir(dir string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		if perm := info.Mode().Perm(); perm&0o007 != 0 {
+			return fmt.Errorf("%s can be accessed by other users (mode %04o)", path, perm)
+		}
+
+		return nil
+	})
+}
+
+// Bindable returns an error unless the address can be listened to, e.g., as
+// another process, such as the daemon already running, listens to it
+func Bindable(addr string) error {
+	lis, err := net.Listen("tcp", addr) //nolint:noctx
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	return lis.Close()
+}
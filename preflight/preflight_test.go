@@ -0,0 +1,68 @@
+package preflight_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/preflight"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	ran := false
+	checks := []preflight.Check{
+		{Name: "passing", Run: func(_ context.Context) error { return nil }},
+		{Name: "failing", Run: func(_ context.Context) error { return errors.New("unreachable") }},
+		{Name: "stuck", Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			time.Sleep(100 * time.Millisecond)
+
+			return nil
+		}},
+		{Name: "dependent", DependsOn: []string{"failing"}, Run: func(_ context.Context) error {
+			ran = true
+
+			return nil
+		}},
+		{Name: "independent", DependsOn: []string{"passing"}, Run: func(_ context.Context) error { return nil }},
+	}
+
+	report := preflight.Run(context.Background(), checks, 50*time.Millisecond)
+	require.False(t, report.Passed)
+	require.Equal(t, 2, report.Failed())
+	require.False(t, ran)
+
+	require.Equal(t, []preflight.Result{
+		{Name: "passing", Status: preflight.StatusPass},
+		{Name: "failing", Status: preflight.StatusFail, Error: "unreachable"},
+		{Name: "stuck", Status: preflight.StatusFail, Error: "timed out after 50ms"},
+		{Name: "dependent", Status: preflight.StatusSkip, Error: "failing did not pass"},
+		{Name: "independent", Status: preflight.StatusPass},
+	}, report.Checks)
+
+	require.Equal(t, "[PASS] passing\n[FAIL] failing: unreachable\n[FAIL] stuck: timed out after 50ms\n"+
+		"[SKIP] dependent: failing did not pass\n[PASS] independent\n", report.Text())
+}
+
+func TestPrivateDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, preflight.PrivateDir(filepath.Join(dir, "missing")))
+
+	keyFile := filepath.Join(dir, "key.info")
+	require.NoError(t, os.WriteFile(keyFile, []byte("key"), 0o600))
+	require.NoError(t, preflight.PrivateDir(dir))
+	require.NoError(t, preflight.WritableDir(dir))
+	require.NoError(t, preflight.WritableDir(filepath.Join(dir, "missing", "data")))
+
+	require.NoError(t, os.Chmod(keyFile, 0o644))
+	require.ErrorContains(t, preflight.PrivateDir(dir), "can be accessed by other users")
+}
@@ -87,6 +87,21 @@ func (mr *MockBabylonControllerMockRecorder) GetFpPopContextV0() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFpPopContextV0", reflect.TypeOf((*MockBabylonController)(nil).GetFpPopContextV0))
 }
 
+// QueryFeePayerBalance mocks base method.
+func (m *MockBabylonController) QueryFeePayerBalance(ctx context.Context, denom string) (*api.FeePayerBalanceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryFeePayerBalance", ctx, denom)
+	ret0, _ := ret[0].(*api.FeePayerBalanceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryFeePayerBalance indicates an expected call of QueryFeePayerBalance.
+func (mr *MockBabylonControllerMockRecorder) QueryFeePayerBalance(ctx, denom any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFeePayerBalance", reflect.TypeOf((*MockBabylonController)(nil).QueryFeePayerBalance), ctx, denom)
+}
+
 // QueryFinalityProvider mocks base method.
 func (m *MockBabylonController) QueryFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey) (*types.QueryFinalityProviderResponse, error) {
 	m.ctrl.T.Helper()
@@ -8,6 +8,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	btcstktypes "github.com/babylonlabs-io/babylon/v4/x/btcstaking/types"
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/api"
 	"github.com/babylonlabs-io/finality-provider/testutil/mocks"
 	"github.com/babylonlabs-io/finality-provider/types"
 )
@@ -48,6 +49,9 @@ func PrepareMockedBabylonController(t *testing.T) *mocks.MockBabylonController {
 	ctl := gomock.NewController(t)
 	mockBabylonController := mocks.NewMockBabylonController(ctl)
 	mockBabylonController.EXPECT().Close().Return(nil).AnyTimes()
+	mockBabylonController.EXPECT().QueryFeePayerBalance(gomock.Any(), gomock.Any()).
+		Return(&api.FeePayerBalanceResponse{Amount: sdkmath.NewInt(1_000_000_000)}, nil).
+		AnyTimes()
 
 	return mockBabylonController
 }
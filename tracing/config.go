@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"fmt"
+)
+
+// Exporters of the spans
+const (
+	ExporterNone = ""
+	ExporterOTLP = "otlp"
+	ExporterFile = "file"
+)
+
+const defaultSampleRatio = 1.0
+
+type Config struct {
+	Exporter     string  `long:"exporter" description:"The exporter of the OpenTelemetry spans: otlp, file, or empty to disable tracing"`
+	OTLPEndpoint string  `long:"otlpendpoint" description:"The host:port of the OTLP gRPC collector the spans are exported to with the otlp exporter"`
+	OTLPInsecure bool    `long:"otlpinsecure" description:"Export the spans to the OTLP collector without TLS"`
+	FilePath     string  `long:"filepath" description:"The path to the file the spans are appended to as JSON with the file exporter"`
+	SampleRatio  float64 `long:"sampleratio" description:"The ratio of the traces which are sampled, from 0 to 1"`
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		SampleRatio: defaultSampleRatio,
+	}
+}
+
+// IsEnabled returns whether the spans are exported
+func (cfg *Config) IsEnabled() bool {
+	return cfg != nil && cfg.Exporter != ExporterNone
+}
+
+func (cfg *Config) Validate() error {
+	switch cfg.Exporter {
+	case ExporterNone:
+		return nil
+	case ExporterOTLP:
+		if cfg.OTLPEndpoint == "" {
+			return fmt.Errorf("the otlp exporter requires otlpendpoint")
+		}
+	case ExporterFile:
+		if cfg.FilePath == "" {
+			return fmt.Errorf("the file exporter requires filepath")
+		}
+	default:
+		return fmt.Errorf("invalid exporter %s, expected otlp, file or empty", cfg.Exporter)
+	}
+
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return fmt.Errorf("invalid sample ratio %v, expected a value from 0 to 1", cfg.SampleRatio)
+	}
+
+	return nil
+}
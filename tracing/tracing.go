@@ -0,0 +1,170 @@
+// Package tracing exports OpenTelemetry spans of fpd and eotsd, so that the
+// time spent in each step of a vote can be told apart
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/babylonlabs-io/finality-provider/version"
+)
+
+// TracerName is the name of the tracer of the spans of fpd and eotsd
+const TracerName = "github.com/babylonlabs-io/finality-provider"
+
+// Attributes of the spans
+const (
+	FpPkKey        = attribute.Key("fp.btc_pk")
+	ChainIDKey     = attribute.Key("chain.id")
+	HeightKey      = attribute.Key("block.height")
+	StartHeightKey = attribute.Key("block.start_height")
+	EndHeightKey   = attribute.Key("block.end_height")
+	NumBlocksKey   = attribute.Key("block.count")
+)
+
+// shutdownTimeout bounds the time to flush the spans on shutdown
+const shutdownTimeout = 5 * time.Second
+
+// enabled is set while the spans are exported. The spans are not started
+// otherwise, so that the contexts passed down are left untouched.
+var enabled atomic.Bool
+
+// Start sets up the export of the spans of the service with the config, and
+// returns the function flushing the spans and stopping the export. The spans
+// are not recorded if tracing is disabled.
+func Start(ctx context.Context, cfg *Config, serviceName string, logger *zap.Logger) (func(), error) {
+	noop := func() {}
+	if !cfg.IsEnabled() {
+		return noop, nil
+	}
+
+	var (
+		exporter sdktrace.SpanExporter
+		file     *os.File
+		err      error
+	)
+	switch cfg.Exporter {
+	case ExporterOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint)}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	case ExporterFile:
+		file, err = os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return noop, fmt.Errorf("failed to open the trace file %s: %w", cfg.FilePath, err)
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(file))
+	default:
+		return noop, fmt.Errorf("invalid exporter %s", cfg.Exporter)
+	}
+	if err != nil {
+		return noop, fmt.Errorf("failed to create the %s span exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version.Version()),
+	))
+	if err != nil {
+		return noop, fmt.Errorf("failed to create the trace resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Warn("failed to export spans", zap.Error(err))
+	}))
+
+	enabled.Store(true)
+	logger.Info("tracing enabled",
+		zap.String("exporter", cfg.Exporter),
+		zap.Float64("sample_ratio", cfg.SampleRatio),
+	)
+
+	return func() {
+		enabled.Store(false)
+
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		err := tp.Shutdown(ctx)
+		if file != nil {
+			err = errors.Join(err, file.Close())
+		}
+		if err != nil {
+			logger.Warn("failed to flush the spans", zap.Error(err))
+		}
+	}, nil
+}
+
+// Tracer returns the tracer of the spans of fpd and eotsd
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// StartSpan starts a span with the given attributes as a child of the span
+// of the context, if any. It returns the context as is with a span doing
+// nothing if tracing is disabled.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !enabled.Load() {
+		return ctx, noop.Span{}
+	}
+
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the error in the span, if any, and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// HeightRange returns the attributes of the range of block heights
+func HeightRange(start, end uint64) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		StartHeightKey.Int64(int64(start)), // #nosec G115 -- block heights fit in int64
+		EndHeightKey.Int64(int64(end)),     // #nosec G115 -- block heights fit in int64
+	}
+}
+
+// ClientDialOption traces the requests of a gRPC client and propagates the
+// trace context to the server
+func ClientDialOption() grpc.DialOption {
+	return grpc.WithStatsHandler(otelgrpc.NewClientHandler())
+}
+
+// ServerOption traces the requests handled by a gRPC server, as children of
+// the spans of the clients
+func ServerOption() grpc.ServerOption {
+	return grpc.StatsHandler(otelgrpc.NewServerHandler())
+}
@@ -0,0 +1,105 @@
+package tracing_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/babylonlabs-io/finality-provider/tracing"
+)
+
+type exportedSpan struct {
+	Name        string
+	SpanContext struct {
+		TraceID string
+	}
+}
+
+// TestTracing checks that the spans of a client and of the server it calls
+// belong to the same trace in the file exporter
+func TestTracing(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "spans.json")
+	stop, err := tracing.Start(t.Context(), &tracing.Config{
+		Exporter:    tracing.ExporterFile,
+		FilePath:    path,
+		SampleRatio: 1,
+	}, "test", zap.NewNop())
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(tracing.ServerOption())
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()), tracing.ClientDialOption())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, span := tracing.StartSpan(t.Context(), "vote", tracing.HeightRange(100, 110)...)
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	tracing.End(span, err)
+	require.NoError(t, err)
+
+	stop()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var spans []exportedSpan
+	dec := json.NewDecoder(f)
+	for {
+		var s exportedSpan
+		err := dec.Decode(&s)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		spans = append(spans, s)
+	}
+
+	// the client and the server spans of the call are both named after the method
+	names := make(map[string]int)
+	for _, s := range spans {
+		names[s.Name]++
+		require.Equal(t, spans[0].SpanContext.TraceID, s.SpanContext.TraceID)
+	}
+	require.Equal(t, map[string]int{"vote": 1, "grpc.health.v1.Health/Check": 2}, names)
+}
+
+func TestConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, tracing.DefaultConfig().Validate())
+	require.False(t, tracing.DefaultConfig().IsEnabled())
+
+	cfg := tracing.DefaultConfig()
+	cfg.Exporter = tracing.ExporterOTLP
+	require.Error(t, cfg.Validate())
+	cfg.OTLPEndpoint = "localhost:4317"
+	require.NoError(t, cfg.Validate())
+
+	cfg.SampleRatio = 2
+	require.Error(t, cfg.Validate())
+
+	cfg.Exporter = "jaeger"
+	require.Error(t, cfg.Validate())
+}
@@ -2,10 +2,7 @@
 package types
 
 import (
-	"encoding/json"
-
 	"github.com/babylonlabs-io/babylon/v4/client/babylonclient"
-	"github.com/spf13/cobra"
 )
 
 // TxResponse handles the transaction response in the interface ConsumerController
@@ -38,14 +35,3 @@ func NewBabylonTxResponse(resp *babylonclient.RelayerTxResponse) *babylonclient.
 		Data:      resp.Data,
 	}
 }
-
-func PrintRespJSON(cmd *cobra.Command, resp interface{}) {
-	jsonBytes, err := json.MarshalIndent(resp, "", "    ")
-	if err != nil {
-		cmd.Println("unable to marshal response: ", err)
-
-		return
-	}
-
-	cmd.Printf("%s\n", jsonBytes)
-}
@@ -0,0 +1,279 @@
+//nolint:revive
+package util
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrClientCertNotPinned is returned by the TLS handshake when the client
+// certificate is signed by the CA but its fingerprint is not pinned
+var ErrClientCertNotPinned = errors.New("client certificate is not pinned")
+
+// CertFingerprint returns the hex encoded SHA-256 hash of the DER encoded certificate
+func CertFingerprint(der []byte) string {
+	h := sha256.Sum256(der)
+
+	return hex.EncodeToString(h[:])
+}
+
+// NormalizeCertFingerprint lowercases the fingerprint and strips the colons
+// separating the bytes, as printed by `openssl x509 -fingerprint -sha256`
+func NormalizeCertFingerprint(fp string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(fp), ":", ""))
+}
+
+// ValidateCertFingerprint checks that the fingerprint is a hex encoded SHA-256 hash
+func ValidateCertFingerprint(fp string) error {
+	bz, err := hex.DecodeString(NormalizeCertFingerprint(fp))
+	if err != nil {
+		return fmt.Errorf("invalid certificate fingerprint %s: %w", fp, err)
+	}
+	if len(bz) != sha256.Size {
+		return fmt.Errorf("invalid certificate fingerprint %s: expected %d bytes, got %d", fp, sha256.Size, len(bz))
+	}
+
+	return nil
+}
+
+// LoadServerTLSConfig returns the TLS config of a gRPC server. If clientCAFile is
+// set, the clients are required to present a certificate signed by one of its CAs,
+// and if pinnedClientCerts is not empty, the fingerprint of the client certificate
+// must also be one of them.
+func LoadServerTLSConfig(certFile, keyFile, clientCAFile string, pinnedClientCerts []string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile == "" {
+		if len(pinnedClientCerts) > 0 {
+			return nil, fmt.Errorf("pinning client certificates requires a client CA")
+		}
+
+		return tlsCfg, nil
+	}
+
+	pool, err := loadCertPool(clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	tlsCfg.ClientCAs = pool
+	tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+
+	if len(pinnedClientCerts) > 0 {
+		pins := make(map[string]struct{}, len(pinnedClientCerts))
+		for _, fp := range pinnedClientCerts {
+			if err := ValidateCertFingerprint(fp); err != nil {
+				return nil, err
+			}
+			pins[NormalizeCertFingerprint(fp)] = struct{}{}
+		}
+
+		// the chain is already verified against the client
+		// CA when this is called, only the leaf is checked
+		tlsCfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return ErrClientCertNotPinned
+			}
+			fp := CertFingerprint(rawCerts[0])
+			if _, ok := pins[fp]; !ok {
+				return fmt.Errorf("%w: %s", ErrClientCertNotPinned, fp)
+			}
+
+			return nil
+		}
+	}
+
+	return tlsCfg, nil
+}
+
+// LoadClientTLSConfig returns the TLS config of a gRPC client. The server
+// certificate is verified against caFile, or the system roots if it is empty.
+// The client certificate is only presented if both certFile and keyFile are set.
+func LoadClientTLSConfig(caFile, certFile, keyFile, serverName string) (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if caFile != "" {
+		pool, err := loadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if (certFile == "") != (keyFile == "") {
+		return nil, fmt.Errorf("both the client certificate and key should be set")
+	}
+
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
+
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	bz, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle %s: %w", caFile, err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bz) {
+		return nil, fmt.Errorf("no valid certificate found in CA bundle %s", caFile)
+	}
+
+	return pool, nil
+}
+
+// TLSCertificate is a generated certificate and its private key, PEM encoded
+type TLSCertificate struct {
+	CertPEM []byte
+	KeyPEM  []byte
+
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// Fingerprint returns the fingerprint of the certificate used for pinning
+func (c *TLSCertificate) Fingerprint() string {
+	return CertFingerprint(c.cert.Raw)
+}
+
+// WriteFiles writes the certificate and the private key, the latter
+// being only readable by the owner
+func (c *TLSCertificate) WriteFiles(certFile, keyFile string) error {
+	if err := os.WriteFile(certFile, c.CertPEM, 0600); err != nil {
+		return fmt.Errorf("failed to write certificate %s: %w", certFile, err)
+	}
+	if err := os.WriteFile(keyFile, c.KeyPEM, 0600); err != nil {
+		return fmt.Errorf("failed to write private key %s: %w", keyFile, err)
+	}
+
+	return nil
+}
+
+// GenerateTLSCA generates a self-signed CA to issue the certificates of a private PKI
+func GenerateTLSCA(commonName string, validity time.Duration) (*TLSCertificate, error) {
+	tmpl, err := certTemplate(commonName, validity)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.IsCA = true
+	tmpl.BasicConstraintsValid = true
+	tmpl.MaxPathLenZero = true
+	tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+
+	return createCertificate(tmpl, nil)
+}
+
+// GenerateTLSServerCert issues a server certificate for the given hosts, which are
+// either IP addresses or DNS names
+func GenerateTLSServerCert(ca *TLSCertificate, commonName string, hosts []string, validity time.Duration) (*TLSCertificate, error) {
+	tmpl, err := certTemplate(commonName, validity)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+	tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		} else {
+			tmpl.DNSNames = append(tmpl.DNSNames, h)
+		}
+	}
+
+	return createCertificate(tmpl, ca)
+}
+
+// GenerateTLSClientCert issues a client certificate identifying the given common name
+func GenerateTLSClientCert(ca *TLSCertificate, commonName string, validity time.Duration) (*TLSCertificate, error) {
+	tmpl, err := certTemplate(commonName, validity)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+	tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+
+	return createCertificate(tmpl, ca)
+}
+
+func certTemplate(commonName string, validity time.Duration) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate certificate serial number: %w", err)
+	}
+
+	now := time.Now()
+
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		// tolerate clock drifts between the hosts
+		NotBefore: now.Add(-time.Hour),
+		NotAfter:  now.Add(validity),
+	}, nil
+}
+
+// createCertificate signs the template with the CA, or self-signs it if ca is nil
+func createCertificate(tmpl *x509.Certificate, ca *TLSCertificate) (*TLSCertificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate private key: %w", err)
+	}
+
+	parent, signer := tmpl, key
+	if ca != nil {
+		parent, signer = ca.cert, ca.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal private key: %w", err)
+	}
+
+	return &TLSCertificate{
+		CertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		KeyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+		cert:    cert,
+		key:     key,
+	}, nil
+}
@@ -0,0 +1,90 @@
+//nolint:revive
+package util_test
+
+import (
+	"crypto/tls"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+func TestMutualTLSWithPinnedClientCert(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	validity := 24 * time.Hour
+
+	ca, err := util.GenerateTLSCA("test-ca", validity)
+	require.NoError(t, err)
+	server, err := util.GenerateTLSServerCert(ca, "eotsd", []string{"127.0.0.1", "localhost"}, validity)
+	require.NoError(t, err)
+	pinned, err := util.GenerateTLSClientCert(ca, "fp-1", validity)
+	require.NoError(t, err)
+	other, err := util.GenerateTLSClientCert(ca, "fp-2", validity)
+	require.NoError(t, err)
+
+	write := func(name string, c *util.TLSCertificate) (string, string) {
+		certFile := filepath.Join(dir, name+".crt")
+		keyFile := filepath.Join(dir, name+".key")
+		require.NoError(t, c.WriteFiles(certFile, keyFile))
+
+		return certFile, keyFile
+	}
+	caFile, _ := write("ca", ca)
+	serverCert, serverKey := write("server", server)
+	pinnedCert, pinnedKey := write("pinned", pinned)
+	otherCert, otherKey := write("other", other)
+
+	serverCfg, err := util.LoadServerTLSConfig(serverCert, serverKey, caFile, []string{pinned.Fingerprint()})
+	require.NoError(t, err)
+
+	handshake := func(certFile, keyFile string) error {
+		clientCfg, err := util.LoadClientTLSConfig(caFile, certFile, keyFile, "localhost")
+		require.NoError(t, err)
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0") //nolint:noctx
+		require.NoError(t, err)
+		defer lis.Close()
+
+		serverErr := make(chan error, 1)
+		go func() {
+			s, err := lis.Accept()
+			if err != nil {
+				serverErr <- err
+
+				return
+			}
+			defer s.Close()
+			serverErr <- tls.Server(s, serverCfg).Handshake()
+		}()
+
+		c, err := net.Dial("tcp", lis.Addr().String()) //nolint:noctx
+		require.NoError(t, err)
+		defer c.Close()
+
+		// with TLS 1.3 the client handshake completes before the
+		// server verifies the client certificate, so the server
+		// side is checked first
+		clientErr := tls.Client(c, clientCfg).Handshake()
+		if err := <-serverErr; err != nil {
+			return err
+		}
+
+		return clientErr
+	}
+
+	require.NoError(t, handshake(pinnedCert, pinnedKey))
+	require.ErrorIs(t, handshake(otherCert, otherKey), util.ErrClientCertNotPinned)
+	require.Error(t, handshake("", ""))
+
+	// pins can be copied from openssl
+	require.NoError(t, util.ValidateCertFingerprint("AB:"+pinned.Fingerprint()[2:]))
+	require.Error(t, util.ValidateCertFingerprint("abcd"))
+
+	_, err = util.LoadServerTLSConfig(serverCert, serverKey, "", []string{pinned.Fingerprint()})
+	require.Error(t, err)
+}
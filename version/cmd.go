@@ -5,8 +5,29 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/output"
 )
 
+// Info describes the version of the binary
+type Info struct {
+	Version      string `json:"version"`
+	GitCommit    string `json:"git_commit"`
+	GitTimestamp string `json:"git_timestamp"`
+}
+
+func (i Info) Text() string {
+	var sb strings.Builder
+	_, _ = sb.WriteString("Version:       " + i.Version)
+	_, _ = sb.WriteString("\n")
+	_, _ = sb.WriteString("Git Commit:    " + i.GitCommit)
+	_, _ = sb.WriteString("\n")
+	_, _ = sb.WriteString("Git Timestamp: " + i.GitTimestamp)
+	_, _ = sb.WriteString("\n")
+
+	return sb.String()
+}
+
 // AddVersionCommands adds all the version-related commands to the provided command.
 // The version commands are generic to {Babylon, Cosmos BSN, rollup BSN} finality providers
 func AddVersionCommands(cmd *cobra.Command, binaryName string) {
@@ -21,7 +42,7 @@ func CommandVersion(binaryName string) *cobra.Command {
 		Aliases: []string{"v"},
 		Example: fmt.Sprintf("%s version", binaryName),
 		Args:    cobra.NoArgs,
-		Run: func(cmd *cobra.Command, _ []string) {
+		RunE: func(cmd *cobra.Command, _ []string) error {
 			v := Version()
 			commit, ts := CommitInfo()
 
@@ -29,15 +50,7 @@ func CommandVersion(binaryName string) *cobra.Command {
 				v = "main"
 			}
 
-			var sb strings.Builder
-			_, _ = sb.WriteString("Version:       " + v)
-			_, _ = sb.WriteString("\n")
-			_, _ = sb.WriteString("Git Commit:    " + commit)
-			_, _ = sb.WriteString("\n")
-			_, _ = sb.WriteString("Git Timestamp: " + ts)
-			_, _ = sb.WriteString("\n")
-
-			cmd.Println(sb.String()) //nolint:govet // it's not an issue
+			return output.Print(cmd, Info{Version: v, GitCommit: commit, GitTimestamp: ts})
 		},
 	}
 